@@ -48,6 +48,10 @@ func (m mockWallet) Nonce() uint64 {
 	return 0
 }
 
+func (m mockWallet) Derive(index uint64) (system.Wallet, error) {
+	return nil, nil
+}
+
 func (m mockWallet) Sign(tx system.Transaction) (system.Transaction, error) {
 	return tx, nil
 }
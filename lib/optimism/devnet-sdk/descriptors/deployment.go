@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -11,6 +13,9 @@ type PortInfo struct {
 	Host        string `json:"host"`
 	Port        int    `json:"port,omitempty"`
 	PrivatePort int    `json:"private_port,omitempty"`
+	// Scheme is the URL scheme to reach the endpoint with (e.g. "https" behind a TLS proxy).
+	// Optional, consumers infer it from the protocol when empty.
+	Scheme string `json:"scheme,omitempty"`
 }
 
 // EndpointMap is a map of service names to their endpoints.
@@ -42,12 +47,23 @@ type Chain struct {
 	JWT       string              `json:"jwt,omitempty"`
 	Config    *params.ChainConfig `json:"config,omitempty"`
 	Addresses AddressMap          `json:"addresses,omitempty"`
+	// Snapshot is set if the chain was started from a pre-seeded state snapshot. Only used for L1.
+	Snapshot *Snapshot `json:"snapshot,omitempty"`
+}
+
+// Snapshot identifies the block of a pre-seeded chain state,
+// which includes the contract deployments of the devnet.
+type Snapshot struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
 }
 
 type L2Chain struct {
 	Chain
 	L1Addresses AddressMap `json:"l1_addresses,omitempty"`
 	L1Wallets   WalletMap  `json:"l1_wallets,omitempty"`
+	// RollupConfig is the rollup config of the chain, as served by its consensus layer nodes.
+	RollupConfig *rollup.Config `json:"rollup_config,omitempty"`
 }
 
 // Wallet represents a wallet with an address and optional private key.
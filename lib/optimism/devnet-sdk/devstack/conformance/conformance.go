@@ -0,0 +1,223 @@
+// Package conformance provides a test suite that every devstack orchestrator backend is expected to pass.
+// A backend is checked by building a system with its options,
+// and then verifying the system frontend is complete, reachable, and cleaned up properly.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// rpcTimeout bounds how long components may take to answer, including any warm-up after setup.
+const rpcTimeout = time.Minute
+
+// Backend describes the orchestrator backend to check.
+type Backend struct {
+	// NewOrchestrator creates a new orchestrator, bound to the given test-handle.
+	// Any resources of the orchestrator must be released by the cleanup functions of the test-handle.
+	NewOrchestrator func(t stack.T, logger log.Logger) stack.Orchestrator
+	// Option builds the system to check, using the orchestrator.
+	Option stack.Option
+}
+
+// Run runs the conformance suite against the backend.
+// Every subtest builds a fresh system with the backend.
+func Run(t *testing.T, backend Backend) {
+	t.Run("registration", func(t *testing.T) {
+		checkRegistration(t, build(t, backend))
+	})
+	t.Run("rpc", func(t *testing.T) {
+		checkRPC(t, build(t, backend))
+	})
+	t.Run("cleanup", func(t *testing.T) {
+		checkCleanup(t, backend)
+	})
+}
+
+// build creates a new setup with an empty system, and applies the backend option to it.
+func build(t stack.T, backend Backend) *stack.Setup {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	logger := testlog.Logger(t, log.LevelInfo)
+	setup := &stack.Setup{
+		Ctx:          ctx,
+		Log:          logger,
+		T:            t,
+		Require:      require.New(t),
+		Orchestrator: backend.NewOrchestrator(t, logger),
+	}
+	setup.System = shim.NewSystem(shim.SystemConfig{
+		CommonConfig: shim.CommonConfigFromSetup(setup),
+	})
+	backend.Option(setup)
+	return setup
+}
+
+// checkRegistration verifies that the registered components are complete,
+// and can be found back by their IDs and chain IDs.
+func checkRegistration(t *testing.T, setup *stack.Setup) {
+	sys := setup.System
+	require.NotEmpty(t, sys.L1Networks(), "system must have an L1")
+
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		require.Equal(t, id, l1.ID())
+		require.Equal(t, id.ChainID, l1.ChainID(), "L1 %s must be registered under its chain ID", id)
+		require.Equal(t, id, sys.L1NetworkID(l1.ChainID()))
+		require.NotEmpty(t, l1.L1ELNodes(), "L1 %s must have an EL node", id)
+		require.Equal(t, stack.SortL1ELNodeIDs(l1.L1ELNodes()), l1.L1ELNodes(), "L1 EL node IDs must be sorted")
+		for _, elID := range l1.L1ELNodes() {
+			require.Equal(t, elID, l1.L1ELNode(elID).ID())
+		}
+		for _, clID := range l1.L1CLNodes() {
+			require.Equal(t, clID, l1.L1CLNode(clID).ID())
+		}
+	}
+
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		require.Equal(t, id, l2.ID())
+		require.Equal(t, id.ChainID, l2.ChainID(), "L2 %s must be registered under its chain ID", id)
+		require.Equal(t, id, sys.L2NetworkID(l2.ChainID()))
+		require.Contains(t, sys.L1Networks(), l2.L1().ID(), "L1 of L2 %s must be part of the system", id)
+		require.Contains(t, sys.Superchains(), l2.Superchain().ID(), "superchain of L2 %s must be part of the system", id)
+		require.NotNil(t, l2.RollupConfig())
+		require.NotEmpty(t, l2.L2ELNodes(), "L2 %s must have an EL node", id)
+		require.NotEmpty(t, l2.L2CLNodes(), "L2 %s must have a CL node", id)
+		require.Equal(t, stack.SortL2CLNodeIDs(l2.L2CLNodes()), l2.L2CLNodes(), "L2 CL node IDs must be sorted")
+		for _, elID := range l2.L2ELNodes() {
+			require.Equal(t, elID, l2.L2ELNode(elID).ID())
+		}
+		for _, clID := range l2.L2CLNodes() {
+			require.Equal(t, clID, l2.L2CLNode(clID).ID())
+		}
+		for _, batcherID := range l2.L2Batchers() {
+			require.Equal(t, batcherID, l2.L2Batcher(batcherID).ID())
+		}
+		for _, proposerID := range l2.L2Proposers() {
+			require.Equal(t, proposerID, l2.L2Proposer(proposerID).ID())
+		}
+		for _, challengerID := range l2.L2Challengers() {
+			require.Equal(t, challengerID, l2.L2Challenger(challengerID).ID())
+		}
+	}
+
+	for _, id := range sys.Supervisors() {
+		require.Equal(t, id, sys.Supervisor(id).ID())
+	}
+}
+
+// checkRPC verifies that the RPC-backed components of the system answer requests.
+func checkRPC(t *testing.T, setup *stack.Setup) {
+	sys := setup.System
+	ctx, cancel := context.WithTimeout(setup.Ctx, rpcTimeout)
+	defer cancel()
+
+	eventually := func(name string, fn func() error) {
+		err := retry.Do0(ctx, int(rpcTimeout/time.Second), retry.Fixed(time.Second), fn)
+		require.NoError(t, err, "%s must be reachable", name)
+	}
+
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		for _, elID := range l1.L1ELNodes() {
+			eventually(elID.String(), elChainCheck(ctx, l1.L1ELNode(elID), id.ChainID))
+		}
+		for _, clID := range l1.L1CLNodes() {
+			cl := l1.L1CLNode(clID)
+			eventually(clID.String(), func() error {
+				_, err := cl.BeaconClient().NodeVersion(ctx)
+				return err
+			})
+		}
+	}
+
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		for _, elID := range l2.L2ELNodes() {
+			eventually(elID.String(), elChainCheck(ctx, l2.L2ELNode(elID), id.ChainID))
+		}
+		for _, clID := range l2.L2CLNodes() {
+			cl := l2.L2CLNode(clID)
+			eventually(clID.String(), func() error {
+				_, err := cl.RollupAPI().SyncStatus(ctx)
+				return err
+			})
+		}
+	}
+
+	for _, id := range sys.Supervisors() {
+		supervisor := sys.Supervisor(id)
+		eventually(id.String(), func() error {
+			_, err := supervisor.QueryAPI().SyncStatus(ctx)
+			return err
+		})
+	}
+
+	require.NoError(t, sys.HealthCheck(ctx), "all components must be healthy")
+}
+
+// elChainCheck checks that the EL node answers requests, and serves the expected chain.
+func elChainCheck(ctx context.Context, el stack.ELNode, expected eth.ChainID) func() error {
+	return func() error {
+		id, err := el.EthClient().ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		if got := eth.ChainIDFromBig(id); got != expected {
+			return fmt.Errorf("expected chain %s, but node serves chain %s", expected, got)
+		}
+		return nil
+	}
+}
+
+// checkCleanup verifies that cleanups registered in a forked scope of the system run when the scope closes,
+// without cleaning up the rest of the system, and that all cleanups of the system run when the test-handle cleans up.
+func checkCleanup(t *testing.T, backend Backend) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	inner := stack.NewToolingT(t.Name(), logger)
+	inner.Fail = func() {
+		t.Fatal("backend failed during setup or cleanup")
+	}
+	// registered before the system is built, so it runs after all cleanups of the system
+	var systemCleaned bool
+	inner.Cleanup(func() {
+		systemCleaned = true
+	})
+	setup := build(inner, backend)
+
+	scope := setup.Fork("conformance")
+	var scopeCleaned bool
+	scope.T.Cleanup(func() {
+		scopeCleaned = true
+	})
+	require.False(t, scopeCleaned, "cleanup must not run before the scope closes")
+	scope.Close()
+	require.True(t, scopeCleaned, "cleanup must run when the scope closes")
+	require.False(t, systemCleaned, "closing a scope must not clean up the system")
+	require.ErrorIs(t, scope.Ctx.Err(), context.Canceled, "context of the scope must be canceled when the scope closes")
+	require.NoError(t, setup.Ctx.Err(), "context of the system must outlive the scope")
+
+	// cleanups registered after the scope closed are left to the system
+	var lateCleaned bool
+	scope.T.Cleanup(func() {
+		lateCleaned = true
+	})
+	require.False(t, lateCleaned, "cleanup of a closed scope must not run right away")
+
+	inner.RunCleanup()
+	require.True(t, lateCleaned, "cleanup of a closed scope must run when the system cleans up")
+	require.True(t, systemCleaned, "all cleanups of the system must complete")
+}
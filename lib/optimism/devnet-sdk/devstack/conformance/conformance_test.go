@@ -0,0 +1,39 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+type fakeOrchestrator struct {
+	t   stack.T
+	log log.Logger
+}
+
+func (o *fakeOrchestrator) T() stack.T {
+	return o.t
+}
+
+func (o *fakeOrchestrator) Log() log.Logger {
+	return o.log
+}
+
+func TestCheckCleanup(t *testing.T) {
+	var released bool
+	checkCleanup(t, Backend{
+		NewOrchestrator: func(t stack.T, logger log.Logger) stack.Orchestrator {
+			return &fakeOrchestrator{t: t, log: logger}
+		},
+		Option: func(setup *stack.Setup) {
+			setup.T.Cleanup(func() {
+				released = true
+			})
+		},
+	})
+	require.True(t, released, "resources of the backend must be released")
+}
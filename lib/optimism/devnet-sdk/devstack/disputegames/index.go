@@ -0,0 +1,137 @@
+// Package disputegames looks up the dispute games of a DisputeGameFactory,
+// for the components and DSL helpers that need to find games without scanning the factory on every query.
+package disputegames
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+// Game is a dispute game of the factory, with the immutable properties of its root claim.
+type Game struct {
+	gameTypes.GameMetadata
+	// Claimant is the account that made the root claim, i.e. the creator of the game.
+	Claimant common.Address
+	// RootClaim is the output root the game claims.
+	RootClaim common.Hash
+	// L2SequenceNumber is the L2 block number, or the L2 timestamp for super roots, the root claim is for.
+	L2SequenceNumber uint64
+}
+
+// Index lists the games of a DisputeGameFactory.
+// Games are only ever appended to the factory, so a lookup only reads the games that were created since the previous one.
+// The index is safe for concurrent use.
+type Index struct {
+	cl         apis.EthCall
+	factory    common.Address
+	factoryABI *abi.ABI
+	gameABI    *abi.ABI
+
+	mu    sync.Mutex
+	games []Game
+}
+
+func NewIndex(cl apis.EthCall, factory common.Address) *Index {
+	return &Index{
+		cl:         cl,
+		factory:    factory,
+		factoryABI: snapshots.LoadDisputeGameFactoryABI(),
+		gameABI:    snapshots.LoadFaultDisputeGameABI(),
+	}
+}
+
+// Games returns all games of the factory, in the order they were created.
+func (x *Index) Games(ctx context.Context) ([]Game, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if err := x.update(ctx); err != nil {
+		return nil, err
+	}
+	return slices.Clone(x.games), nil
+}
+
+// Find returns the most recently created game that matches, or false if no game matches.
+func (x *Index) Find(ctx context.Context, match func(game Game) bool) (Game, bool, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if err := x.update(ctx); err != nil {
+		return Game{}, false, err
+	}
+	for i := len(x.games) - 1; i >= 0; i-- {
+		if match(x.games[i]) {
+			return x.games[i], true, nil
+		}
+	}
+	return Game{}, false, nil
+}
+
+// update reads the games that were created since the previous update.
+func (x *Index) update(ctx context.Context) error {
+	results, err := x.call(ctx, x.factory, x.factoryABI, "gameCount")
+	if err != nil {
+		return err
+	}
+	count := results[0].(*big.Int).Uint64()
+	for idx := uint64(len(x.games)); idx < count; idx++ {
+		game, err := x.read(ctx, idx)
+		if err != nil {
+			return err
+		}
+		x.games = append(x.games, game)
+	}
+	return nil
+}
+
+func (x *Index) read(ctx context.Context, idx uint64) (Game, error) {
+	results, err := x.call(ctx, x.factory, x.factoryABI, "gameAtIndex", new(big.Int).SetUint64(idx))
+	if err != nil {
+		return Game{}, err
+	}
+	proxy := results[2].(common.Address)
+	root, err := x.call(ctx, proxy, x.gameABI, "claimData", big.NewInt(0))
+	if err != nil {
+		return Game{}, err
+	}
+	seqNum, err := x.call(ctx, proxy, x.gameABI, "l2SequenceNumber")
+	if err != nil {
+		return Game{}, err
+	}
+	return Game{
+		GameMetadata: gameTypes.GameMetadata{
+			Index:     idx,
+			GameType:  results[0].(uint32),
+			Timestamp: results[1].(uint64),
+			Proxy:     proxy,
+		},
+		Claimant:         root[2].(common.Address),
+		RootClaim:        common.Hash(root[4].([32]byte)),
+		L2SequenceNumber: seqNum[0].(*big.Int).Uint64(),
+	}, nil
+}
+
+func (x *Index) call(ctx context.Context, to common.Address, contract *abi.ABI, method string, args ...any) ([]any, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call to %s: %w", method, err)
+	}
+	out, err := x.cl.Call(ctx, ethereum.CallMsg{To: &to, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s of %v: %w", method, to, err)
+	}
+	results, err := contract.Unpack(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result of %s of %v: %w", method, to, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"context"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// trackedAddress is an address of which Balances samples the balance, with a name to report it by.
+type trackedAddress struct {
+	name string
+	addr gethcommon.Address
+}
+
+// Balances samples the balances of the fee vaults of a L2 chain, and of any other tracked addresses,
+// so balance changes between two blocks can be asserted.
+type Balances struct {
+	common
+
+	chain   *L2Chain
+	tracked []trackedAddress
+}
+
+// Balances creates a balance sampler of the chain, that tracks the fee vaults of the chain.
+func (c *L2Chain) Balances() *Balances {
+	return &Balances{
+		common: c.common,
+		chain:  c,
+		tracked: []trackedAddress{
+			{name: "BaseFeeVault", addr: predeploys.BaseFeeVaultAddr},
+			{name: "L1FeeVault", addr: predeploys.L1FeeVaultAddr},
+			{name: "SequencerFeeVault", addr: predeploys.SequencerFeeVaultAddr},
+			{name: "OperatorFeeVault", addr: predeploys.OperatorFeeVaultAddr},
+		},
+	}
+}
+
+// Track adds the address to the sampled addresses, reported by the given name.
+func (b *Balances) Track(name string, addr gethcommon.Address) *Balances {
+	b.tracked = append(b.tracked, trackedAddress{name: name, addr: addr})
+	return b
+}
+
+// Snapshot samples the balances and nonces of all tracked addresses at the given block.
+func (b *Balances) Snapshot(block uint64) *system.AccountSnapshot {
+	defer b.record("Balances.Snapshot", "chain", b.chain.ChainID(), "block", block)()
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+	ethCl := b.chain.el().EthClient()
+	snapshot := &system.AccountSnapshot{
+		Block:    new(big.Int).SetUint64(block),
+		Accounts: make(map[gethcommon.Address]system.AccountState, len(b.tracked)),
+	}
+	for _, tracked := range b.tracked {
+		// the account proof holds both the balance and the nonce of the account at the block
+		res, err := ethCl.GetProof(ctx, tracked.addr, nil, hexutil.EncodeUint64(block))
+		b.require.NoErrorf(err, "Failed to fetch account of %s at block %d", tracked.name, block)
+		snapshot.Accounts[tracked.addr] = system.AccountState{Balance: res.Balance.ToInt(), Nonce: uint64(res.Nonce)}
+	}
+	b.log.Debug("Sampled balances", "block", block, "accounts", snapshot.Accounts)
+	return snapshot
+}
+
+// VerifyDeltas verifies that the balances of the given addresses changed by the expected amounts
+// from the start to the end snapshot. Positive deltas are increases, negative deltas are decreases.
+// Tracked addresses without an expected delta are not checked.
+func (b *Balances) VerifyDeltas(start, end *system.AccountSnapshot, expected map[gethcommon.Address]*big.Int) {
+	defer b.record("Balances.VerifyDeltas", "chain", b.chain.ChainID(), "start", start.Block, "end", end.Block, "expected", expected)()
+	for addr := range expected {
+		_, ok := start.Accounts[addr]
+		b.require.Truef(ok, "address %s must be tracked to verify its balance change", addr)
+	}
+	diff := end.Diff(start)
+	for _, tracked := range b.tracked {
+		delta, ok := expected[tracked.addr]
+		if !ok {
+			continue
+		}
+		actual := diff[tracked.addr].Balance
+		b.require.Zerof(delta.Cmp(actual),
+			"%s balance change from block %d to %d: expected %v, got %v (diff: %v)",
+			tracked.name, start.Block, end.Block, delta, actual, new(big.Int).Sub(actual, delta))
+	}
+}
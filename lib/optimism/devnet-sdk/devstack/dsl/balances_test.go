@@ -0,0 +1,84 @@
+package dsl
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// fakeAccounts serves the balances of accounts by block number, with a nonce equal to the block number.
+func fakeAccounts(balances map[string]map[gethcommon.Address]int64) fakeRPC {
+	return fakeRPC{
+		"eth_getProof": func(args ...any) (any, error) {
+			addr, block := args[0].(gethcommon.Address), args[2].(string)
+			atBlock, ok := balances[block]
+			if !ok {
+				return nil, fmt.Errorf("block %s not found", block)
+			}
+			nonce, err := hexutil.DecodeUint64(block)
+			if err != nil {
+				return nil, err
+			}
+			return &eth.AccountResult{
+				Address: addr,
+				Balance: (*hexutil.Big)(big.NewInt(atBlock[addr])),
+				Nonce:   hexutil.Uint64(nonce),
+			}, nil
+		},
+	}
+}
+
+func TestBalancesVerifyDeltas(t *testing.T) {
+	user := gethcommon.HexToAddress("0x1234")
+	el := fakeAccounts(map[string]map[gethcommon.Address]int64{
+		"0x5": {
+			predeploys.BaseFeeVaultAddr:      100,
+			predeploys.L1FeeVaultAddr:        200,
+			predeploys.SequencerFeeVaultAddr: 300,
+			user:                             10_000,
+		},
+		"0x6": {
+			predeploys.BaseFeeVaultAddr:      150,
+			predeploys.L1FeeVaultAddr:        200,
+			predeploys.SequencerFeeVaultAddr: 310,
+			predeploys.OperatorFeeVaultAddr:  7,
+			user:                             9_933,
+		},
+	})
+	expected := map[gethcommon.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:      big.NewInt(50),
+		predeploys.L1FeeVaultAddr:        big.NewInt(0),
+		predeploys.SequencerFeeVaultAddr: big.NewInt(10),
+		predeploys.OperatorFeeVaultAddr:  big.NewInt(7),
+		user:                             big.NewInt(-67),
+	}
+
+	balances := newTestL2Chain(t, t, nil, nil, el).Balances().Track("user", user)
+	start, end := balances.Snapshot(5), balances.Snapshot(6)
+	require.Equal(t, big.NewInt(10_000), start.Balance(user))
+	require.Equal(t, uint64(6), end.Accounts[user].Nonce)
+	balances.VerifyDeltas(start, end, expected)
+
+	t.Run("mismatch", func(t *testing.T) {
+		failing := newTestL2Chain(t, panicT(t), nil, nil, el).Balances().Track("user", user)
+		expected[user] = big.NewInt(-66)
+		require.PanicsWithValue(t, "failed", func() {
+			failing.VerifyDeltas(failing.Snapshot(5), failing.Snapshot(6), expected)
+		}, "balance change that differs from the expected change must fail the test")
+	})
+
+	t.Run("untracked", func(t *testing.T) {
+		failing := newTestL2Chain(t, panicT(t), nil, nil, el).Balances()
+		require.PanicsWithValue(t, "failed", func() {
+			failing.VerifyDeltas(failing.Snapshot(5), failing.Snapshot(6), map[gethcommon.Address]*big.Int{user: big.NewInt(-67)})
+		}, "balance change of an untracked address must fail the test")
+	})
+}
@@ -0,0 +1,79 @@
+package dsl
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Batcher controls batch submission of a L2 chain, through the admin API of the batcher.
+// Submission is verified with the safe head of the chain: once the batches are included on L1,
+// the safe head derived from L1 advances.
+type Batcher struct {
+	common
+
+	batcher stack.L2Batcher
+	chain   *L2Chain
+}
+
+func newBatcher(c common, batcher stack.L2Batcher, chain *L2Chain) *Batcher {
+	return &Batcher{
+		common:  c,
+		batcher: batcher,
+		chain:   chain,
+	}
+}
+
+// PauseBatching stops batch submission, so the unsafe chain runs ahead of the safe chain.
+func (b *Batcher) PauseBatching() {
+	defer b.record("Batcher.PauseBatching", "batcher", b.batcher.ID())()
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+	b.require.NoError(b.batcher.StopBatcher(ctx), "Failed to stop batcher")
+	b.log.Info("Paused batching")
+}
+
+// ResumeBatching restarts batch submission,
+// and waits for the safe head to catch up with the unsafe head at the time of resuming.
+func (b *Batcher) ResumeBatching() {
+	defer b.record("Batcher.ResumeBatching", "batcher", b.batcher.ID())()
+	target := b.chain.cl().SyncStatusSnapshot().UnsafeL2
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+	b.require.NoError(b.batcher.StartBatcher(ctx), "Failed to start batcher")
+	b.log.Info("Resumed batching", "target", target)
+	b.waitSafe(target.Number)
+}
+
+// ForceSubmit submits the L2 blocks the batcher has loaded, without waiting for the current channel to fill up,
+// and waits for the safe head to reach the latest of the flushed blocks.
+// Blocks that the batcher has not loaded yet are not waited for.
+func (b *Batcher) ForceSubmit() {
+	defer b.record("Batcher.ForceSubmit", "batcher", b.batcher.ID())()
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+	target, err := b.batcher.Flush(ctx)
+	b.require.NoError(err, "Failed to flush batcher")
+	if target == (eth.BlockID{}) {
+		b.log.Info("Flushed batcher, no blocks pending submission")
+		return
+	}
+	b.log.Info("Flushed batcher", "target", target)
+	b.waitSafe(target.Number)
+}
+
+func (b *Batcher) waitSafe(num uint64) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+	err := wait.For(ctx, b.pollInterval, func() (bool, error) {
+		safe := b.chain.cl().SyncStatusSnapshot().SafeL2
+		if safe.Number < num {
+			b.log.Info("Batches not yet included and derived", "safe", safe, "target", num)
+			return false, nil
+		}
+		return true, nil
+	})
+	b.require.NoErrorf(err, "Expected safe head to reach block %d", num)
+}
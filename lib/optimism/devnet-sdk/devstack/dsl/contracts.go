@@ -0,0 +1,36 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+)
+
+// contractCall calls the method of the contract at the latest block, and returns the unpacked results.
+// The call is made from the zero address, unless a sender is given.
+func contractCall(ctx context.Context, cl apis.EthCall, to gethcommon.Address, contract *abi.ABI, method string, args ...any) ([]any, error) {
+	return contractCallFrom(ctx, cl, gethcommon.Address{}, to, contract, method, args...)
+}
+
+// contractCallFrom calls the method of the contract at the latest block from the given sender,
+// and returns the unpacked results.
+func contractCallFrom(ctx context.Context, cl apis.EthCall, from, to gethcommon.Address, contract *abi.ABI, method string, args ...any) ([]any, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call to %s: %w", method, err)
+	}
+	out, err := cl.Call(ctx, ethereum.CallMsg{From: from, To: &to, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	results, err := contract.Unpack(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result of %s: %w", method, err)
+	}
+	return results, nil
+}
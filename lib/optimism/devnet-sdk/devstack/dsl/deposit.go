@@ -0,0 +1,102 @@
+package dsl
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	bindingspreview "github.com/ethereum-optimism/optimism/op-node/bindings/preview"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+const (
+	// depositGasLimit is the L2 gas limit of deposits of ETH.
+	depositGasLimit = 100_000
+	// depositTimeout is the minimum time to wait for a deposit to be included on L2:
+	// the sequencer includes deposits once it adopts the L1 block of the deposit as L1 origin.
+	depositTimeout = 2 * time.Minute
+)
+
+// Deposit sends ETH from an L1 user to an address on a L2 chain, through the OptimismPortal of the chain.
+type Deposit struct {
+	common
+
+	chain *L2Chain
+	from  *User
+	to    gethcommon.Address
+}
+
+// NewDeposit creates a deposit from the L1 user to the address on the chain.
+func (c *L2Chain) NewDeposit(from *User, to gethcommon.Address) *Deposit {
+	return &Deposit{
+		common: commonWithLog(c.common, c.log.New("from", from.user.ID(), "to", to)),
+		chain:  c,
+		from:   from,
+		to:     to,
+	}
+}
+
+// Send deposits the amount of wei, waits for the deposit to be included on L2,
+// and verifies that the amount was minted to the recipient. The L2 receipt of the deposit is returned.
+func (d *Deposit) Send(amount *big.Int) *types.Receipt {
+	defer d.record("Deposit.Send", "chain", d.chain.ChainID(), "to", d.to, "amount", amount)()
+	before := d.balance()
+	portalABI, err := bindingspreview.OptimismPortal2MetaData.GetAbi()
+	d.require.NoError(err)
+	data, err := portalABI.Pack("depositTransaction", d.to, amount, uint64(depositGasLimit), false, []byte{})
+	d.require.NoError(err)
+	portal := d.chain.network.Deployment().OptimismPortalProxyAddr()
+	l1Receipt := d.from.sendTx(stack.TxRequest{To: &portal, Value: amount, Data: data})
+
+	var dep *types.DepositTx
+	for _, log := range l1Receipt.Logs {
+		if log.Address != portal || len(log.Topics) == 0 || log.Topics[0] != derive.DepositEventABIHash {
+			continue
+		}
+		dep, err = derive.UnmarshalDepositLogEvent(log)
+		d.require.NoError(err, "Failed to parse deposit event")
+		break
+	}
+	d.require.NotNil(dep, "Deposit transaction must emit a deposit event")
+	d.require.Equal(amount, dep.Mint, "Deposit must mint the deposited amount")
+
+	l2Tx := types.NewTx(dep)
+	d.log.Info("Deposited on L1", "l1Block", l1Receipt.BlockNumber, "l2Tx", l2Tx.Hash())
+	receipt := d.waitIncluded(l2Tx.Hash())
+	d.require.Equal(types.ReceiptStatusSuccessful, receipt.Status, "Deposit must succeed on L2")
+	d.require.Equal(new(big.Int).Add(before, amount), d.balance(), "L2 balance must increase by the deposited amount")
+	return receipt
+}
+
+func (d *Deposit) waitIncluded(txHash gethcommon.Hash) *types.Receipt {
+	ctx, cancel := context.WithTimeout(d.ctx, max(d.timeout, depositTimeout))
+	defer cancel()
+	var receipt *types.Receipt
+	err := wait.For(ctx, d.pollInterval, func() (bool, error) {
+		var err error
+		receipt, err = d.chain.el().EthClient().TransactionReceipt(ctx, txHash)
+		if errors.Is(err, ethereum.NotFound) {
+			d.log.Info("Deposit not included on L2 yet", "tx", txHash)
+			return false, nil
+		}
+		return err == nil, err
+	})
+	d.require.NoError(err, "Expected deposit to be included on L2")
+	d.log.Info("Deposit included on L2", "tx", txHash, "block", receipt.BlockNumber)
+	return receipt
+}
+
+func (d *Deposit) balance() *big.Int {
+	ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+	defer cancel()
+	balance, err := d.chain.el().EthClient().BalanceAt(ctx, d.to, nil)
+	d.require.NoError(err, "Failed to fetch L2 balance")
+	return balance
+}
@@ -0,0 +1,183 @@
+package dsl
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/disputegames"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+// GameStatus is the status of a dispute game, as reported by the game contract.
+type GameStatus uint8
+
+const (
+	GameStatusInProgress GameStatus = iota
+	GameStatusChallengerWins
+	GameStatusDefenderWins
+)
+
+func (s GameStatus) String() string {
+	switch s {
+	case GameStatusInProgress:
+		return "in-progress"
+	case GameStatusChallengerWins:
+		return "challenger-wins"
+	case GameStatusDefenderWins:
+		return "defender-wins"
+	default:
+		return "unknown"
+	}
+}
+
+// DisputeGame is a dispute game of a L2 chain, created by the DisputeGameFactory of the chain.
+// Games are looked up with the dispute game index of the chain, which is shared by all DSL helpers of the chain.
+type DisputeGame struct {
+	common
+
+	chain *L2Chain
+	game  disputegames.Game
+
+	// Index is the index of the game in the DisputeGameFactory.
+	Index    *big.Int
+	Address  gethcommon.Address
+	GameType uint32
+	// CreatedAt is the L1 time the game was created at.
+	CreatedAt uint64
+}
+
+// DisputeGames lists all dispute games of the chain, in order of creation.
+func (c *L2Chain) DisputeGames() []*DisputeGame {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	games, err := c.disputeGameIndex().Games(ctx)
+	c.require.NoError(err, "Failed to list dispute games")
+	out := make([]*DisputeGame, 0, len(games))
+	for _, game := range games {
+		out = append(out, c.newDisputeGame(game))
+	}
+	return out
+}
+
+// DisputeGame returns the dispute game at the given address.
+func (c *L2Chain) DisputeGame(addr gethcommon.Address) *DisputeGame {
+	game := c.findDisputeGame(func(game disputegames.Game) bool { return game.Proxy == addr })
+	c.require.NotNilf(game, "game %s not in dispute game factory", addr)
+	return game
+}
+
+// VerifyGameCreated verifies that a dispute game was created for the output root, and returns the game.
+func (c *L2Chain) VerifyGameCreated(outputRoot gethcommon.Hash) *DisputeGame {
+	defer c.record("L2Chain.VerifyGameCreated", "chain", c.ChainID(), "outputRoot", outputRoot)()
+	game := c.findDisputeGame(func(game disputegames.Game) bool { return game.RootClaim == outputRoot })
+	c.require.NotNilf(game, "no dispute game created for output root %s", outputRoot)
+	return game
+}
+
+// findDisputeGame returns the most recent dispute game that matches, or nil if no game matches.
+func (c *L2Chain) findDisputeGame(match func(game disputegames.Game) bool) *DisputeGame {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	game, ok, err := c.disputeGameIndex().Find(ctx, match)
+	c.require.NoError(err, "Failed to look up dispute game")
+	if !ok {
+		return nil
+	}
+	return c.newDisputeGame(game)
+}
+
+func (c *L2Chain) newDisputeGame(game disputegames.Game) *DisputeGame {
+	return &DisputeGame{
+		common:    commonWithLog(c.common, c.log.New("game", game.Proxy)),
+		chain:     c,
+		game:      game,
+		Index:     new(big.Int).SetUint64(game.Index),
+		Address:   game.Proxy,
+		GameType:  game.GameType,
+		CreatedAt: game.Timestamp,
+	}
+}
+
+// disputeGameIndex returns the dispute game index of the chain, which is created on first use,
+// and then shared by the DSL helpers of the chain, so games are only read from L1 once.
+func (c *L2Chain) disputeGameIndex() *disputegames.Index {
+	c.disputeGames.CreateIfMissing(c.network.ID(), func() *disputegames.Index {
+		return disputegames.NewIndex(c.l1EL().EthClient(), c.network.Deployment().DisputeGameFactoryProxyAddr())
+	})
+	index, _ := c.disputeGames.Get(c.network.ID())
+	return index
+}
+
+// RootClaim returns the claimed output root of the game.
+func (g *DisputeGame) RootClaim() gethcommon.Hash {
+	return g.game.RootClaim
+}
+
+// L2SequenceNumber returns the L2 block number, or the L2 timestamp for super roots, the game claims the output of.
+func (g *DisputeGame) L2SequenceNumber() uint64 {
+	return g.game.L2SequenceNumber
+}
+
+// Status returns the current status of the game.
+func (g *DisputeGame) Status() GameStatus {
+	return GameStatus(g.call("status")[0].(uint8))
+}
+
+// Resolve resolves the game once the clock of the root claim ran out, with transactions sent by the L1 user.
+// Waiting for the clock is skipped with time travel, if the orchestrator supports it.
+// Games that are already resolved are left as is.
+func (g *DisputeGame) Resolve(by *User) GameStatus {
+	defer g.record("DisputeGame.Resolve", "game", g.Address, "by", by.user.ID())()
+	if status := g.Status(); status != GameStatusInProgress {
+		return status
+	}
+	gameABI := snapshots.LoadFaultDisputeGameABI()
+	clock := time.Duration(g.call("maxClockDuration")[0].(uint64)+1) * time.Second
+	g.chain.awaitPeriod("dispute game clock", clock,
+		func(ctx context.Context) error {
+			_, err := contractCallFrom(ctx, g.chain.l1EL().EthClient(), by.Address(), g.Address, gameABI, "resolveClaim", big.NewInt(0), big.NewInt(0))
+			return err
+		})
+
+	data, err := gameABI.Pack("resolveClaim", big.NewInt(0), big.NewInt(0))
+	g.require.NoError(err)
+	by.sendTx(stack.TxRequest{To: &g.Address, Data: data})
+	data, err = gameABI.Pack("resolve")
+	g.require.NoError(err)
+	by.sendTx(stack.TxRequest{To: &g.Address, Data: data})
+	status := g.Status()
+	g.log.Info("Resolved dispute game", "status", status)
+	return status
+}
+
+// WaitResolution waits for the game to be resolved by any party, and returns the resolved status.
+func (g *DisputeGame) WaitResolution() GameStatus {
+	defer g.record("DisputeGame.WaitResolution", "game", g.Address)()
+	ctx, cancel := context.WithTimeout(g.ctx, g.timeout)
+	defer cancel()
+	var status GameStatus
+	err := wait.For(ctx, g.pollInterval, func() (bool, error) {
+		status = g.Status()
+		if status == GameStatusInProgress {
+			g.log.Info("Dispute game not resolved yet")
+			return false, nil
+		}
+		return true, nil
+	})
+	g.require.NoError(err, "Expected dispute game to be resolved")
+	g.log.Info("Dispute game resolved", "status", status)
+	return status
+}
+
+func (g *DisputeGame) call(method string, args ...any) []any {
+	ctx, cancel := context.WithTimeout(g.ctx, g.timeout)
+	defer cancel()
+	results, err := contractCall(ctx, g.chain.l1EL().EthClient(), g.Address, snapshots.LoadFaultDisputeGameABI(), method, args...)
+	g.require.NoErrorf(err, "Failed to call %s of dispute game", method)
+	return results
+}
@@ -4,12 +4,59 @@ import (
 	"context"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/disputegames"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
-const defaultTimeout = 30 * time.Second
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultPollInterval = 1 * time.Second
+)
+
+// Config configures the waiting behavior and recording of all DSL components of a System.
+type Config struct {
+	// Timeout is the maximum time to wait for an assertion or action to complete.
+	Timeout time.Duration
+	// PollInterval is the time between polls while waiting for an assertion to hold.
+	PollInterval time.Duration
+	// Recorder records the invoked DSL verbs into a scenario trace. May be nil to not record.
+	Recorder *Recorder
+	// TracePath is the file to write the scenario trace to when the test fails. Empty to not write a trace.
+	TracePath string
+}
+
+// WithTimeout sets the maximum time to wait for assertions and actions.
+// Systems that are slow to progress, like kurtosis devnets, need longer timeouts than in-process systems.
+func WithTimeout(timeout time.Duration) func(cfg *Config) {
+	return func(cfg *Config) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithPollInterval sets the time between polls while waiting for assertions to hold.
+func WithPollInterval(interval time.Duration) func(cfg *Config) {
+	return func(cfg *Config) {
+		cfg.PollInterval = interval
+	}
+}
+
+// WithRecorder records the invoked DSL verbs with the given recorder.
+func WithRecorder(recorder *Recorder) func(cfg *Config) {
+	return func(cfg *Config) {
+		cfg.Recorder = recorder
+	}
+}
+
+// WithScenarioTrace records the invoked DSL verbs, and writes them as JSON scenario trace
+// to the file at the given path when the test fails.
+func WithScenarioTrace(path string) func(cfg *Config) {
+	return func(cfg *Config) {
+		cfg.TracePath = path
+	}
+}
 
 // common provides a set of common values and methods inherited by all DSL structs.
 // These should be kept very minimal.
@@ -23,43 +70,106 @@ type common struct {
 	t stack.T
 	// Require is a helper around the above T, ready to assert against.
 	require *require.Assertions
+	// timeout is the maximum time to wait for an assertion or action to complete.
+	timeout time.Duration
+	// pollInterval is the time between polls while waiting for an assertion to hold.
+	pollInterval time.Duration
+	// recorder records the invoked verbs. Nil if recording is disabled.
+	recorder *Recorder
 }
 
 // commonWithLog copies the specified common, replacing the log instance.
 // Not an instance method on common to avoid it being inherited to every component that uses common.
 func commonWithLog(c common, log log.Logger) common {
 	return common{
-		ctx:     c.ctx,
-		log:     log,
-		t:       c.t,
-		require: c.require,
+		ctx:          c.ctx,
+		log:          log,
+		t:            c.t,
+		require:      c.require,
+		timeout:      c.timeout,
+		pollInterval: c.pollInterval,
+		recorder:     c.recorder,
 	}
 }
 
 type System struct {
 	common
-	log log.Logger
-	sys stack.System
+	log  log.Logger
+	sys  stack.System
+	orch stack.Orchestrator
+	// disputeGames are the dispute game indexes of the L2 chains, shared by the systems scoped to actions.
+	disputeGames *locks.RWMap[stack.L2NetworkID, *disputegames.Index]
 }
 
 func (s *System) Supervisor(id stack.SupervisorID) *Supervisor {
 	super := s.sys.Supervisor(id)
-	return newSupervisor(commonWithLog(s.common, s.log.New("id", id)), super)
+	return newSupervisor(commonWithLog(s.common, s.log.New("id", id)), super, s.sys)
+}
+
+// L1 returns the DSL of the L1 chain of the system.
+// Systems have a single L1 chain; if there are more, the first one is returned.
+func (s *System) L1() *L1Chain {
+	ids := stack.SortL1NetworkIDs(s.sys.L1Networks())
+	s.require.NotEmpty(ids, "system must have a L1 chain")
+	return newL1Chain(commonWithLog(s.common, s.log.New("id", ids[0])), s.sys.L1Network(ids[0]))
+}
+
+// L2 returns the DSL of the L2 chain with the given ID.
+func (s *System) L2(id stack.L2NetworkID) *L2Chain {
+	network := s.sys.L2Network(id)
+	return newL2Chain(commonWithLog(s.common, s.log.New("id", id)), network, s.orch, s.disputeGames)
 }
 
-func Hydrate(setup *stack.Setup) *System {
+// L2s returns the DSL of every L2 chain of the system, sorted by ID.
+func (s *System) L2s() []*L2Chain {
+	ids := stack.SortL2NetworkIDs(s.sys.L2Networks())
+	chains := make([]*L2Chain, 0, len(ids))
+	for _, id := range ids {
+		chains = append(chains, s.L2(id))
+	}
+	return chains
+}
+
+func Hydrate(setup *stack.Setup, opts ...func(cfg *Config)) *System {
+	cfg := applyOpts(Config{Timeout: defaultTimeout, PollInterval: defaultPollInterval}, opts...)
+	if cfg.TracePath != "" {
+		if cfg.Recorder == nil {
+			cfg.Recorder = NewRecorder()
+		}
+		writeTraceOnFailure(setup, cfg.Recorder, cfg.TracePath)
+	}
 	return &System{
 		common: common{
-			ctx:     setup.Ctx,
-			log:     setup.Log,
-			t:       setup.T,
-			require: setup.Require,
+			ctx:          setup.Ctx,
+			log:          setup.Log,
+			t:            setup.T,
+			require:      setup.Require,
+			timeout:      cfg.Timeout,
+			pollInterval: cfg.PollInterval,
+			recorder:     cfg.Recorder,
 		},
-		log: setup.Log,
-		sys: setup.System,
+		log:          setup.Log,
+		sys:          setup.System,
+		orch:         setup.Orchestrator,
+		disputeGames: new(locks.RWMap[stack.L2NetworkID, *disputegames.Index]),
 	}
 }
 
+// writeTraceOnFailure writes the scenario trace of the recorder when the test of the setup fails.
+// The trace is always written if the test handle cannot report failure.
+func writeTraceOnFailure(setup *stack.Setup, recorder *Recorder, path string) {
+	setup.T.Cleanup(func() {
+		if f, ok := setup.T.(failer); ok && !f.Failed() {
+			return
+		}
+		if err := recorder.WriteFile(path); err != nil {
+			setup.Log.Error("Failed to write scenario trace", "path", path, "err", err)
+			return
+		}
+		setup.Log.Info("Wrote scenario trace", "path", path)
+	})
+}
+
 func applyOpts[Config any](defaultConfig Config, opts ...func(config *Config)) Config {
 	for _, opt := range opts {
 		opt(&defaultConfig)
@@ -0,0 +1,122 @@
+package dsl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeRPC serves the methods of a fake service in-process, with a handler per method.
+// Results are encoded as JSON, like they are by a real RPC server.
+type fakeRPC map[string]func(args ...any) (any, error)
+
+var _ client.RPC = fakeRPC(nil)
+
+func (f fakeRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	handler, ok := f[method]
+	if !ok {
+		return fmt.Errorf("unexpected call to %s", method)
+	}
+	res, err := handler(args...)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f fakeRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = f.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (f fakeRPC) Close() {}
+
+func (f fakeRPC) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("subscriptions are not supported")
+}
+
+var (
+	testL1ChainID = eth.ChainIDFromUInt64(900)
+	testL2ChainID = eth.ChainIDFromUInt64(901)
+)
+
+// newTestL2Chain hydrates the DSL of a L2 chain with a CL and an EL node that are served by the given fakes.
+// The assertions of the DSL fail the given T.
+func newTestL2Chain(t *testing.T, dslT stack.T, chainConfig *params.ChainConfig, cl, el fakeRPC) *L2Chain {
+	logger := testlog.Logger(t, log.LevelInfo)
+	common := shim.CommonConfig{Log: logger, T: t}
+	sys := shim.NewSystem(shim.SystemConfig{CommonConfig: common})
+
+	l1 := shim.NewL1Network(shim.L1NetworkConfig{
+		NetworkConfig: shim.NetworkConfig{CommonConfig: common, ChainConfig: &params.ChainConfig{ChainID: testL1ChainID.ToBig()}},
+		ID:            stack.L1NetworkID{Key: "l1", ChainID: testL1ChainID},
+	})
+	sys.AddL1Network(l1)
+	if chainConfig == nil {
+		chainConfig = &params.ChainConfig{ChainID: testL2ChainID.ToBig()}
+	}
+	l2 := shim.NewL2Network(shim.L2NetworkConfig{
+		NetworkConfig: shim.NetworkConfig{CommonConfig: common, ChainConfig: chainConfig},
+		ID:            stack.L2NetworkID{Key: "l2", ChainID: testL2ChainID},
+		RollupConfig:  &rollup.Config{L1ChainID: testL1ChainID.ToBig(), L2ChainID: testL2ChainID.ToBig()},
+		L1:            l1,
+	})
+	sys.AddL2Network(l2)
+	l2.AddL2ELNode(shim.NewL2ELNode(shim.L2ELNodeConfig{
+		ELNodeConfig: shim.ELNodeConfig{CommonConfig: common, ChainID: testL2ChainID, Client: el},
+		ID:           stack.L2ELNodeID{Key: "sequencer", ChainID: testL2ChainID},
+	}))
+	l2.AddL2CLNode(shim.NewL2CLNode(shim.L2CLNodeConfig{
+		CommonConfig: common,
+		ID:           stack.L2CLNodeID{Key: "sequencer", ChainID: testL2ChainID},
+		Client:       cl,
+	}))
+
+	dsl := Hydrate(&stack.Setup{
+		Ctx:     context.Background(),
+		Log:     logger,
+		T:       dslT,
+		Require: require.New(dslT),
+		System:  sys,
+	}, WithTimeout(time.Second), WithPollInterval(10*time.Millisecond))
+	return dsl.L2(l2.ID())
+}
+
+// panicT is a T whose critical failures panic, to assert failures of the DSL.
+func panicT(t *testing.T) stack.T {
+	failT := stack.NewToolingT(t.Name(), testlog.Logger(t, log.LevelInfo))
+	failT.Fail = func() {
+		panic("failed")
+	}
+	return failT
+}
+
+// big256 returns the number as a 256 bit big-endian hash-sized value.
+func big256(n int64) [32]byte {
+	var out [32]byte
+	big.NewInt(n).FillBytes(out[:])
+	return out
+}
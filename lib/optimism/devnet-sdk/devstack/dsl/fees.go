@@ -0,0 +1,132 @@
+package dsl
+
+import (
+	"context"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/fees"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// Fees reads the fee parameters of a L2 chain, and computes the expected fees of transactions,
+// so fee correctness can be asserted.
+type Fees struct {
+	common
+
+	chain *L2Chain
+}
+
+// Fees returns the fee helpers of the chain.
+func (c *L2Chain) Fees() *Fees {
+	return &Fees{
+		common: c.common,
+		chain:  c,
+	}
+}
+
+// OperatorFeeParams are the operator fee parameters of a L2 chain, as of Isthmus.
+type OperatorFeeParams struct {
+	Scalar   *big.Int
+	Constant *big.Int
+}
+
+// L1FeeScalars are the L1 fee scalars of a L2 chain, as of Ecotone.
+type L1FeeScalars struct {
+	BaseFeeScalar     *big.Int
+	BlobBaseFeeScalar *big.Int
+}
+
+// TxFees are the fees paid by a transaction, by the fee vault that receives them.
+type TxFees struct {
+	// BaseFee is the base fee paid to the BaseFeeVault.
+	BaseFee *big.Int
+	// L1Fee is the L1 data fee paid to the L1FeeVault.
+	L1Fee *big.Int
+	// PriorityFee is the priority fee paid to the SequencerFeeVault.
+	PriorityFee *big.Int
+	// OperatorFee is the operator fee paid to the OperatorFeeVault.
+	OperatorFee *big.Int
+}
+
+// Total returns the sum of all fees.
+func (f TxFees) Total() *big.Int {
+	total := new(big.Int).Add(f.BaseFee, f.L1Fee)
+	total.Add(total, f.PriorityFee)
+	return total.Add(total, f.OperatorFee)
+}
+
+// OperatorFeeParams reads the operator fee parameters of the chain at the given block.
+func (f *Fees) OperatorFeeParams(block gethcommon.Hash) OperatorFeeParams {
+	scalar, constant := types.ExtractOperatorFeeParams(f.stateAt(block).GetState(types.L1BlockAddr, types.OperatorFeeParamsSlot))
+	return OperatorFeeParams{Scalar: scalar, Constant: constant}
+}
+
+// L1FeeScalars reads the L1 fee scalars of the chain at the given block.
+func (f *Fees) L1FeeScalars(block gethcommon.Hash) L1FeeScalars {
+	baseFeeScalar, blobBaseFeeScalar := types.ExtractEcotoneFeeParams(
+		f.stateAt(block).GetState(types.L1BlockAddr, types.L1FeeScalarsSlot).Bytes())
+	return L1FeeScalars{BaseFeeScalar: baseFeeScalar, BlobBaseFeeScalar: blobBaseFeeScalar}
+}
+
+// ExpectedTxFees computes the fees the transaction of the receipt is expected to pay,
+// from the fee parameters of the chain at the block the transaction was included in.
+func (f *Fees) ExpectedTxFees(receipt *types.Receipt) TxFees {
+	ctx, cancel := context.WithTimeout(f.ctx, f.timeout)
+	defer cancel()
+	info, txs, err := f.chain.el().EthClient().InfoAndTxsByHash(ctx, receipt.BlockHash)
+	f.require.NoError(err, "Failed to fetch block of transaction")
+	f.require.Less(int(receipt.TransactionIndex), len(txs), "transaction must be in its block")
+	headerRLP, err := info.HeaderRLP()
+	f.require.NoError(err)
+	var header types.Header
+	f.require.NoError(rlp.DecodeBytes(headerRLP, &header), "Failed to decode block header")
+
+	// the fee parameters only change with the L1 attributes deposit at the start of a block,
+	// so the state at the end of the block applies to all user transactions of the block.
+	checker := fees.NewFeeCheckerWithState(f.log, f.chain.network.ChainConfig(), f.stateAt(receipt.BlockHash))
+	vaultFees := checker.CalculateExpectedFees(receipt.GasUsed, &header, txs[receipt.TransactionIndex])
+	return TxFees{
+		BaseFee:     vaultFees[predeploys.BaseFeeVaultAddr],
+		L1Fee:       vaultFees[predeploys.L1FeeVaultAddr],
+		PriorityFee: vaultFees[predeploys.SequencerFeeVaultAddr],
+		OperatorFee: vaultFees[predeploys.OperatorFeeVaultAddr],
+	}
+}
+
+// ExpectedBalanceDeltas computes the expected balance changes of the fee vaults and the sender,
+// caused by the transaction of the receipt, for use with Balances.VerifyDeltas.
+func (f *Fees) ExpectedBalanceDeltas(receipt *types.Receipt, from gethcommon.Address, value *big.Int) map[gethcommon.Address]*big.Int {
+	fees := f.ExpectedTxFees(receipt)
+	spent := new(big.Int).Add(fees.Total(), value)
+	return map[gethcommon.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:      fees.BaseFee,
+		predeploys.L1FeeVaultAddr:        fees.L1Fee,
+		predeploys.SequencerFeeVaultAddr: fees.PriorityFee,
+		predeploys.OperatorFeeVaultAddr:  fees.OperatorFee,
+		from:                             spent.Neg(spent),
+	}
+}
+
+func (f *Fees) stateAt(block gethcommon.Hash) *blockState {
+	return &blockState{f: f, block: block}
+}
+
+// blockState reads the state of the chain at a block, to compute fees with the cost functions of geth.
+type blockState struct {
+	f     *Fees
+	block gethcommon.Hash
+}
+
+var _ types.StateGetter = (*blockState)(nil)
+
+func (s *blockState) GetState(addr gethcommon.Address, key gethcommon.Hash) gethcommon.Hash {
+	ctx, cancel := context.WithTimeout(s.f.ctx, s.f.timeout)
+	defer cancel()
+	value, err := s.f.chain.el().EthClient().GetStorageAt(ctx, addr, key, s.block.String())
+	s.f.require.NoErrorf(err, "Failed to read storage slot %s of %s", key, addr)
+	return value
+}
@@ -0,0 +1,137 @@
+package dsl
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// isthmusChainConfig is the chain config of a L2 chain with all forks up to Isthmus active at genesis.
+func isthmusChainConfig() *params.ChainConfig {
+	zero := uint64(0)
+	return &params.ChainConfig{
+		ChainID:      testL2ChainID.ToBig(),
+		BedrockBlock: big.NewInt(0),
+		RegolithTime: &zero,
+		CanyonTime:   &zero,
+		EcotoneTime:  &zero,
+		FjordTime:    &zero,
+		GraniteTime:  &zero,
+		HoloceneTime: &zero,
+		IsthmusTime:  &zero,
+		Optimism:     &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50},
+	}
+}
+
+// fakeFeeBlock serves a block with a single transaction, and the fee parameters of the chain at that block.
+type fakeFeeBlock struct {
+	block   *sources.RPCBlock
+	storage map[gethcommon.Hash]gethcommon.Hash
+}
+
+func newFakeFeeBlock(tx *types.Transaction, baseFee *big.Int) *fakeFeeBlock {
+	txs := types.Transactions{tx}
+	header := sources.RPCHeader{
+		UncleHash: types.EmptyUncleHash,
+		TxHash:    types.DeriveSha(txs, trie.NewStackTrie(nil)),
+		Number:    10,
+		GasLimit:  30_000_000,
+		GasUsed:   hexutil.Uint64(tx.Gas()),
+		Time:      1000,
+		BaseFee:   (*hexutil.Big)(baseFee),
+	}
+	header.Hash = header.CreateGethHeader().Hash()
+	return &fakeFeeBlock{
+		block:   &sources.RPCBlock{RPCHeader: header, Transactions: txs},
+		storage: make(map[gethcommon.Hash]gethcommon.Hash),
+	}
+}
+
+func (b *fakeFeeBlock) rpc(t *testing.T) fakeRPC {
+	return fakeRPC{
+		"eth_getBlockByHash": func(args ...any) (any, error) {
+			require.Equal(t, b.block.Hash, args[0])
+			return b.block, nil
+		},
+		"eth_getStorageAt": func(args ...any) (any, error) {
+			require.Equal(t, types.L1BlockAddr, args[0], "fee parameters are stored in the L1Block contract")
+			if args[2] != b.block.Hash.String() {
+				return nil, fmt.Errorf("fee parameters must be read at block %s, not %v", b.block.Hash, args[2])
+			}
+			return b.storage[args[1].(gethcommon.Hash)], nil
+		},
+	}
+}
+
+func TestExpectedTxFees(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := gethcommon.HexToAddress("0x1234")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(testL2ChainID.ToBig()), &types.DynamicFeeTx{
+		ChainID:   testL2ChainID.ToBig(),
+		Nonce:     3,
+		GasTipCap: big.NewInt(2_000),
+		GasFeeCap: big.NewInt(11_000),
+		Gas:       60_000,
+		To:        &to,
+		Value:     big.NewInt(params.GWei),
+		Data:      []byte("calldata of the transaction"),
+	})
+	require.NoError(t, err)
+	baseFee := big.NewInt(10_000)
+	block := newFakeFeeBlock(tx, baseFee)
+
+	l1BaseFee, blobBaseFee := big.NewInt(7_000_000_000), big.NewInt(3)
+	baseFeeScalar, blobBaseFeeScalar := big.NewInt(1_368), big.NewInt(810_949)
+	operatorFeeScalar, operatorFeeConstant := big.NewInt(2_500_000), big.NewInt(40_000)
+	block.storage[types.L1BaseFeeSlot] = big256(l1BaseFee.Int64())
+	block.storage[types.L1BlobBaseFeeSlot] = big256(blobBaseFee.Int64())
+	var scalars gethcommon.Hash
+	baseFeeScalar.FillBytes(scalars[16:20])
+	blobBaseFeeScalar.FillBytes(scalars[20:24])
+	block.storage[types.L1FeeScalarsSlot] = scalars
+	var operatorParams gethcommon.Hash
+	operatorFeeScalar.FillBytes(operatorParams[20:24])
+	operatorFeeConstant.FillBytes(operatorParams[24:32])
+	block.storage[types.OperatorFeeParamsSlot] = operatorParams
+
+	fees := newTestL2Chain(t, t, isthmusChainConfig(), nil, block.rpc(t)).Fees()
+	require.Equal(t, OperatorFeeParams{Scalar: operatorFeeScalar, Constant: operatorFeeConstant}, fees.OperatorFeeParams(block.block.Hash))
+	require.Equal(t, L1FeeScalars{BaseFeeScalar: baseFeeScalar, BlobBaseFeeScalar: blobBaseFeeScalar}, fees.L1FeeScalars(block.block.Hash))
+
+	gasUsed := uint64(50_000)
+	receipt := &types.Receipt{BlockHash: block.block.Hash, TransactionIndex: 0, GasUsed: gasUsed}
+	actual := fees.ExpectedTxFees(receipt)
+	l1Fee, _ := types.NewL1CostFuncFjord(l1BaseFee, blobBaseFee, baseFeeScalar, blobBaseFeeScalar)(tx.RollupCostData())
+	require.Positive(t, l1Fee.Sign())
+	expected := TxFees{
+		BaseFee: big.NewInt(10_000 * 50_000),
+		L1Fee:   l1Fee,
+		// the tip is capped by the fee cap: 11_000 - 10_000 < 2_000
+		PriorityFee: big.NewInt(1_000 * 50_000),
+		OperatorFee: big.NewInt(50_000*2_500_000/1_000_000 + 40_000),
+	}
+	require.Equal(t, expected, actual)
+
+	deltas := fees.ExpectedBalanceDeltas(receipt, crypto.PubkeyToAddress(key.PublicKey), tx.Value())
+	spent := new(big.Int).Add(expected.Total(), tx.Value())
+	require.Equal(t, map[gethcommon.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:           expected.BaseFee,
+		predeploys.L1FeeVaultAddr:             expected.L1Fee,
+		predeploys.SequencerFeeVaultAddr:      expected.PriorityFee,
+		predeploys.OperatorFeeVaultAddr:       expected.OperatorFee,
+		crypto.PubkeyToAddress(key.PublicKey): spent.Neg(spent),
+	}, deltas)
+}
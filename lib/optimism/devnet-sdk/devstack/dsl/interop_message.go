@@ -0,0 +1,148 @@
+package dsl
+
+import (
+	"context"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/bindings"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/constants"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/txintent"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// MessageValidity selects how the executing message of an InteropMessage relates to the initiating message.
+type MessageValidity int
+
+const (
+	// ValidMessage executes the message as it was initiated.
+	ValidMessage MessageValidity = iota
+	// WrongTimestamp executes the message with an identifier timestamp that does not match the initiating block.
+	WrongTimestamp
+	// WrongPayload executes the message with a payload hash that does not match the initiating event.
+	WrongPayload
+)
+
+type ExecuteConfig struct {
+	Validity MessageValidity
+}
+
+// WithValidity sets the validity of the executing message, see MessageValidity.
+func WithValidity(validity MessageValidity) func(cfg *ExecuteConfig) {
+	return func(cfg *ExecuteConfig) {
+		cfg.Validity = validity
+	}
+}
+
+// InteropMessage emits an initiating message on the chain of a user,
+// and executes it on the chain of another user once the supervisor indexed it.
+type InteropMessage struct {
+	common
+
+	supervisor stack.Supervisor
+	from       *User
+	payload    []byte
+
+	emitter gethcommon.Address
+	msg     *suptypes.Message
+}
+
+// NewMessage creates an interop message with the given payload, to be initiated by the given user.
+func (s *Supervisor) NewMessage(from *User, payload []byte) *InteropMessage {
+	return &InteropMessage{
+		common:     commonWithLog(s.common, s.log.New("from", from.user.ID())),
+		supervisor: s.supervisor,
+		from:       from,
+		payload:    payload,
+	}
+}
+
+// Emit deploys an event-logger contract, emits the initiating message with it,
+// and waits for the supervisor to index the block the message was included in.
+func (m *InteropMessage) Emit() *InteropMessage {
+	defer m.record("InteropMessage.Emit", "from", m.from.user.ID())()
+	m.require.Nil(m.msg, "message must not already be emitted")
+	m.emitter = m.from.Deploy(gethcommon.FromHex(bindings.EventloggerMetaData.Bin))
+
+	trigger := &txintent.InitTrigger{
+		Emitter:    m.emitter,
+		Topics:     [][32]byte{crypto.Keccak256Hash(m.payload)},
+		OpaqueData: m.payload,
+	}
+	receipt := m.from.send(trigger)
+	m.require.Len(receipt.Logs, 1, "initiating transaction must emit a single event")
+	event := receipt.Logs[0]
+
+	ctx, cancel := context.WithTimeout(m.ctx, m.timeout)
+	defer cancel()
+	block, err := m.from.user.EL().EthClient().InfoByHash(ctx, receipt.BlockHash)
+	m.require.NoError(err, "Failed to fetch block of initiating message")
+	m.msg = &suptypes.Message{
+		Identifier: suptypes.Identifier{
+			Origin:      event.Address,
+			BlockNumber: event.BlockNumber,
+			LogIndex:    uint32(event.Index),
+			Timestamp:   block.Time(),
+			ChainID:     m.from.user.ChainID(),
+		},
+		PayloadHash: crypto.Keccak256Hash(suptypes.LogToMessagePayload(event)),
+	}
+	m.log.Info("Emitted initiating message", "identifier", m.msg.Identifier, "payloadHash", m.msg.PayloadHash)
+	m.waitIndexed(ctx)
+	return m
+}
+
+// Message returns the initiating message, the message must be emitted.
+func (m *InteropMessage) Message() suptypes.Message {
+	m.require.NotNil(m.msg, "message must be emitted")
+	return *m.msg
+}
+
+func (m *InteropMessage) waitIndexed(ctx context.Context) {
+	chainID := m.msg.Identifier.ChainID
+	err := wait.For(ctx, m.pollInterval, func() (bool, error) {
+		status, err := m.supervisor.QueryAPI().SyncStatus(ctx)
+		if err != nil {
+			return false, err
+		}
+		chStatus, ok := status.Chains[chainID]
+		if !ok || chStatus.LocalUnsafe.Number < m.msg.Identifier.BlockNumber {
+			m.log.Info("Initiating message not indexed yet", "chain", chainID, "block", m.msg.Identifier.BlockNumber)
+			return false, nil
+		}
+		return true, nil
+	})
+	m.require.NoError(err, "Expected initiating message to be indexed by the supervisor")
+}
+
+// ExecuteOn executes the message on the chain of the given user.
+// A valid message must be included successfully, and its receipt is returned.
+// An invalid message must be filtered out by the tx-pool of the node of the user, and nil is returned.
+func (m *InteropMessage) ExecuteOn(to *User, opts ...func(cfg *ExecuteConfig)) *types.Receipt {
+	defer m.record("InteropMessage.ExecuteOn", "from", m.from.user.ID(), "to", to.user.ID())()
+	cfg := applyOpts(ExecuteConfig{}, opts...)
+	msg := m.Message()
+	switch cfg.Validity {
+	case WrongTimestamp:
+		msg.Identifier.Timestamp += 1
+	case WrongPayload:
+		msg.PayloadHash = crypto.Keccak256Hash(msg.PayloadHash[:])
+	}
+	trigger := &txintent.ExecTrigger{
+		Executor: constants.CrossL2Inbox,
+		Msg:      msg,
+	}
+	if cfg.Validity == ValidMessage {
+		return to.send(trigger)
+	}
+	_, err := to.trySend(trigger)
+	// the error is returned over RPC, so it can only be matched by its message
+	m.require.ErrorContains(err, gethcore.ErrTxFilteredOut.Error(), "Executing message with validity %d must be filtered out", cfg.Validity)
+	m.log.Info("Invalid executing message was rejected", "validity", cfg.Validity, "err", err)
+	return nil
+}
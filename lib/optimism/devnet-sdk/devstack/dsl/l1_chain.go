@@ -0,0 +1,78 @@
+package dsl
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// L1Chain provides assertions on the L1 chain of a system, and access to its users.
+// Blocks are read from the first EL node of the chain.
+type L1Chain struct {
+	common
+
+	network stack.L1Network
+}
+
+func newL1Chain(c common, network stack.L1Network) *L1Chain {
+	return &L1Chain{
+		common:  c,
+		network: network,
+	}
+}
+
+func (c *L1Chain) ChainID() eth.ChainID {
+	return c.network.ChainID()
+}
+
+// User returns the DSL of the user with the given ID.
+func (c *L1Chain) User(id stack.UserID) *User {
+	return newUser(commonWithLog(c.common, c.log.New("user", id)), c.network.User(id), c.network)
+}
+
+// NewUser creates a new user, pre-funded by the faucet of the chain.
+func (c *L1Chain) NewUser() *User {
+	user := c.network.Faucet().NewUser()
+	return newUser(commonWithLog(c.common, c.log.New("user", user.ID())), user, c.network)
+}
+
+// WaitForBlock waits until the head of the chain reaches the given block number, and returns the head.
+func (c *L1Chain) WaitForBlock(num uint64) eth.BlockRef {
+	defer c.record("L1Chain.WaitForBlock", "chain", c.ChainID(), "num", num)()
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	var head eth.BlockRef
+	err := wait.For(ctx, c.pollInterval, func() (bool, error) {
+		var err error
+		head, err = c.el().EthClient().BlockRefByLabel(ctx, eth.Unsafe)
+		if err != nil {
+			return false, err
+		}
+		if head.Number < num {
+			c.log.Info("L1 block not reached yet", "head", head, "target", num)
+			return false, nil
+		}
+		return true, nil
+	})
+	c.require.NoErrorf(err, "Expected L1 head to reach block %d", num)
+	return head
+}
+
+// VerifyHeadAdvances verifies that the head of the chain advances by at least the given number of blocks,
+// compared to the head when VerifyHeadAdvances is called.
+func (c *L1Chain) VerifyHeadAdvances(by uint64) {
+	defer c.record("L1Chain.VerifyHeadAdvances", "chain", c.ChainID(), "by", by)()
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	initial, err := c.el().EthClient().BlockRefByLabel(ctx, eth.Unsafe)
+	c.require.NoError(err, "Failed to fetch L1 head")
+	c.WaitForBlock(initial.Number + by)
+}
+
+func (c *L1Chain) el() stack.L1ELNode {
+	ids := c.network.L1ELNodes()
+	c.require.NotEmpty(ids, "chain %s must have an EL node", c.network.ID())
+	return c.network.L1ELNode(ids[0])
+}
@@ -0,0 +1,231 @@
+package dsl
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/disputegames"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
+)
+
+// L2Chain provides assertions on the progression of the heads of a L2 chain.
+// The sync status is read from the first CL node of the chain, blocks from the first EL node.
+type L2Chain struct {
+	common
+
+	network stack.L2Network
+	// orch is the orchestrator of the system, to use optional orchestrator extensions with. May be nil.
+	orch stack.Orchestrator
+	// disputeGames are the dispute game indexes of the chains of the system, shared by all DSL helpers.
+	disputeGames *locks.RWMap[stack.L2NetworkID, *disputegames.Index]
+}
+
+func newL2Chain(c common, network stack.L2Network, orch stack.Orchestrator, disputeGames *locks.RWMap[stack.L2NetworkID, *disputegames.Index]) *L2Chain {
+	return &L2Chain{
+		common:       c,
+		network:      network,
+		orch:         orch,
+		disputeGames: disputeGames,
+	}
+}
+
+func (c *L2Chain) ChainID() eth.ChainID {
+	return c.network.ChainID()
+}
+
+// User returns the DSL of the user with the given ID.
+func (c *L2Chain) User(id stack.UserID) *User {
+	return newUser(commonWithLog(c.common, c.log.New("user", id)), c.network.User(id), c.network)
+}
+
+// Users returns the DSL of every pre-existing user of the chain, sorted by ID.
+func (c *L2Chain) Users() []*User {
+	ids := stack.SortUserIDs(c.network.Users())
+	users := make([]*User, 0, len(ids))
+	for _, id := range ids {
+		users = append(users, c.User(id))
+	}
+	return users
+}
+
+// NewUser creates a new user, pre-funded by the faucet of the chain.
+func (c *L2Chain) NewUser() *User {
+	user := c.network.Faucet().NewUser()
+	return newUser(commonWithLog(c.common, c.log.New("user", user.ID())), user, c.network)
+}
+
+// Batcher returns the DSL of the batcher with the given ID.
+func (c *L2Chain) Batcher(id stack.L2BatcherID) *Batcher {
+	return newBatcher(commonWithLog(c.common, c.log.New("batcher", id)), c.network.L2Batcher(id), c)
+}
+
+// WaitForBlock waits until the unsafe head of the chain reaches the given block number, and returns the unsafe head.
+func (c *L2Chain) WaitForBlock(num uint64) eth.L2BlockRef {
+	defer c.record("L2Chain.WaitForBlock", "chain", c.ChainID(), "num", num)()
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	return c.cl().WaitForUnsafeBlock(ctx, num)
+}
+
+// VerifyUnsafeHeadAdvances verifies that the unsafe head advances by at least the given number of blocks,
+// compared to the unsafe head when VerifyUnsafeHeadAdvances is called.
+func (c *L2Chain) VerifyUnsafeHeadAdvances(by uint64) {
+	defer c.record("L2Chain.VerifyUnsafeHeadAdvances", "chain", c.ChainID(), "by", by)()
+	c.verifyHeadAdvances("unsafe", by, func(status *eth.SyncStatus) eth.L2BlockRef { return status.UnsafeL2 })
+}
+
+// VerifySafeHeadAdvances verifies that the safe head advances by at least the given number of blocks,
+// compared to the safe head when VerifySafeHeadAdvances is called.
+func (c *L2Chain) VerifySafeHeadAdvances(by uint64) {
+	defer c.record("L2Chain.VerifySafeHeadAdvances", "chain", c.ChainID(), "by", by)()
+	c.verifyHeadAdvances("safe", by, func(status *eth.SyncStatus) eth.L2BlockRef { return status.SafeL2 })
+}
+
+// VerifyFinalizedHeadAdvances verifies that the finalized head advances by at least the given number of blocks,
+// compared to the finalized head when VerifyFinalizedHeadAdvances is called.
+func (c *L2Chain) VerifyFinalizedHeadAdvances(by uint64) {
+	defer c.record("L2Chain.VerifyFinalizedHeadAdvances", "chain", c.ChainID(), "by", by)()
+	c.verifyHeadAdvances("finalized", by, func(status *eth.SyncStatus) eth.L2BlockRef { return status.FinalizedL2 })
+}
+
+func (c *L2Chain) verifyHeadAdvances(name string, by uint64, head func(status *eth.SyncStatus) eth.L2BlockRef) {
+	initial := head(c.cl().SyncStatusSnapshot())
+	required := initial.Number + by
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	err := wait.For(ctx, c.pollInterval, func() (bool, error) {
+		current := head(c.cl().SyncStatusSnapshot())
+		if current.Number < required {
+			c.log.Info("Head has not advanced enough", "head", name, "initial", initial, "current", current, "minRequired", required)
+			return false, nil
+		}
+		return true, nil
+	})
+	c.require.NoErrorf(err, "Expected %s head to advance by %d blocks from %s", name, by, initial)
+}
+
+// VerifyNoReorg verifies that the unsafe blocks seen during the given window are not reorged out:
+// every unsafe head observed by a CL node while waiting must still be canonical on the EL node it drives
+// when the window ends.
+func (c *L2Chain) VerifyNoReorg(window time.Duration) {
+	defer c.record("L2Chain.VerifyNoReorg", "chain", c.ChainID(), "window", window)()
+	ids := c.network.L2CLNodes()
+	c.require.NotEmpty(ids, "chain %s must have a CL node", c.network.ID())
+	seen := make(map[stack.L2CLNodeID]map[uint64]eth.L2BlockRef, len(ids))
+	for _, id := range ids {
+		seen[id] = make(map[uint64]eth.L2BlockRef)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, window)
+	defer cancel()
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		for _, id := range ids {
+			head := c.network.L2CLNode(id).SyncStatusSnapshot().UnsafeL2
+			seen[id][head.Number] = head
+		}
+		select {
+		case <-ctx.Done():
+			c.require.NoError(c.ctx.Err(), "test context done before reorg window elapsed")
+			for _, id := range ids {
+				c.verifyCanonical(c.network.L2CLNode(id), seen[id])
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// verifyCanonical verifies that the blocks seen by the CL node are canonical on the EL node it drives.
+func (c *L2Chain) verifyCanonical(cl stack.L2CLNode, blocks map[uint64]eth.L2BlockRef) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	ethCl := c.network.L2ELNode(cl.EL()).EthClient()
+	for num, expected := range blocks {
+		current, err := ethCl.InfoByNumber(ctx, num)
+		c.require.NoErrorf(err, "Failed to fetch block %d from EL node %s", num, cl.EL())
+		c.require.Equalf(expected.Hash, current.Hash(), "Block %d seen by %s was reorged out", num, cl.ID())
+	}
+}
+
+// maxRealTimePeriod is the longest period that awaitPeriod waits for in real time, when the orchestrator cannot time travel.
+const maxRealTimePeriod = 5 * time.Minute
+
+// awaitPeriod waits for the period to pass, with time travel if the orchestrator supports it,
+// until the call succeeds. The call is expected to fail as long as the period has not passed.
+// Periods longer than maxRealTimePeriod require time travel, and fail the test without it.
+func (c *L2Chain) awaitPeriod(name string, period time.Duration, call func(ctx context.Context) error) {
+	timeout := c.timeout
+	if c.timeTravel(name, period) {
+		c.log.Info("Time traveled", "period", name, "duration", period)
+	} else {
+		c.require.LessOrEqualf(period, maxRealTimePeriod,
+			"%s of %s is too long to wait for in real time: the orchestrator must support time travel, e.g. with sysgo.WithTimeTravel", name, period)
+		c.log.Info("Waiting in real time", "period", name, "duration", period)
+		timeout += period
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, timeout)
+	defer cancel()
+	err := wait.For(ctx, c.pollInterval, func() (bool, error) {
+		if err := call(ctx); err != nil {
+			c.log.Info("Waiting period has not passed yet", "period", name, "err", err)
+			return false, nil
+		}
+		return true, nil
+	})
+	c.require.NoErrorf(err, "Expected %s to pass", name)
+}
+
+// timeTravel advances the L1 clock by the period, if the orchestrator supports it,
+// and waits for the L1 chain to include a block at the advanced time.
+// It returns false if the orchestrator cannot time travel.
+func (c *L2Chain) timeTravel(name string, period time.Duration) bool {
+	traveler, ok := c.orch.(stack.TimeTraveler)
+	if !ok {
+		return false
+	}
+	start := c.l1HeadTime()
+	if !traveler.TimeTravel(period) {
+		return false
+	}
+	target := start + uint64(period/time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	err := wait.For(ctx, c.pollInterval, func() (bool, error) {
+		return c.l1HeadTime() >= target, nil
+	})
+	c.require.NoErrorf(err, "time travel of %s did not advance the L1 clock to %d", name, target)
+	return true
+}
+
+// l1HeadTime returns the time of the head block of the L1 chain.
+func (c *L2Chain) l1HeadTime() uint64 {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+	head, err := c.l1EL().EthClient().BlockRefByLabel(ctx, eth.Unsafe)
+	c.require.NoError(err, "Failed to read L1 head")
+	return head.Time
+}
+
+func (c *L2Chain) cl() stack.L2CLNode {
+	ids := c.network.L2CLNodes()
+	c.require.NotEmpty(ids, "chain %s must have a CL node", c.network.ID())
+	return c.network.L2CLNode(ids[0])
+}
+
+func (c *L2Chain) el() stack.L2ELNode {
+	ids := c.network.L2ELNodes()
+	c.require.NotEmpty(ids, "chain %s must have an EL node", c.network.ID())
+	return c.network.L2ELNode(ids[0])
+}
+
+// l1EL returns the first EL node of the L1 chain of the chain.
+func (c *L2Chain) l1EL() stack.L1ELNode {
+	l1 := c.network.L1()
+	ids := l1.L1ELNodes()
+	c.require.NotEmpty(ids, "L1 chain %s must have an EL node", l1.ID())
+	return l1.L1ELNode(ids[0])
+}
@@ -0,0 +1,72 @@
+package dsl
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// fakeSyncStatus serves the sync status of a rollup node, with the safe head 2 blocks behind the unsafe head.
+func fakeSyncStatus(unsafe *atomic.Uint64) fakeRPC {
+	return fakeRPC{
+		"optimism_syncStatus": func(args ...any) (any, error) {
+			num := unsafe.Load()
+			return &eth.SyncStatus{
+				UnsafeL2: eth.L2BlockRef{Number: num},
+				SafeL2:   eth.L2BlockRef{Number: max(num, 2) - 2},
+			}, nil
+		},
+	}
+}
+
+func TestL2ChainWaitForBlock(t *testing.T) {
+	var unsafe atomic.Uint64
+	unsafe.Store(5)
+	chain := newTestL2Chain(t, t, nil, fakeSyncStatus(&unsafe), nil)
+	require.Equal(t, uint64(5), chain.WaitForBlock(3).Number)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		unsafe.Store(8)
+	}()
+	require.Equal(t, uint64(8), chain.WaitForBlock(8).Number)
+}
+
+func TestL2ChainVerifyHeadAdvances(t *testing.T) {
+	var unsafe atomic.Uint64
+	unsafe.Store(5)
+	chain := newTestL2Chain(t, t, nil, fakeSyncStatus(&unsafe), nil)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				unsafe.Add(1)
+			}
+		}
+	}()
+	chain.VerifyUnsafeHeadAdvances(3)
+	chain.VerifySafeHeadAdvances(3)
+}
+
+func TestL2ChainVerifyHeadAdvancesTimeout(t *testing.T) {
+	var unsafe atomic.Uint64
+	unsafe.Store(5)
+	chain := newTestL2Chain(t, panicT(t), nil, fakeSyncStatus(&unsafe), nil)
+
+	start := time.Now()
+	require.PanicsWithValue(t, "failed", func() {
+		chain.VerifyUnsafeHeadAdvances(1)
+	}, "head that does not advance must fail the test")
+	require.Less(t, time.Since(start), 10*time.Second, "the wait must end with the timeout of the DSL")
+}
@@ -0,0 +1,150 @@
+package dsl
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// Action is a named DSL action of a System, to run concurrently with other actions with Parallel.
+type Action struct {
+	name string
+	sys  *System
+	fn   func(sys *System)
+}
+
+// Action creates an action that runs fn against the system.
+// The system passed to fn is scoped to the action: its assertions fail only the action, and it logs with the action name.
+func (s *System) Action(name string, fn func(sys *System)) Action {
+	return Action{name: name, sys: s, fn: fn}
+}
+
+// Parallel runs the actions concurrently, and waits for all of them to complete.
+// Failures of all actions are collected, and reported together once all actions completed,
+// failing the test of the first action.
+func Parallel(actions ...Action) {
+	if len(actions) == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	results := make([]error, len(actions))
+	for i, action := range actions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scoped, t := action.scope()
+			// FailNow exits the goroutine of the action, like it exits the goroutine of a regular test,
+			// so the result of the action is collected in a deferred function.
+			defer func() {
+				if v := recover(); v != nil {
+					t.Errorf("panic: %v", v)
+				}
+				results[i] = t.err()
+			}()
+			scoped.log.Info("Running action")
+			action.fn(scoped)
+			scoped.log.Info("Action completed")
+		}()
+	}
+	wg.Wait()
+
+	var failures []error
+	for i, err := range results {
+		if err != nil {
+			failures = append(failures, fmt.Errorf("action %q: %w", actions[i].name, err))
+		}
+	}
+	first := actions[0].sys
+	first.require.NoErrorf(errors.Join(failures...), "%d of %d parallel actions failed", len(failures), len(actions))
+}
+
+// scope returns the system scoped to the action, and the T of the action.
+func (a Action) scope() (*System, *actionT) {
+	t := &actionT{parent: a.sys.t, name: a.name}
+	logger := a.sys.log.New("action", a.name)
+	return &System{
+		common: common{
+			ctx:          a.sys.ctx,
+			log:          logger,
+			t:            t,
+			require:      require.New(t),
+			timeout:      a.sys.timeout,
+			pollInterval: a.sys.pollInterval,
+			recorder:     a.sys.recorder,
+		},
+		log:          logger,
+		sys:          a.sys.sys,
+		orch:         a.sys.orch,
+		disputeGames: a.sys.disputeGames,
+	}, t
+}
+
+// actionT is the T of an action run with Parallel.
+// Errors are collected to report them when all actions completed, instead of failing the parent T right away.
+// Cleanups, logs and temp dirs are delegated to the parent T.
+type actionT struct {
+	parent stack.T
+	name   string
+
+	mu     sync.Mutex
+	errs   []error
+	failed bool
+}
+
+var _ stack.T = (*actionT)(nil)
+
+func (t *actionT) Errorf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errs = append(t.errs, fmt.Errorf(format, args...))
+}
+
+// FailNow marks the action as failed, and exits the goroutine of the action.
+func (t *actionT) FailNow() {
+	t.mu.Lock()
+	t.failed = true
+	t.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (t *actionT) Failed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failed || len(t.errs) > 0
+}
+
+// err returns the failure of the action, or nil if the action did not fail.
+func (t *actionT) err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.errs) > 0 {
+		return errors.Join(t.errs...)
+	}
+	if t.failed {
+		return errors.New("action failed")
+	}
+	return nil
+}
+
+func (t *actionT) TempDir() string {
+	return t.parent.TempDir()
+}
+
+func (t *actionT) Cleanup(fn func()) {
+	t.parent.Cleanup(fn)
+}
+
+func (t *actionT) Logf(format string, args ...any) {
+	t.parent.Logf(format, args...)
+}
+
+func (t *actionT) Helper() {}
+
+func (t *actionT) Name() string {
+	return t.parent.Name() + "/" + t.name
+}
@@ -0,0 +1,66 @@
+package dsl
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+)
+
+// Proposer provides assertions on the output proposals of a L2 chain.
+type Proposer struct {
+	common
+
+	proposer stack.L2Proposer
+	chain    *L2Chain
+}
+
+// Proposer returns the DSL of the proposer with the given ID.
+func (c *L2Chain) Proposer(id stack.L2ProposerID) *Proposer {
+	return &Proposer{
+		common:   commonWithLog(c.common, c.log.New("proposer", id)),
+		proposer: c.network.L2Proposer(id),
+		chain:    c,
+	}
+}
+
+// VerifyProposalLands waits for the proposer to make a new proposal, compared to when VerifyProposalLands is called,
+// and verifies that the proposal landed on L1 as dispute game of the proposed output root.
+// The dispute game of the proposal is returned.
+func (p *Proposer) VerifyProposalLands() *DisputeGame {
+	defer p.record("Proposer.VerifyProposalLands", "proposer", p.proposer.ID())()
+	initial := p.proposalCount()
+	ctx, cancel := context.WithTimeout(p.ctx, max(p.timeout, proposalTimeout))
+	defer cancel()
+	err := wait.For(ctx, p.pollInterval, func() (bool, error) {
+		count, err := p.proposer.ProposalCount(ctx)
+		if err != nil {
+			return false, err
+		}
+		if count <= initial {
+			p.log.Info("No new proposal yet", "count", count)
+			return false, nil
+		}
+		return true, nil
+	})
+	p.require.NoError(err, "Expected proposer to make a new proposal")
+
+	ctx, cancel = context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+	output, err := p.proposer.LatestProposedOutput(ctx)
+	p.require.NoError(err, "Failed to fetch latest proposal")
+	p.require.NotNil(output, "proposer must have a latest proposal")
+	game := p.chain.DisputeGame(output.Game)
+	p.require.Equal(output.OutputRoot, game.RootClaim(), "dispute game must claim the proposed output root")
+	p.require.Equal(output.L2SequenceNumber, game.L2SequenceNumber(), "dispute game must claim the proposed L2 block")
+	p.log.Info("Proposal landed", "game", game.Address, "outputRoot", output.OutputRoot, "l2Block", output.L2SequenceNumber)
+	return game
+}
+
+func (p *Proposer) proposalCount() uint64 {
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+	count, err := p.proposer.ProposalCount(ctx)
+	p.require.NoError(err, "Failed to fetch proposal count")
+	return count
+}
@@ -0,0 +1,161 @@
+package dsl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// ScenarioStep is a DSL verb invoked during a test, as recorded in a scenario trace.
+type ScenarioStep struct {
+	// Seq numbers the steps in the order they were invoked, starting at 1.
+	Seq uint64 `json:"seq"`
+	// Parent is the Seq of the verb that invoked this verb, or 0 if the test invoked it directly.
+	// Only the steps without parent are replayed, since the verbs invoke the other steps themselves.
+	Parent uint64 `json:"parent,omitempty"`
+	// Verb is the invoked DSL method, qualified by its component, e.g. "L2Chain.VerifySafeHeadAdvances".
+	Verb string `json:"verb"`
+	// Params are the parameters of the verb, as key-value pairs.
+	// The component the verb was invoked on is identified by its parameters too, e.g. by "chain".
+	Params map[string]string `json:"params,omitempty"`
+	Start  time.Time         `json:"start"`
+	// Duration is the time the verb took to complete, or to fail.
+	Duration time.Duration `json:"duration"`
+	// Result is "ok" if the verb completed, or "failed" if an assertion of the verb failed.
+	Result string `json:"result"`
+	// Error is the panic value of the verb, if it panicked.
+	Error string `json:"error,omitempty"`
+}
+
+// Recorder records the DSL verbs invoked during a test into a scenario trace,
+// so a failed test can be reviewed, and replayed step by step with a Replayer.
+// Steps are recorded in order of completion: verbs invoked by other verbs precede the invoking verb.
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	steps []ScenarioStep
+	seq   uint64
+	// open are the steps that are being invoked, by the test they are invoked in, innermost last.
+	// Actions of Parallel are scoped to their own test, so the verbs they invoke nest per action.
+	open map[stack.T][]uint64
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{open: make(map[stack.T][]uint64)}
+}
+
+// Steps returns a copy of the steps recorded so far.
+func (r *Recorder) Steps() []ScenarioStep {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ScenarioStep(nil), r.steps...)
+}
+
+// ReadScenarioTrace reads the steps of a scenario trace, as written with WriteJSON.
+func ReadScenarioTrace(rd io.Reader) ([]ScenarioStep, error) {
+	var steps []ScenarioStep
+	if err := json.NewDecoder(rd).Decode(&steps); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario trace: %w", err)
+	}
+	return steps, nil
+}
+
+// ReadScenarioTraceFile reads the steps of the scenario trace in the file at the given path.
+func ReadScenarioTraceFile(path string) ([]ScenarioStep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scenario trace file: %w", err)
+	}
+	defer f.Close()
+	return ReadScenarioTrace(f)
+}
+
+// WriteJSON writes the scenario trace as JSON.
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Steps())
+}
+
+// WriteFile writes the scenario trace as JSON to the file at the given path.
+func (r *Recorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create scenario trace file: %w", err)
+	}
+	defer f.Close()
+	if err := r.WriteJSON(f); err != nil {
+		return fmt.Errorf("failed to write scenario trace: %w", err)
+	}
+	return nil
+}
+
+// begin numbers a step that is invoked in the test, and returns its number and the number of its parent.
+func (r *Recorder) begin(t stack.T) (seq uint64, parent uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	if open := r.open[t]; len(open) > 0 {
+		parent = open[len(open)-1]
+	}
+	r.open[t] = append(r.open[t], r.seq)
+	return r.seq, parent
+}
+
+// end adds the completed step, which is the innermost open step of the test.
+func (r *Recorder) end(t stack.T, step ScenarioStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if open := r.open[t]; len(open) > 1 {
+		r.open[t] = open[:len(open)-1]
+	} else {
+		delete(r.open, t)
+	}
+	r.steps = append(r.steps, step)
+}
+
+// failer is implemented by test handles that can report whether the test failed, like testing.T.
+type failer interface {
+	Failed() bool
+}
+
+// record starts recording the verb with the given key-value parameters,
+// and returns the function that completes the recording. It must be deferred directly:
+//
+//	defer c.record("L2Chain.WaitForBlock", "num", num)()
+//
+// so a panic of the verb can be recorded. Nothing is recorded if the System has no Recorder.
+func (c common) record(verb string, params ...any) func() {
+	if c.recorder == nil {
+		return func() {}
+	}
+	step := ScenarioStep{Verb: verb, Start: time.Now()}
+	step.Seq, step.Parent = c.recorder.begin(c.t)
+	if len(params) > 0 {
+		step.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			step.Params[fmt.Sprint(params[i])] = fmt.Sprint(params[i+1])
+		}
+	}
+	f, canFail := c.t.(failer)
+	failedBefore := canFail && f.Failed()
+	return func() {
+		step.Duration = time.Since(step.Start)
+		step.Result = "ok"
+		if canFail && !failedBefore && f.Failed() {
+			step.Result = "failed"
+		}
+		if v := recover(); v != nil {
+			step.Result = "failed"
+			step.Error = fmt.Sprint(v)
+			c.recorder.end(c.t, step)
+			panic(v)
+		}
+		c.recorder.end(c.t, step)
+	}
+}
@@ -0,0 +1,89 @@
+package dsl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func newRecordingSystem(t *testing.T) *System {
+	logger := testlog.Logger(t, log.LevelInfo)
+	return &System{
+		common: common{
+			ctx:          context.Background(),
+			log:          logger,
+			t:            t,
+			require:      require.New(t),
+			timeout:      time.Second,
+			pollInterval: time.Millisecond,
+			recorder:     NewRecorder(),
+		},
+		log: logger,
+	}
+}
+
+// outerVerb and innerVerb are verbs like those of the DSL components, the outer verb invoking the inner verb.
+func outerVerb(c common, n int) {
+	defer c.record("Fake.Outer", "n", n)()
+	innerVerb(c, n)
+}
+
+func innerVerb(c common, n int) {
+	defer c.record("Fake.Inner", "n", n)()
+}
+
+func TestRecordReplay(t *testing.T) {
+	sys := newRecordingSystem(t)
+	outerVerb(sys.common, 1)
+	Parallel(
+		sys.Action("a", func(sys *System) { outerVerb(sys.common, 2) }),
+		sys.Action("b", func(sys *System) { outerVerb(sys.common, 3) }),
+	)
+
+	steps := sys.recorder.Steps()
+	require.Len(t, steps, 6)
+	parents := make(map[uint64]ScenarioStep)
+	for _, step := range steps {
+		if step.Verb == "Fake.Outer" {
+			parents[step.Seq] = step
+		}
+	}
+	for _, step := range steps {
+		if step.Verb == "Fake.Inner" {
+			parent, ok := parents[step.Parent]
+			require.True(t, ok, "inner verb must be nested in an outer verb")
+			require.Equal(t, parent.Params["n"], step.Params["n"], "inner verb must be nested in the outer verb that invoked it")
+			require.Less(t, parent.Seq, step.Seq, "outer verb must be invoked first")
+		} else {
+			require.Zero(t, step.Parent, "outer verbs are invoked by the test")
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, sys.recorder.WriteJSON(&buf))
+	trace, err := ReadScenarioTrace(&buf)
+	require.NoError(t, err)
+
+	replayed := newRecordingSystem(t)
+	var calls []string
+	replayer := NewReplayer(replayed)
+	replayer.Register("Fake.Outer", func(sys *System, params map[string]string) error {
+		calls = append(calls, params["n"])
+		outerVerb(sys.common, len(calls))
+		return nil
+	})
+	require.NoError(t, replayer.Replay(trace))
+	require.Equal(t, "1", calls[0], "steps must be replayed in order of invocation")
+	require.ElementsMatch(t, []string{"1", "2", "3"}, calls, "only the verbs invoked by the test must be replayed")
+	require.Len(t, replayed.recorder.Steps(), 6, "replayed verbs must invoke the nested verbs themselves")
+
+	err = NewReplayer(replayed).Replay(trace)
+	require.ErrorContains(t, err, `no replay function for verb "Fake.Outer"`)
+}
@@ -0,0 +1,136 @@
+package dsl
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ReplayFunc replays a recorded verb against a System, with the parameters it was recorded with.
+type ReplayFunc func(sys *System, params map[string]string) error
+
+// Replayer replays the steps of a scenario trace against a System, in the order they were invoked.
+// Only the verbs that the test invoked directly are replayed: they invoke the verbs they depend on themselves.
+// Verbs of actions that ran with Parallel are replayed one after another.
+//
+// The verbs of chains and batchers are replayed by default. Verbs that depend on state of the test,
+// like the users that are created during the test, need a ReplayFunc registered with Register.
+type Replayer struct {
+	sys   *System
+	verbs map[string]ReplayFunc
+}
+
+func NewReplayer(sys *System) *Replayer {
+	r := &Replayer{sys: sys, verbs: make(map[string]ReplayFunc)}
+	r.Register("L1Chain.WaitForBlock", replayL1("num", func(c *L1Chain, num uint64) { c.WaitForBlock(num) }))
+	r.Register("L1Chain.VerifyHeadAdvances", replayL1("by", (*L1Chain).VerifyHeadAdvances))
+	r.Register("L2Chain.WaitForBlock", replayL2("num", func(c *L2Chain, num uint64) { c.WaitForBlock(num) }))
+	r.Register("L2Chain.VerifyUnsafeHeadAdvances", replayL2("by", (*L2Chain).VerifyUnsafeHeadAdvances))
+	r.Register("L2Chain.VerifySafeHeadAdvances", replayL2("by", (*L2Chain).VerifySafeHeadAdvances))
+	r.Register("L2Chain.VerifyFinalizedHeadAdvances", replayL2("by", (*L2Chain).VerifyFinalizedHeadAdvances))
+	r.Register("L2Chain.VerifyNoReorg", func(sys *System, params map[string]string) error {
+		window, err := time.ParseDuration(params["window"])
+		if err != nil {
+			return fmt.Errorf("invalid parameter %q: %w", "window", err)
+		}
+		return replayL2("", func(c *L2Chain, _ uint64) { c.VerifyNoReorg(window) })(sys, params)
+	})
+	r.Register("Batcher.PauseBatching", replayBatcher((*Batcher).PauseBatching))
+	r.Register("Batcher.ResumeBatching", replayBatcher((*Batcher).ResumeBatching))
+	r.Register("Batcher.ForceSubmit", replayBatcher((*Batcher).ForceSubmit))
+	return r
+}
+
+// Register sets the function that replays the verb, replacing the default function of the verb, if any.
+func (r *Replayer) Register(verb string, fn ReplayFunc) {
+	r.verbs[verb] = fn
+}
+
+// Replay replays the steps that the test invoked directly, in the order they were invoked.
+// It stops at the first step that cannot be replayed.
+// Assertions of the replayed verbs fail the test of the System, like they did when the steps were recorded.
+func (r *Replayer) Replay(steps []ScenarioStep) error {
+	var top []ScenarioStep
+	for _, step := range steps {
+		if step.Parent == 0 {
+			top = append(top, step)
+		}
+	}
+	slices.SortFunc(top, func(a, b ScenarioStep) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	for _, step := range top {
+		fn, ok := r.verbs[step.Verb]
+		if !ok {
+			return fmt.Errorf("cannot replay step %d: no replay function for verb %q", step.Seq, step.Verb)
+		}
+		r.sys.log.Info("Replaying step", "seq", step.Seq, "verb", step.Verb, "params", step.Params)
+		if err := fn(r.sys, step.Params); err != nil {
+			return fmt.Errorf("failed to replay step %d (%s): %w", step.Seq, step.Verb, err)
+		}
+	}
+	return nil
+}
+
+// uint64Param parses the parameter with the given key. An empty key parses no parameter.
+func uint64Param(params map[string]string, key string) (uint64, error) {
+	if key == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(params[key], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid parameter %q: %w", key, err)
+	}
+	return v, nil
+}
+
+// replayL1 replays a verb of the L1 chain, with the number parameter of the given key.
+func replayL1(key string, fn func(c *L1Chain, v uint64)) ReplayFunc {
+	return func(sys *System, params map[string]string) error {
+		v, err := uint64Param(params, key)
+		if err != nil {
+			return err
+		}
+		c := sys.L1()
+		if got := c.ChainID().String(); got != params["chain"] {
+			return fmt.Errorf("system has L1 chain %s, not %s", got, params["chain"])
+		}
+		fn(c, v)
+		return nil
+	}
+}
+
+// replayL2 replays a verb of the L2 chain identified by the "chain" parameter,
+// with the number parameter of the given key.
+func replayL2(key string, fn func(c *L2Chain, v uint64)) ReplayFunc {
+	return func(sys *System, params map[string]string) error {
+		v, err := uint64Param(params, key)
+		if err != nil {
+			return err
+		}
+		for _, c := range sys.L2s() {
+			if c.ChainID().String() == params["chain"] {
+				fn(c, v)
+				return nil
+			}
+		}
+		return fmt.Errorf("system has no L2 chain %s", params["chain"])
+	}
+}
+
+// replayBatcher replays a verb of the batcher identified by the "batcher" parameter.
+func replayBatcher(fn func(b *Batcher)) ReplayFunc {
+	return func(sys *System, params map[string]string) error {
+		for _, c := range sys.L2s() {
+			for _, id := range c.network.L2Batchers() {
+				if id.String() == params["batcher"] {
+					fn(c.Batcher(id))
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("system has no batcher %s", params["batcher"])
+	}
+}
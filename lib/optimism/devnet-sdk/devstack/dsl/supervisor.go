@@ -2,7 +2,6 @@ package dsl
 
 import (
 	"context"
-	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
@@ -13,12 +12,15 @@ type Supervisor struct {
 	common
 
 	supervisor stack.Supervisor
+	// sys is the system of the supervisor, to look up the blocks of the chains the supervisor verifies.
+	sys stack.System
 }
 
-func newSupervisor(c common, supervisor stack.Supervisor) *Supervisor {
+func newSupervisor(c common, supervisor stack.Supervisor, sys stack.System) *Supervisor {
 	return &Supervisor{
 		common:     c,
 		supervisor: supervisor,
+		sys:        sys,
 	}
 }
 
@@ -36,11 +38,12 @@ func WithAllLocalUnsafeHeadsAdvancedBy(blocks uint64) func(cfg *VerifySyncStatus
 
 // VerifySyncStatus performs assertions based on the supervisor's SyncStatus endpoint.
 func (s *Supervisor) VerifySyncStatus(opts ...func(config *VerifySyncStatusConfig)) {
+	defer s.record("Supervisor.VerifySyncStatus", "supervisor", s.supervisor.ID())()
 	cfg := applyOpts(VerifySyncStatusConfig{}, opts...)
 	initial := s.fetchSyncStatus()
-	ctx, cancel := context.WithTimeout(s.ctx, defaultTimeout)
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
 	defer cancel()
-	err := wait.For(ctx, 1*time.Second, func() (bool, error) {
+	err := wait.For(ctx, s.pollInterval, func() (bool, error) {
 		status := s.fetchSyncStatus()
 		s.require.Equalf(len(initial.Chains), len(status.Chains), "Expected %d chains in status but got %d", len(initial.Chains), len(status.Chains))
 		for chID, chStatus := range status.Chains {
@@ -57,6 +60,100 @@ func (s *Supervisor) VerifySyncStatus(opts ...func(config *VerifySyncStatusConfi
 	s.require.NoError(err, "Expected sync status not found")
 }
 
+// VerifyCrossSafeAdvances verifies that the cross-safe head of every chain advances by at least one block,
+// compared to the cross-safe head when VerifyCrossSafeAdvances is called.
+func (s *Supervisor) VerifyCrossSafeAdvances() {
+	defer s.record("Supervisor.VerifyCrossSafeAdvances", "supervisor", s.supervisor.ID())()
+	chains := s.fetchSyncStatus().Chains
+	initial := make(map[eth.ChainID]eth.BlockID, len(chains))
+	for chID := range chains {
+		initial[chID] = s.fetchCrossSafe(chID)
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+	err := wait.For(ctx, s.pollInterval, func() (bool, error) {
+		for chID, chInitial := range initial {
+			current := s.fetchCrossSafe(chID)
+			if current.Number <= chInitial.Number {
+				s.log.Info("Cross-safe head has not advanced yet", "chain", chID, "initial", chInitial, "current", current)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	s.require.NoError(err, "Expected cross-safe heads of all chains to advance")
+}
+
+// VerifyBlockReplaced verifies that the block with the given number of the chain is replaced:
+// the supervisor must invalidate the block that is canonical when VerifyBlockReplaced is called,
+// and promote a different block with the same number to cross-safe.
+func (s *Supervisor) VerifyBlockReplaced(chainID eth.ChainID, number uint64) {
+	defer s.record("Supervisor.VerifyBlockReplaced", "supervisor", s.supervisor.ID(), "chain", chainID, "number", number)()
+	ethCl := s.el(chainID).EthClient()
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+	original, err := ethCl.InfoByNumber(ctx, number)
+	s.require.NoErrorf(err, "Failed to fetch block %d", number)
+	s.log.Info("Awaiting block replacement", "chain", chainID, "number", number, "original", original.Hash())
+
+	err = wait.For(ctx, s.pollInterval, func() (bool, error) {
+		crossSafe := s.fetchCrossSafe(chainID)
+		if crossSafe.Number < number {
+			s.log.Info("Block not cross-safe yet", "chain", chainID, "crossSafe", crossSafe, "number", number)
+			return false, nil
+		}
+		current, err := ethCl.InfoByNumber(ctx, number)
+		if err != nil {
+			return false, err
+		}
+		if current.Hash() == original.Hash() {
+			s.log.Info("Block became cross-safe without replacement", "chain", chainID, "number", number, "hash", current.Hash())
+			return false, nil
+		}
+		s.log.Info("Block replaced", "chain", chainID, "number", number, "replacement", current.Hash())
+		return true, nil
+	})
+	s.require.NoErrorf(err, "Expected block %d of chain %s to be replaced", number, chainID)
+}
+
+// AwaitReset waits for the supervisor to reset a chain, and returns the ID of the chain:
+// the local-unsafe head of the chain must be rewound below the local-unsafe head when AwaitReset is called.
+func (s *Supervisor) AwaitReset() eth.ChainID {
+	defer s.record("Supervisor.AwaitReset", "supervisor", s.supervisor.ID())()
+	initial := s.fetchSyncStatus()
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+	var reset eth.ChainID
+	err := wait.For(ctx, s.pollInterval, func() (bool, error) {
+		status := s.fetchSyncStatus()
+		for chID, chStatus := range status.Chains {
+			chInitial, ok := initial.Chains[chID]
+			if ok && chStatus.LocalUnsafe.Number < chInitial.LocalUnsafe.Number {
+				s.log.Info("Chain was reset", "chain", chID, "initialUnsafe", chInitial.LocalUnsafe, "currentUnsafe", chStatus.LocalUnsafe)
+				reset = chID
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	s.require.NoError(err, "Expected supervisor to reset a chain")
+	return reset
+}
+
+func (s *Supervisor) fetchCrossSafe(chainID eth.ChainID) eth.BlockID {
+	crossSafe, err := s.supervisor.QueryAPI().CrossSafe(s.ctx, chainID)
+	s.require.NoErrorf(err, "Failed to fetch cross-safe head of chain %s", chainID)
+	return crossSafe.Derived
+}
+
+// el returns the first EL node of the chain, to read the blocks of the chain with.
+func (s *Supervisor) el(chainID eth.ChainID) stack.L2ELNode {
+	network := s.sys.L2Network(s.sys.L2NetworkID(chainID))
+	ids := network.L2ELNodes()
+	s.require.NotEmpty(ids, "chain %s must have an EL node", network.ID())
+	return network.L2ELNode(ids[0])
+}
+
 func (s *Supervisor) fetchSyncStatus() eth.SupervisorSyncStatus {
 	s.log.Debug("Fetching supervisor sync status")
 	status, err := s.supervisor.QueryAPI().SyncStatus(s.ctx)
@@ -0,0 +1,120 @@
+package dsl
+
+import (
+	"context"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/txintent"
+)
+
+// User sends transactions on behalf of a stack.User, and waits for them to be included.
+// Funding goes through the faucet of the network of the user.
+type User struct {
+	common
+
+	user    stack.User
+	network stack.Network
+}
+
+func newUser(c common, user stack.User, network stack.Network) *User {
+	return &User{
+		common:  c,
+		user:    user,
+		network: network,
+	}
+}
+
+func (u *User) Address() gethcommon.Address {
+	return u.user.Address()
+}
+
+// Balance returns the current balance of the user, in wei.
+func (u *User) Balance() *big.Int {
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	balance, err := u.user.EL().EthClient().BalanceAt(ctx, u.user.Address(), nil)
+	u.require.NoError(err, "Failed to fetch balance")
+	return balance
+}
+
+// Fund requests the faucet of the network to send the amount of wei to the user,
+// and verifies that the balance of the user increased by at least the amount.
+// Other transfers to the user may be included alongside the funding, so the balance is not required to match exactly.
+func (u *User) Fund(amount *big.Int) *types.Receipt {
+	defer u.record("User.Fund", "user", u.user.ID(), "amount", amount)()
+	before := u.Balance()
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	txHash, err := u.network.Faucet().Fund(ctx, u.user.Address(), amount)
+	u.require.NoError(err, "Failed to request funds")
+	receipt := u.waitReceipt(ctx, txHash)
+	expected := new(big.Int).Add(before, amount)
+	balance := u.Balance()
+	u.require.True(balance.Cmp(expected) >= 0, "Balance must increase by at least the funded amount, expected at least %s, got %s", expected, balance)
+	return receipt
+}
+
+// Transfer sends the amount of wei to the given address, and waits for the transfer to be included.
+func (u *User) Transfer(to gethcommon.Address, amount *big.Int) *types.Receipt {
+	defer u.record("User.Transfer", "user", u.user.ID(), "to", to, "amount", amount)()
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	txHash, err := u.user.Transfer(ctx, to, amount)
+	u.require.NoError(err, "Failed to send transfer")
+	return u.waitReceipt(ctx, txHash)
+}
+
+// Deploy deploys a contract with the given init code, waits for the deployment to be included,
+// and returns the address of the contract.
+func (u *User) Deploy(code []byte) gethcommon.Address {
+	defer u.record("User.Deploy", "user", u.user.ID())()
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	txHash, addr, err := u.user.Deploy(ctx, code)
+	u.require.NoError(err, "Failed to send deployment")
+	receipt := u.waitReceipt(ctx, txHash)
+	u.require.Equal(addr, receipt.ContractAddress, "Contract must be deployed at the expected address")
+	return addr
+}
+
+// send sends a transaction with the call, and waits for it to be included successfully.
+func (u *User) send(call txintent.Call) *types.Receipt {
+	return u.sendTx(u.callRequest(call))
+}
+
+// trySend sends a transaction with the call, without waiting for it to be included.
+func (u *User) trySend(call txintent.Call) (gethcommon.Hash, error) {
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	return u.user.SendTx(ctx, u.callRequest(call))
+}
+
+func (u *User) callRequest(call txintent.Call) stack.TxRequest {
+	to, err := call.To()
+	u.require.NoError(err, "Failed to determine recipient")
+	data, err := call.Data()
+	u.require.NoError(err, "Failed to encode calldata")
+	accessList, err := call.AccessList()
+	u.require.NoError(err, "Failed to determine access list")
+	return stack.TxRequest{To: to, Data: data, AccessList: accessList}
+}
+
+// sendTx sends the transaction, and waits for it to be included successfully.
+func (u *User) sendTx(req stack.TxRequest) *types.Receipt {
+	ctx, cancel := context.WithTimeout(u.ctx, u.timeout)
+	defer cancel()
+	txHash, err := u.user.SendTx(ctx, req)
+	u.require.NoError(err, "Failed to send transaction")
+	return u.waitReceipt(ctx, txHash)
+}
+
+func (u *User) waitReceipt(ctx context.Context, txHash gethcommon.Hash) *types.Receipt {
+	receipt, err := u.user.WaitReceipt(ctx, txHash)
+	u.require.NoErrorf(err, "Transaction %s failed", txHash)
+	u.log.Info("Transaction included", "tx", txHash, "block", receipt.BlockNumber)
+	return receipt
+}
@@ -0,0 +1,232 @@
+package dsl
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	bindingspreview "github.com/ethereum-optimism/optimism/op-node/bindings/preview"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+const (
+	// withdrawalGasLimit is the gas limit of the L1 call to the target of a withdrawal.
+	withdrawalGasLimit = 100_000
+	// proposalTimeout is the minimum time to wait for an output covering the withdrawal to be proposed:
+	// the withdrawal has to be batch-submitted, derived as safe, and then proposed.
+	proposalTimeout = 2 * time.Minute
+)
+
+// Withdrawal runs the lifecycle of a withdrawal of ETH from a L2 chain to L1:
+// initiation on L2, proving and finalizing on L1, with balance assertions along the way.
+// Waiting periods are skipped with time travel, if the orchestrator supports it.
+type Withdrawal struct {
+	common
+
+	chain *L2Chain
+	from  *User
+	to    *User
+
+	ev      *bindings.L2ToL1MessagePasserMessagePassed
+	l2Block uint64
+	output  *stack.ProposedOutput
+	game    *DisputeGame
+}
+
+// NewWithdrawal creates a withdrawal from the L2 user on the chain, to the L1 user.
+// The L1 user also sends the prove and finalize transactions.
+func (c *L2Chain) NewWithdrawal(from *User, to *User) *Withdrawal {
+	return &Withdrawal{
+		common: commonWithLog(c.common, c.log.New("from", from.user.ID(), "to", to.user.ID())),
+		chain:  c,
+		from:   from,
+		to:     to,
+	}
+}
+
+// Complete initiates, proves and finalizes a withdrawal of the given amount.
+func (w *Withdrawal) Complete(amount *big.Int) {
+	w.Initiate(amount)
+	w.WaitForProposal()
+	w.Prove()
+	w.Finalize()
+}
+
+// Initiate sends the withdrawal of the amount of wei through the L2ToL1MessagePasser,
+// and verifies that the balance of the L2 user decreased by at least the amount.
+func (w *Withdrawal) Initiate(amount *big.Int) {
+	defer w.record("Withdrawal.Initiate", "chain", w.chain.ChainID(), "from", w.from.user.ID(), "amount", amount)()
+	w.require.Nil(w.ev, "withdrawal must not already be initiated")
+	before := w.from.Balance()
+	passerABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	w.require.NoError(err)
+	data, err := passerABI.Pack("initiateWithdrawal", w.to.Address(), big.NewInt(withdrawalGasLimit), []byte{})
+	w.require.NoError(err)
+	receipt := w.from.sendTx(stack.TxRequest{To: &predeploys.L2ToL1MessagePasserAddr, Value: amount, Data: data})
+
+	w.ev, err = withdrawals.ParseMessagePassed(receipt)
+	w.require.NoError(err, "Failed to parse withdrawal event")
+	w.l2Block = receipt.BlockNumber.Uint64()
+	w.log.Info("Initiated withdrawal", "hash", gethcommon.Hash(w.ev.WithdrawalHash), "block", w.l2Block)
+	w.require.LessOrEqual(w.from.Balance().Cmp(new(big.Int).Sub(before, amount)), 0,
+		"L2 balance must decrease by at least the withdrawn amount")
+}
+
+// WaitForProposal waits for the proposer of the chain to propose an output that includes the withdrawal.
+func (w *Withdrawal) WaitForProposal() {
+	defer w.record("Withdrawal.WaitForProposal", "chain", w.chain.ChainID())()
+	w.require.NotNil(w.ev, "withdrawal must be initiated")
+	proposers := w.chain.network.L2Proposers()
+	w.require.NotEmpty(proposers, "chain must have a proposer")
+	proposer := w.chain.network.L2Proposer(proposers[0])
+
+	ctx, cancel := context.WithTimeout(w.ctx, max(w.timeout, proposalTimeout))
+	defer cancel()
+	err := wait.For(ctx, w.pollInterval, func() (bool, error) {
+		output, err := proposer.LatestProposedOutput(ctx)
+		if err != nil {
+			return false, err
+		}
+		if output == nil || output.L2SequenceNumber < w.l2Block {
+			w.log.Info("Withdrawal not proposed yet", "withdrawalBlock", w.l2Block, "latest", output)
+			return false, nil
+		}
+		w.output = output
+		return true, nil
+	})
+	w.require.NoError(err, "Expected output with withdrawal to be proposed")
+	w.game = w.chain.DisputeGame(w.output.Game)
+	w.log.Info("Withdrawal proposed", "game", w.output.Game, "index", w.game.Index, "l2Block", w.output.L2SequenceNumber)
+}
+
+// Prove proves the withdrawal on L1, against the output that was proposed with it.
+// The proof is generated and verified with the withdrawal helpers of op-node, as in op-e2e.
+func (w *Withdrawal) Prove() {
+	defer w.record("Withdrawal.Prove", "chain", w.chain.ChainID())()
+	w.require.NotNil(w.output, "withdrawal must be proposed")
+	ctx, cancel := context.WithTimeout(w.ctx, w.timeout)
+	defer cancel()
+	l2 := w.from.user.EL().EthClient()
+	block, err := l2.InfoByNumber(ctx, w.output.L2SequenceNumber)
+	w.require.NoError(err, "Failed to fetch proposed L2 block")
+	headerRLP, err := block.HeaderRLP()
+	w.require.NoError(err)
+	var header types.Header
+	w.require.NoError(rlp.DecodeBytes(headerRLP, &header), "Failed to decode proposed L2 block header")
+	params, err := withdrawals.ProveWithdrawalParametersForEvent(ctx, proofClient{l2}, w.ev, &header, w.game.Index)
+	w.require.NoError(err, "Failed to prove withdrawal parameters")
+	outputRootProof := bindingspreview.TypesOutputRootProof{
+		Version:                  params.OutputRootProof.Version,
+		StateRoot:                params.OutputRootProof.StateRoot,
+		MessagePasserStorageRoot: params.OutputRootProof.MessagePasserStorageRoot,
+		LatestBlockhash:          params.OutputRootProof.LatestBlockhash,
+	}
+
+	portalABI, err := bindingspreview.OptimismPortal2MetaData.GetAbi()
+	w.require.NoError(err)
+	data, err := portalABI.Pack("proveWithdrawalTransaction", w.withdrawalTx(), params.L2OutputIndex, outputRootProof, params.WithdrawalProof)
+	w.require.NoError(err)
+	portal := w.chain.network.Deployment().OptimismPortalProxyAddr()
+	w.to.sendTx(stack.TxRequest{To: &portal, Data: data})
+	w.log.Info("Proved withdrawal")
+}
+
+// Finalize resolves the dispute game of the withdrawal, waits for the finalization window to pass,
+// finalizes the withdrawal on L1, and verifies that the L1 user received the withdrawn amount.
+func (w *Withdrawal) Finalize() {
+	defer w.record("Withdrawal.Finalize", "chain", w.chain.ChainID(), "to", w.to.user.ID())()
+	w.require.NotNil(w.game, "withdrawal must be proven")
+	w.game.Resolve(w.to)
+
+	ctx, cancel := context.WithTimeout(w.ctx, w.timeout)
+	defer cancel()
+	l1 := w.to.user.EL().EthClient()
+	portal := w.chain.network.Deployment().OptimismPortalProxyAddr()
+	portalABI, err := bindingspreview.OptimismPortal2MetaData.GetAbi()
+	w.require.NoError(err)
+	results, err := contractCall(ctx, l1, portal, portalABI, "proofMaturityDelaySeconds")
+	w.require.NoError(err)
+	delay := results[0].(*big.Int).Uint64()
+	results, err = contractCall(ctx, l1, portal, portalABI, "disputeGameFinalityDelaySeconds")
+	w.require.NoError(err)
+	delay = max(delay, results[0].(*big.Int).Uint64())
+	w.chain.awaitPeriod("finalization window", time.Duration(delay+1)*time.Second,
+		func(ctx context.Context) error {
+			_, err := contractCallFrom(ctx, l1, w.to.Address(), portal, portalABI, "checkWithdrawal",
+				gethcommon.Hash(w.ev.WithdrawalHash), w.to.Address())
+			return err
+		})
+
+	before := w.to.Balance()
+	data, err := portalABI.Pack("finalizeWithdrawalTransaction", w.withdrawalTx())
+	w.require.NoError(err)
+	receipt := w.to.sendTx(stack.TxRequest{To: &portal, Data: data})
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	expected := new(big.Int).Sub(new(big.Int).Add(before, w.ev.Value), fee)
+	w.require.Equal(expected, w.to.Balance(), "L1 balance must increase by the withdrawn amount, minus the finalization fee")
+	w.log.Info("Finalized withdrawal")
+}
+
+func (w *Withdrawal) withdrawalTx() bindingspreview.TypesWithdrawalTransaction {
+	return bindingspreview.TypesWithdrawalTransaction{
+		Nonce:    w.ev.Nonce,
+		Sender:   w.ev.Sender,
+		Target:   w.ev.Target,
+		Value:    w.ev.Value,
+		GasLimit: w.ev.GasLimit,
+		Data:     w.ev.Data,
+	}
+}
+
+// proofClient adapts an EthClient to the proof client of the withdrawal helpers of op-node.
+type proofClient struct {
+	cl apis.EthClient
+}
+
+var _ withdrawals.ProofClient = proofClient{}
+
+func (p proofClient) GetProof(ctx context.Context, addr gethcommon.Address, storage []string, block *big.Int) (*gethclient.AccountResult, error) {
+	keys := make([]gethcommon.Hash, len(storage))
+	for i, key := range storage {
+		keys[i] = gethcommon.HexToHash(key)
+	}
+	res, err := p.cl.GetProof(ctx, addr, keys, hexutil.EncodeBig(block))
+	if err != nil {
+		return nil, err
+	}
+	out := &gethclient.AccountResult{
+		Address:      res.Address,
+		AccountProof: make([]string, len(res.AccountProof)),
+		Balance:      res.Balance.ToInt(),
+		CodeHash:     res.CodeHash,
+		Nonce:        uint64(res.Nonce),
+		StorageHash:  res.StorageHash,
+		StorageProof: make([]gethclient.StorageResult, len(res.StorageProof)),
+	}
+	for i, node := range res.AccountProof {
+		out.AccountProof[i] = node.String()
+	}
+	for i, entry := range res.StorageProof {
+		proof := make([]string, len(entry.Proof))
+		for j, node := range entry.Proof {
+			proof[j] = node.String()
+		}
+		out.StorageProof[i] = gethclient.StorageResult{
+			Key:   hexutil.Encode(entry.Key),
+			Value: entry.Value.ToInt(),
+			Proof: proof,
+		}
+	}
+	return out, nil
+}
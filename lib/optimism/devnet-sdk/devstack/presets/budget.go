@@ -0,0 +1,101 @@
+package presets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// stateDumpTimeout bounds how long the state dump of a test that exceeded its budget may take.
+const stateDumpTimeout = 10 * time.Second
+
+// WithBudget limits the wall-clock time of the test, counted from when the option is applied.
+// When the test exceeds its budget, the test fails with a dump of the state of the system:
+// the heads of the EL nodes, the sync status of the CL nodes and supervisors, and the recent logs of the test.
+// The context of the setup is canceled as well, so DSL verbs that wait on the system return.
+//
+// The option must be applied before the DSL is hydrated, so the DSL uses the context that is canceled.
+func WithBudget(budget time.Duration) stack.Option {
+	return func(setup *stack.Setup) {
+		setup.Require.NotNil(setup.System, "need system to dump the state of")
+		ctx, cancel := context.WithCancel(setup.Ctx)
+		setup.Ctx = ctx
+		start := time.Now()
+		timer := time.AfterFunc(budget, func() {
+			setup.T.Errorf("test exceeded its budget of %s after %s\n%s", budget, time.Since(start), dumpState(setup))
+			cancel()
+		})
+		setup.T.Cleanup(func() {
+			timer.Stop()
+			cancel()
+		})
+	}
+}
+
+// dumpState describes the state of the system of the setup, for a test failure report.
+// Errors to fetch the state of a component are part of the description.
+func dumpState(setup *stack.Setup) string {
+	ctx, cancel := context.WithTimeout(context.Background(), stateDumpTimeout)
+	defer cancel()
+	sys := setup.System
+
+	var out strings.Builder
+	out.WriteString("System state:\n")
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		for _, elID := range l1.L1ELNodes() {
+			head, err := l1.L1ELNode(elID).EthClient().BlockRefByLabel(ctx, eth.Unsafe)
+			writeState(&out, elID, fmt.Sprintf("head %s", head), err)
+		}
+	}
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		for _, elID := range l2.L2ELNodes() {
+			head, err := l2.L2ELNode(elID).EthClient().BlockRefByLabel(ctx, eth.Unsafe)
+			writeState(&out, elID, fmt.Sprintf("head %s", head), err)
+		}
+		for _, clID := range l2.L2CLNodes() {
+			status, err := l2.L2CLNode(clID).RollupAPI().SyncStatus(ctx)
+			if err != nil {
+				writeState(&out, clID, "", err)
+				continue
+			}
+			writeState(&out, clID, fmt.Sprintf("current L1 %s, unsafe %s, safe %s, finalized %s",
+				status.CurrentL1, status.UnsafeL2, status.SafeL2, status.FinalizedL2), nil)
+		}
+	}
+	for _, id := range sys.Supervisors() {
+		status, err := sys.Supervisor(id).QueryAPI().SyncStatus(ctx)
+		if err != nil {
+			writeState(&out, id, "", err)
+			continue
+		}
+		writeState(&out, id, fmt.Sprintf("min synced L1 %s, safe timestamp %d, finalized timestamp %d",
+			status.MinSyncedL1, status.SafeTimestamp, status.FinalizedTimestamp), nil)
+		for chainID, chain := range status.Chains {
+			fmt.Fprintf(&out, "    chain %s: local unsafe %s, safe %s, finalized %s\n",
+				chainID, chain.LocalUnsafe, chain.Safe, chain.Finalized)
+		}
+	}
+
+	if recent, ok := testRecentLogs.Get(setup.T); ok {
+		out.WriteString("Recent logs:\n")
+		for _, line := range recent.Lines() {
+			out.WriteString(line)
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+func writeState(out *strings.Builder, id fmt.Stringer, state string, err error) {
+	if err != nil {
+		fmt.Fprintf(out, "  %s: error: %v\n", id, err)
+		return
+	}
+	fmt.Fprintf(out, "  %s: %s\n", id, state)
+}
@@ -0,0 +1,37 @@
+package presets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// WithBlockEvents watches all L2 CL nodes of the system, and publishes a stack.BlockBuiltEvent
+// on the event bus of the system for every new unsafe block.
+// The option must be applied after the L2 CL nodes are added to the system.
+// Watching stops when the test is cleaned up.
+func WithBlockEvents(interval time.Duration) stack.Option {
+	return func(setup *stack.Setup) {
+		setup.Require.NotNil(setup.System, "need system to watch")
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		for _, l2ID := range setup.System.L2Networks() {
+			l2 := setup.System.L2Network(l2ID)
+			for _, nodeID := range l2.L2CLNodes() {
+				node := l2.L2CLNode(nodeID)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					shim.WatchBlocks(ctx, setup.Log, setup.System.Events(), node, interval)
+				}()
+			}
+		}
+		setup.T.Cleanup(func() {
+			cancel()
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package presets
+
+import (
+	"testing"
+)
+
+// TestMain ensures the orchestrator is setup correctly for this package.
+func TestMain(m *testing.M) {
+	DoMain(m)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/dsl"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/sysgo"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/syskt"
 )
 
 type SimpleInterop struct {
@@ -27,10 +28,76 @@ func NewSimpleInterop(t stack.T, opts ...stack.Option) *SimpleInterop {
 	setup.Require.NoError(err, "could not get contract paths")
 	ids, opt := sysgo.DefaultInteropSystem(contracts)
 	opt(setup)
+	applyAfterSetup(setup)
 
+	awaitReady(setup)
 	sys := dsl.Hydrate(setup)
 	return &SimpleInterop{
 		Log:        setup.Log,
 		Supervisor: sys.Supervisor(ids.Supervisor),
 	}
 }
+
+// InteropUsers are the pre-existing users of the chains of an Interop system.
+type InteropUsers struct {
+	L2A []*dsl.User
+	L2B []*dsl.User
+}
+
+// Interop is a system of two interop L2 chains, verified by a supervisor.
+type Interop struct {
+	Log    log.Logger
+	System *dsl.System
+
+	Supervisor *dsl.Supervisor
+	L2A        *dsl.L2Chain
+	L2B        *dsl.L2Chain
+	Users      InteropUsers
+}
+
+type interopIDs struct {
+	Supervisor stack.SupervisorID
+	L2A        stack.L2NetworkID
+	L2B        stack.L2NetworkID
+}
+
+// NewInterop creates an Interop system with the default interop system of the backend of the global orchestrator,
+// with the options applied to the setup of the system:
+// an in-process system with sysgo, or the devnet described by DEVNET_ENV_URL with syskt.
+// With sysgo, the system is owned by the test, unless the Shared option is passed.
+func NewInterop(t stack.T, opts ...stack.Option) *Interop {
+	setup, ids := setupPreset(t, "interop", opts, func(setup *stack.Setup) (ids interopIDs) {
+		switch setup.Orchestrator.(type) {
+		case *sysgo.Orchestrator:
+			contracts, err := contractPaths()
+			setup.Require.NoError(err, "could not get contract paths")
+			sysIDs, opt := sysgo.DefaultInteropSystem(contracts)
+			opt(setup)
+			ids = interopIDs{Supervisor: sysIDs.Supervisor, L2A: sysIDs.L2A, L2B: sysIDs.L2B}
+		case *syskt.Orchestrator:
+			sysIDs, opt := syskt.DefaultSystemExt(loadDevnetEnv(setup))
+			opt(setup)
+			setup.Require.GreaterOrEqual(len(sysIDs.L2s), 2, "interop devnet must have two L2 chains")
+			setup.Require.NotEmpty(sysIDs.Supervisor, "interop devnet must have a supervisor")
+			ids = interopIDs{Supervisor: sysIDs.Supervisor, L2A: sysIDs.L2s[0].L2, L2B: sysIDs.L2s[1].L2}
+		default:
+			setup.Require.Failf("unsupported orchestrator", "cannot create an interop system with orchestrator %T", setup.Orchestrator)
+		}
+		return ids
+	})
+
+	awaitReady(setup)
+	sys := dsl.Hydrate(setup)
+	l2A, l2B := sys.L2(ids.L2A), sys.L2(ids.L2B)
+	return &Interop{
+		Log:        setup.Log,
+		System:     sys,
+		Supervisor: sys.Supervisor(ids.Supervisor),
+		L2A:        l2A,
+		L2B:        l2B,
+		Users: InteropUsers{
+			L2A: l2A.Users(),
+			L2B: l2B.Users(),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSimpleInterop(t *testing.T) {
+	sys := NewSimpleInterop(t)
+	require.NotNil(t, sys.Supervisor)
+	sys.Supervisor.VerifySyncStatus()
+}
+
+func TestNewInterop(t *testing.T) {
+	sys := NewInterop(t)
+	require.NotNil(t, sys.Supervisor)
+	require.NotEqual(t, sys.L2A.ChainID(), sys.L2B.ChainID())
+	require.NotEmpty(t, sys.Users.L2A, "chains must have pre-funded users")
+	require.NotEmpty(t, sys.Users.L2B)
+	sys.L2A.VerifyUnsafeHeadAdvances(2)
+	sys.L2B.VerifyUnsafeHeadAdvances(2)
+}
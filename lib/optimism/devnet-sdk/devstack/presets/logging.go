@@ -0,0 +1,175 @@
+package presets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	// LogLevelVar is the env var to change the log level of the main and test loggers. Defaults to info.
+	LogLevelVar = "DEVSTACK_LOG_LEVEL"
+	// LogFormatVar is the env var to change the log format: text, terminal, logfmt or json. Defaults to terminal.
+	LogFormatVar = "DEVSTACK_LOG_FORMAT"
+	// LogFileVar is the env var to enable writing the logs of each test to a <test-name>.log file.
+	LogFileVar = "DEVSTACK_LOG_FILE"
+	// LogDirVar is the env var to change the directory of the test log files. Defaults to artifacts.
+	LogDirVar = "DEVSTACK_LOG_DIR"
+	// GlobalLogLevelVar is the env var to change the log level of the global geth logger. Defaults to warn.
+	GlobalLogLevelVar = "DEVSTACK_GLOBAL_LOG_LEVEL"
+
+	defaultLogDir = "artifacts"
+)
+
+// logConfig is the logging configuration of tests, as read from the environment.
+type logConfig struct {
+	Level       slog.Level
+	Format      oplog.FormatType
+	GlobalLevel slog.Level
+	// FileDir is the directory to write test log files to, empty if test log files are disabled.
+	FileDir string
+}
+
+func readLogConfig() (*logConfig, error) {
+	cfg := &logConfig{
+		Level:       log.LevelInfo,
+		Format:      oplog.FormatTerminal,
+		GlobalLevel: log.LevelWarn,
+	}
+	if v, ok := os.LookupEnv(LogLevelVar); ok {
+		lvl, err := oplog.LevelFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", LogLevelVar, err)
+		}
+		cfg.Level = lvl
+	}
+	if v, ok := os.LookupEnv(GlobalLogLevelVar); ok {
+		lvl, err := oplog.LevelFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", GlobalLogLevelVar, err)
+		}
+		cfg.GlobalLevel = lvl
+	}
+	if v, ok := os.LookupEnv(LogFormatVar); ok {
+		var fv oplog.FormatFlagValue
+		if err := fv.Set(v); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", LogFormatVar, err)
+		}
+		cfg.Format = fv.FormatType()
+	}
+	if v, ok := os.LookupEnv(LogFileVar); ok {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", LogFileVar, err)
+		}
+		if enabled {
+			cfg.FileDir = defaultLogDir
+			if dir, ok := os.LookupEnv(LogDirVar); ok && dir != "" {
+				cfg.FileDir = dir
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// openTestLogFile creates the log file of the test, and returns a handler that writes to it.
+// The file is truncated if it already exists.
+func openTestLogFile(cfg *logConfig, testName string) (slog.Handler, *os.File, error) {
+	if err := os.MkdirAll(cfg.FileDir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create log dir %q: %w", cfg.FileDir, err)
+	}
+	// subtests have a "/" in their name, which should not create subdirectories
+	name := strings.ReplaceAll(testName, "/", "_") + ".log"
+	f, err := os.Create(filepath.Join(cfg.FileDir, name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create log file: %w", err)
+	}
+	h := oplog.NewDynamicLogHandler(cfg.Level, oplog.FormatHandler(cfg.Format, false)(f))
+	return h, f, nil
+}
+
+// teeHandler sends log records to all of its handlers.
+type teeHandler []slog.Handler
+
+var _ slog.Handler = teeHandler(nil)
+
+func (t teeHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+// recentLogsLimit is the number of log lines that are kept of each test, to include in failure reports.
+const recentLogsLimit = 200
+
+// recentLogs keeps the most recent log lines of a test.
+// Log handlers write a whole record at once, so every write is a line.
+type recentLogs struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+var _ io.Writer = (*recentLogs)(nil)
+
+func (r *recentLogs) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, strings.TrimRight(string(p), "\n"))
+	if len(r.lines) > recentLogsLimit {
+		r.lines = r.lines[len(r.lines)-recentLogsLimit:]
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of the recent log lines, oldest first.
+func (r *recentLogs) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.lines)
+}
+
+// testRecentLogs are the recent logs of the tests with a test logger, by test.
+var testRecentLogs locks.RWMap[stack.T, *recentLogs]
@@ -0,0 +1,47 @@
+package presets
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// MetricsAddrVar is the env var to serve the aggregated metrics of the first shared system on, as host:port, see Shared.
+// The metrics are not served if unset.
+const MetricsAddrVar = "DEVSTACK_METRICS_ADDR"
+
+// WithMetricsServer serves the aggregated metrics of the system of the setup, see shim.SystemMetrics.
+// Components that are added to the system later are included too.
+// The server is closed when the setup is cleaned up.
+func WithMetricsServer(host string, port int) stack.Option {
+	return func(setup *stack.Setup) {
+		setup.Require.NotNil(setup.System, "need a system to serve the metrics of")
+		srv, err := shim.ServeSystemMetrics(setup.System, host, port)
+		setup.Require.NoError(err, "failed to serve system metrics")
+		setup.Log.Info("Serving system metrics", "addr", srv.Addr())
+		setup.T.Cleanup(func() {
+			if err := srv.Stop(context.Background()); err != nil {
+				setup.Log.Warn("Failed to stop system metrics server", "err", err)
+			}
+		})
+	}
+}
+
+// withMetricsServerFromEnv serves the metrics of the system of the setup if MetricsAddrVar is set.
+func withMetricsServerFromEnv() stack.Option {
+	return func(setup *stack.Setup) {
+		addr, ok := os.LookupEnv(MetricsAddrVar)
+		if !ok || addr == "" {
+			return
+		}
+		host, portStr, err := net.SplitHostPort(addr)
+		setup.Require.NoError(err, "invalid %s", MetricsAddrVar)
+		port, err := strconv.Atoi(portStr)
+		setup.Require.NoError(err, "invalid port in %s", MetricsAddrVar)
+		WithMetricsServer(host, port)(setup)
+	}
+}
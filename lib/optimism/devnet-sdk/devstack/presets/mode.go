@@ -0,0 +1,208 @@
+package presets
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/sysgo"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/syskt"
+)
+
+// Shared selects the shared system mode:
+// the system of a preset is created once, and reused by every test of the package that creates the same preset
+// in shared mode. Systems are only shared if the tests opt in, as the tests then have to tolerate
+// each other's changes to the system.
+//
+// With sysgo, every preset shares a system on an orchestrator of its own,
+// since the systems of different presets use the same chain IDs.
+// The options of a preset are applied to the shared system once it exists, and do not change how it is created.
+// With syskt, every test attaches to the devnet, which is shared by design.
+//
+// When used with NewSetup, Shared attaches the global orchestrator.
+// Mode options must be passed to presets directly, not bundled with other options.
+func Shared() stack.Option {
+	return sharedMode
+}
+
+// Isolated selects the isolated system mode:
+// the system of a preset is created on a new orchestrator, owned by the test,
+// and its services are stopped when the test completes.
+//
+// Only sysgo can create isolated systems.
+// With syskt the test is skipped, since the devnet is shared by all tests,
+// or fails if DEVNET_EXPECT_PRECONDITIONS_MET is set to true.
+//
+// Without a mode option, presets create an isolated system with sysgo, and attach to the devnet with syskt.
+//
+// When used with NewSetup, Isolated attaches a new orchestrator, and an empty system.
+// Mode options must be passed to presets directly, not bundled with other options.
+func Isolated() stack.Option {
+	return isolatedMode
+}
+
+func sharedMode(setup *stack.Setup) {
+	WithGlobalOrchestrator()(setup)
+}
+
+func isolatedMode(setup *stack.Setup) {
+	setup.Require.Nil(setup.Orchestrator, "cannot change existing orchestrator of setup")
+	switch Orchestrator().(type) {
+	case *sysgo.Orchestrator:
+		setup.Orchestrator = sysgo.NewOrchestrator(setup.T, setup.Log)
+	case *syskt.Orchestrator:
+		preconditionNotMet(setup, errors.New("kurtosis devnets are shared by all tests, cannot create an isolated system"))
+		return
+	default:
+		setup.Require.Failf("unsupported orchestrator", "cannot create an isolated system with orchestrator %T", Orchestrator())
+	}
+	WithEmptySystem()(setup)
+}
+
+// isMode checks if the option is the given mode option.
+// Options are functions, and thus only comparable by the code they point to.
+func isMode(opt stack.Option, mode stack.Option) bool {
+	return reflect.ValueOf(opt).Pointer() == reflect.ValueOf(mode).Pointer()
+}
+
+// sharedSystem is a system created in Shared mode, reused by all tests that create the same preset in shared mode.
+type sharedSystem struct {
+	orch   stack.Orchestrator
+	system stack.ExtensibleSystem
+	ids    any
+	// errs are the errors the system failed to be created with, reported to every test of the preset.
+	errs []error
+}
+
+var (
+	// sharedSystemsLock guards sharedSystems, and serializes their creation, so every preset is created once.
+	sharedSystemsLock sync.Mutex
+	// sharedSystems are the shared systems of the presets, by preset name.
+	sharedSystems = make(map[string]*sharedSystem)
+)
+
+type presetMode int
+
+const (
+	defaultPresetMode presetMode = iota
+	sharedPresetMode
+	isolatedPresetMode
+)
+
+// setupPreset creates a setup with the system of a preset, in the mode that is selected by the options.
+// The preset system is created with the create function, which returns the IDs of the preset components.
+// The other options are applied to the setup before the system is created, like options of NewSetup,
+// except in shared mode, where the system may already exist, and the options are applied to the shared system.
+// The options registered to run after setup are applied last.
+func setupPreset[IDs any](t stack.T, preset string, opts []stack.Option, create func(setup *stack.Setup) IDs) (*stack.Setup, IDs) {
+	mode := defaultPresetMode
+	var remaining []stack.Option
+	for _, opt := range opts {
+		switch {
+		case isMode(opt, isolatedMode):
+			mode = isolatedPresetMode
+		case isMode(opt, sharedMode):
+			mode = sharedPresetMode
+		default:
+			remaining = append(remaining, opt)
+		}
+	}
+
+	_, inProcess := Orchestrator().(*sysgo.Orchestrator)
+	switch {
+	case mode == isolatedPresetMode || (mode == defaultPresetMode && inProcess):
+		setup := NewSetup(t, WithTestLogger(), Isolated())
+		return finishSetup(setup, remaining, create)
+	case !inProcess:
+		// other backends attach to a system that runs outside the test process,
+		// and every test can attach to it on its own.
+		setup := NewSetup(t, WithTestLogger(), WithGlobalOrchestrator(), WithEmptySystem())
+		return finishSetup(setup, remaining, create)
+	}
+
+	setup := NewSetup(t, WithTestLogger())
+	ids := sharedPresetSystem(setup, preset, create)
+	for _, opt := range remaining {
+		opt(setup)
+	}
+	applyAfterSetup(setup)
+	return setup, ids
+}
+
+// finishSetup applies the options to the setup, creates the system, and then applies the options registered to run after setup.
+func finishSetup[IDs any](setup *stack.Setup, opts []stack.Option, create func(setup *stack.Setup) IDs) (*stack.Setup, IDs) {
+	for _, opt := range opts {
+		opt(setup)
+	}
+	ids := create(setup)
+	applyAfterSetup(setup)
+	return setup, ids
+}
+
+// applyAfterSetup applies the options that were registered to run once the system of the setup is created.
+func applyAfterSetup(setup *stack.Setup) {
+	hooks := setup.AfterSetup
+	setup.AfterSetup = nil
+	for _, opt := range hooks {
+		opt(setup)
+	}
+}
+
+// sharedPresetSystem attaches the shared system of the preset, and its orchestrator, to the setup.
+// The system is created on a new sysgo orchestrator if it does not exist yet.
+// If the system cannot be created, the errors are reported to the test of the setup, and to every later test of the preset.
+func sharedPresetSystem[IDs any](setup *stack.Setup, preset string, create func(setup *stack.Setup) IDs) IDs {
+	shared := getOrCreateSharedSystem(preset, func(sharedSetup *stack.Setup) any {
+		return create(sharedSetup)
+	})
+	if len(shared.errs) > 0 {
+		setup.Require.Failf("shared system failed", "could not create shared %s system: %v", preset, errors.Join(shared.errs...))
+	}
+	setup.Orchestrator = shared.orch
+	setup.System = shared.system
+	return shared.ids.(IDs)
+}
+
+func getOrCreateSharedSystem(preset string, create func(setup *stack.Setup) any) *sharedSystem {
+	sharedSystemsLock.Lock()
+	defer sharedSystemsLock.Unlock()
+	if shared, ok := sharedSystems[preset]; ok {
+		return shared
+	}
+
+	// The shared system outlives the test that happens to create it,
+	// so it is created with a T that collects the errors of the creation instead of failing the test,
+	// and that is cleaned up with the global orchestrator.
+	global := Orchestrator()
+	logger := global.Log().New("preset", preset)
+	sharedT := stack.NewCollectingT("shared "+preset, logger)
+	global.T().Cleanup(sharedT.RunCleanup)
+	sharedSetup := &stack.Setup{
+		Ctx:          context.Background(),
+		Log:          logger,
+		T:            sharedT,
+		Require:      require.New(sharedT),
+		Orchestrator: sysgo.NewOrchestrator(sharedT, logger),
+	}
+	shared := &sharedSystem{orch: sharedSetup.Orchestrator}
+	sharedT.Run(func() {
+		WithEmptySystem()(sharedSetup)
+		shared.ids = create(sharedSetup)
+		shared.system = sharedSetup.System
+		// shared systems are long-lived, and worth monitoring while the tests run.
+		// Only the first one is served, as the systems would conflict on the metrics address.
+		if len(sharedSystems) == 0 {
+			withMetricsServerFromEnv()(sharedSetup)
+		}
+	})
+	shared.errs = sharedT.Errors()
+	if sharedT.Failed() && len(shared.errs) == 0 {
+		shared.errs = []error{errors.New("creation stopped without error")}
+	}
+	sharedSystems[preset] = shared
+	return shared
+}
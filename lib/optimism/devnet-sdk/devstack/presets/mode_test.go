@@ -0,0 +1,77 @@
+package presets
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSetupPresetModes(t *testing.T) {
+	created := make(map[string]int)
+	create := func(preset string) func(setup *stack.Setup) string {
+		return func(setup *stack.Setup) string {
+			require.NotNil(t, setup.System, "preset must be created in a system")
+			created[preset]++
+			return preset
+		}
+	}
+
+	sharedA, ids := setupPreset(t, "test-shared-a", []stack.Option{Shared()}, create("a"))
+	require.Equal(t, "a", ids)
+	sharedA2, _ := setupPreset(t, "test-shared-a", []stack.Option{Shared()}, create("a"))
+	sharedB, ids := setupPreset(t, "test-shared-b", []stack.Option{Shared()}, create("b"))
+	require.Equal(t, "b", ids)
+	require.Equal(t, 1, created["a"], "shared system must be created once")
+	require.Same(t, sharedA.System, sharedA2.System)
+	require.Equal(t, 1, created["b"], "every preset must have a shared system of its own")
+	require.NotSame(t, sharedA.System, sharedB.System)
+	require.NotSame(t, sharedA.Orchestrator, sharedB.Orchestrator, "shared systems of presets must not conflict on an orchestrator")
+
+	own, _ := setupPreset(t, "test-shared-a", nil, create("own"))
+	require.Equal(t, 1, created["own"], "systems must not be shared by default")
+	require.NotSame(t, sharedA.System, own.System)
+	require.NotSame(t, Orchestrator(), own.Orchestrator)
+	isolated, _ := setupPreset(t, "test-shared-a", []stack.Option{Isolated()}, create("own"))
+	require.Equal(t, 2, created["own"])
+	require.NotSame(t, own.System, isolated.System)
+}
+
+func TestSetupPresetOptionOrder(t *testing.T) {
+	var order []string
+	option := func(setup *stack.Setup) {
+		require.Empty(t, setup.System.L2Networks(), "options must be applied before the system is created")
+		order = append(order, "option")
+		setup.AfterSetup = append(setup.AfterSetup, func(setup *stack.Setup) {
+			order = append(order, "after setup")
+		})
+	}
+	setupPreset(t, "test-option-order", []stack.Option{option}, func(setup *stack.Setup) struct{} {
+		order = append(order, "create")
+		return struct{}{}
+	})
+	require.Equal(t, []string{"option", "create", "after setup"}, order)
+}
+
+func TestSharedSystemFailure(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createFailing := func(setup *stack.Setup) string {
+		setup.Require.Fail("cannot create chain")
+		return "unreachable"
+	}
+	for i := 0; i < 2; i++ {
+		testT := stack.NewCollectingT(t.Name(), logger)
+		t.Cleanup(testT.RunCleanup)
+		ok := testT.Run(func() {
+			setupPreset(testT, "test-shared-failing", []stack.Option{Shared()}, createFailing)
+		})
+		require.False(t, ok, "failure to create the shared system must fail the test that needs it")
+		err := errors.Join(testT.Errors()...)
+		require.ErrorContains(t, err, "cannot create chain", "the error of the shared system must be reported to every test")
+	}
+}
@@ -0,0 +1,111 @@
+package presets
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/dsl"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/sysgo"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/syskt"
+)
+
+// Topology describes the shape of a multi-chain system.
+type Topology struct {
+	// L2s is the number of L2 chains.
+	L2s int
+	// VerifiersPerChain is the number of verifier nodes of each L2 chain, in addition to the sequencer.
+	VerifiersPerChain int
+	// Supervisors is the number of supervisors. The L2 chains are interop chains if there is at least one supervisor.
+	Supervisors int
+}
+
+func (t Topology) String() string {
+	return fmt.Sprintf("%d L2s, %d verifiers per chain, %d supervisors", t.L2s, t.VerifiersPerChain, t.Supervisors)
+}
+
+// MultiChain is a system with the L2 chains and supervisors of a Topology.
+type MultiChain struct {
+	Log    log.Logger
+	System *dsl.System
+
+	L1          *dsl.L1Chain
+	L2s         []*dsl.L2Chain
+	Supervisors []*dsl.Supervisor
+}
+
+type multiChainIDs struct {
+	L2s         []stack.L2NetworkID
+	Supervisors []stack.SupervisorID
+}
+
+// NewMultiChain creates a MultiChain with the given topology with the backend of the global orchestrator,
+// with the options applied to the setup of the system.
+// With sysgo the topology is created in-process.
+// With syskt the devnet described by DEVNET_ENV_URL is used, and the test is skipped
+// if the devnet does not have enough chains, nodes per chain, or supervisors for the topology.
+// With sysgo, the system is owned by the test, unless the Shared option is passed.
+func NewMultiChain(t stack.T, topology Topology, opts ...stack.Option) *MultiChain {
+	setup, ids := setupPreset(t, "multi-chain ("+topology.String()+")", opts, func(setup *stack.Setup) (ids multiChainIDs) {
+		switch setup.Orchestrator.(type) {
+		case *sysgo.Orchestrator:
+			contracts, err := contractPaths()
+			setup.Require.NoError(err, "could not get contract paths")
+			sysIDs, opt := sysgo.MultiChainSystem(contracts, topology.L2s, topology.VerifiersPerChain, topology.Supervisors)
+			opt(setup)
+			for _, l2 := range sysIDs.L2s {
+				ids.L2s = append(ids.L2s, l2.L2)
+			}
+			ids.Supervisors = sysIDs.Supervisors
+		case *syskt.Orchestrator:
+			sysIDs, opt := syskt.DefaultSystemExt(loadDevnetEnv(setup))
+			if err := checkTopology(sysIDs, topology); err != nil {
+				preconditionNotMet(setup, err)
+				return
+			}
+			opt(setup)
+			for _, l2 := range sysIDs.L2s[:topology.L2s] {
+				ids.L2s = append(ids.L2s, l2.L2)
+			}
+			for _, supervisor := range sysIDs.Supervisors[:topology.Supervisors] {
+				ids.Supervisors = append(ids.Supervisors, supervisor.ID)
+			}
+		default:
+			setup.Require.Failf("unsupported orchestrator", "cannot create a multi-chain system with orchestrator %T", setup.Orchestrator)
+		}
+		return ids
+	})
+
+	awaitReady(setup)
+	sys := dsl.Hydrate(setup)
+	out := &MultiChain{
+		Log:    setup.Log,
+		System: sys,
+		L1:     sys.L1(),
+	}
+	for _, id := range ids.L2s {
+		out.L2s = append(out.L2s, sys.L2(id))
+	}
+	for _, id := range ids.Supervisors {
+		out.Supervisors = append(out.Supervisors, sys.Supervisor(id))
+	}
+	return out
+}
+
+// checkTopology checks that a devnet has at least the chains, nodes and supervisors of the topology.
+func checkTopology(ids syskt.DefaultSystemExtIDs, topology Topology) error {
+	if len(ids.L2s) < topology.L2s {
+		return fmt.Errorf("devnet has %d L2 chains, topology needs %d", len(ids.L2s), topology.L2s)
+	}
+	for _, l2 := range ids.L2s[:topology.L2s] {
+		if len(l2.Nodes) < 1+topology.VerifiersPerChain {
+			return fmt.Errorf("chain %s has %d nodes, topology needs a sequencer and %d verifiers",
+				l2.L2, len(l2.Nodes), topology.VerifiersPerChain)
+		}
+	}
+	if len(ids.Supervisors) < topology.Supervisors {
+		return fmt.Errorf("devnet has %d supervisors, topology needs %d", len(ids.Supervisors), topology.Supervisors)
+	}
+	return nil
+}
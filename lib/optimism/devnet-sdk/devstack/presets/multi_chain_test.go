@@ -0,0 +1,23 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiChain(t *testing.T) {
+	topology := Topology{L2s: 3, VerifiersPerChain: 1, Supervisors: 1}
+	sys := NewMultiChain(t, topology)
+	require.NotNil(t, sys.L1)
+	require.Len(t, sys.L2s, topology.L2s)
+	require.Len(t, sys.Supervisors, topology.Supervisors)
+	for _, l2 := range sys.L2s {
+		l2.VerifyUnsafeHeadAdvances(2)
+	}
+}
+
+func TestNewMultiChainRequirements(t *testing.T) {
+	NewMultiChain(t, Topology{L2s: 2}, RequiresL2Chains(3))
+	t.Fatal("test must be skipped when the system does not meet the requirements")
+}
@@ -3,6 +3,7 @@ package presets
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"testing"
 
@@ -35,21 +36,24 @@ func DoMain(m *testing.M) {
 		}
 	}()
 
-	// This may be tuned with env or CLI flags in the future, to customize test output
+	logCfg, err := readLogConfig()
+	if err != nil {
+		panic(err)
+	}
 	logger := oplog.NewLogger(os.Stdout, oplog.CLIConfig{
-		Level:  log.LevelInfo,
+		Level:  logCfg.Level,
 		Color:  true,
-		Format: oplog.FormatTerminal,
+		Format: logCfg.Format,
 		Pid:    false,
 	})
 
 	t := stack.NewToolingT("Main", logger)
 
 	// For the global geth logs,
-	// capture them in the global test logger.
+	// capture them in the global test logger, filtered by the global log level to reduce noise.
 	// No other tool / test should change the global logger.
-	// TODO(#15139): set log-level filter, reduce noise
-	//log.SetDefault(t.Log.New("logger", "global"))
+	oplog.SetGlobalLogHandler(oplog.NewDynamicLogHandler(logCfg.GlobalLevel,
+		t.Log.New("logger", "global").Handler()))
 
 	initOrchestrator(t, t.Log)
 	code := m.Run()
@@ -107,11 +111,36 @@ func WithGlobalOrchestrator() stack.Option {
 	}
 }
 
-// WithTestLogger attaches a test-logger
+// WithTestLogger attaches a test-logger.
+// The log level is configured with DEVSTACK_LOG_LEVEL,
+// and the logs are also written to a <test-name>.log file if DEVSTACK_LOG_FILE is true.
+// The most recent logs, including debug logs, are kept to report when the test exceeds its budget, see WithBudget.
 func WithTestLogger() stack.Option {
 	return func(setup *stack.Setup) {
 		setup.Require.Nil(setup.Log, "must not already have a logger")
-		setup.Log = testlog.Logger(setup.T, log.LevelInfo)
+		logCfg, err := readLogConfig()
+		setup.Require.NoError(err, "invalid log config")
+
+		recent := new(recentLogs)
+		testRecentLogs.Set(setup.T, recent)
+		setup.T.Cleanup(func() {
+			testRecentLogs.Delete(setup.T)
+		})
+		handlers := teeHandler{log.NewTerminalHandlerWithLevel(recent, log.LevelDebug, false)}
+
+		if logCfg.FileDir != "" {
+			fileHandler, f, err := openTestLogFile(logCfg, setup.T.Name())
+			setup.Require.NoError(err, "failed to open test log file")
+			setup.T.Cleanup(func() {
+				if err := f.Close(); err != nil {
+					setup.T.Logf("failed to close test log file %s: %v", f.Name(), err)
+				}
+			})
+			handlers = append(handlers, fileHandler)
+		}
+		setup.Log = testlog.LoggerWithHandlerMod(setup.T, logCfg.Level, func(h slog.Handler) slog.Handler {
+			return append(teeHandler{h}, handlers...)
+		})
 	}
 }
 
@@ -0,0 +1,104 @@
+package presets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// defaultReadyTimeout bounds how long presets wait for the system to become ready.
+	defaultReadyTimeout = 2 * time.Minute
+	readyPollInterval   = time.Second
+)
+
+// WithReadyTimeout changes how long the preset waits for the system to be ready, before the test runs.
+// A zero timeout disables the readiness barrier.
+func WithReadyTimeout(timeout time.Duration) stack.Option {
+	return func(setup *stack.Setup) {
+		setup.ReadyTimeout = &timeout
+	}
+}
+
+// awaitReady blocks until the system of the setup is ready for the test to run:
+// the L1 and all L2 chains produce blocks, and every supervisor synced up to the L1 head.
+// The test fails if the system is not ready within the ready timeout.
+func awaitReady(setup *stack.Setup) {
+	timeout := defaultReadyTimeout
+	if setup.ReadyTimeout != nil {
+		timeout = *setup.ReadyTimeout
+	}
+	if timeout == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(setup.Ctx, timeout)
+	defer cancel()
+	sys := setup.System
+
+	// Every chain must produce a block past its head at the start of the barrier.
+	type chainHead struct {
+		name   string
+		client apis.EthClient
+		start  uint64
+		l1     bool
+	}
+	var heads []chainHead
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		for _, elID := range l1.L1ELNodes() {
+			heads = append(heads, chainHead{name: elID.String(), client: l1.L1ELNode(elID).EthClient(), l1: true})
+		}
+	}
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		for _, elID := range l2.L2ELNodes() {
+			heads = append(heads, chainHead{name: elID.String(), client: l2.L2ELNode(elID).EthClient()})
+		}
+	}
+	for i := range heads {
+		head, err := heads[i].client.BlockRefByLabel(ctx, eth.Unsafe)
+		setup.Require.NoErrorf(err, "failed to fetch head of %s", heads[i].name)
+		heads[i].start = head.Number
+	}
+
+	// Supervisors must sync up to the L1 head at the start of the barrier.
+	var l1Head uint64
+	for _, h := range heads {
+		if h.l1 {
+			l1Head = max(l1Head, h.start)
+		}
+	}
+
+	err := wait.For(ctx, readyPollInterval, func() (bool, error) {
+		var pending []error
+		for _, h := range heads {
+			head, err := h.client.BlockRefByLabel(ctx, eth.Unsafe)
+			if err != nil {
+				pending = append(pending, fmt.Errorf("%s: %w", h.name, err))
+			} else if head.Number <= h.start {
+				pending = append(pending, fmt.Errorf("%s: no new block since %d", h.name, h.start))
+			}
+		}
+		for _, id := range sys.Supervisors() {
+			status, err := sys.Supervisor(id).QueryAPI().SyncStatus(ctx)
+			if err != nil {
+				pending = append(pending, fmt.Errorf("%s: %w", id, err))
+			} else if status.MinSyncedL1.Number < l1Head {
+				pending = append(pending, fmt.Errorf("%s: synced to L1 block %d, L1 head is %d", id, status.MinSyncedL1.Number, l1Head))
+			}
+		}
+		if len(pending) > 0 {
+			setup.Log.Info("Waiting for system to be ready", "pending", errors.Join(pending...))
+			return false, nil
+		}
+		return true, nil
+	})
+	setup.Require.NoErrorf(err, "system not ready within %s", timeout)
+	setup.Log.Info("System is ready")
+}
@@ -0,0 +1,82 @@
+package presets
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/shell/env"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// skipper is implemented by test handles that can skip a test, like testing.T.
+type skipper interface {
+	Skipf(format string, args ...any)
+}
+
+// requirement creates an option that checks a capability of the system,
+// and skips the test if the system does not provide it.
+// The check is registered to run after setup, once the preset has created the system.
+//
+// Like the precondition validators of systest, the test fails instead of skipping
+// if DEVNET_EXPECT_PRECONDITIONS_MET is set to true, or if the test handle cannot skip.
+func requirement(check func(sys stack.System) error) stack.Option {
+	return func(setup *stack.Setup) {
+		setup.AfterSetup = append(setup.AfterSetup, func(setup *stack.Setup) {
+			setup.Require.NotNil(setup.System, "need system to check requirements of")
+			if err := check(setup.System); err != nil {
+				preconditionNotMet(setup, err)
+			}
+		})
+	}
+}
+
+// preconditionNotMet skips the test of the setup, or fails it if it cannot be skipped.
+func preconditionNotMet(setup *stack.Setup, err error) {
+	expectMet, _ := strconv.ParseBool(os.Getenv(env.ExpectPreconditionsMet))
+	if s, ok := setup.T.(skipper); ok && !expectMet {
+		s.Skipf("precondition not met: %v", err)
+		return
+	}
+	setup.Require.NoError(err, "precondition not met")
+}
+
+// RequiresForks skips the test unless the forks are active on every L2 chain of the system.
+func RequiresForks(forks ...rollup.ForkName) stack.Option {
+	return requirement(func(sys stack.System) error {
+		now := uint64(time.Now().Unix())
+		for _, id := range sys.L2Networks() {
+			l2 := sys.L2Network(id)
+			for _, fork := range forks {
+				if !l2.IsForkActive(fork, now) {
+					return fmt.Errorf("fork %s is not active on chain %s", fork, id)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// RequiresNodesPerChain skips the test unless every L2 chain of the system has at least the given number of nodes.
+func RequiresNodesPerChain(minNodes int) stack.Option {
+	return requirement(func(sys stack.System) error {
+		for _, id := range sys.L2Networks() {
+			if count := len(sys.L2Network(id).L2CLNodes()); count < minNodes {
+				return fmt.Errorf("chain %s has %d nodes, need at least %d", id, count, minNodes)
+			}
+		}
+		return nil
+	})
+}
+
+// RequiresL2Chains skips the test unless the system has at least the given number of L2 chains.
+func RequiresL2Chains(minChains int) stack.Option {
+	return requirement(func(sys stack.System) error {
+		if count := len(sys.L2Networks()); count < minChains {
+			return fmt.Errorf("system has %d L2 chains, need at least %d", count, minChains)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,77 @@
+package presets
+
+import (
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/dsl"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/sysgo"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/syskt"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/shell/env"
+)
+
+// SimpleL2 is a single L2 chain without interop: one L1, and one L2 with a sequencer, batcher and proposer.
+type SimpleL2 struct {
+	Log    log.Logger
+	System *dsl.System
+
+	L1       *dsl.L1Chain
+	L2       *dsl.L2Chain
+	Batcher  *dsl.Batcher
+	Proposer *dsl.Proposer
+}
+
+type simpleL2IDs struct {
+	L2         stack.L2NetworkID
+	L2Batcher  stack.L2BatcherID
+	L2Proposer stack.L2ProposerID
+}
+
+// NewSimpleL2 creates a SimpleL2 with the backend of the global orchestrator, with the options applied to the setup of the system:
+// an in-process system with sysgo, or the first L2 of the devnet described by DEVNET_ENV_URL with syskt.
+// With sysgo, the system is owned by the test, unless the Shared option is passed.
+func NewSimpleL2(t stack.T, opts ...stack.Option) *SimpleL2 {
+	setup, ids := setupPreset(t, "simple-l2", opts, func(setup *stack.Setup) (ids simpleL2IDs) {
+		switch setup.Orchestrator.(type) {
+		case *sysgo.Orchestrator:
+			contracts, err := contractPaths()
+			setup.Require.NoError(err, "could not get contract paths")
+			sysIDs, opt := sysgo.DefaultMinimalSystem(contracts)
+			opt(setup)
+			ids = simpleL2IDs{L2: sysIDs.L2, L2Batcher: sysIDs.L2Batcher, L2Proposer: sysIDs.L2Proposer}
+		case *syskt.Orchestrator:
+			sysIDs, opt := syskt.DefaultSystemExt(loadDevnetEnv(setup))
+			opt(setup)
+			setup.Require.NotEmpty(sysIDs.L2s, "devnet must have a L2 chain")
+			l2 := sysIDs.L2s[0]
+			ids = simpleL2IDs{L2: l2.L2, L2Batcher: l2.L2Batcher, L2Proposer: l2.L2Proposer}
+		default:
+			setup.Require.Failf("unsupported orchestrator", "cannot create a simple L2 with orchestrator %T", setup.Orchestrator)
+		}
+		return ids
+	})
+
+	awaitReady(setup)
+	sys := dsl.Hydrate(setup)
+	l2 := sys.L2(ids.L2)
+	return &SimpleL2{
+		Log:      setup.Log,
+		System:   sys,
+		L1:       sys.L1(),
+		L2:       l2,
+		Batcher:  l2.Batcher(ids.L2Batcher),
+		Proposer: l2.Proposer(ids.L2Proposer),
+	}
+}
+
+// loadDevnetEnv loads the devnet descriptor that DEVNET_ENV_URL points to.
+func loadDevnetEnv(setup *stack.Setup) *descriptors.DevnetEnvironment {
+	url, ok := os.LookupEnv(env.EnvURLVar)
+	setup.Require.True(ok, "%s must be set to use a kurtosis devnet", env.EnvURLVar)
+	devnet, err := env.LoadDevnetFromURL(url)
+	setup.Require.NoError(err, "could not load devnet descriptor")
+	return &devnet.Config
+}
@@ -0,0 +1,17 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSimpleL2(t *testing.T) {
+	sys := NewSimpleL2(t)
+	require.NotNil(t, sys.L1)
+	require.NotNil(t, sys.L2)
+	require.NotNil(t, sys.Batcher)
+	require.NotNil(t, sys.Proposer)
+	require.Len(t, sys.System.L2s(), 1)
+	sys.L2.VerifyUnsafeHeadAdvances(2)
+}
@@ -0,0 +1,89 @@
+package shim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+const beaconHeadersMethodPrefix = "eth/v1/beacon/headers/"
+
+// beaconAPI implements stack.BeaconAPI on top of the beacon HTTP client.
+type beaconAPI struct {
+	beacon *sources.BeaconHTTPClient
+	// http serves the requests the beacon HTTP client does not have bindings for.
+	http client.HTTP
+}
+
+var _ stack.BeaconAPI = (*beaconAPI)(nil)
+
+func (b *beaconAPI) GenesisTime(ctx context.Context) (uint64, error) {
+	resp, err := b.beacon.BeaconGenesis(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch beacon genesis: %w", err)
+	}
+	return uint64(resp.Data.GenesisTime), nil
+}
+
+func (b *beaconAPI) SecondsPerSlot(ctx context.Context) (uint64, error) {
+	resp, err := b.beacon.ConfigSpec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch beacon config: %w", err)
+	}
+	if resp.Data.SecondsPerSlot == 0 {
+		return 0, fmt.Errorf("beacon config has no slot duration")
+	}
+	return uint64(resp.Data.SecondsPerSlot), nil
+}
+
+func (b *beaconAPI) SlotAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	genesis, err := b.GenesisTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+	slotTime, err := b.SecondsPerSlot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp < genesis {
+		return 0, fmt.Errorf("timestamp %d is before beacon genesis %d", timestamp, genesis)
+	}
+	return (timestamp - genesis) / slotTime, nil
+}
+
+func (b *beaconAPI) BlobSidecars(ctx context.Context, slot uint64) ([]*eth.APIBlobSidecar, error) {
+	resp, err := b.beacon.BeaconBlobSideCars(ctx, true, slot, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars of slot %d: %w", slot, err)
+	}
+	return resp.Data, nil
+}
+
+func (b *beaconAPI) Header(ctx context.Context, slot uint64) (eth.SignedBeaconBlockHeader, error) {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := b.http.Get(ctx, beaconHeadersMethodPrefix+strconv.FormatUint(slot, 10), nil, headers)
+	if err != nil {
+		return eth.SignedBeaconBlockHeader{}, fmt.Errorf("failed to fetch header of slot %d: %w", slot, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return eth.SignedBeaconBlockHeader{}, fmt.Errorf("failed to fetch header of slot %d: status %d", slot, resp.StatusCode)
+	}
+	var result struct {
+		Data struct {
+			Header eth.SignedBeaconBlockHeader `json:"header"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return eth.SignedBeaconBlockHeader{}, fmt.Errorf("failed to decode header of slot %d: %w", slot, err)
+	}
+	return result.Data.Header, nil
+}
@@ -2,6 +2,7 @@ package shim
 
 import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 )
 
@@ -17,11 +18,13 @@ type presetCluster struct {
 	commonImpl
 	depSet depset.DependencySet
 	id     stack.ClusterID
+
+	l2s locks.RWMap[stack.L2NetworkID, struct{}]
 }
 
-var _ stack.Cluster = (*presetCluster)(nil)
+var _ stack.ExtensibleCluster = (*presetCluster)(nil)
 
-func NewCluster(cfg ClusterConfig) stack.Cluster {
+func NewCluster(cfg ClusterConfig) stack.ExtensibleCluster {
 	cfg.Log = cfg.Log.New("id", cfg.ID)
 	return &presetCluster{
 		id:         cfg.ID,
@@ -37,3 +40,16 @@ func (p *presetCluster) ID() stack.ClusterID {
 func (p *presetCluster) DependencySet() depset.DependencySet {
 	return p.depSet
 }
+
+func (p *presetCluster) L2s() []stack.L2NetworkID {
+	return stack.SortL2NetworkIDs(p.l2s.Keys())
+}
+
+func (p *presetCluster) AddL2(id stack.L2NetworkID) {
+	p.require().True(p.l2s.SetIfMissing(id, struct{}{}), "l2 chain %s must not already be part of cluster %s", id, p.id)
+}
+
+func (p *presetCluster) RemoveL2(id stack.L2NetworkID) {
+	p.require().True(p.l2s.Has(id), "l2 chain %s must be part of cluster %s", id, p.id)
+	p.l2s.Delete(id)
+}
@@ -1,46 +1,93 @@
 package shim
 
 import (
+	"context"
+	"maps"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 // CommonConfig provides common inputs for creating a new component
 type CommonConfig struct {
 	Log log.Logger
 	T   stack.T
+	// Registry is the registry that the metrics of the component are gathered from, see SystemMetrics. Optional.
+	// In-process services record to it directly, remote services can be scraped into it with NewScrapingRegistry.
+	// Components should not share a registry, to attribute the metrics to the right component.
+	Registry *prometheus.Registry
+	// Endpoint is the URL the component is reached at, if it is served over RPC or HTTP. Optional.
+	Endpoint string
+	// MetricsEndpoint is the URL the metrics of the component are served at. Optional.
+	// The metrics are scraped from it, if the component is not given a metrics client.
+	MetricsEndpoint string
+	// Labels are arbitrary key-value pairs to select the component by, see stack.Labeled. Optional.
+	Labels map[string]string
+	// IDs is the registry of the system that components added to this component are registered in.
+	// Optional, IDs are not registered if nil.
+	IDs *stack.IDRegistry
 }
 
 // CommonConfigFromSetup is a convenience method to build the config common between all components.
 // Note that component constructors will decorate the logger with metadata for internal use,
 // the caller of the component constructor can generally leave the logger as-is.
 func CommonConfigFromSetup(setup *stack.Setup) CommonConfig {
-	return CommonConfig{
+	cfg := CommonConfig{
 		Log: setup.Log,
 		T:   setup.T,
 	}
+	if setup.System != nil {
+		cfg.IDs = setup.System.IDRegistry()
+	}
+	return cfg
+}
+
+// rpcClient returns the given client, or a client for the endpoint of the component if no client is given.
+// The client dials lazily, so components can be attached to services that are not ready yet.
+func rpcClient(cfg CommonConfig, cl client.RPC) client.RPC {
+	if cl != nil || cfg.Endpoint == "" {
+		return cl
+	}
+	cl, err := client.NewRPC(context.Background(), cfg.Log, cfg.Endpoint, client.WithLazyDial())
+	require.NoError(cfg.T, err, "failed to create RPC client for %s", cfg.Endpoint)
+	return cl
 }
 
 type commonImpl struct {
-	log log.Logger
-	t   stack.T
-	req *require.Assertions
+	log      log.Logger
+	t        stack.T
+	req      *require.Assertions
+	registry *prometheus.Registry
+	endpoint string
+	metrics  string
+	labels   map[string]string
+	ids      *stack.IDRegistry
 }
 
 var _ interface {
 	stack.Common
+	stack.MetricsRecorder
+	stack.EndpointSource
+	stack.Labeled
 	require() *require.Assertions
 } = (*commonImpl)(nil)
 
 // newCommon creates an object to hold on to common component data, safe to embed in other structs
 func newCommon(cfg CommonConfig) commonImpl {
 	return commonImpl{
-		log: cfg.Log,
-		t:   cfg.T,
-		req: require.New(cfg.T),
+		log:      cfg.Log,
+		t:        cfg.T,
+		req:      require.New(cfg.T),
+		registry: cfg.Registry,
+		endpoint: cfg.Endpoint,
+		metrics:  cfg.MetricsEndpoint,
+		labels:   maps.Clone(cfg.Labels),
+		ids:      cfg.IDs,
 	}
 }
 
@@ -48,6 +95,67 @@ func (c *commonImpl) Logger() log.Logger {
 	return c.log
 }
 
+// MetricsRegistry returns the registry of the metrics of the component, or nil if it has none.
+func (c *commonImpl) MetricsRegistry() *prometheus.Registry {
+	return c.registry
+}
+
+// Endpoint returns the URL the component is reached at, or an empty string if unknown.
+func (c *commonImpl) Endpoint() string {
+	return c.endpoint
+}
+
+// MetricsEndpoint returns the URL the metrics of the component are served at, or an empty string if unknown.
+func (c *commonImpl) MetricsEndpoint() string {
+	return c.metrics
+}
+
+func (c *commonImpl) Label(key string) (string, bool) {
+	v, ok := c.labels[key]
+	return v, ok
+}
+
+func (c *commonImpl) Labels() map[string]string {
+	return maps.Clone(c.labels)
+}
+
 func (c *commonImpl) require() *require.Assertions {
 	return c.req
 }
+
+// registerID registers the ID of a component that is added to this component,
+// and fails with the original registration if the ID is already registered.
+func (c *commonImpl) registerID(id any) {
+	if c.ids == nil {
+		return
+	}
+	c.require().NoError(c.ids.Register(id), "%v must not already exist", id)
+}
+
+// unregisterID forgets the ID of a component that is removed from this component.
+func (c *commonImpl) unregisterID(id any) {
+	if c.ids == nil {
+		return
+	}
+	c.ids.Unregister(id)
+}
+
+// scopeT returns the T the component was created with, see removeWithScope.
+func (c *commonImpl) scopeT() stack.T {
+	return c.t
+}
+
+// removeWithScope registers the removal of a component that is added to this component,
+// to run when the forked setup the component was created with is closed, see stack.Setup.Fork.
+// Components created in the same scope as this component are removed along with this component instead.
+func (c *commonImpl) removeWithScope(v any, remove func()) {
+	scoped, ok := v.(interface{ scopeT() stack.T })
+	if !ok {
+		return
+	}
+	t, ok := scoped.scopeT().(*stack.ScopedT)
+	if !ok || c.t == stack.T(t) {
+		return
+	}
+	t.Cleanup(remove)
+}
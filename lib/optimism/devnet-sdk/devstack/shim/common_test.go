@@ -0,0 +1,62 @@
+package shim
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRPCClientFromEndpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := "http://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	// the node is attached before the service is ready
+	cfg := CommonConfig{Log: logger, T: t, Endpoint: endpoint}
+	node := NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{CommonConfig: cfg, ChainID: eth.ChainIDFromUInt64(900)},
+		ID:           stack.L1ELNodeID{Key: "miner", ChainID: eth.ChainIDFromUInt64(900)},
+	})
+	_, err = node.EthClient().ChainID(context.Background())
+	require.Error(t, err, "service is not ready yet")
+
+	listener, err = net.Listen("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0x384"})
+	}))
+	srv.Listener = listener
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := node.EthClient().ChainID(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, uint64(900), id.Uint64())
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,182 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/disputegames"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+// disputeGamesBatchSize is the number of contract calls batched together when querying dispute games.
+const disputeGamesBatchSize = 100
+
+// disputeGames queries the dispute games of a L2 on L1,
+// for the services that act on them, but do not serve an RPC to inspect them.
+type disputeGames struct {
+	client      client.RPC
+	multiCaller *batching.MultiCaller
+	factory     *contracts.DisputeGameFactoryContract
+	gameABI     *abi.ABI
+}
+
+// newDisputeGames creates a dispute-game querier, or returns nil if there is no L1 client to query with.
+func newDisputeGames(cl client.RPC, factory common.Address) *disputeGames {
+	if cl == nil {
+		return nil
+	}
+	multiCaller := batching.NewMultiCaller(cl, disputeGamesBatchSize)
+	return &disputeGames{
+		client:      cl,
+		multiCaller: multiCaller,
+		factory:     contracts.NewDisputeGameFactoryContract(contractMetrics.NoopContractMetrics, factory, multiCaller),
+		gameABI:     snapshots.LoadFaultDisputeGameABI(),
+	}
+}
+
+// newDisputeGameIndex creates an index of the games of the factory, or returns nil if there is no L1 client to query with.
+func newDisputeGameIndex(cl client.RPC, factory common.Address) *disputegames.Index {
+	if cl == nil {
+		return nil
+	}
+	return disputegames.NewIndex(ethCaller{cl}, factory)
+}
+
+// ethCaller makes eth_calls at the latest block with a RPC client.
+type ethCaller struct {
+	client client.RPC
+}
+
+func (c ethCaller) Call(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var out hexutil.Bytes
+	if err := c.client.CallContext(ctx, &out, "eth_call", sources.ToCallArg(msg), "latest"); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// head returns the hash of the latest L1 block, to query multiple games at a consistent state.
+func (d *disputeGames) head(ctx context.Context) (common.Hash, error) {
+	var head *types.Header
+	if err := d.client.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	return head.Hash(), nil
+}
+
+// all lists the games of the factory at the given L1 block, in the order they were created.
+func (d *disputeGames) all(ctx context.Context, head common.Hash) ([]gameTypes.GameMetadata, error) {
+	games, err := d.factory.GetAllGames(ctx, head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dispute games: %w", err)
+	}
+	return games, nil
+}
+
+// claimCounts returns the number of claims of each of the given games at the given L1 block, in one batch.
+func (d *disputeGames) claimCounts(ctx context.Context, head common.Hash, games ...common.Address) ([]uint64, error) {
+	calls := make([]batching.Call, len(games))
+	for i, game := range games {
+		calls[i] = batching.NewBoundContract(d.gameABI, game).Call("claimDataLen")
+	}
+	results, err := d.multiCaller.Call(ctx, rpcblock.ByHash(head), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim counts of dispute games: %w", err)
+	}
+	counts := make([]uint64, len(results))
+	for i, result := range results {
+		counts[i] = result.GetBigInt(0).Uint64()
+	}
+	return counts, nil
+}
+
+// claims reads the claims at the given indices of a game at the given L1 block, in one batch.
+// Unlike reading all claims of the game, the cost only depends on the number of requested claims.
+func (d *disputeGames) claims(ctx context.Context, head common.Hash, game common.Address, indices []uint64) ([]faultTypes.Claim, error) {
+	contract := batching.NewBoundContract(d.gameABI, game)
+	calls := make([]batching.Call, len(indices))
+	for i, idx := range indices {
+		calls[i] = contract.Call("claimData", new(big.Int).SetUint64(idx))
+	}
+	results, err := d.multiCaller.Call(ctx, rpcblock.ByHash(head), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claims of dispute game %v: %w", game, err)
+	}
+	claims := make([]faultTypes.Claim, len(results))
+	for i, result := range results {
+		claims[i] = faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Value:    result.GetHash(4),
+				Position: faultTypes.NewPositionFromGIndex(result.GetBigInt(5)),
+				Bond:     result.GetBigInt(3),
+			},
+			CounteredBy:         result.GetAddress(1),
+			Claimant:            result.GetAddress(2),
+			ContractIndex:       int(indices[i]),
+			ParentContractIndex: int(result.GetUint32(0)),
+		}
+	}
+	return claims, nil
+}
+
+// resolved returns whether the subgames of the claims at the given indices of a game are resolved.
+func (d *disputeGames) resolved(ctx context.Context, head common.Hash, game common.Address, indices []uint64) ([]bool, error) {
+	contract := batching.NewBoundContract(d.gameABI, game)
+	calls := make([]batching.Call, len(indices))
+	for i, idx := range indices {
+		calls[i] = contract.Call("resolvedSubgames", new(big.Int).SetUint64(idx))
+	}
+	results, err := d.multiCaller.Call(ctx, rpcblock.ByHash(head), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resolution of claims of dispute game %v: %w", game, err)
+	}
+	resolved := make([]bool, len(results))
+	for i, result := range results {
+		resolved[i] = result.GetBool(0)
+	}
+	return resolved, nil
+}
+
+// statuses returns the status of each of the given games at the given L1 block, in one batch.
+func (d *disputeGames) statuses(ctx context.Context, head common.Hash, games ...common.Address) ([]gameTypes.GameStatus, error) {
+	calls := make([]batching.Call, len(games))
+	for i, game := range games {
+		calls[i] = batching.NewBoundContract(d.gameABI, game).Call("status")
+	}
+	results, err := d.multiCaller.Call(ctx, rpcblock.ByHash(head), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of dispute games: %w", err)
+	}
+	statuses := make([]gameTypes.GameStatus, len(results))
+	for i, result := range results {
+		statuses[i], err = gameTypes.GameStatusFromUint8(result.GetUint8(0))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status of dispute game %v: %w", games[i], err)
+		}
+	}
+	return statuses, nil
+}
+
+// gameClaims tracks the claims that one account made in a dispute game.
+// Claims are only ever appended to a game, so repeated queries only need to read the claims added since.
+type gameClaims struct {
+	// scanned is the number of claims of the game that were checked for the claimant
+	scanned uint64
+	// own are the indices of the claims made by the claimant
+	own []uint64
+}
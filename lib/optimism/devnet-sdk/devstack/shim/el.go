@@ -12,12 +12,20 @@ import (
 
 type ELNodeConfig struct {
 	CommonConfig
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
 	Client  client.RPC
 	ChainID eth.ChainID
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
 }
 
 type rpcELNode struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 
 	client    client.RPC
 	ethClient *sources.EthClient
@@ -25,17 +33,22 @@ type rpcELNode struct {
 }
 
 var _ stack.ELNode = (*rpcELNode)(nil)
+var _ stack.MetricsSource = (*rpcELNode)(nil)
+var _ stack.Lifecycle = (*rpcELNode)(nil)
 
 // newRpcELNode creates a generic ELNode, safe to embed in other structs
 func newRpcELNode(cfg ELNodeConfig) rpcELNode {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
 	ethCl, err := sources.NewEthClient(cfg.Client, cfg.Log, nil, sources.DefaultEthClientConfig(10))
 	require.NoError(cfg.T, err)
 
 	return rpcELNode{
-		commonImpl: newCommon(cfg.CommonConfig),
-		client:     cfg.Client,
-		ethClient:  ethCl,
-		chainID:    cfg.ChainID,
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		client:        cfg.Client,
+		ethClient:     ethCl,
+		chainID:       cfg.ChainID,
 	}
 }
 
@@ -0,0 +1,67 @@
+package shim
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
+)
+
+type eventBus struct {
+	subscribers locks.RWMap[uint64, func(ev stack.Event)]
+	nextID      atomic.Uint64
+}
+
+var _ stack.EventBus = (*eventBus)(nil)
+
+// NewEventBus creates an event bus that delivers events to subscribers synchronously.
+func NewEventBus() stack.EventBus {
+	return &eventBus{}
+}
+
+func (b *eventBus) Publish(ev stack.Event) {
+	// subscribers are called outside of the lock, so they can unsubscribe while handling the event
+	for _, fn := range b.subscribers.Values() {
+		fn(ev)
+	}
+}
+
+func (b *eventBus) Subscribe(fn func(ev stack.Event)) (unsubscribe func()) {
+	id := b.nextID.Add(1)
+	b.subscribers.Set(id, fn)
+	return func() {
+		b.subscribers.Delete(id)
+	}
+}
+
+// WatchBlocks polls the sync status of the L2 CL node, and publishes a stack.BlockBuiltEvent for every new unsafe head,
+// until the context is canceled.
+// The rollup node does not serve subscriptions to its head, so this is the one place that polls it:
+// tests can subscribe to the bus, instead of polling the node in their own loops.
+func WatchBlocks(ctx context.Context, logger log.Logger, bus stack.EventBus, node stack.L2CLNode, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last *eth.L2BlockRef
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		status, err := node.RollupAPI().SyncStatus(ctx)
+		if err != nil {
+			logger.Debug("Failed to fetch sync status", "node", node.ID(), "err", err)
+			continue
+		}
+		head := status.UnsafeL2
+		if last == nil || head != *last {
+			last = &head
+			bus.Publish(stack.BlockBuiltEvent{Node: node.ID(), Block: head})
+		}
+	}
+}
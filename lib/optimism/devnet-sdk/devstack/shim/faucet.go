@@ -1,31 +1,89 @@
 package shim
 
-import "github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+const defaultUserFundsTimeout = 2 * time.Minute
 
 type FaucetConfig struct {
 	CommonConfig
 	ID stack.FaucetID
+	// Funder is the funded user that the faucet sends funds from.
+	// New users interact with the chain through the EL node of the funder.
+	Funder stack.User
+	// UserFunds is the amount of wei given to every new user. Defaults to 1 ETH.
+	UserFunds *big.Int
 }
 
-type presetFaucet struct {
+// userFaucet funds accounts with transfers from a funded user.
+// The user assigns the nonces of concurrent requests in order,
+// also with the transactions that the user sends outside of the faucet.
+type userFaucet struct {
 	commonImpl
-	id stack.FaucetID
+	id        stack.FaucetID
+	funder    stack.User
+	userFunds *big.Int
 }
 
-var _ stack.Faucet = (*presetFaucet)(nil)
+var _ stack.Faucet = (*userFaucet)(nil)
 
 func NewFaucet(cfg FaucetConfig) stack.Faucet {
-	return &presetFaucet{
-		id:         cfg.ID,
+	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
+	require.NotNil(cfg.T, cfg.Funder, "faucet %s needs a funded user", cfg.ID)
+	require.Equal(cfg.T, cfg.ID.ChainID, cfg.Funder.ChainID(), "faucet must be on the same chain as the funder")
+	userFunds := cfg.UserFunds
+	if userFunds == nil {
+		userFunds = big.NewInt(params.Ether)
+	}
+	return &userFaucet{
 		commonImpl: newCommon(cfg.CommonConfig),
+		id:         cfg.ID,
+		funder:     cfg.Funder,
+		userFunds:  userFunds,
 	}
 }
 
-func (p *presetFaucet) ID() stack.FaucetID {
+func (p *userFaucet) ID() stack.FaucetID {
 	return p.id
 }
 
-func (p *presetFaucet) NewUser() stack.User {
-	p.require().Fail("not implemented")
-	return nil
+func (p *userFaucet) Fund(ctx context.Context, addr common.Address, amount *big.Int) (common.Hash, error) {
+	txHash, err := p.funder.Transfer(ctx, addr, amount)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to send %s wei to %s: %w", amount, addr, err)
+	}
+	p.log.Info("Funded account", "addr", addr, "amount", amount, "tx", txHash)
+	return txHash, nil
+}
+
+func (p *userFaucet) NewUser() stack.User {
+	priv, err := crypto.GenerateKey()
+	p.require().NoError(err)
+	addr := crypto.PubkeyToAddress(priv.PublicKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultUserFundsTimeout)
+	defer cancel()
+	txHash, err := p.Fund(ctx, addr, p.userFunds)
+	p.require().NoError(err)
+	_, err = p.funder.WaitReceipt(ctx, txHash)
+	p.require().NoError(err, "funding of user %s must be included", addr)
+
+	return NewUser(UserConfig{
+		CommonConfig: CommonConfig{Log: p.log, T: p.t},
+		ID:           stack.UserID{Key: addr.Hex(), ChainID: p.id.ChainID},
+		Priv:         priv,
+		EL:           p.funder.EL(),
+	})
 }
@@ -0,0 +1,86 @@
+package shim
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFaucetFund(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	chainID := eth.ChainIDFromUInt64(901)
+	cl := &fakeEthClient{pendingNonce: 3}
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	funder := NewUser(UserConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.UserID{Key: "funder", ChainID: chainID},
+		Priv:         priv,
+		EL:           &fakeELNode{chainID: chainID, client: cl},
+	})
+	faucet := NewFaucet(FaucetConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.FaucetID{Key: "faucet", ChainID: chainID},
+		Funder:       funder,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := faucet.Fund(context.Background(), common.Address{byte(i)}, big.NewInt(int64(i+1)))
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, cl.sent, 4)
+	for i, tx := range cl.sent {
+		require.Equal(t, uint64(3+i), tx.Nonce(), "concurrent requests must use consecutive nonces")
+		require.Equal(t, big.NewInt(int64(tx.To()[0])+1), tx.Value())
+	}
+	// the funder keeps its nonces in order with the requests of the faucet
+	_, err = funder.Transfer(context.Background(), common.Address{0xaa}, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), cl.sent[4].Nonce())
+}
+
+func TestNetworkFaucet(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	chainID := eth.ChainIDFromUInt64(901)
+	net := newNetwork(NetworkConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ChainConfig:  &params.ChainConfig{ChainID: chainID.ToBig()},
+	})
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	faucet := NewFaucet(FaucetConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.FaucetID{Key: "faucet", ChainID: chainID},
+		Funder: NewUser(UserConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+			ID:           stack.UserID{Key: "funder", ChainID: chainID},
+			Priv:         priv,
+			EL:           &fakeELNode{chainID: chainID, client: &fakeEthClient{}},
+		}),
+	})
+
+	net.AddFaucet(faucet)
+	require.Equal(t, faucet, net.Faucet())
+	net.RemoveFaucet()
+	net.AddFaucet(faucet)
+	require.Equal(t, faucet, net.Faucet())
+}
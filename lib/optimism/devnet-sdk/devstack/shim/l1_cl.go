@@ -1,6 +1,8 @@
 package shim
 
 import (
+	"context"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -11,22 +13,35 @@ type L1CLNodeConfig struct {
 	CommonConfig
 	ID     stack.L1CLNodeID
 	Client client.HTTP
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
 }
 
 type rpcL1CLNode struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 	id     stack.L1CLNodeID
 	client apis.BeaconClient
+	beacon *beaconAPI
 }
 
 var _ stack.L1CLNode = (*rpcL1CLNode)(nil)
+var _ stack.MetricsSource = (*rpcL1CLNode)(nil)
+var _ stack.Lifecycle = (*rpcL1CLNode)(nil)
 
 func NewL1CLNode(cfg L1CLNodeConfig) stack.L1CLNode {
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
+	beacon := sources.NewBeaconHTTPClient(cfg.Client)
 	return &rpcL1CLNode{
-		commonImpl: newCommon(cfg.CommonConfig),
-		id:         cfg.ID,
-		client:     sources.NewBeaconHTTPClient(cfg.Client),
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, beaconHealth(beacon)),
+		id:            cfg.ID,
+		client:        beacon,
+		beacon:        &beaconAPI{beacon: beacon, http: cfg.Client},
 	}
 }
 
@@ -37,3 +52,15 @@ func (r *rpcL1CLNode) ID() stack.L1CLNodeID {
 func (r *rpcL1CLNode) BeaconClient() apis.BeaconClient {
 	return r.client
 }
+
+func (r *rpcL1CLNode) Beacon() stack.BeaconAPI {
+	return r.beacon
+}
+
+// beaconHealth checks that the beacon API answers requests.
+func beaconHealth(beacon apis.BeaconClient) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := beacon.NodeVersion(ctx)
+		return err
+	}
+}
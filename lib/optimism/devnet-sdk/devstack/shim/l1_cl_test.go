@@ -0,0 +1,103 @@
+package shim
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestL1CLBeaconAPI(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"genesis_time":"1000"}}`))
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"SECONDS_PER_SLOT":"6"}}`))
+	})
+	mux.HandleFunc("/eth/v1/beacon/headers/3", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"root":"0x01","canonical":true,"header":{"message":{` +
+			`"slot":"3","proposer_index":"7",` +
+			`"parent_root":"0x0200000000000000000000000000000000000000000000000000000000000000",` +
+			`"state_root":"0x0300000000000000000000000000000000000000000000000000000000000000",` +
+			`"body_root":"0x0400000000000000000000000000000000000000000000000000000000000000"},` +
+			`"signature":"0x05"}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	cl := NewL1CLNode(L1CLNodeConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.L1CLNodeID{Key: "beacon", ChainID: eth.ChainIDFromUInt64(900)},
+		Client:       client.NewBasicHTTPClient(srv.URL, logger),
+	})
+	ctx := context.Background()
+
+	slot, err := cl.Beacon().SlotAt(ctx, 1000+3*6+1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), slot)
+	_, err = cl.Beacon().SlotAt(ctx, 999)
+	require.Error(t, err, "timestamps before genesis have no slot")
+
+	header, err := cl.Beacon().Header(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, eth.Uint64String(3), header.Message.Slot)
+	require.Equal(t, eth.Uint64String(7), header.Message.ProposerIndex)
+	require.Equal(t, eth.Bytes32{0x04}, header.Message.BodyRoot)
+
+	_, err = cl.Beacon().Header(ctx, 4)
+	require.Error(t, err, "missing headers must fail")
+}
+
+func TestL1NetworkBlobSource(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	var genesisRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		genesisRequests.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"genesis_time":"1000"}}`))
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"SECONDS_PER_SLOT":"6"}}`))
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	cfg := CommonConfig{Log: logger, T: t}
+	l1ChainID := eth.ChainIDFromUInt64(900)
+	l1 := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: big.NewInt(900)}},
+		ID:            stack.L1NetworkID{Key: "l1", ChainID: l1ChainID},
+	})
+	l1.AddL1CLNode(NewL1CLNode(L1CLNodeConfig{
+		CommonConfig: cfg,
+		ID:           stack.L1CLNodeID{Key: "beacon", ChainID: l1ChainID},
+		Client:       client.NewBasicHTTPClient(srv.URL, logger),
+	}))
+
+	source := l1.BlobSource()
+	require.Same(t, source, l1.BlobSource(), "blob source must be reused")
+	ref := eth.L1BlockRef{Number: 3, Time: 1000 + 3*6}
+	hashes := []eth.IndexedBlobHash{{Index: 0}}
+	for i := 0; i < 3; i++ {
+		_, err := l1.BlobSource().GetBlobSidecars(context.Background(), ref, hashes)
+		require.ErrorContains(t, err, "failed to fetch blob sidecars for slot 3")
+	}
+	require.Equal(t, int32(1), genesisRequests.Load(), "beacon genesis must only be fetched once")
+}
@@ -6,19 +6,32 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/locks"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
 type L1NetworkConfig struct {
 	NetworkConfig
 	ID stack.L1NetworkID
+	// Snapshot is the block of the pre-seeded state the L1 was started from. Optional.
+	Snapshot *eth.BlockID
 }
 
 type presetL1Network struct {
 	presetNetwork
-	id stack.L1NetworkID
+	id       stack.L1NetworkID
+	snapshot *eth.BlockID
 
 	els locks.RWMap[stack.L1ELNodeID, stack.L1ELNode]
 	cls locks.RWMap[stack.L1CLNodeID, stack.L1CLNode]
+
+	// blobs is the blob source of the CL node that blobs are read from,
+	// reused so the beacon client only fetches the genesis and chain spec once.
+	blobs locks.RWValue[*beaconBlobs]
+}
+
+type beaconBlobs struct {
+	cl     stack.L1CLNodeID
+	source stack.BlobSource
 }
 
 var _ stack.ExtensibleL1Network = (*presetL1Network)(nil)
@@ -26,16 +39,42 @@ var _ stack.ExtensibleL1Network = (*presetL1Network)(nil)
 func NewL1Network(cfg L1NetworkConfig) stack.ExtensibleL1Network {
 	require.Equal(cfg.T, cfg.ID.ChainID, eth.ChainIDFromBig(cfg.NetworkConfig.ChainConfig.ChainID), "chain config must match expected chain")
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
-	return &presetL1Network{
+	p := &presetL1Network{
 		id:            cfg.ID,
 		presetNetwork: newNetwork(cfg.NetworkConfig),
+		snapshot:      cfg.Snapshot,
 	}
+	p.blobSource = p.beaconBlobSource
+	return p
+}
+
+// beaconBlobSource reads blobs from the beacon API of the first L1 CL node.
+// The blob source is created once per CL node.
+func (p *presetL1Network) beaconBlobSource() stack.BlobSource {
+	cls := p.L1CLNodes()
+	p.require().NotEmpty(cls, "l1 chain %s must have a CL node to read blobs from", p.id)
+	p.blobs.Lock()
+	defer p.blobs.Unlock()
+	if p.blobs.Value == nil || p.blobs.Value.cl != cls[0] {
+		p.blobs.Value = &beaconBlobs{
+			cl:     cls[0],
+			source: sources.NewL1BeaconClient(p.L1CLNode(cls[0]).BeaconClient(), sources.L1BeaconClientConfig{}),
+		}
+	}
+	return p.blobs.Value.source
 }
 
 func (p *presetL1Network) ID() stack.L1NetworkID {
 	return p.id
 }
 
+func (p *presetL1Network) Snapshot() (eth.BlockID, bool) {
+	if p.snapshot == nil {
+		return eth.BlockID{}, false
+	}
+	return *p.snapshot, true
+}
+
 func (p *presetL1Network) L1ELNode(id stack.L1ELNodeID) stack.L1ELNode {
 	v, ok := p.els.Get(id)
 	p.require().True(ok, "l1 EL node %s must exist", id)
@@ -45,7 +84,13 @@ func (p *presetL1Network) L1ELNode(id stack.L1ELNodeID) stack.L1ELNode {
 func (p *presetL1Network) AddL1ELNode(v stack.L1ELNode) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l1 EL node %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.els.SetIfMissing(id, v), "l1 EL node %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.els.Has(id) {
+			p.RemoveL1ELNode(id)
+		}
+	})
 }
 
 func (p *presetL1Network) L1CLNode(id stack.L1CLNodeID) stack.L1CLNode {
@@ -57,7 +102,25 @@ func (p *presetL1Network) L1CLNode(id stack.L1CLNodeID) stack.L1CLNode {
 func (p *presetL1Network) AddL1CLNode(v stack.L1CLNode) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l1 CL node %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.cls.SetIfMissing(id, v), "l1 CL node %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.cls.Has(id) {
+			p.RemoveL1CLNode(id)
+		}
+	})
+}
+
+func (p *presetL1Network) RemoveL1ELNode(id stack.L1ELNodeID) {
+	p.require().True(p.els.Has(id), "l1 EL node %s must exist", id)
+	p.els.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL1Network) RemoveL1CLNode(id stack.L1CLNodeID) {
+	p.require().True(p.cls.Has(id), "l1 CL node %s must exist", id)
+	p.cls.Delete(id)
+	p.unregisterID(id)
 }
 
 func (p *presetL1Network) L1ELNodes() []stack.L1ELNodeID {
@@ -67,3 +130,11 @@ func (p *presetL1Network) L1ELNodes() []stack.L1ELNodeID {
 func (p *presetL1Network) L1CLNodes() []stack.L1CLNodeID {
 	return stack.SortL1CLNodeIDs(p.cls.Keys())
 }
+
+func (p *presetL1Network) L1ELNodesWithLabel(key, value string) []stack.L1ELNodeID {
+	return stack.FilterByLabel(p.L1ELNodes(), p.L1ELNode, key, value)
+}
+
+func (p *presetL1Network) L1CLNodesWithLabel(key, value string) []stack.L1CLNodeID {
+	return stack.FilterByLabel(p.L1CLNodes(), p.L1CLNode, key, value)
+}
@@ -1,33 +1,63 @@
 package shim
 
 import (
+	"context"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type L2BatcherConfig struct {
 	CommonConfig
-	ID     stack.L2BatcherID
+	ID stack.L2BatcherID
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
 	Client client.RPC
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
 }
 
 type rpcL2Batcher struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 	id     stack.L2BatcherID
 	client client.RPC
 }
 
 var _ stack.L2Batcher = (*rpcL2Batcher)(nil)
+var _ stack.MetricsSource = (*rpcL2Batcher)(nil)
+var _ stack.Lifecycle = (*rpcL2Batcher)(nil)
 
 func NewL2Batcher(cfg L2BatcherConfig) stack.L2Batcher {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
 	return &rpcL2Batcher{
-		commonImpl: newCommon(cfg.CommonConfig),
-		id:         cfg.ID,
-		client:     cfg.Client,
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		id:            cfg.ID,
+		client:        cfg.Client,
 	}
 }
 
 func (r *rpcL2Batcher) ID() stack.L2BatcherID {
 	return r.id
 }
+
+func (r *rpcL2Batcher) StartBatcher(ctx context.Context) error {
+	return r.client.CallContext(ctx, nil, "admin_startBatcher")
+}
+
+func (r *rpcL2Batcher) StopBatcher(ctx context.Context) error {
+	return r.client.CallContext(ctx, nil, "admin_stopBatcher")
+}
+
+func (r *rpcL2Batcher) Flush(ctx context.Context) (eth.BlockID, error) {
+	var latest eth.BlockID
+	err := r.client.CallContext(ctx, &latest, "admin_flushBatcher")
+	return latest, err
+}
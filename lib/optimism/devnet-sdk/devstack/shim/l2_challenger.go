@@ -1,27 +1,197 @@
 package shim
 
-import "github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// challengerTrackedGamesMetric is the op-challenger gauge of tracked games, labeled by game status.
+const challengerTrackedGamesMetric = "op_challenger_tracked_games"
 
 type L2ChallengerConfig struct {
 	CommonConfig
 	ID stack.L2ChallengerID
+	// MetricsClient is used to scrape the challenger metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+
+	// L1Client is used to query the dispute games the challenger acts on. Optional.
+	L1Client client.RPC
+	// DisputeGameFactory is the factory of the dispute games of the L2. Required if L1Client is set.
+	DisputeGameFactory common.Address
+	// Address is the account the challenger makes claims from. Required if L1Client is set.
+	Address common.Address
 }
 
 type rpcL2Challenger struct {
 	commonImpl
+	metricsImpl
 	id stack.L2ChallengerID
+
+	games   *disputeGames
+	address common.Address
+
+	trackedMu sync.Mutex
+	// tracked are the claims of the challenger per dispute game, as far as they were read
+	tracked map[common.Address]*gameClaims
 }
 
 var _ stack.L2Challenger = (*rpcL2Challenger)(nil)
+var _ stack.MetricsSource = (*rpcL2Challenger)(nil)
 
 func NewL2Challenger(cfg L2ChallengerConfig) stack.L2Challenger {
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
 	return &rpcL2Challenger{
-		commonImpl: newCommon(cfg.CommonConfig),
-		id:         cfg.ID,
+		commonImpl:  newCommon(cfg.CommonConfig),
+		metricsImpl: newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		id:          cfg.ID,
+		games:       newDisputeGames(cfg.L1Client, cfg.DisputeGameFactory),
+		address:     cfg.Address,
+		tracked:     make(map[common.Address]*gameClaims),
 	}
 }
 
 func (r *rpcL2Challenger) ID() stack.L2ChallengerID {
 	return r.id
 }
+
+func (r *rpcL2Challenger) GameStatus(ctx context.Context) (stack.ChallengerGameStatus, error) {
+	families, err := r.Metrics(ctx)
+	if err != nil {
+		return stack.ChallengerGameStatus{}, err
+	}
+	var status stack.ChallengerGameStatus
+	family, ok := families[challengerTrackedGamesMetric]
+	if !ok {
+		// the challenger has not tracked any games yet
+		return status, nil
+	}
+	for _, m := range family.GetMetric() {
+		count := uint64(metricValue(m))
+		switch metricLabel(m, "status") {
+		case "in_progress":
+			status.InProgress = count
+		case "defender_won":
+			status.DefenderWon = count
+		case "challenger_won":
+			status.ChallengerWon = count
+		}
+	}
+	return status, nil
+}
+
+func (r *rpcL2Challenger) Games(ctx context.Context) ([]stack.ChallengerGame, error) {
+	r.requireGames()
+	head, err := r.games.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	games, err := r.games.all(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]common.Address, len(games))
+	for i, game := range games {
+		addrs[i] = game.Proxy
+	}
+	own, err := r.ownClaims(ctx, head, addrs...)
+	if err != nil {
+		return nil, err
+	}
+	var claimed []gameTypes.GameMetadata
+	var claimedAddrs []common.Address
+	for i, game := range games {
+		if len(own[i]) > 0 {
+			claimed = append(claimed, game)
+			claimedAddrs = append(claimedAddrs, game.Proxy)
+		}
+	}
+	statuses, err := r.games.statuses(ctx, head, claimedAddrs...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]stack.ChallengerGame, len(claimed))
+	for i, game := range claimed {
+		out[i] = stack.ChallengerGame{GameMetadata: game, Status: statuses[i]}
+	}
+	return out, nil
+}
+
+func (r *rpcL2Challenger) Claims(ctx context.Context, game common.Address) ([]stack.ChallengerClaim, error) {
+	r.requireGames()
+	head, err := r.games.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	own, err := r.ownClaims(ctx, head, game)
+	if err != nil {
+		return nil, err
+	}
+	// the claims of the challenger are read again, since they may have been countered since they were tracked
+	claims, err := r.games.claims(ctx, head, game, own[0])
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := r.games.resolved(ctx, head, game, own[0])
+	if err != nil {
+		return nil, err
+	}
+	out := make([]stack.ChallengerClaim, len(claims))
+	for i, claim := range claims {
+		out[i] = stack.ChallengerClaim{
+			Index:       uint64(claim.ContractIndex),
+			ParentIndex: uint64(claim.ParentContractIndex),
+			Value:       claim.Value,
+			CounteredBy: claim.CounteredBy,
+			Resolved:    resolved[i],
+		}
+	}
+	return out, nil
+}
+
+// ownClaims returns the indices of the claims of the challenger in each of the given games, at the given L1 block.
+// Only the claims that were added to a game since it was last queried are read.
+func (r *rpcL2Challenger) ownClaims(ctx context.Context, head common.Hash, games ...common.Address) ([][]uint64, error) {
+	r.trackedMu.Lock()
+	defer r.trackedMu.Unlock()
+	counts, err := r.games.claimCounts(ctx, head, games...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]uint64, len(games))
+	for i, game := range games {
+		tracked, ok := r.tracked[game]
+		if !ok {
+			tracked = new(gameClaims)
+			r.tracked[game] = tracked
+		}
+		if count := counts[i]; count > tracked.scanned {
+			indices := make([]uint64, 0, count-tracked.scanned)
+			for idx := tracked.scanned; idx < count; idx++ {
+				indices = append(indices, idx)
+			}
+			claims, err := r.games.claims(ctx, head, game, indices)
+			if err != nil {
+				return nil, err
+			}
+			for _, claim := range claims {
+				if claim.Claimant == r.address {
+					tracked.own = append(tracked.own, uint64(claim.ContractIndex))
+				}
+			}
+			tracked.scanned = count
+		}
+		out[i] = slices.Clone(tracked.own)
+	}
+	return out, nil
+}
+
+func (r *rpcL2Challenger) requireGames() {
+	r.require().NotNil(r.games, "challenger %s must have an L1 client to query dispute games", r.id)
+}
@@ -0,0 +1,155 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+func TestL2ChallengerGameStatus(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/metrics", r.URL.Path)
+		_, _ = w.Write([]byte(`# HELP op_challenger_tracked_games Number of games being tracked by the challenger
+# TYPE op_challenger_tracked_games gauge
+op_challenger_tracked_games{status="challenger_won"} 1
+op_challenger_tracked_games{status="defender_won"} 2
+op_challenger_tracked_games{status="in_progress"} 3
+`))
+	}))
+	t.Cleanup(srv.Close)
+
+	challenger := NewL2Challenger(L2ChallengerConfig{
+		CommonConfig:  CommonConfig{Log: logger, T: t},
+		ID:            stack.L2ChallengerID{Key: "main", ChainID: eth.ChainIDFromUInt64(901)},
+		MetricsClient: client.NewBasicHTTPClient(srv.URL, logger),
+	})
+	status, err := challenger.GameStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, stack.ChallengerGameStatus{InProgress: 3, DefenderWon: 2, ChallengerWon: 1}, status)
+	require.Equal(t, uint64(6), status.Total())
+
+	noMetrics := NewL2Challenger(L2ChallengerConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.L2ChallengerID{Key: "other", ChainID: eth.ChainIDFromUInt64(901)},
+	})
+	_, err = noMetrics.GameStatus(context.Background())
+	require.ErrorIs(t, err, stack.ErrNoMetrics)
+}
+
+func TestL2ChallengerClaims(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	factory := common.Address{0xfa}
+	game := common.Address{0x9a}
+	other := common.Address{0x9b}
+	challenger := common.Address{0xc1}
+	honest := common.Address{0xaa}
+
+	stub := batchingTest.NewAbiBasedRpc(t, factory, snapshots.LoadDisputeGameFactoryABI())
+	stub.AddContract(game, snapshots.LoadFaultDisputeGameABI())
+	stub.AddContract(other, snapshots.LoadFaultDisputeGameABI())
+	head := &types.Header{Number: big.NewInt(100)}
+	block := rpcblock.ByHash(head.Hash())
+	expectGames := func(games ...common.Address) {
+		stub.AddExpectedCall(&headCall{header: head})
+		stub.SetResponse(factory, "gameCount", block, nil, []any{big.NewInt(int64(len(games)))})
+		for i, addr := range games {
+			stub.SetResponse(factory, "gameAtIndex", block, []any{big.NewInt(int64(i))}, []any{uint32(0), uint64(1000 + i), addr})
+		}
+	}
+	expectClaim := func(game common.Address, idx int64, claimant common.Address, counteredBy common.Address) {
+		stub.SetResponse(game, "claimData", block, []any{big.NewInt(idx)}, []any{
+			uint32(0), counteredBy, claimant, big.NewInt(1), common.Hash{byte(idx)}, big.NewInt(idx + 1), big.NewInt(0),
+		})
+	}
+
+	l2Challenger := NewL2Challenger(L2ChallengerConfig{
+		CommonConfig:       CommonConfig{Log: logger, T: t},
+		ID:                 stack.L2ChallengerID{Key: "main", ChainID: eth.ChainIDFromUInt64(901)},
+		L1Client:           &stubRPC{AbiBasedRpc: stub},
+		DisputeGameFactory: factory,
+		Address:            challenger,
+	})
+
+	// the challenger countered the root claim of the first game only
+	expectGames(game, other)
+	stub.SetResponse(game, "claimDataLen", block, nil, []any{big.NewInt(2)})
+	stub.SetResponse(other, "claimDataLen", block, nil, []any{big.NewInt(1)})
+	expectClaim(game, 0, honest, challenger)
+	expectClaim(game, 1, challenger, common.Address{})
+	expectClaim(other, 0, honest, common.Address{})
+	stub.SetResponse(game, "status", block, nil, []any{uint8(gameTypes.GameStatusInProgress)})
+	games, err := l2Challenger.Games(context.Background())
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+	require.Equal(t, game, games[0].Proxy)
+	require.Equal(t, gameTypes.GameStatusInProgress, games[0].Status)
+
+	// claims that were read before are not read again, except for the claims of the challenger
+	stub.ClearResponses()
+	expectGames(game, other)
+	stub.SetResponse(game, "claimDataLen", block, nil, []any{big.NewInt(4)})
+	stub.SetResponse(other, "claimDataLen", block, nil, []any{big.NewInt(1)})
+	expectClaim(game, 1, challenger, honest)
+	expectClaim(game, 2, honest, common.Address{})
+	expectClaim(game, 3, challenger, common.Address{})
+	stub.SetResponse(game, "resolvedSubgames", block, []any{big.NewInt(1)}, []any{true})
+	stub.SetResponse(game, "resolvedSubgames", block, []any{big.NewInt(3)}, []any{false})
+	claims, err := l2Challenger.Claims(context.Background(), game)
+	require.NoError(t, err)
+	require.Equal(t, []stack.ChallengerClaim{
+		{Index: 1, Value: common.Hash{1}, CounteredBy: honest, Resolved: true},
+		{Index: 3, Value: common.Hash{3}, Resolved: false},
+	}, claims)
+}
+
+// headCall serves the latest L1 block header.
+type headCall struct {
+	header *types.Header
+}
+
+func (c *headCall) Matches(rpcMethod string, args ...any) error {
+	if rpcMethod != "eth_getBlockByNumber" {
+		return fmt.Errorf("expected eth_getBlockByNumber but was %v", rpcMethod)
+	}
+	return nil
+}
+
+func (c *headCall) Execute(t *testing.T, out any) error {
+	*out.(**types.Header) = c.header
+	return nil
+}
+
+func (c *headCall) String() string {
+	return "eth_getBlockByNumber"
+}
+
+// stubRPC adapts a stubbed RPC to the client.RPC interface.
+type stubRPC struct {
+	*batchingTest.AbiBasedRpc
+}
+
+func (s *stubRPC) Close() {}
+
+func (s *stubRPC) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("subscriptions are not supported")
+}
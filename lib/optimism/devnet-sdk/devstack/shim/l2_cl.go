@@ -1,33 +1,60 @@
 package shim
 
 import (
+	"context"
+	"time"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
+const (
+	// defaultSyncStatusTimeout is how long to wait for the sync status of a L2 CL node.
+	defaultSyncStatusTimeout = 10 * time.Second
+	syncStatusPollInterval   = 500 * time.Millisecond
+)
+
 type L2CLNodeConfig struct {
 	CommonConfig
-	ID     stack.L2CLNodeID
+	ID stack.L2CLNodeID
+	// EL is the EL node that the CL node drives.
+	EL stack.L2ELNodeID
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
 	Client client.RPC
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
 }
 
 type rpcL2CLNode struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 	id           stack.L2CLNodeID
+	el           stack.L2ELNodeID
 	client       client.RPC
 	rollupClient stack.RollupAPI
 }
 
 var _ stack.L2CLNode = (*rpcL2CLNode)(nil)
+var _ stack.MetricsSource = (*rpcL2CLNode)(nil)
+var _ stack.Lifecycle = (*rpcL2CLNode)(nil)
 
 func NewL2CLNode(cfg L2CLNodeConfig) stack.L2CLNode {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
 	return &rpcL2CLNode{
-		commonImpl:   newCommon(cfg.CommonConfig),
-		id:           cfg.ID,
-		client:       cfg.Client,
-		rollupClient: sources.NewRollupClient(cfg.Client),
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		id:            cfg.ID,
+		el:            cfg.EL,
+		client:        cfg.Client,
+		rollupClient:  sources.NewRollupClient(cfg.Client),
 	}
 }
 
@@ -35,6 +62,54 @@ func (r *rpcL2CLNode) ID() stack.L2CLNodeID {
 	return r.id
 }
 
+func (r *rpcL2CLNode) EL() stack.L2ELNodeID {
+	return r.el
+}
+
 func (r *rpcL2CLNode) RollupAPI() stack.RollupAPI {
 	return r.rollupClient
 }
+
+func (r *rpcL2CLNode) WaitForUnsafeBlock(ctx context.Context, num uint64) eth.L2BlockRef {
+	return r.waitForHead(ctx, "unsafe", num, func(status *eth.SyncStatus) eth.L2BlockRef {
+		return status.UnsafeL2
+	})
+}
+
+func (r *rpcL2CLNode) WaitForSafeBlock(ctx context.Context, num uint64) eth.L2BlockRef {
+	return r.waitForHead(ctx, "safe", num, func(status *eth.SyncStatus) eth.L2BlockRef {
+		return status.SafeL2
+	})
+}
+
+func (r *rpcL2CLNode) SyncStatusSnapshot() *eth.SyncStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultSyncStatusTimeout)
+	defer cancel()
+	status, err := r.rollupClient.SyncStatus(ctx)
+	r.require().NoError(err, "failed to get sync status of %s", r.id)
+	return status
+}
+
+// waitForHead polls the sync status until the selected head reaches the given block number.
+// Errors of individual polls are tolerated, e.g. while the node is restarting.
+// The test fails if the head does not reach the block before the context is done.
+func (r *rpcL2CLNode) waitForHead(ctx context.Context, name string, num uint64, head func(status *eth.SyncStatus) eth.L2BlockRef) eth.L2BlockRef {
+	ticker := time.NewTicker(syncStatusPollInterval)
+	defer ticker.Stop()
+	var last eth.L2BlockRef
+	for {
+		status, err := r.rollupClient.SyncStatus(ctx)
+		if err != nil {
+			r.log.Debug("Failed to get sync status", "err", err)
+		} else if last = head(status); last.Number >= num {
+			return last
+		}
+		select {
+		case <-ctx.Done():
+			r.require().FailNow("timed out waiting for head", "%s head of %s did not reach block %d, last seen at %s",
+				name, r.id, num, last)
+			return last
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestL2CLWaitForBlock(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	rollup := &fakeRollupRPC{}
+	rollup.unsafe.Store(5)
+	newNode := func(t stack.T) stack.L2CLNode {
+		return NewL2CLNode(L2CLNodeConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+			ID:           stack.L2CLNodeID{Key: "sequencer", ChainID: eth.ChainIDFromUInt64(901)},
+			Client:       rollup,
+		})
+	}
+	node := newNode(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.Equal(t, uint64(5), node.WaitForUnsafeBlock(ctx, 3).Number)
+	go func() {
+		time.Sleep(syncStatusPollInterval)
+		rollup.unsafe.Store(7)
+	}()
+	require.Equal(t, uint64(7), node.WaitForUnsafeBlock(ctx, 7).Number)
+
+	// the wait ends with the deadline of the context
+	failT := stack.NewToolingT(t.Name(), logger)
+	failT.Fail = func() {
+		panic("failed")
+	}
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer shortCancel()
+	start := time.Now()
+	require.PanicsWithValue(t, "failed", func() {
+		newNode(failT).WaitForSafeBlock(shortCtx, 1)
+	})
+	require.Less(t, time.Since(start), 10*time.Second)
+}
+
+// fakeRollupRPC serves the sync status of a rollup node, with a safe head at genesis.
+type fakeRollupRPC struct {
+	unsafe atomic.Uint64
+}
+
+func (f *fakeRollupRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if method != "optimism_syncStatus" {
+		return fmt.Errorf("unexpected call to %s", method)
+	}
+	*result.(**eth.SyncStatus) = &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Number: f.unsafe.Load()}}
+	return nil
+}
+
+func (f *fakeRollupRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return fmt.Errorf("batch calls are not supported")
+}
+
+func (f *fakeRollupRPC) Close() {}
+
+func (f *fakeRollupRPC) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("subscriptions are not supported")
+}
@@ -0,0 +1,92 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+type L2ConductorConfig struct {
+	CommonConfig
+	ID        stack.L2ConductorID
+	Sequencer stack.L2CLNodeID
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
+	Client client.RPC
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
+}
+
+type rpcL2Conductor struct {
+	commonImpl
+	metricsImpl
+	lifecycleImpl
+	id        stack.L2ConductorID
+	sequencer stack.L2CLNodeID
+	api       *conductorClient
+}
+
+var _ stack.L2Conductor = (*rpcL2Conductor)(nil)
+var _ stack.MetricsSource = (*rpcL2Conductor)(nil)
+var _ stack.Lifecycle = (*rpcL2Conductor)(nil)
+
+func NewL2Conductor(cfg L2ConductorConfig) stack.L2Conductor {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
+	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
+	return &rpcL2Conductor{
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		id:            cfg.ID,
+		sequencer:     cfg.Sequencer,
+		api:           &conductorClient{client: cfg.Client},
+	}
+}
+
+func (r *rpcL2Conductor) ID() stack.L2ConductorID {
+	return r.id
+}
+
+func (r *rpcL2Conductor) Sequencer() stack.L2CLNodeID {
+	return r.sequencer
+}
+
+func (r *rpcL2Conductor) ConductorAPI() stack.ConductorAPI {
+	return r.api
+}
+
+// conductorClient implements the conductor RPC namespace on top of a generic RPC client.
+type conductorClient struct {
+	client client.RPC
+}
+
+var _ stack.ConductorAPI = (*conductorClient)(nil)
+
+func (c *conductorClient) Leader(ctx context.Context) (bool, error) {
+	var leader bool
+	err := c.client.CallContext(ctx, &leader, "conductor_leader")
+	return leader, err
+}
+
+func (c *conductorClient) Active(ctx context.Context) (bool, error) {
+	var active bool
+	err := c.client.CallContext(ctx, &active, "conductor_active")
+	return active, err
+}
+
+func (c *conductorClient) SequencerHealthy(ctx context.Context) (bool, error) {
+	var healthy bool
+	err := c.client.CallContext(ctx, &healthy, "conductor_sequencerHealthy")
+	return healthy, err
+}
+
+func (c *conductorClient) TransferLeader(ctx context.Context) error {
+	return c.client.CallContext(ctx, nil, "conductor_transferLeader")
+}
+
+func (c *conductorClient) TransferLeaderToServer(ctx context.Context, id string, addr string) error {
+	return c.client.CallContext(ctx, nil, "conductor_transferLeaderToServer", id, addr)
+}
@@ -1,20 +1,37 @@
 package shim
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/require"
 
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
 type L2ELNodeConfig struct {
 	ELNodeConfig
 	ID stack.L2ELNodeID
+
+	// AuthRPC is the URL of the authenticated engine API of the node. Optional.
+	AuthRPC string
+	// JWTSecret is the secret to authenticate engine API requests with. Required to use the engine API.
+	JWTSecret [32]byte
+	// RollupConfig determines the engine API versions to use. Required if AuthRPC is set.
+	RollupConfig *rollup.Config
 }
 
 type rpcL2ELNode struct {
 	rpcELNode
 
-	id stack.L2ELNodeID
+	id           stack.L2ELNodeID
+	authRPC      string
+	engineClient *sources.EngineAPIClient
 }
 
 var _ stack.L2ELNode = (*rpcL2ELNode)(nil)
@@ -22,12 +39,27 @@ var _ stack.L2ELNode = (*rpcL2ELNode)(nil)
 func NewL2ELNode(cfg L2ELNodeConfig) stack.L2ELNode {
 	require.Equal(cfg.T, cfg.ID.ChainID, cfg.ELNodeConfig.ChainID, "chainID must be configured to match node chainID")
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
+	var engineClient *sources.EngineAPIClient
+	if cfg.AuthRPC != "" && cfg.JWTSecret != ([32]byte{}) {
+		require.NotNil(cfg.T, cfg.RollupConfig, "engine API of %s needs a rollup config", cfg.ID)
+		authClient, err := client.NewRPC(context.Background(), cfg.Log, cfg.AuthRPC, client.WithLazyDial(),
+			client.WithGethRPCOptions(rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.JWTSecret))))
+		require.NoError(cfg.T, err)
+		engineClient = sources.NewEngineAPIClient(authClient, cfg.Log, cfg.RollupConfig)
+	}
 	return &rpcL2ELNode{
-		rpcELNode: newRpcELNode(cfg.ELNodeConfig),
-		id:        cfg.ID,
+		rpcELNode:    newRpcELNode(cfg.ELNodeConfig),
+		id:           cfg.ID,
+		authRPC:      cfg.AuthRPC,
+		engineClient: engineClient,
 	}
 }
 
 func (r *rpcL2ELNode) ID() stack.L2ELNodeID {
 	return r.id
 }
+
+func (r *rpcL2ELNode) EngineClient() stack.EngineAPI {
+	r.require().NotNil(r.engineClient, "l2 EL node %s must have an engine API client", r.id)
+	return r.engineClient
+}
@@ -0,0 +1,54 @@
+package shim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestL2ELEngineClient(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "), "engine API requests must be authenticated")
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.True(t, strings.HasPrefix(req.Method, "engine_forkchoiceUpdated"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	chainID := eth.ChainIDFromUInt64(901)
+	el := NewL2ELNode(L2ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+			ChainID:      chainID,
+		},
+		ID:           stack.L2ELNodeID{Key: "sequencer", ChainID: chainID},
+		AuthRPC:      srv.URL,
+		JWTSecret:    [32]byte{1},
+		RollupConfig: &rollup.Config{},
+	})
+	result, err := el.EngineClient().ForkchoiceUpdate(context.Background(), &eth.ForkchoiceState{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, result.PayloadStatus.Status)
+}
@@ -1,6 +1,8 @@
 package shim
 
 import (
+	"slices"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
@@ -36,6 +38,7 @@ type presetL2Network struct {
 	batchers    locks.RWMap[stack.L2BatcherID, stack.L2Batcher]
 	proposers   locks.RWMap[stack.L2ProposerID, stack.L2Proposer]
 	challengers locks.RWMap[stack.L2ChallengerID, stack.L2Challenger]
+	conductors  locks.RWMap[stack.L2ConductorID, stack.L2Conductor]
 
 	els locks.RWMap[stack.L2ELNodeID, stack.L2ELNode]
 	cls locks.RWMap[stack.L2CLNodeID, stack.L2CLNode]
@@ -49,7 +52,7 @@ func NewL2Network(cfg L2NetworkConfig) stack.ExtensibleL2Network {
 	require.Equal(cfg.T, cfg.L1.ChainID(), eth.ChainIDFromBig(cfg.RollupConfig.L1ChainID), "rollup config must match expected L1 chain")
 	require.Equal(cfg.T, cfg.ID.ChainID, eth.ChainIDFromBig(cfg.RollupConfig.L2ChainID), "rollup config must match expected L2 chain")
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
-	return &presetL2Network{
+	p := &presetL2Network{
 		id:            cfg.ID,
 		presetNetwork: newNetwork(cfg.NetworkConfig),
 		rollupCfg:     cfg.RollupConfig,
@@ -59,6 +62,11 @@ func NewL2Network(cfg L2NetworkConfig) stack.ExtensibleL2Network {
 		l1:            cfg.L1,
 		cluster:       cfg.Cluster,
 	}
+	// batches are submitted to L1, blobs are read from there
+	p.blobSource = func() stack.BlobSource {
+		return p.L1().BlobSource()
+	}
+	return p
 }
 
 func (p *presetL2Network) ID() stack.L2NetworkID {
@@ -70,6 +78,20 @@ func (p *presetL2Network) RollupConfig() *rollup.Config {
 	return p.rollupCfg
 }
 
+func (p *presetL2Network) IsForkActive(fork rollup.ForkName, timestamp uint64) bool {
+	activation, ok := p.ForkActivationTime(fork)
+	return ok && timestamp >= activation
+}
+
+func (p *presetL2Network) ForkActivationTime(fork rollup.ForkName) (uint64, bool) {
+	p.require().True(slices.Contains(rollup.AllForks, fork), "unknown fork %q of l2 chain %s", fork, p.ID())
+	activation := p.RollupConfig().ActivationTime(fork)
+	if activation == nil {
+		return 0, false
+	}
+	return *activation, true
+}
+
 func (p *presetL2Network) Deployment() stack.L2Deployment {
 	p.require().NotNil(p.deployment, "l2 chain %s must have a deployment", p.ID())
 	return p.deployment
@@ -85,6 +107,16 @@ func (p *presetL2Network) Superchain() stack.Superchain {
 	return p.superchain
 }
 
+// l2Membership returns the superchain and cluster of the L2 network, nil for each the network is not part of.
+// The accessors of the stack.L2Network interface fail if the network is not part of a superchain or cluster,
+// preset networks are inspected directly.
+func l2Membership(l2 stack.L2Network) (stack.Superchain, stack.Cluster) {
+	if preset, ok := l2.(*presetL2Network); ok {
+		return preset.superchain, preset.cluster
+	}
+	return l2.Superchain(), l2.Cluster()
+}
+
 func (p *presetL2Network) L1() stack.L1Network {
 	p.require().NotNil(p.l1, "l2 chain %s must have an L1 chain", p.ID())
 	return p.l1
@@ -104,7 +136,13 @@ func (p *presetL2Network) L2Batcher(id stack.L2BatcherID) stack.L2Batcher {
 func (p *presetL2Network) AddL2Batcher(v stack.L2Batcher) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l2 batcher %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.batchers.SetIfMissing(id, v), "l2 batcher %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.batchers.Has(id) {
+			p.RemoveL2Batcher(id)
+		}
+	})
 }
 
 func (p *presetL2Network) L2Proposer(id stack.L2ProposerID) stack.L2Proposer {
@@ -116,7 +154,13 @@ func (p *presetL2Network) L2Proposer(id stack.L2ProposerID) stack.L2Proposer {
 func (p *presetL2Network) AddL2Proposer(v stack.L2Proposer) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l2 proposer %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.proposers.SetIfMissing(id, v), "l2 proposer %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.proposers.Has(id) {
+			p.RemoveL2Proposer(id)
+		}
+	})
 }
 
 func (p *presetL2Network) L2Challenger(id stack.L2ChallengerID) stack.L2Challenger {
@@ -128,7 +172,33 @@ func (p *presetL2Network) L2Challenger(id stack.L2ChallengerID) stack.L2Challeng
 func (p *presetL2Network) AddL2Challenger(v stack.L2Challenger) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l2 challenger %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.challengers.SetIfMissing(id, v), "l2 challenger %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.challengers.Has(id) {
+			p.RemoveL2Challenger(id)
+		}
+	})
+}
+
+func (p *presetL2Network) L2Conductor(id stack.L2ConductorID) stack.L2Conductor {
+	v, ok := p.conductors.Get(id)
+	p.require().True(ok, "l2 conductor %s must exist", id)
+	return v
+}
+
+func (p *presetL2Network) AddL2Conductor(v stack.L2Conductor) {
+	id := v.ID()
+	p.require().Equal(p.chainID, id.ChainID, "l2 conductor %s must be on chain %s", id, p.chainID)
+	_, ok := p.cls.Get(v.Sequencer())
+	p.require().True(ok, "sequencer %s of l2 conductor %s must exist", v.Sequencer(), id)
+	p.registerID(id)
+	p.require().True(p.conductors.SetIfMissing(id, v), "l2 conductor %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.conductors.Has(id) {
+			p.RemoveL2Conductor(id)
+		}
+	})
 }
 
 func (p *presetL2Network) L2CLNode(id stack.L2CLNodeID) stack.L2CLNode {
@@ -140,7 +210,13 @@ func (p *presetL2Network) L2CLNode(id stack.L2CLNodeID) stack.L2CLNode {
 func (p *presetL2Network) AddL2CLNode(v stack.L2CLNode) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l2 CL node %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.cls.SetIfMissing(id, v), "l2 CL node %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.cls.Has(id) {
+			p.RemoveL2CLNode(id)
+		}
+	})
 }
 
 func (p *presetL2Network) L2ELNode(id stack.L2ELNodeID) stack.L2ELNode {
@@ -152,7 +228,53 @@ func (p *presetL2Network) L2ELNode(id stack.L2ELNodeID) stack.L2ELNode {
 func (p *presetL2Network) AddL2ELNode(v stack.L2ELNode) {
 	id := v.ID()
 	p.require().Equal(p.chainID, id.ChainID, "l2 EL node %s must be on chain %s", id, p.chainID)
+	p.registerID(id)
 	p.require().True(p.els.SetIfMissing(id, v), "l2 EL node %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.els.Has(id) {
+			p.RemoveL2ELNode(id)
+		}
+	})
+}
+
+func (p *presetL2Network) RemoveL2Batcher(id stack.L2BatcherID) {
+	p.require().True(p.batchers.Has(id), "l2 batcher %s must exist", id)
+	p.batchers.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL2Network) RemoveL2Proposer(id stack.L2ProposerID) {
+	p.require().True(p.proposers.Has(id), "l2 proposer %s must exist", id)
+	p.proposers.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL2Network) RemoveL2Challenger(id stack.L2ChallengerID) {
+	p.require().True(p.challengers.Has(id), "l2 challenger %s must exist", id)
+	p.challengers.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL2Network) RemoveL2Conductor(id stack.L2ConductorID) {
+	p.require().True(p.conductors.Has(id), "l2 conductor %s must exist", id)
+	p.conductors.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL2Network) RemoveL2CLNode(id stack.L2CLNodeID) {
+	p.require().True(p.cls.Has(id), "l2 CL node %s must exist", id)
+	p.conductors.Range(func(conductorID stack.L2ConductorID, v stack.L2Conductor) bool {
+		p.require().NotEqual(id, v.Sequencer(), "l2 CL node %s must not be controlled by l2 conductor %s", id, conductorID)
+		return true
+	})
+	p.cls.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetL2Network) RemoveL2ELNode(id stack.L2ELNodeID) {
+	p.require().True(p.els.Has(id), "l2 EL node %s must exist", id)
+	p.els.Delete(id)
+	p.unregisterID(id)
 }
 
 func (p *presetL2Network) L2Batchers() []stack.L2BatcherID {
@@ -167,6 +289,10 @@ func (p *presetL2Network) L2Challengers() []stack.L2ChallengerID {
 	return stack.SortL2ChallengerIDs(p.challengers.Keys())
 }
 
+func (p *presetL2Network) L2Conductors() []stack.L2ConductorID {
+	return stack.SortL2ConductorIDs(p.conductors.Keys())
+}
+
 func (p *presetL2Network) L2CLNodes() []stack.L2CLNodeID {
 	return stack.SortL2CLNodeIDs(p.cls.Keys())
 }
@@ -174,3 +300,27 @@ func (p *presetL2Network) L2CLNodes() []stack.L2CLNodeID {
 func (p *presetL2Network) L2ELNodes() []stack.L2ELNodeID {
 	return stack.SortL2ELNodeIDs(p.els.Keys())
 }
+
+func (p *presetL2Network) L2BatchersWithLabel(key, value string) []stack.L2BatcherID {
+	return stack.FilterByLabel(p.L2Batchers(), p.L2Batcher, key, value)
+}
+
+func (p *presetL2Network) L2ProposersWithLabel(key, value string) []stack.L2ProposerID {
+	return stack.FilterByLabel(p.L2Proposers(), p.L2Proposer, key, value)
+}
+
+func (p *presetL2Network) L2ChallengersWithLabel(key, value string) []stack.L2ChallengerID {
+	return stack.FilterByLabel(p.L2Challengers(), p.L2Challenger, key, value)
+}
+
+func (p *presetL2Network) L2ConductorsWithLabel(key, value string) []stack.L2ConductorID {
+	return stack.FilterByLabel(p.L2Conductors(), p.L2Conductor, key, value)
+}
+
+func (p *presetL2Network) L2CLNodesWithLabel(key, value string) []stack.L2CLNodeID {
+	return stack.FilterByLabel(p.L2CLNodes(), p.L2CLNode, key, value)
+}
+
+func (p *presetL2Network) L2ELNodesWithLabel(key, value string) []stack.L2ELNodeID {
+	return stack.FilterByLabel(p.L2ELNodes(), p.L2ELNode, key, value)
+}
@@ -1,33 +1,120 @@
 package shim
 
 import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/disputegames"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 type L2ProposerConfig struct {
 	CommonConfig
-	ID     stack.L2ProposerID
+	ID stack.L2ProposerID
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
 	Client client.RPC
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
+
+	// L1Client is used to query the proposals, as dispute games on L1.
+	// The admin RPC of the proposer does not report on proposals. Optional.
+	L1Client client.RPC
+	// DisputeGameFactory is the factory of the dispute games of the L2. Required if L1Client is set.
+	DisputeGameFactory common.Address
+	// Address is the account the proposer creates games from. Required if L1Client is set.
+	Address common.Address
+	// ProposalInterval is the delay between proposals. Optional, required for NextProposalTime.
+	ProposalInterval time.Duration
 }
 
 type rpcL2Proposer struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 	id     stack.L2ProposerID
 	client client.RPC
+
+	games            *disputegames.Index
+	address          common.Address
+	proposalInterval time.Duration
 }
 
 var _ stack.L2Proposer = (*rpcL2Proposer)(nil)
+var _ stack.MetricsSource = (*rpcL2Proposer)(nil)
+var _ stack.Lifecycle = (*rpcL2Proposer)(nil)
 
 func NewL2Proposer(cfg L2ProposerConfig) stack.L2Proposer {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
 	cfg.Log = cfg.Log.New("chainID", cfg.ID.ChainID, "id", cfg.ID)
 	return &rpcL2Proposer{
-		commonImpl: newCommon(cfg.CommonConfig),
-		id:         cfg.ID,
-		client:     cfg.Client,
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		id:            cfg.ID,
+		client:        cfg.Client,
+
+		games:            newDisputeGameIndex(cfg.L1Client, cfg.DisputeGameFactory),
+		address:          cfg.Address,
+		proposalInterval: cfg.ProposalInterval,
 	}
 }
 
 func (r *rpcL2Proposer) ID() stack.L2ProposerID {
 	return r.id
 }
+
+func (r *rpcL2Proposer) ProposalCount(ctx context.Context) (uint64, error) {
+	proposals, err := r.proposals(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(proposals)), nil
+}
+
+func (r *rpcL2Proposer) LatestProposedOutput(ctx context.Context) (*stack.ProposedOutput, error) {
+	proposals, err := r.proposals(ctx)
+	if err != nil || len(proposals) == 0 {
+		return nil, err
+	}
+	latest := proposals[len(proposals)-1]
+	return &stack.ProposedOutput{
+		Game:             latest.Proxy,
+		L2SequenceNumber: latest.L2SequenceNumber,
+		OutputRoot:       latest.RootClaim,
+		Timestamp:        latest.Timestamp,
+	}, nil
+}
+
+func (r *rpcL2Proposer) NextProposalTime(ctx context.Context) (time.Time, error) {
+	r.require().NotZero(r.proposalInterval, "proposer %s must have a known proposal interval", r.id)
+	proposals, err := r.proposals(ctx)
+	if err != nil || len(proposals) == 0 {
+		return time.Time{}, err
+	}
+	latest := proposals[len(proposals)-1]
+	return time.Unix(int64(latest.Timestamp), 0).Add(r.proposalInterval), nil
+}
+
+// proposals lists the games created by the proposer, in the order they were created.
+// The games of the factory are indexed, so only the games created since the previous query are read.
+func (r *rpcL2Proposer) proposals(ctx context.Context) ([]disputegames.Game, error) {
+	r.require().NotNil(r.games, "proposer %s must have an L1 client to query proposals", r.id)
+	games, err := r.games.Games(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []disputegames.Game
+	for _, game := range games {
+		// the root claim is made by the creator of the game
+		if game.Claimant == r.address {
+			out = append(out, game)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,155 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+func TestL2ProposerProposals(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	proposerAddr := common.Address{0xb0}
+	l1 := newFakeFactoryRPC(t, common.Address{0xfa})
+	l1.createGame(proposerAddr, common.Hash{1}, 10, 1000)
+	l1.createGame(common.Address{0xaa}, common.Hash{2}, 11, 1010)
+
+	proposer := NewL2Proposer(L2ProposerConfig{
+		CommonConfig:       CommonConfig{Log: logger, T: t},
+		ID:                 stack.L2ProposerID{Key: "main", ChainID: eth.ChainIDFromUInt64(901)},
+		Client:             l1,
+		L1Client:           l1,
+		DisputeGameFactory: l1.factory,
+		Address:            proposerAddr,
+		ProposalInterval:   time.Minute,
+	})
+	count, err := proposer.ProposalCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	require.Equal(t, 2, l1.gamesRead)
+
+	// only the games created since the previous query are read
+	l1.createGame(proposerAddr, common.Hash{3}, 12, 1020)
+	output, err := proposer.LatestProposedOutput(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &stack.ProposedOutput{
+		Game:             l1.games[2].proxy,
+		L2SequenceNumber: 12,
+		OutputRoot:       common.Hash{3},
+		Timestamp:        1020,
+	}, output)
+	require.Equal(t, 3, l1.gamesRead)
+
+	next, err := proposer.NextProposalTime(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1020, 0).Add(time.Minute), next)
+	require.Equal(t, 3, l1.gamesRead)
+}
+
+type fakeGame struct {
+	proxy     common.Address
+	claimant  common.Address
+	rootClaim common.Hash
+	seqNum    uint64
+	timestamp uint64
+}
+
+// fakeFactoryRPC serves the eth_calls to a DisputeGameFactory and its games.
+type fakeFactoryRPC struct {
+	t          *testing.T
+	factory    common.Address
+	factoryABI *abi.ABI
+	gameABI    *abi.ABI
+	games      []fakeGame
+	// gamesRead counts the games read from the factory
+	gamesRead int
+}
+
+func newFakeFactoryRPC(t *testing.T, factory common.Address) *fakeFactoryRPC {
+	return &fakeFactoryRPC{
+		t:          t,
+		factory:    factory,
+		factoryABI: snapshots.LoadDisputeGameFactoryABI(),
+		gameABI:    snapshots.LoadFaultDisputeGameABI(),
+	}
+}
+
+func (f *fakeFactoryRPC) createGame(claimant common.Address, rootClaim common.Hash, seqNum uint64, timestamp uint64) {
+	f.games = append(f.games, fakeGame{
+		proxy:     common.BigToAddress(big.NewInt(int64(0x1000 + len(f.games)))),
+		claimant:  claimant,
+		rootClaim: rootClaim,
+		seqNum:    seqNum,
+		timestamp: timestamp,
+	})
+}
+
+func (f *fakeFactoryRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	require.Equal(f.t, "eth_call", method)
+	callArg := args[0].(map[string]any)
+	to := callArg["to"].(*common.Address)
+	data := callArg["data"].(hexutil.Bytes)
+	contract := f.gameABI
+	if *to == f.factory {
+		contract = f.factoryABI
+	}
+	m, err := contract.MethodById(data[:4])
+	require.NoError(f.t, err)
+	inputs, err := m.Inputs.Unpack(data[4:])
+	require.NoError(f.t, err)
+	var outputs []any
+	switch m.Name {
+	case "gameCount":
+		outputs = []any{big.NewInt(int64(len(f.games)))}
+	case "gameAtIndex":
+		f.gamesRead++
+		game := f.games[inputs[0].(*big.Int).Uint64()]
+		outputs = []any{uint32(0), game.timestamp, game.proxy}
+	case "claimData":
+		game := f.game(*to)
+		outputs = []any{uint32(0), common.Address{}, game.claimant, big.NewInt(0), game.rootClaim, big.NewInt(1), big.NewInt(0)}
+	case "l2SequenceNumber":
+		outputs = []any{new(big.Int).SetUint64(f.game(*to).seqNum)}
+	default:
+		return fmt.Errorf("unexpected call to %s", m.Name)
+	}
+	out, err := m.Outputs.Pack(outputs...)
+	require.NoError(f.t, err)
+	*result.(*hexutil.Bytes) = out
+	return nil
+}
+
+func (f *fakeFactoryRPC) game(proxy common.Address) fakeGame {
+	for _, game := range f.games {
+		if game.proxy == proxy {
+			return game
+		}
+	}
+	f.t.Fatalf("unknown game %v", proxy)
+	return fakeGame{}
+}
+
+func (f *fakeFactoryRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return fmt.Errorf("batch calls are not supported")
+}
+
+func (f *fakeFactoryRPC) Close() {}
+
+func (f *fakeFactoryRPC) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("subscriptions are not supported")
+}
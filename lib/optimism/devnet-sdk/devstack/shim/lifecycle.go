@@ -0,0 +1,53 @@
+package shim
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// LifecycleHooks lets the orchestrator control the service backing a component.
+// Both hooks are optional: without them, starting or stopping the component fails with stack.ErrLifecycleUnsupported.
+type LifecycleHooks struct {
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// lifecycleImpl implements stack.Lifecycle, safe to embed in other structs.
+type lifecycleImpl struct {
+	hooks   LifecycleHooks
+	healthy func(ctx context.Context) error
+}
+
+var _ stack.Lifecycle = (*lifecycleImpl)(nil)
+
+func newLifecycle(hooks LifecycleHooks, healthy func(ctx context.Context) error) lifecycleImpl {
+	return lifecycleImpl{hooks: hooks, healthy: healthy}
+}
+
+func (l *lifecycleImpl) Start(ctx context.Context) error {
+	if l.hooks.Start == nil {
+		return stack.ErrLifecycleUnsupported
+	}
+	return l.hooks.Start(ctx)
+}
+
+func (l *lifecycleImpl) Stop(ctx context.Context) error {
+	if l.hooks.Stop == nil {
+		return stack.ErrLifecycleUnsupported
+	}
+	return l.hooks.Stop(ctx)
+}
+
+func (l *lifecycleImpl) Healthy(ctx context.Context) error {
+	return l.healthy(ctx)
+}
+
+// rpcHealth checks that the RPC server answers requests.
+func rpcHealth(cl client.RPC) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var modules map[string]string
+		return cl.CallContext(ctx, &modules, "rpc_modules")
+	}
+}
@@ -0,0 +1,330 @@
+package shim
+
+import (
+	"encoding/json"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+)
+
+var _ stack.ManifestSource = (*presetSystem)(nil)
+
+// Manifest describes the system, see stack.Manifest.
+func (p *presetSystem) Manifest() *stack.Manifest {
+	m := &stack.Manifest{}
+	for _, id := range p.Superchains() {
+		sm := stack.SuperchainManifest{ID: id}
+		if d := p.Superchain(id).Deployment(); d != nil {
+			sm.Deployment = &stack.SuperchainDeploymentManifest{
+				ProtocolVersions: d.ProtocolVersionsAddr(),
+				SuperchainConfig: d.SuperchainConfigAddr(),
+			}
+		}
+		m.Superchains = append(m.Superchains, sm)
+	}
+	for _, id := range p.Clusters() {
+		cm := stack.ClusterManifest{ID: id}
+		if depSet, ok := p.Cluster(id).DependencySet().(json.Marshaler); ok {
+			data, err := depSet.MarshalJSON()
+			p.require().NoError(err, "failed to encode dependency set of cluster %s", id)
+			cm.DependencySet = data
+		}
+		m.Clusters = append(m.Clusters, cm)
+	}
+	for _, id := range p.L1Networks() {
+		l1 := p.L1Network(id)
+		lm := stack.L1NetworkManifest{
+			NetworkManifest: networkManifest(l1),
+			ID:              id,
+		}
+		if snapshot, ok := l1.Snapshot(); ok {
+			lm.Snapshot = &snapshot
+		}
+		for _, nodeID := range l1.L1ELNodes() {
+			lm.ELNodes = append(lm.ELNodes, componentManifest(nodeID, l1.L1ELNode(nodeID)))
+		}
+		for _, nodeID := range l1.L1CLNodes() {
+			lm.CLNodes = append(lm.CLNodes, componentManifest(nodeID, l1.L1CLNode(nodeID)))
+		}
+		m.L1Networks = append(m.L1Networks, lm)
+	}
+	for _, id := range p.L2Networks() {
+		m.L2Networks = append(m.L2Networks, l2NetworkManifest(p.L2Network(id)))
+	}
+	for _, id := range p.Supervisors() {
+		m.Supervisors = append(m.Supervisors, componentManifest(id, p.Supervisor(id)))
+	}
+	return m
+}
+
+// MarshalJSON encodes the Manifest of the system.
+func (p *presetSystem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Manifest())
+}
+
+func networkManifest(net stack.Network) stack.NetworkManifest {
+	return stack.NetworkManifest{
+		ChainID:     net.ChainID(),
+		ChainConfig: net.ChainConfig(),
+		Users:       net.Users(),
+	}
+}
+
+func componentManifest[ID any](id ID, component any) stack.ComponentManifest[ID] {
+	m := stack.ComponentManifest[ID]{
+		ID:              id,
+		Endpoint:        stack.ComponentEndpoint(component),
+		MetricsEndpoint: stack.ComponentMetricsEndpoint(component),
+	}
+	if labeled, ok := component.(stack.Labeled); ok {
+		m.Labels = labeled.Labels()
+	}
+	return m
+}
+
+func l2NetworkManifest(l2 stack.L2Network) stack.L2NetworkManifest {
+	lm := stack.L2NetworkManifest{
+		NetworkManifest: networkManifest(l2),
+		ID:              l2.ID(),
+		RollupConfig:    l2.RollupConfig(),
+		L1:              l2.L1().ID(),
+	}
+	// The deployment is optional on preset networks, and the accessor fails if it is missing.
+	var deployment stack.L2Deployment
+	if preset, ok := l2.(*presetL2Network); ok {
+		deployment = preset.deployment
+	} else {
+		deployment = l2.Deployment()
+	}
+	superchain, cluster := l2Membership(l2)
+	if deployment != nil {
+		lm.Deployment = &stack.L2DeploymentManifest{
+			SystemConfigProxy:           deployment.SystemConfigProxyAddr(),
+			DisputeGameFactoryProxy:     deployment.DisputeGameFactoryProxyAddr(),
+			OptimismPortalProxy:         deployment.OptimismPortalProxyAddr(),
+			L1StandardBridgeProxy:       deployment.L1StandardBridgeProxyAddr(),
+			L1CrossDomainMessengerProxy: deployment.L1CrossDomainMessengerProxyAddr(),
+			AddressManager:              deployment.AddressManagerAddr(),
+			AnchorStateRegistryProxy:    deployment.AnchorStateRegistryProxyAddr(),
+		}
+	}
+	if superchain != nil {
+		lm.Superchain = superchain.ID()
+	}
+	if cluster != nil {
+		lm.Cluster = cluster.ID()
+	}
+	for _, id := range l2.L2ELNodes() {
+		el := l2.L2ELNode(id)
+		em := stack.L2ELNodeManifest{ComponentManifest: componentManifest(id, el)}
+		if x, ok := el.(*rpcL2ELNode); ok {
+			em.AuthEndpoint = x.authRPC
+		}
+		lm.ELNodes = append(lm.ELNodes, em)
+	}
+	for _, id := range l2.L2CLNodes() {
+		cl := l2.L2CLNode(id)
+		lm.CLNodes = append(lm.CLNodes, stack.L2CLNodeManifest{
+			ComponentManifest: componentManifest(id, cl),
+			EL:                cl.EL(),
+		})
+	}
+	for _, id := range l2.L2Batchers() {
+		lm.Batchers = append(lm.Batchers, componentManifest(id, l2.L2Batcher(id)))
+	}
+	for _, id := range l2.L2Proposers() {
+		proposer := l2.L2Proposer(id)
+		pm := stack.L2ProposerManifest{ComponentManifest: componentManifest(id, proposer)}
+		if x, ok := proposer.(*rpcL2Proposer); ok {
+			pm.Address = x.address
+			pm.ProposalInterval = x.proposalInterval
+		}
+		lm.Proposers = append(lm.Proposers, pm)
+	}
+	for _, id := range l2.L2Challengers() {
+		challenger := l2.L2Challenger(id)
+		cm := stack.L2ChallengerManifest{ComponentManifest: componentManifest(id, challenger)}
+		if x, ok := challenger.(*rpcL2Challenger); ok {
+			cm.Address = x.address
+		}
+		lm.Challengers = append(lm.Challengers, cm)
+	}
+	for _, id := range l2.L2Conductors() {
+		conductor := l2.L2Conductor(id)
+		lm.Conductors = append(lm.Conductors, stack.L2ConductorManifest{
+			ComponentManifest: componentManifest(id, conductor),
+			Sequencer:         conductor.Sequencer(),
+		})
+	}
+	return lm
+}
+
+// NewSystemFromManifest reconstructs a read-only system from a manifest,
+// e.g. to inspect a system that runs in another process.
+// The services are dialed lazily, at the endpoints of the manifest,
+// and the test fails if a service that is served over RPC or HTTP has no endpoint.
+// Dispute games are queried through the first L1 EL node of the L1 of the L2 network.
+// Users are not reconstructed, since the manifest does not include their keys,
+// and neither are the keys of the L2 networks. The engine API of L2 EL nodes is unusable,
+// since the manifest does not include the JWT secrets either.
+func NewSystemFromManifest(cfg CommonConfig, m *stack.Manifest) stack.System {
+	req := require.New(cfg.T)
+	sys := NewSystem(SystemConfig{CommonConfig: cfg})
+	cfg.IDs = sys.IDRegistry()
+
+	served := func(id any, endpoint string) string {
+		req.NotEmpty(endpoint, "manifest must have an endpoint for %v", id)
+		return endpoint
+	}
+
+	for _, sm := range m.Superchains {
+		sc := SuperchainConfig{CommonConfig: cfg, ID: sm.ID}
+		if sm.Deployment != nil {
+			sc.Deployment = sm.Deployment
+		}
+		sys.AddSuperchain(NewSuperchain(sc))
+	}
+	for _, cm := range m.Clusters {
+		cc := ClusterConfig{CommonConfig: cfg, ID: cm.ID}
+		if len(cm.DependencySet) > 0 {
+			var depSet depset.StaticConfigDependencySet
+			req.NoError(json.Unmarshal(cm.DependencySet, &depSet), "failed to decode dependency set of cluster %s", cm.ID)
+			cc.DependencySet = &depSet
+		}
+		sys.AddCluster(NewCluster(cc))
+	}
+	for _, lm := range m.L1Networks {
+		l1 := NewL1Network(L1NetworkConfig{
+			NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: lm.ChainConfig},
+			ID:            lm.ID,
+			Snapshot:      lm.Snapshot,
+		})
+		for _, node := range lm.ELNodes {
+			served(node.ID, node.Endpoint)
+			l1.AddL1ELNode(NewL1ELNode(L1ELNodeConfig{
+				ELNodeConfig: ELNodeConfig{
+					CommonConfig: componentConfig(cfg, node),
+					ChainID:      lm.ChainID,
+				},
+				ID: node.ID,
+			}))
+		}
+		for _, node := range lm.CLNodes {
+			l1.AddL1CLNode(NewL1CLNode(L1CLNodeConfig{
+				CommonConfig: componentConfig(cfg, node),
+				ID:           node.ID,
+				Client:       client.NewBasicHTTPClient(served(node.ID, node.Endpoint), cfg.Log),
+			}))
+		}
+		sys.AddL1Network(l1)
+	}
+	for _, lm := range m.L2Networks {
+		lc := L2NetworkConfig{
+			NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: lm.ChainConfig},
+			ID:            lm.ID,
+			RollupConfig:  lm.RollupConfig,
+			L1:            sys.L1Network(lm.L1),
+		}
+		if lm.Deployment != nil {
+			lc.Deployment = lm.Deployment
+		}
+		if lm.Superchain != "" {
+			lc.Superchain = sys.Superchain(lm.Superchain)
+		}
+		if lm.Cluster != "" {
+			lc.Cluster = sys.Cluster(lm.Cluster)
+		}
+		l2 := NewL2Network(lc)
+
+		// proposals and claims are dispute games on L1
+		var l1Client client.RPC
+		if l1ELs := lc.L1.L1ELNodes(); len(l1ELs) > 0 {
+			l1Client = rpcClient(CommonConfig{Log: cfg.Log, T: cfg.T, Endpoint: stack.ComponentEndpoint(lc.L1.L1ELNode(l1ELs[0]))}, nil)
+		}
+		var disputeGameFactory common.Address
+		if lm.Deployment != nil {
+			disputeGameFactory = lm.Deployment.DisputeGameFactoryProxy
+		}
+
+		for _, node := range lm.ELNodes {
+			served(node.ID, node.Endpoint)
+			l2.AddL2ELNode(NewL2ELNode(L2ELNodeConfig{
+				ELNodeConfig: ELNodeConfig{
+					CommonConfig: componentConfig(cfg, node.ComponentManifest),
+					ChainID:      lm.ChainID,
+				},
+				ID:           node.ID,
+				AuthRPC:      node.AuthEndpoint,
+				RollupConfig: lm.RollupConfig,
+			}))
+		}
+		for _, node := range lm.CLNodes {
+			served(node.ID, node.Endpoint)
+			l2.AddL2CLNode(NewL2CLNode(L2CLNodeConfig{
+				CommonConfig: componentConfig(cfg, node.ComponentManifest),
+				ID:           node.ID,
+				EL:           node.EL,
+			}))
+		}
+		for _, batcher := range lm.Batchers {
+			served(batcher.ID, batcher.Endpoint)
+			l2.AddL2Batcher(NewL2Batcher(L2BatcherConfig{
+				CommonConfig: componentConfig(cfg, batcher),
+				ID:           batcher.ID,
+			}))
+		}
+		for _, proposer := range lm.Proposers {
+			served(proposer.ID, proposer.Endpoint)
+			l2.AddL2Proposer(NewL2Proposer(L2ProposerConfig{
+				CommonConfig:       componentConfig(cfg, proposer.ComponentManifest),
+				ID:                 proposer.ID,
+				L1Client:           l1Client,
+				DisputeGameFactory: disputeGameFactory,
+				Address:            proposer.Address,
+				ProposalInterval:   proposer.ProposalInterval,
+			}))
+		}
+		for _, challenger := range lm.Challengers {
+			// the challenger does not serve an RPC, it is inspected through its metrics and the dispute games
+			served(challenger.ID, challenger.MetricsEndpoint)
+			l2.AddL2Challenger(NewL2Challenger(L2ChallengerConfig{
+				CommonConfig:       componentConfig(cfg, challenger.ComponentManifest),
+				ID:                 challenger.ID,
+				L1Client:           l1Client,
+				DisputeGameFactory: disputeGameFactory,
+				Address:            challenger.Address,
+			}))
+		}
+		for _, conductor := range lm.Conductors {
+			served(conductor.ID, conductor.Endpoint)
+			l2.AddL2Conductor(NewL2Conductor(L2ConductorConfig{
+				CommonConfig: componentConfig(cfg, conductor.ComponentManifest),
+				ID:           conductor.ID,
+				Sequencer:    conductor.Sequencer,
+			}))
+		}
+		sys.AddL2Network(l2)
+	}
+	for _, sm := range m.Supervisors {
+		served(sm.ID, sm.Endpoint)
+		sys.AddSupervisor(NewSupervisor(SupervisorConfig{
+			CommonConfig: componentConfig(cfg, sm),
+			ID:           sm.ID,
+		}))
+	}
+	return sys
+}
+
+// componentConfig is the config of a component that is reconstructed from a manifest.
+// Clients of the component are created from the endpoints, see rpcClient and newMetrics.
+func componentConfig[ID any](cfg CommonConfig, m stack.ComponentManifest[ID]) CommonConfig {
+	cfg.Endpoint = m.Endpoint
+	cfg.MetricsEndpoint = m.MetricsEndpoint
+	cfg.Labels = m.Labels
+	return cfg
+}
@@ -0,0 +1,109 @@
+package shim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// metricsImpl implements stack.MetricsSource, safe to embed in other structs.
+// The client is optional: without it, all metrics queries fail with stack.ErrNoMetrics.
+type metricsImpl struct {
+	metricsClient client.HTTP
+}
+
+var _ stack.MetricsSource = (*metricsImpl)(nil)
+
+// newMetrics creates the metrics of a component, scraped with the given client,
+// or with a client of the metrics endpoint of the component if no client is given.
+func newMetrics(cfg CommonConfig, cl client.HTTP) metricsImpl {
+	if cl == nil && cfg.MetricsEndpoint != "" {
+		cl = client.NewBasicHTTPClient(cfg.MetricsEndpoint, cfg.Log)
+	}
+	return metricsImpl{metricsClient: cl}
+}
+
+func (m *metricsImpl) Metrics(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	if m.metricsClient == nil {
+		return nil, stack.ErrNoMetrics
+	}
+	return scrapeMetrics(ctx, m.metricsClient)
+}
+
+func (m *metricsImpl) MetricValue(ctx context.Context, name string, labels map[string]string) (float64, error) {
+	families, err := m.Metrics(ctx)
+	if err != nil {
+		return 0, err
+	}
+	family, ok := families[name]
+	if !ok {
+		return 0, nil
+	}
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		if metricMatches(metric, labels) {
+			sum += metricValue(metric)
+		}
+	}
+	return sum, nil
+}
+
+// scrapeMetrics fetches and parses the Prometheus text exposition served by a metrics endpoint.
+func scrapeMetrics(ctx context.Context, cl client.HTTP) (map[string]*dto.MetricFamily, error) {
+	resp, err := cl.Get(ctx, "/metrics", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected metrics response status %d: %s", resp.StatusCode, body)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return families, nil
+}
+
+// metricValue returns the value of a gauge, counter or untyped metric.
+func metricValue(m *dto.Metric) float64 {
+	switch {
+	case m.GetGauge() != nil:
+		return m.GetGauge().GetValue()
+	case m.GetCounter() != nil:
+		return m.GetCounter().GetValue()
+	case m.GetUntyped() != nil:
+		return m.GetUntyped().GetValue()
+	default:
+		return 0
+	}
+}
+
+// metricLabel returns the value of the named label of the metric, or an empty string if it is not set.
+func metricLabel(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// metricMatches checks if the metric has all the given label values.
+func metricMatches(m *dto.Metric, labels map[string]string) bool {
+	for name, value := range labels {
+		if metricLabel(m, name) != value {
+			return false
+		}
+	}
+	return true
+}
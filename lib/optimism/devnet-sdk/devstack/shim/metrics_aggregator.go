@@ -0,0 +1,89 @@
+package shim
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+)
+
+// componentLabel is the label added to aggregated metrics, to identify the component that recorded them.
+const componentLabel = "component"
+
+// SystemMetrics gathers the in-process metrics of all components of the system that implement stack.MetricsRecorder.
+// Every series is labeled with the ID of the component that recorded it.
+// Components are looked up on every gather, so components added later are included too.
+func SystemMetrics(sys stack.System) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families := make(map[string]*dto.MetricFamily)
+		seen := make(map[*prometheus.Registry]struct{})
+		var gatherErr error
+		forEachComponent(sys, func(id fmt.Stringer, component any) {
+			recorder, ok := component.(stack.MetricsRecorder)
+			if !ok || gatherErr != nil {
+				return
+			}
+			registry := recorder.MetricsRegistry()
+			if registry == nil {
+				return
+			}
+			// a shared registry is attributed to the first component that uses it
+			if _, ok := seen[registry]; ok {
+				return
+			}
+			seen[registry] = struct{}{}
+			gathered, err := registry.Gather()
+			if err != nil {
+				gatherErr = fmt.Errorf("failed to gather metrics of %s: %w", id, err)
+				return
+			}
+			mergeFamilies(families, gathered, id.String())
+		})
+		if gatherErr != nil {
+			return nil, gatherErr
+		}
+		out := make([]*dto.MetricFamily, 0, len(families))
+		for _, family := range families {
+			out = append(out, family)
+		}
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].GetName() < out[j].GetName()
+		})
+		return out, nil
+	})
+}
+
+// mergeFamilies adds the gathered metric families into the merged set,
+// labeling every series with the component.
+// The gathered families are modified in-place: a registry returns new families on every gather.
+func mergeFamilies(merged map[string]*dto.MetricFamily, gathered []*dto.MetricFamily, component string) {
+	for _, family := range gathered {
+		target, ok := merged[family.GetName()]
+		if !ok {
+			target = &dto.MetricFamily{Name: family.Name, Help: family.Help, Type: family.Type}
+			merged[family.GetName()] = target
+		}
+		for _, m := range family.GetMetric() {
+			name, value := componentLabel, component
+			m.Label = append(m.Label, &dto.LabelPair{Name: &name, Value: &value})
+			sort.Slice(m.Label, func(i, j int) bool {
+				return m.Label[i].GetName() < m.Label[j].GetName()
+			})
+			target.Metric = append(target.Metric, m)
+		}
+	}
+}
+
+// ServeSystemMetrics serves the aggregated metrics of the system on a single endpoint,
+// for scraping during long-running devstack sessions. The caller is responsible for closing the server.
+func ServeSystemMetrics(sys stack.System, host string, port int) (*httputil.HTTPServer, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	return httputil.StartHTTPServer(addr, promhttp.HandlerFor(SystemMetrics(sys), promhttp.HandlerOpts{}))
+}
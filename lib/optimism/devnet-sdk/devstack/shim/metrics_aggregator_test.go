@@ -0,0 +1,116 @@
+package shim
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSystemMetrics(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	sys := NewSystem(SystemConfig{CommonConfig: CommonConfig{Log: logger, T: t}})
+
+	newCounter := func(registry *prometheus.Registry, v float64) {
+		counter := opmetrics.With(registry).NewCounter(prometheus.CounterOpts{Name: "requests_total"})
+		counter.Add(v)
+	}
+
+	netRegistry := prometheus.NewRegistry()
+	newCounter(netRegistry, 1)
+	l1Net := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t, Registry: netRegistry},
+			ChainConfig:  &params.ChainConfig{ChainID: big.NewInt(900)},
+		},
+		ID: stack.L1NetworkID{Key: "devnet", ChainID: eth.ChainIDFromUInt64(900)},
+	})
+	sys.AddL1Network(l1Net)
+
+	elRegistry := prometheus.NewRegistry()
+	newCounter(elRegistry, 2)
+	l1Net.AddL1ELNode(NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t, Registry: elRegistry},
+			ChainID:      l1Net.ChainID(),
+		},
+		ID: stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ChainID()},
+	}))
+
+	families, err := SystemMetrics(sys).Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	values := make(map[string]float64)
+	for _, m := range families[0].GetMetric() {
+		values[metricLabel(m, componentLabel)] = metricValue(m)
+	}
+	require.Equal(t, map[string]float64{
+		l1Net.ID().String(): 1,
+		stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ChainID()}.String(): 2,
+	}, values)
+}
+
+func TestServeSystemMetrics(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	sys := NewSystem(SystemConfig{CommonConfig: CommonConfig{Log: logger, T: t}})
+
+	// the metrics of a remote service are scraped into the registry of the component
+	remoteRegistry := prometheus.NewRegistry()
+	opmetrics.With(remoteRegistry).NewGauge(prometheus.GaugeOpts{Name: "up"}).Set(1)
+	remote := httptest.NewServer(promhttp.HandlerFor(remoteRegistry, promhttp.HandlerOpts{}))
+	t.Cleanup(remote.Close)
+
+	l1Net := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+			ChainConfig:  &params.ChainConfig{ChainID: big.NewInt(900)},
+		},
+		ID: stack.L1NetworkID{Key: "devnet", ChainID: eth.ChainIDFromUInt64(900)},
+	})
+	sys.AddL1Network(l1Net)
+	elID := stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ChainID()}
+	l1Net.AddL1ELNode(NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: CommonConfig{
+				Log:      logger,
+				T:        t,
+				Registry: NewScrapingRegistry(logger, client.NewBasicHTTPClient(remote.URL, logger)),
+			},
+			ChainID: l1Net.ChainID(),
+		},
+		ID: elID,
+	}))
+
+	srv, err := ServeSystemMetrics(sys, "127.0.0.1", 0)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+
+	families, err := scrapeMetrics(context.Background(), client.NewBasicHTTPClient("http://"+srv.Addr().String(), logger))
+	require.NoError(t, err)
+	require.Contains(t, families, "up")
+	metrics := families["up"].GetMetric()
+	require.Len(t, metrics, 1)
+	require.Equal(t, elID.String(), metricLabel(metrics[0], componentLabel))
+	require.Equal(t, 1.0, metricValue(metrics[0]))
+
+	// the remote service going away must not break the metrics of the rest of the system
+	remote.Close()
+	families, err = scrapeMetrics(context.Background(), client.NewBasicHTTPClient("http://"+srv.Addr().String(), logger))
+	require.NoError(t, err)
+	require.NotContains(t, families, "up")
+}
@@ -0,0 +1,72 @@
+package shim
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+const scrapeTimeout = 10 * time.Second
+
+// NewScrapingRegistry creates a registry that gathers the metrics served by the metrics endpoint of a remote service,
+// so the metrics of services that do not run in-process can be aggregated with SystemMetrics too.
+// The endpoint is scraped on every gather. If it cannot be scraped, the error is logged and no metrics are gathered.
+func NewScrapingRegistry(logger log.Logger, cl client.HTTP) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	// the metrics of the remote service are not known upfront, so the collector is unchecked.
+	registry.MustRegister(&scrapingCollector{log: logger, client: cl})
+	return registry
+}
+
+type scrapingCollector struct {
+	log    log.Logger
+	client client.HTTP
+}
+
+var _ prometheus.Collector = (*scrapingCollector)(nil)
+
+// Describe sends no descriptors, to register the collector as unchecked.
+func (c *scrapingCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *scrapingCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+	families, err := scrapeMetrics(ctx, c.client)
+	if err != nil {
+		c.log.Warn("Failed to scrape metrics", "err", err)
+		return
+	}
+	for _, family := range families {
+		desc := prometheus.NewDesc(family.GetName(), family.GetHelp(), nil, nil)
+		for _, m := range family.GetMetric() {
+			ch <- &scrapedMetric{desc: desc, metric: m}
+		}
+	}
+}
+
+// scrapedMetric is a prometheus.Metric that writes a metric as it was scraped.
+type scrapedMetric struct {
+	desc   *prometheus.Desc
+	metric *dto.Metric
+}
+
+func (m *scrapedMetric) Desc() *prometheus.Desc {
+	return m.desc
+}
+
+func (m *scrapedMetric) Write(out *dto.Metric) error {
+	out.Label = m.metric.Label
+	out.Gauge = m.metric.Gauge
+	out.Counter = m.metric.Counter
+	out.Summary = m.metric.Summary
+	out.Untyped = m.metric.Untyped
+	out.Histogram = m.metric.Histogram
+	out.TimestampMs = m.metric.TimestampMs
+	return nil
+}
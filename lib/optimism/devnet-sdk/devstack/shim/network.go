@@ -15,11 +15,14 @@ type NetworkConfig struct {
 
 type presetNetwork struct {
 	commonImpl
-	faucet   stack.Faucet
 	chainCfg *params.ChainConfig
 	chainID  eth.ChainID
 
-	users locks.RWMap[stack.UserID, stack.User]
+	// blobSource is provided by the L1 or L2 network that embeds the network
+	blobSource func() stack.BlobSource
+
+	users  locks.RWMap[stack.UserID, stack.User]
+	faucet locks.RWValue[stack.Faucet]
 }
 
 var _ stack.Network = (*presetNetwork)(nil)
@@ -42,8 +45,23 @@ func (p *presetNetwork) ChainConfig() *params.ChainConfig {
 }
 
 func (p *presetNetwork) Faucet() stack.Faucet {
-	p.require().NotNil(p.faucet, "faucet not available")
-	return p.faucet
+	v := p.faucet.Get()
+	p.require().NotNil(v, "faucet not available")
+	return v
+}
+
+func (p *presetNetwork) BlobSource() stack.BlobSource {
+	p.require().NotNil(p.blobSource, "blob source not available")
+	return p.blobSource()
+}
+
+func (p *presetNetwork) AddFaucet(v stack.Faucet) {
+	p.require().Equal(p.chainID, v.ID().ChainID, "faucet %s must be on chain %s", v.ID(), p.chainID)
+	p.faucet.Lock()
+	defer p.faucet.Unlock()
+	p.require().Nil(p.faucet.Value, "faucet must not already exist")
+	p.registerID(v.ID())
+	p.faucet.Value = v
 }
 
 func (p *presetNetwork) User(id stack.UserID) stack.User {
@@ -53,9 +71,34 @@ func (p *presetNetwork) User(id stack.UserID) stack.User {
 }
 
 func (p *presetNetwork) AddUser(v stack.User) {
-	p.require().True(p.users.SetIfMissing(v.ID(), v), "user %s must not already exist", v.ID())
+	id := v.ID()
+	p.registerID(id)
+	p.require().True(p.users.SetIfMissing(id, v), "user %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.users.Has(id) {
+			p.RemoveUser(id)
+		}
+	})
+}
+
+func (p *presetNetwork) RemoveUser(id stack.UserID) {
+	p.require().True(p.users.Has(id), "user %s must exist", id)
+	p.users.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetNetwork) RemoveFaucet() {
+	p.faucet.Lock()
+	defer p.faucet.Unlock()
+	p.require().NotNil(p.faucet.Value, "faucet must exist")
+	p.unregisterID(p.faucet.Value.ID())
+	p.faucet.Value = nil
 }
 
 func (p *presetNetwork) Users() []stack.UserID {
 	return stack.SortUserIDs(p.users.Keys())
 }
+
+func (p *presetNetwork) UsersWithLabel(key, value string) []stack.UserID {
+	return stack.FilterByLabel(p.Users(), p.User, key, value)
+}
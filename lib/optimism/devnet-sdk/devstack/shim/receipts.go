@@ -0,0 +1,40 @@
+package shim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+)
+
+const receiptPollInterval = 500 * time.Millisecond
+
+// waitForReceipt polls the node until the receipt of the transaction is available,
+// and returns an error if the transaction failed, or the context expires.
+func waitForReceipt(ctx context.Context, cl apis.ReceiptFetcher, txHash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := cl.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("transaction %s failed with status %d", txHash, receipt.Status)
+			}
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to fetch receipt of %s: %w", txHash, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for receipt of %s: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package shim
 
 import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
 )
 
 type SuperchainConfig struct {
@@ -14,11 +15,13 @@ type presetSuperchain struct {
 	commonImpl
 	id         stack.SuperchainID
 	deployment stack.SuperchainDeployment
+
+	l2s locks.RWMap[stack.L2NetworkID, struct{}]
 }
 
-var _ stack.Superchain = (*presetSuperchain)(nil)
+var _ stack.ExtensibleSuperchain = (*presetSuperchain)(nil)
 
-func NewSuperchain(cfg SuperchainConfig) stack.Superchain {
+func NewSuperchain(cfg SuperchainConfig) stack.ExtensibleSuperchain {
 	cfg.Log = cfg.Log.New("id", cfg.ID)
 	return &presetSuperchain{
 		commonImpl: newCommon(cfg.CommonConfig),
@@ -31,6 +34,19 @@ func (p *presetSuperchain) ID() stack.SuperchainID {
 	return p.id
 }
 
-func (p presetSuperchain) Deployment() stack.SuperchainDeployment {
+func (p *presetSuperchain) Deployment() stack.SuperchainDeployment {
 	return p.deployment
 }
+
+func (p *presetSuperchain) L2s() []stack.L2NetworkID {
+	return stack.SortL2NetworkIDs(p.l2s.Keys())
+}
+
+func (p *presetSuperchain) AddL2(id stack.L2NetworkID) {
+	p.require().True(p.l2s.SetIfMissing(id, struct{}{}), "l2 chain %s must not already be part of superchain %s", id, p.id)
+}
+
+func (p *presetSuperchain) RemoveL2(id stack.L2NetworkID) {
+	p.require().True(p.l2s.Has(id), "l2 chain %s must be part of superchain %s", id, p.id)
+	p.l2s.Delete(id)
+}
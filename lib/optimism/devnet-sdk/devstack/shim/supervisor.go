@@ -9,12 +9,20 @@ import (
 
 type SupervisorConfig struct {
 	CommonConfig
-	ID     stack.SupervisorID
+	ID stack.SupervisorID
+	// Client is the RPC client of the component. Optional if the Endpoint is set,
+	// the client is then created from the endpoint, and connects on first use.
 	Client client.RPC
+	// MetricsClient is used to scrape the metrics endpoint. Optional, created from the MetricsEndpoint if not set.
+	MetricsClient client.HTTP
+	// Lifecycle controls the service backing the component. Optional.
+	Lifecycle LifecycleHooks
 }
 
 type rpcSupervisor struct {
 	commonImpl
+	metricsImpl
+	lifecycleImpl
 	id stack.SupervisorID
 
 	client client.RPC
@@ -22,14 +30,19 @@ type rpcSupervisor struct {
 }
 
 var _ stack.Supervisor = (*rpcSupervisor)(nil)
+var _ stack.MetricsSource = (*rpcSupervisor)(nil)
+var _ stack.Lifecycle = (*rpcSupervisor)(nil)
 
 func NewSupervisor(cfg SupervisorConfig) stack.Supervisor {
+	cfg.Client = rpcClient(cfg.CommonConfig, cfg.Client)
 	cfg.Log = cfg.Log.New("id", cfg.ID)
 	return &rpcSupervisor{
-		commonImpl: newCommon(cfg.CommonConfig),
-		id:         cfg.ID,
-		client:     cfg.Client,
-		api:        sources.NewSupervisorClient(cfg.Client),
+		commonImpl:    newCommon(cfg.CommonConfig),
+		metricsImpl:   newMetrics(cfg.CommonConfig, cfg.MetricsClient),
+		lifecycleImpl: newLifecycle(cfg.Lifecycle, rpcHealth(cfg.Client)),
+		id:            cfg.ID,
+		client:        cfg.Client,
+		api:           sources.NewSupervisorClient(cfg.Client),
 	}
 }
 
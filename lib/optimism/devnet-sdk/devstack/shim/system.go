@@ -1,6 +1,10 @@
 package shim
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/locks"
@@ -10,6 +14,8 @@ import (
 // It is intentially very minimal, the system is expected to be extended after creation, using Option functions
 type SystemConfig struct {
 	CommonConfig
+	// Events is the event bus of the system. Optional, a new bus is created if nil.
+	Events stack.EventBus
 }
 
 type presetSystem struct {
@@ -32,17 +38,31 @@ type presetSystem struct {
 	networks locks.RWMap[eth.ChainID, stack.Network]
 
 	supervisors locks.RWMap[stack.SupervisorID, stack.Supervisor]
+
+	events stack.EventBus
 }
 
 var _ stack.ExtensibleSystem = (*presetSystem)(nil)
 
 // NewSystem creates a new empty System
 func NewSystem(cfg SystemConfig) stack.ExtensibleSystem {
+	events := cfg.Events
+	if events == nil {
+		events = NewEventBus()
+	}
+	if cfg.IDs == nil {
+		cfg.IDs = stack.NewIDRegistry()
+	}
 	return &presetSystem{
 		commonImpl: newCommon(cfg.CommonConfig),
+		events:     events,
 	}
 }
 
+func (p *presetSystem) IDRegistry() *stack.IDRegistry {
+	return p.ids
+}
+
 func (p *presetSystem) Superchain(id stack.SuperchainID) stack.Superchain {
 	v, ok := p.superchains.Get(id)
 	p.require().True(ok, "superchain %s must exist", id)
@@ -50,7 +70,14 @@ func (p *presetSystem) Superchain(id stack.SuperchainID) stack.Superchain {
 }
 
 func (p *presetSystem) AddSuperchain(v stack.Superchain) {
-	p.require().True(p.superchains.SetIfMissing(v.ID(), v), "superchain %s must not already exist", v.ID())
+	id := v.ID()
+	p.registerID(id)
+	p.require().True(p.superchains.SetIfMissing(id, v), "superchain %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.superchains.Has(id) {
+			p.RemoveSuperchain(id)
+		}
+	})
 }
 
 func (p *presetSystem) Cluster(id stack.ClusterID) stack.Cluster {
@@ -60,7 +87,14 @@ func (p *presetSystem) Cluster(id stack.ClusterID) stack.Cluster {
 }
 
 func (p *presetSystem) AddCluster(v stack.Cluster) {
-	p.require().True(p.clusters.SetIfMissing(v.ID(), v), "cluster %s must not already exist", v.ID())
+	id := v.ID()
+	p.registerID(id)
+	p.require().True(p.clusters.SetIfMissing(id, v), "cluster %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.clusters.Has(id) {
+			p.RemoveCluster(id)
+		}
+	})
 }
 
 func (p *presetSystem) L1Network(id stack.L1NetworkID) stack.L1Network {
@@ -71,9 +105,15 @@ func (p *presetSystem) L1Network(id stack.L1NetworkID) stack.L1Network {
 
 func (p *presetSystem) AddL1Network(v stack.L1Network) {
 	id := v.ID()
+	p.registerID(id)
 	p.require().True(p.networks.SetIfMissing(id.ChainID, v), "chain with id %s must not already exist", id.ChainID)
 	p.require().True(p.l1ChainIDs.SetIfMissing(id.ChainID, id), "l1 chain id %s mapping must not already exist", id)
 	p.require().True(p.l1Networks.SetIfMissing(id, v), "l1 chain %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.l1Networks.Has(id) {
+			p.RemoveL1Network(id)
+		}
+	})
 }
 
 func (p *presetSystem) L2Network(id stack.L2NetworkID) stack.L2Network {
@@ -84,9 +124,22 @@ func (p *presetSystem) L2Network(id stack.L2NetworkID) stack.L2Network {
 
 func (p *presetSystem) AddL2Network(v stack.L2Network) {
 	id := v.ID()
+	p.registerID(id)
 	p.require().True(p.networks.SetIfMissing(id.ChainID, v), "chain with id %s must not already exist", id.ChainID)
 	p.require().True(p.l2ChainIDs.SetIfMissing(id.ChainID, id), "l2 chain id %s mapping must not already exist", id)
 	p.require().True(p.l2Networks.SetIfMissing(id, v), "l2 chain %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.l2Networks.Has(id) {
+			p.RemoveL2Network(id)
+		}
+	})
+	superchain, cluster := l2Membership(v)
+	if s, ok := superchain.(stack.ExtensibleSuperchain); ok {
+		s.AddL2(id)
+	}
+	if c, ok := cluster.(stack.ExtensibleCluster); ok {
+		c.AddL2(id)
+	}
 }
 
 func (p *presetSystem) L1NetworkID(id eth.ChainID) stack.L1NetworkID {
@@ -108,7 +161,64 @@ func (p *presetSystem) Supervisor(id stack.SupervisorID) stack.Supervisor {
 }
 
 func (p *presetSystem) AddSupervisor(v stack.Supervisor) {
-	p.require().True(p.supervisors.SetIfMissing(v.ID(), v), "supervisor %s must not already exist", v.ID())
+	id := v.ID()
+	p.registerID(id)
+	p.require().True(p.supervisors.SetIfMissing(id, v), "supervisor %s must not already exist", id)
+	p.removeWithScope(v, func() {
+		if p.supervisors.Has(id) {
+			p.RemoveSupervisor(id)
+		}
+	})
+}
+
+func (p *presetSystem) RemoveSuperchain(id stack.SuperchainID) {
+	v, ok := p.superchains.Get(id)
+	p.require().True(ok, "superchain %s must exist", id)
+	p.require().Empty(v.L2s(), "superchain %s must not have any l2 chains", id)
+	p.superchains.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetSystem) RemoveCluster(id stack.ClusterID) {
+	v, ok := p.clusters.Get(id)
+	p.require().True(ok, "cluster %s must exist", id)
+	p.require().Empty(v.L2s(), "cluster %s must not have any l2 chains", id)
+	p.clusters.Delete(id)
+	p.unregisterID(id)
+}
+
+func (p *presetSystem) RemoveL1Network(id stack.L1NetworkID) {
+	p.require().True(p.l1Networks.Has(id), "l1 chain %s must exist", id)
+	p.l2Networks.Range(func(l2ID stack.L2NetworkID, l2 stack.L2Network) bool {
+		p.require().NotEqual(id, l2.L1().ID(), "l1 chain %s must not be used by l2 chain %s", id, l2ID)
+		return true
+	})
+	p.l1Networks.Delete(id)
+	p.unregisterID(id)
+	p.l1ChainIDs.Delete(id.ChainID)
+	p.networks.Delete(id.ChainID)
+}
+
+func (p *presetSystem) RemoveL2Network(id stack.L2NetworkID) {
+	v, ok := p.l2Networks.Get(id)
+	p.require().True(ok, "l2 chain %s must exist", id)
+	superchain, cluster := l2Membership(v)
+	if s, ok := superchain.(stack.ExtensibleSuperchain); ok {
+		s.RemoveL2(id)
+	}
+	if c, ok := cluster.(stack.ExtensibleCluster); ok {
+		c.RemoveL2(id)
+	}
+	p.l2Networks.Delete(id)
+	p.unregisterID(id)
+	p.l2ChainIDs.Delete(id.ChainID)
+	p.networks.Delete(id.ChainID)
+}
+
+func (p *presetSystem) RemoveSupervisor(id stack.SupervisorID) {
+	p.require().True(p.supervisors.Has(id), "supervisor %s must exist", id)
+	p.supervisors.Delete(id)
+	p.unregisterID(id)
 }
 
 func (p *presetSystem) Superchains() []stack.SuperchainID {
@@ -130,3 +240,72 @@ func (p *presetSystem) L2Networks() []stack.L2NetworkID {
 func (p *presetSystem) Supervisors() []stack.SupervisorID {
 	return stack.SortSupervisorIDs(p.supervisors.Keys())
 }
+
+func (p *presetSystem) L1NetworksWithLabel(key, value string) []stack.L1NetworkID {
+	return stack.FilterByLabel(p.L1Networks(), p.L1Network, key, value)
+}
+
+func (p *presetSystem) L2NetworksWithLabel(key, value string) []stack.L2NetworkID {
+	return stack.FilterByLabel(p.L2Networks(), p.L2Network, key, value)
+}
+
+func (p *presetSystem) SupervisorsWithLabel(key, value string) []stack.SupervisorID {
+	return stack.FilterByLabel(p.Supervisors(), p.Supervisor, key, value)
+}
+
+func (p *presetSystem) Events() stack.EventBus {
+	return p.events
+}
+
+func (p *presetSystem) HealthCheck(ctx context.Context) error {
+	var result error
+	forEachComponent(p, func(id fmt.Stringer, component any) {
+		lifecycle, ok := component.(stack.Lifecycle)
+		if !ok {
+			return
+		}
+		if err := lifecycle.Healthy(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("%s is unhealthy: %w", id, err))
+		}
+	})
+	return result
+}
+
+// forEachComponent calls fn for every network, node and service of the system, in order of ID.
+func forEachComponent(sys stack.System, fn func(id fmt.Stringer, component any)) {
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		fn(id, l1)
+		for _, nodeID := range l1.L1ELNodes() {
+			fn(nodeID, l1.L1ELNode(nodeID))
+		}
+		for _, nodeID := range l1.L1CLNodes() {
+			fn(nodeID, l1.L1CLNode(nodeID))
+		}
+	}
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		fn(id, l2)
+		for _, nodeID := range l2.L2ELNodes() {
+			fn(nodeID, l2.L2ELNode(nodeID))
+		}
+		for _, nodeID := range l2.L2CLNodes() {
+			fn(nodeID, l2.L2CLNode(nodeID))
+		}
+		for _, batcherID := range l2.L2Batchers() {
+			fn(batcherID, l2.L2Batcher(batcherID))
+		}
+		for _, proposerID := range l2.L2Proposers() {
+			fn(proposerID, l2.L2Proposer(proposerID))
+		}
+		for _, challengerID := range l2.L2Challengers() {
+			fn(challengerID, l2.L2Challenger(challengerID))
+		}
+		for _, conductorID := range l2.L2Conductors() {
+			fn(conductorID, l2.L2Conductor(conductorID))
+		}
+	}
+	for _, id := range sys.Supervisors() {
+		fn(id, sys.Supervisor(id))
+	}
+}
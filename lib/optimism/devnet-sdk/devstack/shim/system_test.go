@@ -2,9 +2,13 @@ package shim
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -198,3 +202,394 @@ func TestSystemTypes(t *testing.T) {
 	elNode := l2NetA.L2ELNode(l2NetA.L2ELNodes()[0])
 	elNode.Logger().Info("L2 EL Node")
 }
+
+// TestSystemRemove checks that removed components are cleaned up from all lookups,
+// so that they can be replaced.
+func TestSystemRemove(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	commonConfig := CommonConfig{Log: logger, T: t}
+	sys := NewSystem(SystemConfig{CommonConfig: commonConfig})
+
+	newL1 := func() stack.ExtensibleL1Network {
+		return NewL1Network(L1NetworkConfig{
+			NetworkConfig: NetworkConfig{
+				CommonConfig: commonConfig,
+				ChainConfig:  &params.ChainConfig{ChainID: big.NewInt(900)},
+			},
+			ID: stack.L1NetworkID{Key: "devnet", ChainID: eth.ChainIDFromUInt64(900)},
+		})
+	}
+	l1Net := newL1()
+	sys.AddL1Network(l1Net)
+
+	l1EL := NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: commonConfig,
+			ChainID:      l1Net.ChainID(),
+		},
+		ID: stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ID().ChainID},
+	})
+	l1Net.AddL1ELNode(l1EL)
+	l1Net.RemoveL1ELNode(l1EL.ID())
+	require.Empty(t, l1Net.L1ELNodes())
+	l1Net.AddL1ELNode(l1EL)
+	require.Len(t, l1Net.L1ELNodes(), 1)
+
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	user := NewUser(UserConfig{
+		CommonConfig: commonConfig,
+		ID:           stack.UserID{Key: "user", ChainID: l1Net.ID().ChainID},
+		Priv:         priv,
+		EL:           l1EL,
+	})
+	l1Net.AddUser(user)
+	l1Net.RemoveUser(user.ID())
+	require.Empty(t, l1Net.Users())
+
+	sys.RemoveL1Network(l1Net.ID())
+	require.Empty(t, sys.L1Networks())
+	// the chain ID must be free again, for a replacement network
+	sys.AddL1Network(newL1())
+	require.Equal(t, l1Net.ID(), sys.L1NetworkID(l1Net.ChainID()))
+}
+
+// otherEvent is an event type that is not a stack.BlockBuiltEvent.
+type otherEvent struct{}
+
+func (otherEvent) String() string { return "other" }
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	node := stack.L2CLNodeID{Key: "sequencer", ChainID: eth.ChainIDFromUInt64(901)}
+
+	var blocks []stack.BlockBuiltEvent
+	unsubscribe := stack.SubscribeTo(bus, func(ev stack.BlockBuiltEvent) {
+		blocks = append(blocks, ev)
+	})
+	bus.Publish(stack.BlockBuiltEvent{Node: node})
+	bus.Publish(otherEvent{})
+	require.Len(t, blocks, 1, "subscriber must only receive events of its type")
+	unsubscribe()
+	bus.Publish(stack.BlockBuiltEvent{Node: node})
+	require.Len(t, blocks, 1, "subscriber must not receive events after unsubscribing")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// keep publishing, the event may be published before the subscription is registered
+	go func() {
+		for ctx.Err() == nil {
+			bus.Publish(stack.BlockBuiltEvent{Node: node, Block: eth.L2BlockRef{Number: 10}})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	ev, err := stack.AwaitEvent(ctx, bus, func(ev stack.BlockBuiltEvent) bool {
+		return ev.Block.Number == 10
+	})
+	require.NoError(t, err)
+	require.Equal(t, node, ev.Node)
+}
+
+func TestOrderedOptions(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	setup := &stack.Setup{
+		Ctx:     context.Background(),
+		Log:     logger,
+		T:       t,
+		Require: require.New(t),
+		System: NewSystem(SystemConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+		}),
+	}
+
+	var order []string
+	record := func(name string) stack.Option {
+		return func(setup *stack.Setup) {
+			order = append(order, name)
+		}
+	}
+	addSuperchain := stack.Option(func(setup *stack.Setup) {
+		order = append(order, "superchain")
+		setup.System.AddSuperchain(NewSuperchain(SuperchainConfig{
+			CommonConfig: CommonConfigFromSetup(setup),
+			ID:           "dev",
+		}))
+	})
+	stack.Ordered(
+		stack.OptionWithRequires(record("batcher"), stack.L2CLNodeKind, stack.SuperchainKind).WithProvides(stack.L2BatcherKind),
+		stack.OptionWithRequires(record("cl"), stack.L2ELNodeKind).WithProvides(stack.L2CLNodeKind),
+		stack.OptionWithRequires(record("el")).WithProvides(stack.L2ELNodeKind),
+		stack.OptionWithRequires(addSuperchain).WithProvides(stack.SuperchainKind),
+	)(setup)
+	require.Equal(t, []string{"el", "cl", "superchain", "batcher"}, order)
+
+	// the superchain now exists, and satisfies the requirement without an option providing it
+	order = nil
+	stack.Ordered(stack.OptionWithRequires(record("cluster"), stack.SuperchainKind))(setup)
+	require.Equal(t, []string{"cluster"}, order)
+
+	require.False(t, stack.HasKind(setup.System, stack.SupervisorKind))
+}
+
+func TestSystemManifest(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := CommonConfig{Log: logger, T: t}
+	sys := NewSystem(SystemConfig{CommonConfig: cfg})
+
+	sys.AddSuperchain(NewSuperchain(SuperchainConfig{
+		CommonConfig: cfg,
+		ID:           "dev",
+		Deployment:   &stack.SuperchainDeploymentManifest{SuperchainConfig: common.Address{0xaa}},
+	}))
+	l1ChainID := eth.ChainIDFromUInt64(900)
+	l1 := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: l1ChainID.ToBig()}},
+		ID:            stack.L1NetworkID{Key: "l1", ChainID: l1ChainID},
+	})
+	elCfg := cfg
+	elCfg.Endpoint = "http://l1-el:8545"
+	l1.AddL1ELNode(NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{CommonConfig: elCfg, ChainID: l1ChainID},
+		ID:           stack.L1ELNodeID{Key: "miner", ChainID: l1ChainID},
+	}))
+	sys.AddL1Network(l1)
+
+	l2ChainID := eth.ChainIDFromUInt64(901)
+	l2 := NewL2Network(L2NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: l2ChainID.ToBig()}},
+		ID:            stack.L2NetworkID{Key: "l2", ChainID: l2ChainID},
+		RollupConfig:  &rollup.Config{L1ChainID: l1ChainID.ToBig(), L2ChainID: l2ChainID.ToBig()},
+		Superchain:    sys.Superchain("dev"),
+		L1:            l1,
+	})
+	l2ELCfg := cfg
+	l2ELCfg.Endpoint = "http://l2-el:8545"
+	l2ELCfg.MetricsEndpoint = "http://l2-el:9001"
+	l2ELCfg.Labels = map[string]string{"role": "sequencer"}
+	l2.AddL2ELNode(NewL2ELNode(L2ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{CommonConfig: l2ELCfg, ChainID: l2ChainID},
+		ID:           stack.L2ELNodeID{Key: "sequencer", ChainID: l2ChainID},
+		AuthRPC:      "http://l2-el:8551",
+		JWTSecret:    [32]byte{1},
+		RollupConfig: l2.RollupConfig(),
+	}))
+	clCfg := cfg
+	clCfg.Endpoint = "http://l2-cl:9545"
+	sequencer := stack.L2CLNodeID{Key: "sequencer", ChainID: l2ChainID}
+	sequencerEL := stack.L2ELNodeID{Key: "sequencer", ChainID: l2ChainID}
+	l2.AddL2CLNode(NewL2CLNode(L2CLNodeConfig{CommonConfig: clCfg, ID: sequencer, EL: sequencerEL}))
+	conductorCfg := cfg
+	conductorCfg.Endpoint = "http://conductor:8547"
+	l2.AddL2Conductor(NewL2Conductor(L2ConductorConfig{
+		CommonConfig: conductorCfg,
+		ID:           stack.L2ConductorID{Key: "conductor", ChainID: l2ChainID},
+		Sequencer:    sequencer,
+	}))
+	proposerCfg := cfg
+	proposerCfg.Endpoint = "http://proposer:8560"
+	l2.AddL2Proposer(NewL2Proposer(L2ProposerConfig{
+		CommonConfig:     proposerCfg,
+		ID:               stack.L2ProposerID{Key: "proposer", ChainID: l2ChainID},
+		Address:          common.Address{0xbb},
+		ProposalInterval: 6 * time.Second,
+	}))
+	challengerCfg := cfg
+	challengerCfg.MetricsEndpoint = "http://challenger:7300"
+	l2.AddL2Challenger(NewL2Challenger(L2ChallengerConfig{
+		CommonConfig: challengerCfg,
+		ID:           stack.L2ChallengerID{Key: "challenger", ChainID: l2ChainID},
+		Address:      common.Address{0xcc},
+	}))
+	sys.AddL2Network(l2)
+
+	data, err := json.Marshal(sys)
+	require.NoError(t, err)
+	var manifest stack.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.Equal(t, "http://l1-el:8545", manifest.L1Networks[0].ELNodes[0].Endpoint)
+	require.Equal(t, stack.SuperchainID("dev"), manifest.L2Networks[0].Superchain)
+	require.Empty(t, manifest.L2Networks[0].Cluster)
+	require.Equal(t, sequencer, manifest.L2Networks[0].Conductors[0].Sequencer)
+
+	loaded := NewSystemFromManifest(cfg, &manifest)
+	loadedL2 := loaded.L2Network(l2.ID())
+	require.Equal(t, "http://l2-cl:9545", stack.ComponentEndpoint(loadedL2.L2CLNode(sequencer)))
+	require.Equal(t, sequencerEL, loadedL2.L2CLNode(sequencer).EL())
+	require.Equal(t, common.Address{0xaa}, loadedL2.Superchain().Deployment().SuperchainConfigAddr())
+	require.Equal(t, sys.(stack.ManifestSource).Manifest(), loaded.(stack.ManifestSource).Manifest(),
+		"loaded system must describe the same system")
+	loadedEL := loadedL2.L2ELNode(stack.L2ELNodeID{Key: "sequencer", ChainID: l2ChainID})
+	require.True(t, stack.HasLabel(loadedEL, "role", "sequencer"))
+	require.Equal(t, "http://l2-el:9001", stack.ComponentMetricsEndpoint(loadedEL))
+
+	// services that are served over RPC cannot be reconstructed without an endpoint
+	manifest.L2Networks[0].Conductors[0].Endpoint = ""
+	failT := stack.NewToolingT(t.Name(), logger)
+	failT.Fail = func() {
+		panic("failed")
+	}
+	require.PanicsWithValue(t, "failed", func() {
+		NewSystemFromManifest(CommonConfig{Log: logger, T: failT}, &manifest)
+	})
+}
+
+func TestComponentLabels(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := CommonConfig{Log: logger, T: t}
+	l1ChainID := eth.ChainIDFromUInt64(900)
+	l1 := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: l1ChainID.ToBig()}},
+		ID:            stack.L1NetworkID{Key: "l1", ChainID: l1ChainID},
+	})
+	l2ChainID := eth.ChainIDFromUInt64(901)
+	l2 := NewL2Network(L2NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: l2ChainID.ToBig()}},
+		ID:            stack.L2NetworkID{Key: "l2", ChainID: l2ChainID},
+		RollupConfig:  &rollup.Config{L1ChainID: l1ChainID.ToBig(), L2ChainID: l2ChainID.ToBig()},
+		L1:            l1,
+	})
+	for i, role := range []string{"sequencer", "verifier", "verifier"} {
+		nodeCfg := cfg
+		nodeCfg.Labels = map[string]string{"role": role}
+		l2.AddL2CLNode(NewL2CLNode(L2CLNodeConfig{
+			CommonConfig: nodeCfg,
+			ID:           stack.L2CLNodeID{Key: fmt.Sprintf("node%d", i), ChainID: l2ChainID},
+		}))
+	}
+
+	verifiers := l2.L2CLNodesWithLabel("role", "verifier")
+	require.Equal(t, []stack.L2CLNodeID{
+		{Key: "node1", ChainID: l2ChainID},
+		{Key: "node2", ChainID: l2ChainID},
+	}, verifiers)
+	require.Len(t, l2.L2CLNodesWithLabel("role", "sequencer"), 1)
+	require.Empty(t, l2.L2CLNodesWithLabel("zone", "b"))
+	require.Empty(t, l2.L2ELNodesWithLabel("role", "verifier"))
+}
+
+func TestSystemMembership(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := CommonConfig{Log: logger, T: t}
+	sys := NewSystem(SystemConfig{CommonConfig: cfg})
+
+	superchain := NewSuperchain(SuperchainConfig{CommonConfig: cfg, ID: "dev"})
+	sys.AddSuperchain(superchain)
+	cluster := NewCluster(ClusterConfig{CommonConfig: cfg, ID: "dev"})
+	sys.AddCluster(cluster)
+
+	l1ChainID := eth.ChainIDFromUInt64(900)
+	l1 := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: l1ChainID.ToBig()}},
+		ID:            stack.L1NetworkID{Key: "l1", ChainID: l1ChainID},
+	})
+	sys.AddL1Network(l1)
+	addL2 := func(chainID eth.ChainID, cluster stack.Cluster) stack.L2NetworkID {
+		l2 := NewL2Network(L2NetworkConfig{
+			NetworkConfig: NetworkConfig{CommonConfig: cfg, ChainConfig: &params.ChainConfig{ChainID: chainID.ToBig()}},
+			ID:            stack.L2NetworkID{Key: "l2", ChainID: chainID},
+			RollupConfig:  &rollup.Config{L1ChainID: l1ChainID.ToBig(), L2ChainID: chainID.ToBig()},
+			Superchain:    superchain,
+			L1:            l1,
+			Cluster:       cluster,
+		})
+		sys.AddL2Network(l2)
+		return l2.ID()
+	}
+	l2A := addL2(eth.ChainIDFromUInt64(901), cluster)
+	l2B := addL2(eth.ChainIDFromUInt64(902), nil)
+
+	require.Equal(t, []stack.L2NetworkID{l2A, l2B}, sys.Superchain("dev").L2s())
+	require.Equal(t, []stack.L2NetworkID{l2A}, sys.Cluster("dev").L2s())
+
+	sys.RemoveL2Network(l2A)
+	require.Equal(t, []stack.L2NetworkID{l2B}, sys.Superchain("dev").L2s())
+	require.Empty(t, sys.Cluster("dev").L2s())
+	sys.RemoveCluster("dev")
+}
+
+func TestIDRegistry(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	sys := NewSystem(SystemConfig{CommonConfig: CommonConfig{Log: logger, T: t}})
+	commonConfig := CommonConfig{Log: logger, T: t, IDs: sys.IDRegistry()}
+
+	l1Net := NewL1Network(L1NetworkConfig{
+		NetworkConfig: NetworkConfig{
+			CommonConfig: commonConfig,
+			ChainConfig:  &params.ChainConfig{ChainID: big.NewInt(900)},
+		},
+		ID: stack.L1NetworkID{Key: "devnet", ChainID: eth.ChainIDFromUInt64(900)},
+	})
+	sys.AddL1Network(l1Net)
+	l1EL := NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: commonConfig,
+			ChainID:      l1Net.ChainID(),
+		},
+		ID: stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ID().ChainID},
+	})
+	l1Net.AddL1ELNode(l1EL)
+
+	reg, ok := sys.IDRegistry().Lookup(l1EL.ID())
+	require.True(t, ok)
+	require.Contains(t, reg.Source, "TestIDRegistry", "source must be the caller that added the node")
+	require.Contains(t, reg.Stack, "AddL1ELNode")
+
+	// IDs of different kinds do not collide
+	require.NoError(t, sys.IDRegistry().Register(stack.L1CLNodeID(l1EL.ID())))
+
+	err := sys.IDRegistry().Register(l1EL.ID())
+	require.ErrorContains(t, err, "already registered by")
+	require.ErrorContains(t, err, "TestIDRegistry")
+
+	l1Net.RemoveL1ELNode(l1EL.ID())
+	_, ok = sys.IDRegistry().Lookup(l1EL.ID())
+	require.False(t, ok)
+	l1Net.AddL1ELNode(l1EL)
+}
+
+// TestSetupFork checks that components created with a forked setup are removed when the fork is closed,
+// while the components of the parent setup remain.
+func TestSetupFork(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	setup := &stack.Setup{
+		Ctx:     context.Background(),
+		Log:     logger,
+		T:       t,
+		Require: require.New(t),
+		System: NewSystem(SystemConfig{
+			CommonConfig: CommonConfig{Log: logger, T: t},
+		}),
+	}
+	newL1 := func(setup *stack.Setup, chainID uint64) stack.ExtensibleL1Network {
+		return NewL1Network(L1NetworkConfig{
+			NetworkConfig: NetworkConfig{
+				CommonConfig: CommonConfigFromSetup(setup),
+				ChainConfig:  &params.ChainConfig{ChainID: new(big.Int).SetUint64(chainID)},
+			},
+			ID: stack.L1NetworkID{Key: "devnet", ChainID: eth.ChainIDFromUInt64(chainID)},
+		})
+	}
+	l1Net := newL1(setup, 900)
+	setup.System.AddL1Network(l1Net)
+
+	fork := setup.Fork("temporary")
+	forkNet := newL1(fork, 901)
+	setup.System.AddL1Network(forkNet)
+	l1EL := NewL1ELNode(L1ELNodeConfig{
+		ELNodeConfig: ELNodeConfig{
+			CommonConfig: CommonConfigFromSetup(fork),
+			ChainID:      l1Net.ChainID(),
+		},
+		ID: stack.L1ELNodeID{Key: "miner", ChainID: l1Net.ID().ChainID},
+	})
+	l1Net.AddL1ELNode(l1EL)
+	require.Len(t, setup.System.L1Networks(), 2)
+
+	fork.Close()
+	require.Error(t, fork.Ctx.Err(), "context of the fork must be cancelled")
+	require.Equal(t, []stack.L1NetworkID{l1Net.ID()}, setup.System.L1Networks())
+	require.Empty(t, l1Net.L1ELNodes())
+	_, ok := setup.System.IDRegistry().Lookup(forkNet.ID())
+	require.False(t, ok, "ID of removed network must be unregistered")
+	require.NoError(t, setup.Ctx.Err())
+}
@@ -1,11 +1,17 @@
 package shim
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
@@ -25,6 +31,11 @@ type presetUser struct {
 	priv *ecdsa.PrivateKey
 	addr common.Address
 	el   stack.ELNode
+
+	// nonceLock serializes sending of transactions, to assign nonces in order.
+	nonceLock sync.Mutex
+	// nonce is the next nonce to use, or nil if it is to be fetched from the EL node.
+	nonce *uint64
 }
 
 func (p *presetUser) ID() stack.UserID {
@@ -47,6 +58,102 @@ func (p *presetUser) Key() *ecdsa.PrivateKey {
 	return p.priv
 }
 
+func (p *presetUser) SendTx(ctx context.Context, req stack.TxRequest) (common.Hash, error) {
+	tx, err := p.send(ctx, req)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// send signs and submits the transaction, with the next nonce of the user.
+func (p *presetUser) send(ctx context.Context, req stack.TxRequest) (*types.Transaction, error) {
+	p.nonceLock.Lock()
+	defer p.nonceLock.Unlock()
+	tx, err := p.signTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.el.EthClient().SendTransaction(ctx, tx); err != nil {
+		// the nonce may or may not have been used, fetch it again for the next transaction
+		p.nonce = nil
+		return nil, fmt.Errorf("failed to send transaction with nonce %d: %w", tx.Nonce(), err)
+	}
+	next := tx.Nonce() + 1
+	p.nonce = &next
+	p.log.Debug("Sent transaction", "tx", tx.Hash(), "nonce", tx.Nonce())
+	return tx, nil
+}
+
+// signTx fills in the nonce, gas limit and fees of the transaction, and signs it.
+// The caller must hold the nonce lock.
+func (p *presetUser) signTx(ctx context.Context, req stack.TxRequest) (*types.Transaction, error) {
+	cl := p.el.EthClient()
+	if p.nonce == nil {
+		nonce, err := cl.PendingNonceAt(ctx, p.addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch nonce: %w", err)
+		}
+		p.nonce = &nonce
+	}
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gas := req.Gas
+	if gas == 0 {
+		estimate, err := cl.EstimateGas(ctx, ethereum.CallMsg{From: p.addr, To: req.To, Value: value, Data: req.Data, AccessList: req.AccessList})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		gas = estimate
+	}
+	head, err := cl.InfoByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch base fee: %w", err)
+	}
+	gasPrice, err := cl.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch gas price: %w", err)
+	}
+	// The suggested gas price includes the base fee, the remainder is the tip.
+	tip := new(big.Int).Sub(gasPrice, head.BaseFee())
+	if tip.Sign() <= 0 {
+		tip = big.NewInt(1)
+	}
+	// allow the base fee to double before the transaction is included
+	feeCap := new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee(), big.NewInt(2)))
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    p.id.ChainID.ToBig(),
+		Nonce:      *p.nonce,
+		GasTipCap:  tip,
+		GasFeeCap:  feeCap,
+		Gas:        gas,
+		To:         req.To,
+		Value:      value,
+		Data:       req.Data,
+		AccessList: req.AccessList,
+	})
+	return types.SignTx(tx, types.LatestSignerForChainID(p.id.ChainID.ToBig()), p.priv)
+}
+
+func (p *presetUser) Transfer(ctx context.Context, to common.Address, amount *big.Int) (common.Hash, error) {
+	return p.SendTx(ctx, stack.TxRequest{To: &to, Value: amount})
+}
+
+func (p *presetUser) Deploy(ctx context.Context, code []byte) (common.Hash, common.Address, error) {
+	tx, err := p.send(ctx, stack.TxRequest{Data: code})
+	if err != nil {
+		return common.Hash{}, common.Address{}, err
+	}
+	return tx.Hash(), crypto.CreateAddress(p.addr, tx.Nonce()), nil
+}
+
+func (p *presetUser) WaitReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return waitForReceipt(ctx, p.el.EthClient(), txHash)
+}
+
 var _ stack.User = (*presetUser)(nil)
 
 func NewUser(cfg UserConfig) stack.User {
@@ -0,0 +1,111 @@
+package shim
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// fakeEthClient implements the parts of the EthClient used to send transactions.
+type fakeEthClient struct {
+	apis.EthClient
+	pendingNonce uint64
+	sendErr      error
+	sent         []*types.Transaction
+}
+
+func (f *fakeEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return f.pendingNonce, nil
+}
+
+func (f *fakeEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (f *fakeEthClient) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	return &testutils.MockBlockInfo{InfoBaseFee: big.NewInt(100)}, nil
+}
+
+func (f *fakeEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(110), nil
+}
+
+func (f *fakeEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+type fakeELNode struct {
+	stack.ELNode
+	chainID eth.ChainID
+	client  *fakeEthClient
+}
+
+func (f *fakeELNode) ChainID() eth.ChainID {
+	return f.chainID
+}
+
+func (f *fakeELNode) EthClient() apis.EthClient {
+	return f.client
+}
+
+func TestUserSendTx(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	chainID := eth.ChainIDFromUInt64(901)
+	cl := &fakeEthClient{pendingNonce: 5}
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	user := NewUser(UserConfig{
+		CommonConfig: CommonConfig{Log: logger, T: t},
+		ID:           stack.UserID{Key: "alice", ChainID: chainID},
+		Priv:         priv,
+		EL:           &fakeELNode{chainID: chainID, client: cl},
+	})
+	ctx := context.Background()
+
+	to := common.Address{0xaa}
+	_, err = user.Transfer(ctx, to, big.NewInt(1000))
+	require.NoError(t, err)
+	_, addr, err := user.Deploy(ctx, []byte{0x60, 0x00})
+	require.NoError(t, err)
+	require.Equal(t, crypto.CreateAddress(user.Address(), 6), addr)
+
+	require.Len(t, cl.sent, 2)
+	require.Equal(t, uint64(5), cl.sent[0].Nonce())
+	require.Equal(t, uint64(6), cl.sent[1].Nonce(), "nonce must be tracked by the user")
+	require.Equal(t, big.NewInt(10), cl.sent[0].GasTipCap())
+	require.Equal(t, big.NewInt(210), cl.sent[0].GasFeeCap())
+	require.Equal(t, uint64(21000), cl.sent[0].Gas())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID.ToBig()), cl.sent[0])
+	require.NoError(t, err)
+	require.Equal(t, user.Address(), sender)
+
+	// a failed transaction may not have used its nonce, the nonce is fetched again
+	cl.sendErr = errors.New("boom")
+	_, err = user.Transfer(ctx, to, big.NewInt(1))
+	require.ErrorIs(t, err, cl.sendErr)
+	cl.sendErr = nil
+	cl.pendingNonce = 7
+	_, err = user.SendTx(ctx, stack.TxRequest{To: &to, Gas: 50000})
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), cl.sent[2].Nonce())
+	require.Equal(t, uint64(50000), cl.sent[2].Gas())
+}
@@ -32,4 +32,15 @@ type Cluster interface {
 	ID() ClusterID
 
 	DependencySet() depset.DependencySet
+
+	// L2s returns the L2 networks of the system that are part of the cluster.
+	L2s() []L2NetworkID
+}
+
+// ExtensibleCluster is an optional extension interface for Cluster,
+// for the system to maintain the membership of L2 networks.
+type ExtensibleCluster interface {
+	Cluster
+	AddL2(id L2NetworkID)
+	RemoveL2(id L2NetworkID)
 }
@@ -0,0 +1,62 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Event is a notification published by a component, or by a watcher of a component.
+type Event interface {
+	String() string
+}
+
+// EventBus distributes events to subscribers.
+// Subscribers are called synchronously by the publisher, and should not block.
+type EventBus interface {
+	Publish(ev Event)
+	// Subscribe registers fn to be called for every published event, until the returned function is called.
+	Subscribe(fn func(ev Event)) (unsubscribe func())
+}
+
+// SubscribeTo registers fn to be called for every published event of type E.
+func SubscribeTo[E Event](bus EventBus, fn func(ev E)) (unsubscribe func()) {
+	return bus.Subscribe(func(ev Event) {
+		if x, ok := ev.(E); ok {
+			fn(x)
+		}
+	})
+}
+
+// AwaitEvent blocks until an event of type E that matches the filter is published,
+// or until the context is done. Only events published after the call are considered.
+func AwaitEvent[E Event](ctx context.Context, bus EventBus, filter func(ev E) bool) (E, error) {
+	found := make(chan E, 1)
+	unsubscribe := SubscribeTo(bus, func(ev E) {
+		if filter == nil || filter(ev) {
+			select {
+			case found <- ev:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+	select {
+	case ev := <-found:
+		return ev, nil
+	case <-ctx.Done():
+		var zero E
+		return zero, fmt.Errorf("event did not happen: %w", ctx.Err())
+	}
+}
+
+// BlockBuiltEvent is published when a new unsafe block is seen on an L2 CL node.
+type BlockBuiltEvent struct {
+	Node  L2CLNodeID
+	Block eth.L2BlockRef
+}
+
+func (ev BlockBuiltEvent) String() string {
+	return fmt.Sprintf("block-built(%s, %s)", ev.Node, ev.Block)
+}
@@ -1,5 +1,12 @@
 package stack
 
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // FaucetID identifies a Faucet by name and chainID, is type-safe, and can be value-copied and used as map key.
 type FaucetID idWithChain
 
@@ -28,4 +35,7 @@ type Faucet interface {
 	ID() FaucetID
 	// NewUser creates a new pre-funded user account
 	NewUser() User
+	// Fund requests the faucet to send the given amount of wei to the address.
+	// It returns the hash of the funding transaction, without waiting for it to be included.
+	Fund(ctx context.Context, addr common.Address, amount *big.Int) (common.Hash, error)
 }
@@ -1,7 +1,10 @@
 package stack
 
 import (
+	"context"
+
 	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 // L1CLNodeID identifies a L1CLNode by name and chainID, is type-safe, and can be value-copied and used as map key.
@@ -34,4 +37,23 @@ type L1CLNode interface {
 	ID() L1CLNodeID
 
 	BeaconClient() apis.BeaconClient
+
+	// Beacon is a typed client of the beacon API of the node.
+	Beacon() BeaconAPI
+}
+
+// BeaconAPI is a typed client of the beacon API, for assertions on the L1 consensus-layer,
+// such as the availability of blobs.
+type BeaconAPI interface {
+	// GenesisTime returns the timestamp of the beacon genesis.
+	GenesisTime(ctx context.Context) (uint64, error)
+	// SecondsPerSlot returns the slot duration of the beacon chain.
+	SecondsPerSlot(ctx context.Context) (uint64, error)
+	// SlotAt returns the slot of the L1 block with the given timestamp.
+	SlotAt(ctx context.Context, timestamp uint64) (uint64, error)
+	// BlobSidecars returns all blob sidecars of the block at the given slot.
+	BlobSidecars(ctx context.Context, slot uint64) ([]*eth.APIBlobSidecar, error)
+	// Header returns the signed header of the block at the given slot.
+	// Mock beacon nodes may not serve headers.
+	Header(ctx context.Context, slot uint64) (eth.SignedBeaconBlockHeader, error)
 }
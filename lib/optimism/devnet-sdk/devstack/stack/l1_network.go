@@ -1,5 +1,9 @@
 package stack
 
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
 // L1NetworkID identifies a L1Network by name and chainID, is type-safe, and can be value-copied and used as map key.
 type L1NetworkID idWithChain
 
@@ -28,11 +32,18 @@ type L1Network interface {
 	Network
 	ID() L1NetworkID
 
+	// Snapshot returns the block of the pre-seeded state the L1 was started from,
+	// and false if the L1 was not started from a snapshot.
+	Snapshot() (eth.BlockID, bool)
+
 	L1ELNode(id L1ELNodeID) L1ELNode
 	L1CLNode(id L1CLNodeID) L1CLNode
 
 	L1ELNodes() []L1ELNodeID
 	L1CLNodes() []L1CLNodeID
+
+	L1ELNodesWithLabel(key, value string) []L1ELNodeID
+	L1CLNodesWithLabel(key, value string) []L1CLNodeID
 }
 
 type ExtensibleL1Network interface {
@@ -40,4 +51,7 @@ type ExtensibleL1Network interface {
 	L1Network
 	AddL1ELNode(v L1ELNode)
 	AddL1CLNode(v L1CLNode)
+
+	RemoveL1ELNode(id L1ELNodeID)
+	RemoveL1CLNode(id L1CLNodeID)
 }
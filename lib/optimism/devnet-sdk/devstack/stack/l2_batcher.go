@@ -1,5 +1,11 @@
 package stack
 
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
 // L2BatcherID identifies a L2Batcher by name and chainID, is type-safe, and can be value-copied and used as map key.
 type L2BatcherID idWithChain
 
@@ -28,5 +34,11 @@ type L2Batcher interface {
 	Common
 	ID() L2BatcherID
 
-	// API to interact with batcher will be added here later
+	// StartBatcher resumes batch submission.
+	StartBatcher(ctx context.Context) error
+	// StopBatcher pauses batch submission, e.g. to let the unsafe chain run ahead of the safe chain.
+	StopBatcher(ctx context.Context) error
+	// Flush submits the L2 blocks the batcher has loaded, without waiting for the current channel to fill up.
+	// It returns the latest L2 block pending submission, or the zero block ID if there is none.
+	Flush(ctx context.Context) (eth.BlockID, error)
 }
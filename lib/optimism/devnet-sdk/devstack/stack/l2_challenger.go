@@ -1,5 +1,13 @@
 package stack
 
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
 // L2ChallengerID identifies a L2Challenger by name and chainID, is type-safe, and can be value-copied and used as map key.
 type L2ChallengerID idWithChain
 
@@ -23,7 +31,46 @@ func SortL2ChallengerIDs(ids []L2ChallengerID) []L2ChallengerID {
 	})
 }
 
+// ChallengerGameStatus counts the dispute games tracked by a challenger, by game status.
+type ChallengerGameStatus struct {
+	InProgress    uint64
+	DefenderWon   uint64
+	ChallengerWon uint64
+}
+
+// Total is the number of games the challenger participates in.
+func (s ChallengerGameStatus) Total() uint64 {
+	return s.InProgress + s.DefenderWon + s.ChallengerWon
+}
+
+// ChallengerGame is a dispute game that the challenger made claims in.
+type ChallengerGame struct {
+	gameTypes.GameMetadata
+	Status gameTypes.GameStatus
+}
+
+// ChallengerClaim is a claim that the challenger made in a dispute game.
+type ChallengerClaim struct {
+	// Index of the claim in the game
+	Index       uint64
+	ParentIndex uint64
+	Value       common.Hash
+	// CounteredBy is the account that countered the claim, zero if the claim is uncountered.
+	CounteredBy common.Address
+	Resolved    bool
+}
+
+// L2Challenger is a dispute-game challenger, acting on games of the L2 on L1.
 type L2Challenger interface {
 	Common
 	ID() L2ChallengerID
+
+	// GameStatus reports the games the challenger is currently tracking.
+	GameStatus(ctx context.Context) (ChallengerGameStatus, error)
+
+	// Games lists the dispute games of the L2 that the challenger made claims in,
+	// in the order the games were created.
+	Games(ctx context.Context) ([]ChallengerGame, error)
+	// Claims lists the claims that the challenger made in the given game, and whether they are resolved.
+	Claims(ctx context.Context, game common.Address) ([]ChallengerClaim, error)
 }
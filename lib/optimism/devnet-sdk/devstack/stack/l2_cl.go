@@ -37,6 +37,17 @@ type RollupAPI interface {
 type L2CLNode interface {
 	Common
 	ID() L2CLNodeID
+	// EL is the EL node that the CL node drives through the engine API.
+	EL() L2ELNodeID
 
 	RollupAPI() RollupAPI
+
+	// WaitForUnsafeBlock waits until the unsafe head of the node reaches the given L2 block number,
+	// and returns the unsafe head. The test fails if the node does not reach the block before the context is done.
+	WaitForUnsafeBlock(ctx context.Context, num uint64) eth.L2BlockRef
+	// WaitForSafeBlock waits until the safe head of the node reaches the given L2 block number,
+	// and returns the safe head. The test fails if the node does not reach the block before the context is done.
+	WaitForSafeBlock(ctx context.Context, num uint64) eth.L2BlockRef
+	// SyncStatusSnapshot returns the current sync status of the node, and fails the test if it is unavailable.
+	SyncStatusSnapshot() *eth.SyncStatus
 }
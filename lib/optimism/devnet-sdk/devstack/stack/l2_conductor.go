@@ -0,0 +1,53 @@
+package stack
+
+import (
+	"context"
+)
+
+// L2ConductorID identifies a L2Conductor by name and chainID, is type-safe, and can be value-copied and used as map key.
+type L2ConductorID idWithChain
+
+const L2ConductorKind Kind = "L2Conductor"
+
+func (id L2ConductorID) String() string {
+	return idWithChain(id).string(L2ConductorKind)
+}
+
+func (id L2ConductorID) MarshalText() ([]byte, error) {
+	return idWithChain(id).marshalText(L2ConductorKind)
+}
+
+func (id *L2ConductorID) UnmarshalText(data []byte) error {
+	return (*idWithChain)(id).unmarshalText(L2ConductorKind, data)
+}
+
+func SortL2ConductorIDs(ids []L2ConductorID) []L2ConductorID {
+	return copyAndSort(ids, func(a, b L2ConductorID) bool {
+		return lessIDWithChain(idWithChain(a), idWithChain(b))
+	})
+}
+
+type ConductorAPI interface {
+	// Leader returns true if the conductor is the leader of the sequencer set.
+	Leader(ctx context.Context) (bool, error)
+	// Active returns true if the conductor is not paused or stopped.
+	Active(ctx context.Context) (bool, error)
+	// SequencerHealthy returns true if the sequencer of the conductor is healthy.
+	SequencerHealthy(ctx context.Context) (bool, error)
+	// TransferLeader transfers leadership to another conductor of the sequencer set.
+	TransferLeader(ctx context.Context) error
+	// TransferLeaderToServer transfers leadership to the conductor with the given raft server ID and address.
+	TransferLeaderToServer(ctx context.Context, id string, addr string) error
+}
+
+// L2Conductor is an op-conductor service, coordinating one of the sequencers
+// of a high-availability sequencer set of an L2.
+type L2Conductor interface {
+	Common
+	ID() L2ConductorID
+
+	// Sequencer is the CL node that the conductor controls.
+	Sequencer() L2CLNodeID
+
+	ConductorAPI() ConductorAPI
+}
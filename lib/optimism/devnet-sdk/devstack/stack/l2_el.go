@@ -1,5 +1,13 @@
 package stack
 
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
 // L2ELNodeID identifies a L2ELNode by name and chainID, is type-safe, and can be value-copied and used as map key.
 type L2ELNodeID idWithChain
 
@@ -23,9 +31,21 @@ func SortL2ELNodeIDs(ids []L2ELNodeID) []L2ELNodeID {
 	})
 }
 
+// EngineAPI is the engine API of an execution-layer node,
+// as used by the consensus-layer to build and import blocks.
+type EngineAPI interface {
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
+}
+
 // L2ELNode is a L2 ethereum execution-layer node
 type L2ELNode interface {
 	ID() L2ELNodeID
 
+	// EngineClient is the authenticated engine API client of the node,
+	// to drive forkchoice and payload calls directly.
+	EngineClient() EngineAPI
+
 	ELNode
 }
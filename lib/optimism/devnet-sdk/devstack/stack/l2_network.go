@@ -35,6 +35,11 @@ func SortL2NetworkIDs(ids []L2NetworkID) []L2NetworkID {
 type L2Deployment interface {
 	SystemConfigProxyAddr() common.Address
 	DisputeGameFactoryProxyAddr() common.Address
+	OptimismPortalProxyAddr() common.Address
+	L1StandardBridgeProxyAddr() common.Address
+	L1CrossDomainMessengerProxyAddr() common.Address
+	AddressManagerAddr() common.Address
+	AnchorStateRegistryProxyAddr() common.Address
 	// Other addresses will be added here later
 }
 
@@ -49,6 +54,11 @@ type L2Network interface {
 	Network
 	ID() L2NetworkID
 	RollupConfig() *rollup.Config
+	// IsForkActive returns whether the fork is active at the given L2 timestamp, per the rollup config.
+	IsForkActive(fork rollup.ForkName, timestamp uint64) bool
+	// ForkActivationTime returns the L2 timestamp the fork activates at,
+	// and false if the fork is not scheduled.
+	ForkActivationTime(fork rollup.ForkName) (uint64, bool)
 	Deployment() L2Deployment
 	Keys() L2Keys
 
@@ -59,14 +69,23 @@ type L2Network interface {
 	L2Batcher(id L2BatcherID) L2Batcher
 	L2Proposer(id L2ProposerID) L2Proposer
 	L2Challenger(id L2ChallengerID) L2Challenger
+	L2Conductor(id L2ConductorID) L2Conductor
 	L2CLNode(id L2CLNodeID) L2CLNode
 	L2ELNode(id L2ELNodeID) L2ELNode
 
 	L2Batchers() []L2BatcherID
 	L2Proposers() []L2ProposerID
 	L2Challengers() []L2ChallengerID
+	L2Conductors() []L2ConductorID
 	L2CLNodes() []L2CLNodeID
 	L2ELNodes() []L2ELNodeID
+
+	L2BatchersWithLabel(key, value string) []L2BatcherID
+	L2ProposersWithLabel(key, value string) []L2ProposerID
+	L2ChallengersWithLabel(key, value string) []L2ChallengerID
+	L2ConductorsWithLabel(key, value string) []L2ConductorID
+	L2CLNodesWithLabel(key, value string) []L2CLNodeID
+	L2ELNodesWithLabel(key, value string) []L2ELNodeID
 }
 
 // ExtensibleL2Network is an optional extension interface for L2Network,
@@ -77,6 +96,14 @@ type ExtensibleL2Network interface {
 	AddL2Batcher(v L2Batcher)
 	AddL2Proposer(v L2Proposer)
 	AddL2Challenger(v L2Challenger)
+	AddL2Conductor(v L2Conductor)
 	AddL2CLNode(v L2CLNode)
 	AddL2ELNode(v L2ELNode)
+
+	RemoveL2Batcher(id L2BatcherID)
+	RemoveL2Proposer(id L2ProposerID)
+	RemoveL2Challenger(id L2ChallengerID)
+	RemoveL2Conductor(id L2ConductorID)
+	RemoveL2CLNode(id L2CLNodeID)
+	RemoveL2ELNode(id L2ELNodeID)
 }
@@ -1,5 +1,12 @@
 package stack
 
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // L2ProposerID identifies a L2Proposer by name and chainID, is type-safe, and can be value-copied and used as map key.
 type L2ProposerID idWithChain
 
@@ -23,8 +30,27 @@ func SortL2ProposerIDs(ids []L2ProposerID) []L2ProposerID {
 	})
 }
 
+// ProposedOutput is an output root that the proposer proposed, by creating a dispute game on L1.
+type ProposedOutput struct {
+	Game common.Address
+	// L2SequenceNumber is the L2 block number, or the L2 timestamp for super roots, the output was proposed at.
+	L2SequenceNumber uint64
+	OutputRoot       common.Hash
+	// Timestamp is the L1 time the proposal was made at.
+	Timestamp uint64
+}
+
 // L2Proposer is a L2 output proposer, posting claims of L2 state to L1.
 type L2Proposer interface {
 	Common
 	ID() L2ProposerID
+
+	// ProposalCount returns the number of outputs the proposer has proposed.
+	ProposalCount(ctx context.Context) (uint64, error)
+	// LatestProposedOutput returns the most recent proposal, or nil if the proposer has not proposed yet.
+	LatestProposedOutput(ctx context.Context) (*ProposedOutput, error)
+	// NextProposalTime returns the time the proposer is next due to propose at:
+	// the time of the latest proposal plus the proposal interval.
+	// The zero time is returned if the proposer has not proposed yet, as it then proposes as soon as it can.
+	NextProposalTime(ctx context.Context) (time.Time, error)
 }
@@ -0,0 +1,41 @@
+package stack
+
+const (
+	// ServiceLabel is the name of the service that backs the component.
+	ServiceLabel = "service"
+	// RoleLabel is the role of a L2 node in its network: RoleSequencer or RoleVerifier.
+	RoleLabel     = "role"
+	RoleSequencer = "sequencer"
+	RoleVerifier  = "verifier"
+)
+
+// Labeled is an optional extension of components, to select components by label rather than by ID.
+// Labels are arbitrary key-value pairs, e.g. "role" = "verifier", or "zone" = "b".
+type Labeled interface {
+	// Label returns the value of the label with the given key, and false if the component does not have the label.
+	Label(key string) (string, bool)
+	// Labels returns a copy of all labels of the component.
+	Labels() map[string]string
+}
+
+// HasLabel checks if the component has the label with the given key and value.
+func HasLabel(component any, key string, value string) bool {
+	labeled, ok := component.(Labeled)
+	if !ok {
+		return false
+	}
+	v, ok := labeled.Label(key)
+	return ok && v == value
+}
+
+// FilterByLabel returns the IDs, in the same order, of the components that have the given label.
+// The get function looks up the component of each ID.
+func FilterByLabel[ID any, C any](ids []ID, get func(id ID) C, key string, value string) []ID {
+	var out []ID
+	for _, id := range ids {
+		if HasLabel(get(id), key, value) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
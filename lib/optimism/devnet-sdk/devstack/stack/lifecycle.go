@@ -0,0 +1,21 @@
+package stack
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLifecycleUnsupported is returned by Lifecycle implementations
+// that cannot start or stop the service backing the component, e.g. when attached to a remote service.
+var ErrLifecycleUnsupported = errors.New("component lifecycle is not controlled by the orchestrator")
+
+// Lifecycle is an optional extension interface for components that are backed by a service.
+// Use a type-assertion on the component to access it.
+type Lifecycle interface {
+	// Start starts the service backing the component, if it is not already running.
+	Start(ctx context.Context) error
+	// Stop stops the service backing the component, if it is running.
+	Stop(ctx context.Context) error
+	// Healthy returns an error if the service backing the component is not reachable or not healthy.
+	Healthy(ctx context.Context) error
+}
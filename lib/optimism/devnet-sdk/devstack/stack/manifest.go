@@ -0,0 +1,194 @@
+package stack
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// EndpointSource is an optional extension of components that are served over RPC or HTTP,
+// to report the endpoint the component is reached at.
+type EndpointSource interface {
+	// Endpoint returns the URL of the component, or an empty string if unknown.
+	Endpoint() string
+	// MetricsEndpoint returns the URL of the metrics endpoint of the component, or an empty string if unknown.
+	MetricsEndpoint() string
+}
+
+// ManifestSource is an optional extension of a System, to describe the system in a serializable Manifest.
+type ManifestSource interface {
+	Manifest() *Manifest
+}
+
+// Manifest is a serializable description of a system:
+// the IDs of all components, the chains and their configs, the endpoints of the services, and the deployments.
+// A manifest allows out-of-process tools to inspect a running system.
+// Secrets, such as keys of users, are not part of the manifest.
+type Manifest struct {
+	Superchains []SuperchainManifest              `json:"superchains,omitempty"`
+	Clusters    []ClusterManifest                 `json:"clusters,omitempty"`
+	L1Networks  []L1NetworkManifest               `json:"l1_networks,omitempty"`
+	L2Networks  []L2NetworkManifest               `json:"l2_networks,omitempty"`
+	Supervisors []ComponentManifest[SupervisorID] `json:"supervisors,omitempty"`
+}
+
+// ComponentManifest describes a component that may be reached at an endpoint.
+type ComponentManifest[ID any] struct {
+	ID              ID                `json:"id"`
+	Endpoint        string            `json:"endpoint,omitempty"`
+	MetricsEndpoint string            `json:"metrics_endpoint,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+type SuperchainManifest struct {
+	ID         SuperchainID                  `json:"id"`
+	Deployment *SuperchainDeploymentManifest `json:"deployment,omitempty"`
+}
+
+type SuperchainDeploymentManifest struct {
+	ProtocolVersions common.Address `json:"protocol_versions"`
+	SuperchainConfig common.Address `json:"superchain_config"`
+}
+
+var _ SuperchainDeployment = (*SuperchainDeploymentManifest)(nil)
+
+func (d *SuperchainDeploymentManifest) ProtocolVersionsAddr() common.Address {
+	return d.ProtocolVersions
+}
+
+func (d *SuperchainDeploymentManifest) SuperchainConfigAddr() common.Address {
+	return d.SuperchainConfig
+}
+
+type ClusterManifest struct {
+	ID ClusterID `json:"id"`
+	// DependencySet is the JSON encoding of the dependency set of the cluster,
+	// omitted if the dependency set cannot be encoded.
+	DependencySet json.RawMessage `json:"dependency_set,omitempty"`
+}
+
+// NetworkManifest describes the parts common to L1 and L2 networks.
+type NetworkManifest struct {
+	ChainID     eth.ChainID         `json:"chain_id"`
+	ChainConfig *params.ChainConfig `json:"chain_config,omitempty"`
+	Users       []UserID            `json:"users,omitempty"`
+}
+
+type L1NetworkManifest struct {
+	NetworkManifest
+	ID       L1NetworkID  `json:"id"`
+	Snapshot *eth.BlockID `json:"snapshot,omitempty"`
+
+	ELNodes []ComponentManifest[L1ELNodeID] `json:"el_nodes,omitempty"`
+	CLNodes []ComponentManifest[L1CLNodeID] `json:"cl_nodes,omitempty"`
+}
+
+type L2NetworkManifest struct {
+	NetworkManifest
+	ID           L2NetworkID           `json:"id"`
+	RollupConfig *rollup.Config        `json:"rollup_config,omitempty"`
+	Deployment   *L2DeploymentManifest `json:"deployment,omitempty"`
+
+	L1         L1NetworkID  `json:"l1"`
+	Superchain SuperchainID `json:"superchain,omitempty"`
+	Cluster    ClusterID    `json:"cluster,omitempty"`
+
+	ELNodes     []L2ELNodeManifest               `json:"el_nodes,omitempty"`
+	CLNodes     []L2CLNodeManifest               `json:"cl_nodes,omitempty"`
+	Batchers    []ComponentManifest[L2BatcherID] `json:"batchers,omitempty"`
+	Proposers   []L2ProposerManifest             `json:"proposers,omitempty"`
+	Challengers []L2ChallengerManifest           `json:"challengers,omitempty"`
+	Conductors  []L2ConductorManifest            `json:"conductors,omitempty"`
+}
+
+type L2ELNodeManifest struct {
+	ComponentManifest[L2ELNodeID]
+	// AuthEndpoint is the URL of the engine API of the node.
+	// The JWT secret to authenticate with is not part of the manifest.
+	AuthEndpoint string `json:"auth_endpoint,omitempty"`
+}
+
+type L2CLNodeManifest struct {
+	ComponentManifest[L2CLNodeID]
+	EL L2ELNodeID `json:"el"`
+}
+
+type L2ProposerManifest struct {
+	ComponentManifest[L2ProposerID]
+	// Address is the account the proposer creates dispute games from.
+	Address common.Address `json:"address"`
+	// ProposalInterval is the delay between proposals, zero if unknown.
+	ProposalInterval time.Duration `json:"proposal_interval,omitempty"`
+}
+
+type L2ChallengerManifest struct {
+	ComponentManifest[L2ChallengerID]
+	// Address is the account the challenger makes claims from.
+	Address common.Address `json:"address"`
+}
+
+type L2DeploymentManifest struct {
+	SystemConfigProxy           common.Address `json:"system_config_proxy"`
+	DisputeGameFactoryProxy     common.Address `json:"dispute_game_factory_proxy"`
+	OptimismPortalProxy         common.Address `json:"optimism_portal_proxy"`
+	L1StandardBridgeProxy       common.Address `json:"l1_standard_bridge_proxy"`
+	L1CrossDomainMessengerProxy common.Address `json:"l1_cross_domain_messenger_proxy"`
+	AddressManager              common.Address `json:"address_manager"`
+	AnchorStateRegistryProxy    common.Address `json:"anchor_state_registry_proxy"`
+}
+
+var _ L2Deployment = (*L2DeploymentManifest)(nil)
+
+func (d *L2DeploymentManifest) SystemConfigProxyAddr() common.Address {
+	return d.SystemConfigProxy
+}
+
+func (d *L2DeploymentManifest) DisputeGameFactoryProxyAddr() common.Address {
+	return d.DisputeGameFactoryProxy
+}
+
+func (d *L2DeploymentManifest) OptimismPortalProxyAddr() common.Address {
+	return d.OptimismPortalProxy
+}
+
+func (d *L2DeploymentManifest) L1StandardBridgeProxyAddr() common.Address {
+	return d.L1StandardBridgeProxy
+}
+
+func (d *L2DeploymentManifest) L1CrossDomainMessengerProxyAddr() common.Address {
+	return d.L1CrossDomainMessengerProxy
+}
+
+func (d *L2DeploymentManifest) AddressManagerAddr() common.Address {
+	return d.AddressManager
+}
+
+func (d *L2DeploymentManifest) AnchorStateRegistryProxyAddr() common.Address {
+	return d.AnchorStateRegistryProxy
+}
+
+type L2ConductorManifest struct {
+	ComponentManifest[L2ConductorID]
+	Sequencer L2CLNodeID `json:"sequencer"`
+}
+
+// ComponentEndpoint returns the endpoint of the component if it reports one, or an empty string otherwise.
+func ComponentEndpoint(component any) string {
+	if src, ok := component.(EndpointSource); ok {
+		return src.Endpoint()
+	}
+	return ""
+}
+
+// ComponentMetricsEndpoint returns the metrics endpoint of the component if it reports one, or an empty string otherwise.
+func ComponentMetricsEndpoint(component any) string {
+	if src, ok := component.(EndpointSource); ok {
+		return src.MetricsEndpoint()
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package stack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ErrNoMetrics is returned by a MetricsSource that has no metrics endpoint to scrape.
+var ErrNoMetrics = errors.New("component does not expose metrics")
+
+// MetricsSource is an optional extension interface for components that serve Prometheus metrics.
+// Use a type-assertion on the component to access it.
+type MetricsSource interface {
+	// Metrics scrapes all metrics of the component, keyed by metric-family name.
+	Metrics(ctx context.Context) (map[string]*dto.MetricFamily, error)
+	// MetricValue scrapes the named metric, and sums the values of all series that match the given labels.
+	// It returns 0 if the metric has not been registered yet.
+	MetricValue(ctx context.Context, name string, labels map[string]string) (float64, error)
+}
+
+// MetricsRecorder is an optional extension interface for components that gather their metrics in a registry,
+// e.g. the metrics that an in-process service records, or the metrics scraped from a remote service.
+// Use a type-assertion on the component to access it.
+type MetricsRecorder interface {
+	// MetricsRegistry returns the registry of the component, or nil if the component does not record metrics.
+	MetricsRegistry() *prometheus.Registry
+}
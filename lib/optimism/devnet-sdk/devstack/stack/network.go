@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -18,11 +20,27 @@ type Network interface {
 
 	Faucet() Faucet
 
+	// BlobSource returns a reader of the blobs that batches of the network are submitted in.
+	// For L2 networks, this is the blob source of the L1 network.
+	BlobSource() BlobSource
+
 	User(id UserID) User
 	Users() []UserID
+
+	UsersWithLabel(key, value string) []UserID
 }
 
 type ExtensibleNetwork interface {
 	Network
 	AddUser(v User)
+	AddFaucet(v Faucet)
+
+	RemoveUser(id UserID)
+	RemoveFaucet()
+}
+
+// BlobSource reads the blob sidecars of L1 blocks, like the derivation pipeline does.
+type BlobSource interface {
+	GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error)
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
 }
@@ -2,6 +2,7 @@ package stack
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -28,6 +29,14 @@ type Orchestrator interface {
 
 }
 
+// TimeTraveler is an optional Orchestrator extension, for backends that control the clock of the L1 chain.
+// Tests can skip over waiting periods, like dispute-game clocks and withdrawal delays, instead of waiting in real time.
+type TimeTraveler interface {
+	// TimeTravel advances the L1 clock by the given duration.
+	// It returns false if time travel is not enabled for the system.
+	TimeTravel(d time.Duration) bool
+}
+
 // GateWithRemediation is an example of a test-gate that checks a system and may use an orchestrator to remediate any shortcomings.
 // func GateWithRemediation(sys System, orchestrator Orchestrator) {
 // step 1: check if system already does the right thing
@@ -56,6 +65,13 @@ type Setup struct {
 	// Orchestrator is the backend responsible for managing components,
 	// and providing backend-specific options where needed, e.g. spawning new services.
 	Orchestrator Orchestrator
+	// AfterSetup are options that are applied once the system is constructed,
+	// e.g. to check the capabilities of the system.
+	// Options that run during construction may register these, the constructor of the system applies them.
+	AfterSetup []Option
+	// ReadyTimeout bounds how long presets wait for the system to be ready, before the test runs.
+	// Presets use their default timeout if nil, and do not wait if zero.
+	ReadyTimeout *time.Duration
 }
 
 // Option is used to define a function that inspects and/or changes a System.
@@ -72,3 +88,34 @@ func (fn *Option) Add(other ...Option) {
 		}
 	}
 }
+
+// Fork creates a child setup, to construct a part of the system that can be torn down on its own,
+// e.g. an L2 chain and its services, while the rest of the system keeps running.
+// The child shares the system and orchestrator with the parent,
+// but registers its cleanups in a ScopedT that runs them on Close.
+// Any cleanups that remain are run when the parent cleans up.
+func (s *Setup) Fork(name string) *Setup {
+	t := NewScopedT(s.T, name)
+	ctx, cancel := context.WithCancel(s.Ctx)
+	t.Cleanup(cancel)
+	logger := s.Log
+	if logger != nil {
+		logger = logger.New("scope", name)
+	}
+	return &Setup{
+		Ctx:          ctx,
+		Log:          logger,
+		T:            t,
+		Require:      require.New(t),
+		System:       s.System,
+		Orchestrator: s.Orchestrator,
+	}
+}
+
+// Close runs the cleanups of a setup created with Fork,
+// which removes the components created with the setup from the system, and stops their services.
+func (s *Setup) Close() {
+	t, ok := s.T.(*ScopedT)
+	s.Require.True(ok, "only a forked setup can be closed")
+	t.Close()
+}
@@ -0,0 +1,93 @@
+package stack
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxRegistrationFrames is the number of call-stack frames recorded per registration.
+const maxRegistrationFrames = 16
+
+// IDRegistration describes where a component ID was registered.
+type IDRegistration struct {
+	ID any
+	// Source is the first caller outside of the stack and shim packages,
+	// typically the option that added the component.
+	Source string
+	// Stack is the call stack of the registration, one frame per line.
+	Stack string
+}
+
+// IDRegistry records the IDs of all components of a system, along with where they were registered,
+// so that an ID collision can be reported with the original registration.
+// The IDs are typed, so IDs of different kinds never collide, even if their text is the same.
+type IDRegistry struct {
+	mu      sync.Mutex
+	entries map[any]IDRegistration
+}
+
+func NewIDRegistry() *IDRegistry {
+	return &IDRegistry{entries: make(map[any]IDRegistration)}
+}
+
+// Register records the ID with the stack of the caller,
+// and returns an error describing the original registration if the ID is already registered.
+func (r *IDRegistry) Register(id any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev, ok := r.entries[id]; ok {
+		return fmt.Errorf("%v is already registered by %s, at:\n%s", id, prev.Source, prev.Stack)
+	}
+	source, stack := callers(2)
+	r.entries[id] = IDRegistration{ID: id, Source: source, Stack: stack}
+	return nil
+}
+
+// Unregister forgets the ID, e.g. when the component is removed from the system.
+func (r *IDRegistry) Unregister(id any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, id)
+}
+
+// Lookup returns where the ID was registered, and false if the ID is not registered.
+func (r *IDRegistry) Lookup(id any) (IDRegistration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.entries[id]
+	return v, ok
+}
+
+// callers returns the first caller outside of the stack and shim packages, and the formatted call stack,
+// skipping the given number of frames.
+func callers(skip int) (source string, stack string) {
+	pcs := make([]uintptr, maxRegistrationFrames)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var out strings.Builder
+	for {
+		frame, more := frames.Next()
+		loc := fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+		if source == "" && !isDevstackInternal(frame.File) {
+			source = loc
+		}
+		out.WriteString("\t" + loc + "\n")
+		if !more {
+			break
+		}
+	}
+	if source == "" {
+		source = "unknown"
+	}
+	return source, out.String()
+}
+
+// isDevstackInternal checks if the source file is part of the stack or shim packages, excluding their tests.
+func isDevstackInternal(file string) bool {
+	if strings.HasSuffix(file, "_test.go") {
+		return false
+	}
+	return strings.Contains(file, "/devstack/stack/") || strings.Contains(file, "/devstack/shim/")
+}
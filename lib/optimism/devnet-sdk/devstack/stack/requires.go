@@ -0,0 +1,182 @@
+package stack
+
+import (
+	"fmt"
+	"slices"
+)
+
+// DependentOption is an Option that declares which kinds of components it adds to the system,
+// and which kinds of components must exist in the system before it can be applied.
+// See Ordered to apply a set of dependent options in order of their dependencies.
+type DependentOption struct {
+	Option Option
+
+	// Name describes the option in errors, e.g. about missing prerequisites.
+	Name string
+
+	Provides []Kind
+	Requires []Kind
+}
+
+// OptionWithRequires declares that the option requires components of the given kinds to exist before it runs.
+func OptionWithRequires(opt Option, requires ...Kind) DependentOption {
+	return DependentOption{Option: opt, Requires: requires}
+}
+
+// WithProvides returns a copy of the option that additionally declares to add components of the given kinds.
+func (o DependentOption) WithProvides(kinds ...Kind) DependentOption {
+	o.Provides = append(slices.Clone(o.Provides), kinds...)
+	return o
+}
+
+// WithName returns a copy of the option with the given name, to identify the option in errors.
+func (o DependentOption) WithName(name string) DependentOption {
+	o.Name = name
+	return o
+}
+
+func (o DependentOption) String() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return fmt.Sprintf("option(provides=%v, requires=%v)", o.Provides, o.Requires)
+}
+
+// Ordered combines the options into a single Option that applies them in order of their dependencies:
+// an option runs only after all the options that provide a kind of component it requires.
+// Options without a dependency between them keep their relative order.
+//
+// Required kinds that none of the options provide must already be present in the system
+// when the combined option is applied, and the setup fails with a descriptive error otherwise.
+// The setup also fails if the options have a circular dependency.
+func Ordered(opts ...DependentOption) Option {
+	return func(setup *Setup) {
+		order, err := orderOptions(opts)
+		setup.Require.NoError(err, "failed to order options")
+		provided := make(map[Kind]struct{})
+		for _, o := range opts {
+			for _, kind := range o.Provides {
+				provided[kind] = struct{}{}
+			}
+		}
+		for _, i := range order {
+			o := opts[i]
+			for _, kind := range o.Requires {
+				if _, ok := provided[kind]; ok {
+					continue
+				}
+				setup.Require.True(HasKind(setup.System, kind),
+					"%s requires a %s, but no option provides it and the system does not have any", o, kind)
+			}
+			o.Option(setup)
+		}
+	}
+}
+
+// orderOptions topologically sorts the options by their dependencies, and returns the order as indices.
+// Of the options that are ready to run, the one that comes first in the input is picked first.
+func orderOptions(opts []DependentOption) ([]int, error) {
+	// number of options still to run that provide each kind
+	pending := make(map[Kind]int)
+	for _, o := range opts {
+		for _, kind := range o.Provides {
+			pending[kind]++
+		}
+	}
+	ready := func(o DependentOption) bool {
+		for _, kind := range o.Requires {
+			count := pending[kind]
+			// an option may require more of a kind that it provides itself
+			if slices.Contains(o.Provides, kind) {
+				count--
+			}
+			if count > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	done := make([]bool, len(opts))
+	order := make([]int, 0, len(opts))
+	for len(order) < len(opts) {
+		next := -1
+		for i, o := range opts {
+			if !done[i] && ready(o) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var stuck []string
+			for i, o := range opts {
+				if !done[i] {
+					stuck = append(stuck, o.String())
+				}
+			}
+			return nil, fmt.Errorf("circular dependency between options: %v", stuck)
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, kind := range opts[next].Provides {
+			pending[kind]--
+		}
+	}
+	return order, nil
+}
+
+// HasKind checks if the system has at least one component of the given kind.
+// Users are looked up on all L1 and L2 networks.
+// Networks do not list their faucet, so the system is never reported to have a faucet.
+func HasKind(sys System, kind Kind) bool {
+	switch kind {
+	case SuperchainKind:
+		return len(sys.Superchains()) > 0
+	case ClusterKind:
+		return len(sys.Clusters()) > 0
+	case SupervisorKind:
+		return len(sys.Supervisors()) > 0
+	case L1NetworkKind:
+		return len(sys.L1Networks()) > 0
+	case L2NetworkKind:
+		return len(sys.L2Networks()) > 0
+	}
+	for _, id := range sys.L1Networks() {
+		l1 := sys.L1Network(id)
+		var ids int
+		switch kind {
+		case L1ELNodeKind:
+			ids = len(l1.L1ELNodes())
+		case L1CLNodeKind:
+			ids = len(l1.L1CLNodes())
+		case UserKind:
+			ids = len(l1.Users())
+		}
+		if ids > 0 {
+			return true
+		}
+	}
+	for _, id := range sys.L2Networks() {
+		l2 := sys.L2Network(id)
+		var ids int
+		switch kind {
+		case L2ELNodeKind:
+			ids = len(l2.L2ELNodes())
+		case L2CLNodeKind:
+			ids = len(l2.L2CLNodes())
+		case L2BatcherKind:
+			ids = len(l2.L2Batchers())
+		case L2ProposerKind:
+			ids = len(l2.L2Proposers())
+		case L2ChallengerKind:
+			ids = len(l2.L2Challengers())
+		case L2ConductorKind:
+			ids = len(l2.L2Conductors())
+		case UserKind:
+			ids = len(l2.Users())
+		}
+		if ids > 0 {
+			return true
+		}
+	}
+	return false
+}
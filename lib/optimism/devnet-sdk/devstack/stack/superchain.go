@@ -34,4 +34,15 @@ type Superchain interface {
 	ID() SuperchainID
 
 	Deployment() SuperchainDeployment
+
+	// L2s returns the L2 networks of the system that are part of the superchain.
+	L2s() []L2NetworkID
+}
+
+// ExtensibleSuperchain is an optional extension interface for Superchain,
+// for the system to maintain the membership of L2 networks.
+type ExtensibleSuperchain interface {
+	Superchain
+	AddL2(id L2NetworkID)
+	RemoveL2(id L2NetworkID)
 }
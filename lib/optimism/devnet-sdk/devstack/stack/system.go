@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"context"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -25,16 +27,36 @@ type System interface {
 
 	Supervisor(id SupervisorID) Supervisor
 	Supervisors() []SupervisorID
+
+	L1NetworksWithLabel(key, value string) []L1NetworkID
+	L2NetworksWithLabel(key, value string) []L2NetworkID
+	SupervisorsWithLabel(key, value string) []SupervisorID
+
+	// Events is the bus that components and watchers publish events to.
+	Events() EventBus
+
+	// HealthCheck checks the health of all components that implement Lifecycle,
+	// and returns the combined errors of all unhealthy components.
+	HealthCheck(ctx context.Context) error
 }
 
-// ExtensibleSystem is an extension-interface to add new components to the system.
+// ExtensibleSystem is an extension-interface to add or remove components of the system.
 // Regular tests should not be modifying the system.
 // Test gates may use this to remediate any shortcomings of an existing system.
 type ExtensibleSystem interface {
 	System
+	// IDRegistry records where the components of the system were registered, to diagnose ID collisions.
+	IDRegistry() *IDRegistry
+
 	AddSuperchain(v Superchain)
 	AddCluster(v Cluster)
 	AddL1Network(v L1Network)
 	AddL2Network(v L2Network)
 	AddSupervisor(v Supervisor)
+
+	RemoveSuperchain(id SuperchainID)
+	RemoveCluster(id ClusterID)
+	RemoveL1Network(id L1NetworkID)
+	RemoveL2Network(id L2NetworkID)
+	RemoveSupervisor(id SupervisorID)
 }
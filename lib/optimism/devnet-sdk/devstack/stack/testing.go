@@ -3,6 +3,7 @@ package stack
 import (
 	"fmt"
 	"os"
+	"runtime"
 	"sync"
 
 	"github.com/stretchr/testify/require"
@@ -56,6 +57,12 @@ func (t *ToolingT) FailNow() {
 }
 
 func (t *ToolingT) TempDir() string {
+	return makeTempDir(t, t.Log)
+}
+
+// makeTempDir creates a temp dir that is removed when t cleans up.
+// Failure to create the dir is reported to t.
+func makeTempDir(t T, logger log.Logger) string {
 	// The last "*" will be replaced with the random temp dir name
 	tempDir, err := os.MkdirTemp("", "op-dev-*")
 	if err != nil {
@@ -66,7 +73,7 @@ func (t *ToolingT) TempDir() string {
 	require.NotEqual(t, "/", tempDir, "sanity-check temp-dir is not root")
 	t.Cleanup(func() {
 		if err := os.RemoveAll(tempDir); err != nil {
-			t.Log.Error("Failed to clean up temp dir", "dir", tempDir, "err", err)
+			logger.Error("Failed to clean up temp dir", "dir", tempDir, "err", err)
 		}
 	})
 	return tempDir
@@ -125,6 +132,85 @@ func (t *ToolingT) Name() string {
 	return t.TestName
 }
 
+// ScopedT is a T with its own scope of cleanups, see Setup.Fork.
+// Failures, logs and temp dirs are delegated to the parent T.
+// Cleanups run when the scope is closed, or when the parent cleans up, whichever comes first.
+type ScopedT struct {
+	parent T
+	name   string
+
+	cleanupLock    sync.Mutex
+	cleanupBacklog []func()
+	closed         bool
+}
+
+var _ T = (*ScopedT)(nil)
+
+// NewScopedT creates a new cleanup scope within the parent T.
+func NewScopedT(parent T, name string) *ScopedT {
+	t := &ScopedT{parent: parent, name: name}
+	parent.Cleanup(t.Close)
+	return t
+}
+
+func (t *ScopedT) Errorf(format string, args ...interface{}) {
+	t.parent.Errorf(format, args...)
+}
+
+func (t *ScopedT) FailNow() {
+	t.parent.FailNow()
+}
+
+func (t *ScopedT) TempDir() string {
+	return t.parent.TempDir()
+}
+
+// Cleanup registers a cleanup function to run when the scope closes.
+// Cleanups registered after the scope closed are passed on to the parent.
+func (t *ScopedT) Cleanup(fn func()) {
+	t.cleanupLock.Lock()
+	defer t.cleanupLock.Unlock()
+	if t.closed {
+		t.parent.Cleanup(fn)
+		return
+	}
+	t.cleanupBacklog = append(t.cleanupBacklog, fn)
+}
+
+// Close runs the cleanups of the scope, in reverse order of registration.
+// If a cleanup panics, the remaining cleanups are left to the parent cleanup.
+func (t *ScopedT) Close() {
+	t.cleanupLock.Lock()
+	t.closed = true
+	t.cleanupLock.Unlock()
+	for {
+		var cleanup func()
+		t.cleanupLock.Lock()
+		if len(t.cleanupBacklog) > 0 {
+			last := len(t.cleanupBacklog) - 1
+			cleanup = t.cleanupBacklog[last]
+			t.cleanupBacklog = t.cleanupBacklog[:last]
+		}
+		t.cleanupLock.Unlock()
+		if cleanup == nil {
+			return
+		}
+		cleanup()
+	}
+}
+
+func (t *ScopedT) Logf(format string, args ...any) {
+	t.parent.Logf(format, args...)
+}
+
+func (t *ScopedT) Helper() {
+	t.parent.Helper()
+}
+
+func (t *ScopedT) Name() string {
+	return t.parent.Name() + "/" + t.name
+}
+
 func NewToolingT(name string, logger log.Logger) *ToolingT {
 	t := &ToolingT{
 		TestName: name,
@@ -136,3 +222,86 @@ func NewToolingT(name string, logger log.Logger) *ToolingT {
 	}
 	return t
 }
+
+// CollectingT is a ToolingT that does not exit on critical failures,
+// but collects the errors, so long-running tools can report them and continue.
+// Like testing.T, FailNow stops the goroutine that calls it:
+// steps that may fail must be run with Run, so the tool continues after a failed step.
+type CollectingT struct {
+	ToolingT
+
+	errLock sync.Mutex
+	errs    []error
+	failed  bool
+}
+
+var _ T = (*CollectingT)(nil)
+
+// NewCollectingT creates a new CollectingT, errors are logged to the given logger as well as collected.
+func NewCollectingT(name string, logger log.Logger) *CollectingT {
+	t := &CollectingT{}
+	t.TestName = name
+	t.Log = logger
+	t.Fail = func() {
+		t.errLock.Lock()
+		defer t.errLock.Unlock()
+		t.failed = true
+	}
+	return t
+}
+
+func (t *CollectingT) Errorf(format string, args ...interface{}) {
+	err := fmt.Errorf(format, args...)
+	t.Log.Error(err.Error())
+	t.errLock.Lock()
+	defer t.errLock.Unlock()
+	t.errs = append(t.errs, err)
+}
+
+// FailNow marks the T as failed, and stops the calling goroutine with runtime.Goexit.
+// It must be called from a step started with Run.
+func (t *CollectingT) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+// TempDir creates a temp dir that is removed on cleanup, and collects the error if it cannot be created.
+func (t *CollectingT) TempDir() string {
+	return makeTempDir(t, t.Log)
+}
+
+// Run runs the step in a new goroutine and waits for it.
+// It returns false if the step was stopped by FailNow, without stopping the caller.
+func (t *CollectingT) Run(step func()) bool {
+	completed := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		step()
+		completed = true
+	}()
+	<-done
+	return completed
+}
+
+// Failed returns true if FailNow was called since the last Reset.
+func (t *CollectingT) Failed() bool {
+	t.errLock.Lock()
+	defer t.errLock.Unlock()
+	return t.failed
+}
+
+// Errors returns the errors collected since the last Reset.
+func (t *CollectingT) Errors() []error {
+	t.errLock.Lock()
+	defer t.errLock.Unlock()
+	return append([]error(nil), t.errs...)
+}
+
+// Reset clears the collected errors and the failed state.
+func (t *CollectingT) Reset() {
+	t.errLock.Lock()
+	defer t.errLock.Unlock()
+	t.errs = nil
+	t.failed = false
+}
@@ -0,0 +1,59 @@
+package stack
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestCollectingT(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelCrit)
+	ct := NewCollectingT(t.Name(), logger)
+
+	reached := false
+	ok := ct.Run(func() {
+		require.Equal(ct, 1, 2, "values must match")
+		reached = true
+	})
+	require.False(t, ok, "failed step must not complete")
+	require.False(t, reached, "FailNow must stop the step")
+	require.True(t, ct.Failed())
+	require.Len(t, ct.Errors(), 1)
+
+	ct.Reset()
+	require.True(t, ct.Run(func() {
+		ct.Errorf("not critical")
+	}), "step that does not call FailNow must complete")
+	require.False(t, ct.Failed())
+	require.Len(t, ct.Errors(), 1)
+}
+
+func TestCollectingTTempDir(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelCrit)
+	ct := NewCollectingT(t.Name(), logger)
+	var dir string
+	require.True(t, ct.Run(func() {
+		dir = ct.TempDir()
+	}))
+	require.DirExists(t, dir)
+	ct.RunCleanup()
+	require.NoDirExists(t, dir)
+
+	// errors of creating the temp dir are collected
+	t.Setenv("TMPDIR", "/nonexistent")
+	if os.TempDir() != "/nonexistent" {
+		t.Skip("temp dir location cannot be overridden on this platform")
+	}
+	ct.Reset()
+	require.False(t, ct.Run(func() {
+		ct.TempDir()
+	}))
+	require.True(t, ct.Failed())
+	require.Len(t, ct.Errors(), 1)
+	require.Contains(t, ct.Errors()[0].Error(), "failed to create temp dir")
+}
@@ -1,10 +1,13 @@
 package stack
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // UserID identifies a User by name and chainID, is type-safe, and can be value-copied and used as map key.
@@ -44,4 +47,29 @@ type User interface {
 
 	// EL is the default node used to interact with the chain
 	EL() ELNode
+
+	// SendTx signs and submits a transaction to the EL node of the user.
+	// The nonce is tracked by the user, and the gas limit and fees are determined by the EL node, unless set.
+	SendTx(ctx context.Context, req TxRequest) (common.Hash, error)
+	// Transfer sends the amount of wei to the given address.
+	Transfer(ctx context.Context, to common.Address, amount *big.Int) (common.Hash, error)
+	// Deploy sends a contract-creation transaction with the given init code,
+	// and returns the address the contract will be deployed at.
+	Deploy(ctx context.Context, code []byte) (common.Hash, common.Address, error)
+	// WaitReceipt waits for the transaction to be included,
+	// and returns an error if the transaction failed.
+	WaitReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// TxRequest describes a transaction for a User to send.
+type TxRequest struct {
+	// To is the recipient of the transaction, or nil to create a contract.
+	To    *common.Address
+	Value *big.Int
+	Data  []byte
+	// Gas is the gas limit of the transaction. The gas is estimated if zero.
+	Gas uint64
+	// AccessList is the access list of the transaction. Optional,
+	// e.g. to declare the interop messages that the transaction executes.
+	AccessList types.AccessList
 }
@@ -0,0 +1,34 @@
+package sysgo
+
+import (
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
+)
+
+// faucetUserIndex is the index of the prefunded user key of a chain that faucets send funds from.
+// It is the last of the prefunded keys, to not share nonces with tests that use the first keys.
+const faucetUserIndex = 19
+
+// WithFaucet registers a faucet on the L2 network of the EL node, that funds accounts from a prefunded user key.
+func WithFaucet(id stack.FaucetID, elID stack.L2ELNodeID) stack.Option {
+	return func(setup *stack.Setup) {
+		orch := setup.Orchestrator.(*Orchestrator)
+		commonConfig := shim.CommonConfigFromSetup(setup)
+
+		l2 := setup.System.L2Network(setup.System.L2NetworkID(id.ChainID)).(stack.ExtensibleL2Network)
+		priv, err := orch.keys.Secret(devkeys.ChainUserKeys(id.ChainID.ToBig())(faucetUserIndex))
+		setup.Require.NoError(err)
+		funder := shim.NewUser(shim.UserConfig{
+			CommonConfig: commonConfig,
+			ID:           stack.UserID{Key: id.Key, ChainID: id.ChainID},
+			Priv:         priv,
+			EL:           l2.L2ELNode(elID),
+		})
+		l2.AddFaucet(shim.NewFaucet(shim.FaucetConfig{
+			CommonConfig: commonConfig,
+			ID:           id,
+			Funder:       funder,
+		}))
+	}
+}
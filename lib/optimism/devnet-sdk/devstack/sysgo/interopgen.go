@@ -22,8 +22,13 @@ type ContractPaths struct {
 }
 
 type L2Deployment struct {
-	systemConfigProxyAddr   common.Address
-	disputeGameFactoryProxy common.Address
+	systemConfigProxyAddr       common.Address
+	disputeGameFactoryProxy     common.Address
+	optimismPortalProxy         common.Address
+	l1StandardBridgeProxy       common.Address
+	l1CrossDomainMessengerProxy common.Address
+	addressManager              common.Address
+	anchorStateRegistryProxy    common.Address
 }
 
 var _ stack.L2Deployment = &L2Deployment{}
@@ -36,6 +41,26 @@ func (d *L2Deployment) DisputeGameFactoryProxyAddr() common.Address {
 	return d.disputeGameFactoryProxy
 }
 
+func (d *L2Deployment) OptimismPortalProxyAddr() common.Address {
+	return d.optimismPortalProxy
+}
+
+func (d *L2Deployment) L1StandardBridgeProxyAddr() common.Address {
+	return d.l1StandardBridgeProxy
+}
+
+func (d *L2Deployment) L1CrossDomainMessengerProxyAddr() common.Address {
+	return d.l1CrossDomainMessengerProxy
+}
+
+func (d *L2Deployment) AddressManagerAddr() common.Address {
+	return d.addressManager
+}
+
+func (d *L2Deployment) AnchorStateRegistryProxyAddr() common.Address {
+	return d.anchorStateRegistryProxy
+}
+
 type SuperchainDeployment struct {
 	protocolVersionsAddr common.Address
 	superchainConfigAddr common.Address
@@ -54,6 +79,20 @@ func (d *SuperchainDeployment) ProtocolVersionsAddr() common.Address {
 // WithInteropGen is a system option that will create a L1 chain, superchain, cluster and L2 chains.
 func WithInteropGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
 	clusterID stack.ClusterID, l2IDs []stack.L2NetworkID, res ContractPaths) stack.Option {
+	return withWorldGen(l1ID, superchainID, &clusterID, l2IDs, res)
+}
+
+// WithChainGen is a system option that will create a L1 chain, superchain and L2 chains, without interop:
+// the L2 chains do not activate the interop fork, and no cluster is created.
+func WithChainGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
+	l2IDs []stack.L2NetworkID, res ContractPaths) stack.Option {
+	return withWorldGen(l1ID, superchainID, nil, l2IDs, res)
+}
+
+// withWorldGen creates the L1 chain, superchain and L2 chains.
+// The L2 chains are interop chains of a cluster, if a cluster ID is given.
+func withWorldGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
+	clusterID *stack.ClusterID, l2IDs []stack.L2NetworkID, res ContractPaths) stack.Option {
 
 	return func(setup *stack.Setup) {
 		orch := setup.Orchestrator.(*Orchestrator)
@@ -78,6 +117,12 @@ func WithInteropGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
 
 		worldCfg, err := recipe.Build(orch.keys)
 		setup.Require.NoError(err)
+		if clusterID == nil {
+			for _, l2Cfg := range worldCfg.L2s {
+				l2Cfg.L2GenesisInteropTimeOffset = nil
+				l2Cfg.UseInterop = false
+			}
+		}
 
 		// create a logger for the world configuration
 		logger := setup.Log.New("role", "world")
@@ -120,25 +165,27 @@ func WithInteropGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
 		})
 		setup.System.AddSuperchain(sysSuperchain)
 
-		depSetContents := make(map[eth.ChainID]*depset.StaticConfigDependency)
-		for _, l2Out := range worldOutput.L2s {
-			chainID := eth.ChainIDFromBig(l2Out.Genesis.Config.ChainID)
-			chainIndex := supervisortypes.ChainIndex(100 + slices.Index(ids, chainID))
-			depSetContents[chainID] = &depset.StaticConfigDependency{
-				ChainIndex:     chainIndex,
-				ActivationTime: 0,
-				HistoryMinTime: 0,
+		if clusterID != nil {
+			depSetContents := make(map[eth.ChainID]*depset.StaticConfigDependency)
+			for _, l2Out := range worldOutput.L2s {
+				chainID := eth.ChainIDFromBig(l2Out.Genesis.Config.ChainID)
+				chainIndex := supervisortypes.ChainIndex(100 + slices.Index(ids, chainID))
+				depSetContents[chainID] = &depset.StaticConfigDependency{
+					ChainIndex:     chainIndex,
+					ActivationTime: 0,
+					HistoryMinTime: 0,
+				}
 			}
-		}
-		staticDepSet, err := depset.NewStaticConfigDependencySet(depSetContents)
-		setup.Require.NoError(err)
+			staticDepSet, err := depset.NewStaticConfigDependencySet(depSetContents)
+			setup.Require.NoError(err)
 
-		sysCluster := shim.NewCluster(shim.ClusterConfig{
-			CommonConfig:  shim.CommonConfigFromSetup(setup),
-			ID:            clusterID,
-			DependencySet: staticDepSet,
-		})
-		setup.System.AddCluster(sysCluster)
+			sysCluster := shim.NewCluster(shim.ClusterConfig{
+				CommonConfig:  shim.CommonConfigFromSetup(setup),
+				ID:            *clusterID,
+				DependencySet: staticDepSet,
+			})
+			setup.System.AddCluster(sysCluster)
+		}
 
 		for _, l2ID := range l2IDs {
 			l2Out, ok := worldOutput.L2s[l2ID.ChainID.String()]
@@ -153,8 +200,13 @@ func WithInteropGen(l1ID stack.L1NetworkID, superchainID stack.SuperchainID,
 			orch.l2Nets.Set(l2ID, l2Net)
 
 			dep := &L2Deployment{
-				systemConfigProxyAddr:   l2Dep.SystemConfigProxy,
-				disputeGameFactoryProxy: l2Dep.DisputeGameFactoryProxy,
+				systemConfigProxyAddr:       l2Dep.SystemConfigProxy,
+				disputeGameFactoryProxy:     l2Dep.DisputeGameFactoryProxy,
+				optimismPortalProxy:         l2Dep.OptimismPortalProxy,
+				l1StandardBridgeProxy:       l2Dep.L1StandardBridgeProxy,
+				l1CrossDomainMessengerProxy: l2Dep.L1CrossDomainMessengerProxy,
+				addressManager:              l2Dep.AddressManager,
+				anchorStateRegistryProxy:    l2Dep.AnchorStateRegistryProxy,
 			}
 			sysL2Net := shim.NewL2Network(shim.L2NetworkConfig{
 				NetworkConfig: shim.NetworkConfig{
@@ -44,8 +44,9 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option
 
 		clLog := setup.Log.New("id", l1CLID)
 		bcn := fakebeacon.NewBeacon(clLog, e2eutils.NewBlobStore(), l1Net.genesis.Timestamp, blockTimeL1)
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			_ = bcn.Close()
+			orch.l1CLs.Delete(l1CLID)
 		})
 		setup.Require.NoError(bcn.Start("127.0.0.1:0"))
 		beaconApiAddr := bcn.BeaconAddr()
@@ -60,9 +61,10 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option
 			bcn)
 		setup.Require.NoError(err)
 		setup.Require.NoError(l1Geth.Node.Start())
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			clLog.Info("Closing L1 geth")
 			_ = l1Geth.Close()
+			orch.l1ELs.Delete(l1ELID)
 		})
 
 		l1ELNode := &L1ELNode{
@@ -85,7 +87,7 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option
 			shim.NewL1ELNode(shim.L1ELNodeConfig{
 				ID: l1ELID,
 				ELNodeConfig: shim.ELNodeConfig{
-					CommonConfig: shim.CommonConfigFromSetup(setup),
+					CommonConfig: serviceConfig(setup, "geth", l1ELNode.userRPC),
 					Client:       elClient,
 					ChainID:      l1ELID.ChainID,
 				},
@@ -95,7 +97,7 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option
 		beaconCl := client.NewBasicHTTPClient(bcn.BeaconAddr(), clLog)
 		sysL1Net.AddL1CLNode(
 			shim.NewL1CLNode(shim.L1CLNodeConfig{
-				CommonConfig: shim.CommonConfigFromSetup(setup),
+				CommonConfig: serviceConfig(setup, "fakebeacon", bcn.BeaconAddr()),
 				ID:           l1CLID,
 				Client:       beaconCl,
 			}),
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 type L2Batcher struct {
@@ -72,6 +73,10 @@ func WithBatcher(batcherID stack.L2BatcherID, l1ELID stack.L1ELNodeID, l2CLID st
 				Level:  log.LevelInfo,
 				Format: oplog.FormatText,
 			},
+			RPC: oprpc.CLIConfig{
+				EnableAdmin: true,
+			},
+			MetricsConfig:         metricsConfig(),
 			Stopped:               false,
 			BatchType:             derive.SpanBatchType,
 			MaxBlocksPerSpanBatch: 10,
@@ -84,12 +89,13 @@ func WithBatcher(batcherID stack.L2BatcherID, l1ELID stack.L1ELNodeID, l2CLID st
 			logger.New("service", "batcher"))
 		setup.Require.NoError(err)
 		setup.Require.NoError(batcher.Start(setup.Ctx))
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			ctx, cancel := context.WithCancel(setup.Ctx)
 			cancel() // force-quit
 			logger.Info("Closing batcher")
 			_ = batcher.Stop(ctx)
 			logger.Info("Closed batcher")
+			orch.batchers.Delete(batcherID)
 		})
 
 		b := &L2Batcher{
@@ -104,10 +110,18 @@ func WithBatcher(batcherID stack.L2BatcherID, l1ELID stack.L1ELNodeID, l2CLID st
 		rpcCl, err := client.NewRPC(setup.Ctx, setup.Log, b.rpc, client.WithLazyDial())
 		setup.Require.NoError(err)
 
+		driver := batcher.TestDriver()
+		lifecycle := &driverLifecycle{
+			start: driver.StartBatchSubmitting,
+			stop:  driver.StopBatchSubmittingIfRunning,
+		}
+		commonConfig := serviceConfig(setup, "op-batcher", b.rpc)
+		commonConfig.Registry = metricsRegistry(setup, batcher.Metrics)
 		bFrontend := shim.NewL2Batcher(shim.L2BatcherConfig{
-			CommonConfig: shim.CommonConfigFromSetup(setup),
+			CommonConfig: commonConfig,
 			ID:           batcherID,
 			Client:       rpcCl,
+			Lifecycle:    lifecycle.hooks(),
 		})
 		l2Chain.AddL2Batcher(bFrontend)
 	}
@@ -112,12 +112,13 @@ func WithL2CLNode(l2CLID stack.L2CLNodeID, isSequencer bool, l1CLID stack.L1CLNo
 			setup.Require.NoError(err, "op-node critical error")
 		})
 		setup.Require.NoError(err, "op-node failed to start")
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel() // force-quit
 			logger.Info("Closing op-node")
 			closeErr := opNode.Stop(ctx)
 			logger.Info("Closed op-node", "err", closeErr)
+			orch.l2CLs.Delete(l2CLID)
 		})
 
 		l2CLNode := &L2CLNode{
@@ -129,9 +130,15 @@ func WithL2CLNode(l2CLID stack.L2CLNodeID, isSequencer bool, l1CLID stack.L1CLNo
 		rollupClient, err := client.NewRPC(setup.Ctx, logger, l2CLNode.rpc, client.WithLazyDial())
 		setup.Require.NoError(err)
 
+		commonConfig := serviceConfig(setup, "op-node", l2CLNode.rpc)
+		commonConfig.Labels[stack.RoleLabel] = stack.RoleVerifier
+		if isSequencer {
+			commonConfig.Labels[stack.RoleLabel] = stack.RoleSequencer
+		}
 		sysL2CL := shim.NewL2CLNode(shim.L2CLNodeConfig{
-			CommonConfig: shim.CommonConfigFromSetup(setup),
+			CommonConfig: commonConfig,
 			ID:           l2CLID,
+			EL:           l2ELID,
 			Client:       rollupClient,
 		})
 		sysL2.AddL2CLNode(sysL2CL)
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
@@ -26,9 +27,9 @@ func WithL2ELNode(id stack.L2ELNodeID, supervisorID *stack.SupervisorID) stack.O
 
 		sysL2Net := setup.System.L2Network(l2ID).(stack.ExtensibleL2Network)
 
-		jwtPath, _ := orch.writeDefaultJWT()
+		jwtPath, jwtSecret := orch.writeDefaultJWT()
 
-		useInterop := sysL2Net.ChainConfig().InteropTime != nil
+		_, useInterop := sysL2Net.ForkActivationTime(rollup.Interop)
 
 		supervisorRPC := ""
 		if useInterop {
@@ -46,10 +47,11 @@ func WithL2ELNode(id stack.L2ELNodeID, supervisorID *stack.SupervisorID) stack.O
 			})
 		setup.Require.NoError(err)
 		setup.Require.NoError(l2Geth.Node.Start())
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			setup.Log.Info("Closing op-geth", "id", id)
 			closeErr := l2Geth.Close()
 			setup.Log.Info("Closed op-geth", "id", id, "err", closeErr)
+			orch.l2ELs.Delete(id)
 		})
 
 		rpcCl, err := client.NewRPC(setup.Ctx, setup.Log, l2Geth.UserRPC().RPC(), client.WithLazyDial())
@@ -63,11 +65,14 @@ func WithL2ELNode(id stack.L2ELNodeID, supervisorID *stack.SupervisorID) stack.O
 
 		sysL2EL := shim.NewL2ELNode(shim.L2ELNodeConfig{
 			ELNodeConfig: shim.ELNodeConfig{
-				CommonConfig: shim.CommonConfigFromSetup(setup),
+				CommonConfig: serviceConfig(setup, "op-geth", l2EL.userRPC),
 				Client:       rpcCl,
 				ChainID:      id.ChainID,
 			},
-			ID: id,
+			ID:           id,
+			AuthRPC:      l2EL.authRPC,
+			JWTSecret:    jwtSecret,
+			RollupConfig: sysL2Net.RollupConfig(),
 		})
 		sysL2Net.AddL2ELNode(sysL2EL)
 	}
@@ -2,6 +2,7 @@ package sysgo
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -11,11 +12,11 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/setuputils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	ps "github.com/ethereum-optimism/optimism/op-proposer/proposer"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
-	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -55,7 +56,7 @@ func WithProposer(proposerID stack.L2ProposerID, l1ELID stack.L1ELNodeID,
 				Level:  log.LvlInfo,
 				Format: oplog.FormatText,
 			},
-			MetricsConfig:                opmetrics.CLIConfig{},
+			MetricsConfig:                metricsConfig(),
 			PprofConfig:                  oppprof.CLIConfig{},
 			DGFAddress:                   disputeGameFactoryAddr.Hex(),
 			ProposalInterval:             6 * time.Second,
@@ -64,7 +65,7 @@ func WithProposer(proposerID stack.L2ProposerID, l1ELID stack.L1ELNodeID,
 			WaitNodeSync:                 false,
 		}
 
-		if l2Net.ChainConfig().InteropTime != nil {
+		if _, ok := l2Net.ForkActivationTime(rollup.Interop); ok {
 			setup.Require.NotNil(supervisorID, "need supervisor to connect to in interop")
 			supervisorNode, ok := orch.supervisors.Get(*supervisorID)
 			setup.Require.True(ok)
@@ -80,12 +81,13 @@ func WithProposer(proposerID stack.L2ProposerID, l1ELID stack.L1ELNodeID,
 		setup.Require.NoError(err)
 
 		setup.Require.NoError(proposer.Start(setup.Ctx))
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			ctx, cancel := context.WithCancel(setup.Ctx)
 			cancel() // force-quit
 			logger.Info("Closing proposer")
 			_ = proposer.Stop(ctx)
 			logger.Info("Closed proposer")
+			orch.proposers.Delete(proposerID)
 		})
 
 		p := &L2Proposer{
@@ -97,10 +99,29 @@ func WithProposer(proposerID stack.L2ProposerID, l1ELID stack.L1ELNodeID,
 		rpcCl, err := client.NewRPC(setup.Ctx, setup.Log, p.userRPC, client.WithLazyDial())
 		setup.Require.NoError(err)
 
+		l1Cl, err := client.NewRPC(setup.Ctx, setup.Log, l1EL.userRPC, client.WithLazyDial())
+		setup.Require.NoError(err)
+
+		lifecycle := &driverLifecycle{
+			start: proposer.Driver().StartL2OutputSubmitting,
+			stop: func(ctx context.Context) error {
+				if err := proposer.Driver().StopL2OutputSubmitting(); !errors.Is(err, ps.ErrProposerNotRunning) {
+					return err
+				}
+				return nil
+			},
+		}
+		commonConfig := serviceConfig(setup, "op-proposer", p.userRPC)
+		commonConfig.Registry = metricsRegistry(setup, proposer.Metrics)
 		bFrontend := shim.NewL2Proposer(shim.L2ProposerConfig{
-			CommonConfig: shim.CommonConfigFromSetup(setup),
-			ID:           proposerID,
-			Client:       rpcCl,
+			CommonConfig:       commonConfig,
+			ID:                 proposerID,
+			Client:             rpcCl,
+			L1Client:           l1Cl,
+			DisputeGameFactory: disputeGameFactoryAddr,
+			Address:            crypto.PubkeyToAddress(proposerSecret.PublicKey),
+			ProposalInterval:   proposerCLIConfig.ProposalInterval,
+			Lifecycle:          lifecycle.hooks(),
 		})
 		l2Net.AddL2Proposer(bFrontend)
 	}
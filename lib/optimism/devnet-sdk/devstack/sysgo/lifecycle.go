@@ -0,0 +1,47 @@
+package sysgo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+)
+
+// driverLifecycle controls the work loop of an in-process service, as the lifecycle of its component.
+// The RPC server of the service keeps running while the loop is stopped, so the component stays reachable.
+type driverLifecycle struct {
+	mu      sync.Mutex
+	stopped bool
+
+	start func() error
+	stop  func(ctx context.Context) error
+}
+
+func (d *driverLifecycle) hooks() shim.LifecycleHooks {
+	return shim.LifecycleHooks{
+		Start: func(ctx context.Context) error {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if !d.stopped {
+				return nil
+			}
+			if err := d.start(); err != nil {
+				return err
+			}
+			d.stopped = false
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if d.stopped {
+				return nil
+			}
+			if err := d.stop(ctx); err != nil {
+				return err
+			}
+			d.stopped = true
+			return nil
+		},
+	}
+}
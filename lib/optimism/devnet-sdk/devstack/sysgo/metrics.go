@@ -0,0 +1,25 @@
+package sysgo
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// metricsConfig enables the metrics of an in-process service, served on a random local port.
+// The service then records its metrics to a registry that is gathered by shim.SystemMetrics.
+func metricsConfig() opmetrics.CLIConfig {
+	return opmetrics.CLIConfig{
+		Enabled:    true,
+		ListenAddr: "127.0.0.1",
+		ListenPort: 0,
+	}
+}
+
+// metricsRegistry returns the registry that an in-process service records its metrics to.
+func metricsRegistry(setup *stack.Setup, metrics any) *prometheus.Registry {
+	recorder, ok := metrics.(opmetrics.RegistryMetricer)
+	setup.Require.True(ok, "metrics %T must expose a registry", metrics)
+	return recorder.Registry()
+}
@@ -4,12 +4,14 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
@@ -41,6 +43,8 @@ type Orchestrator struct {
 	jwtPathOnce sync.Once
 }
 
+var _ stack.TimeTraveler = (*Orchestrator)(nil)
+
 func NewOrchestrator(t stack.T, log log.Logger) *Orchestrator {
 	return &Orchestrator{t: t, log: log}
 }
@@ -49,6 +53,37 @@ func (o *Orchestrator) T() stack.T {
 	return o.t
 }
 
+// scopeT returns the T to register the cleanup of services started by the setup with:
+// the T of a forked setup, to stop the services when the fork closes,
+// or the T of the orchestrator otherwise, to keep the services running as long as the orchestrator.
+func (o *Orchestrator) scopeT(setup *stack.Setup) stack.T {
+	if t, ok := setup.T.(*stack.ScopedT); ok {
+		return t
+	}
+	return o.t
+}
+
+// TimeTravel advances the clock of the L1 chains, if the orchestrator was created with time travel enabled.
+func (o *Orchestrator) TimeTravel(d time.Duration) bool {
+	if o.timeTravelClock == nil {
+		return false
+	}
+	o.timeTravelClock.AdvanceTime(d)
+	return true
+}
+
+// WithTimeTravel enables time travel, see Orchestrator.TimeTravel.
+// The L1 nodes follow the clock they are created with, so time travel must be enabled before the L1 nodes are created.
+func WithTimeTravel() stack.Option {
+	return func(setup *stack.Setup) {
+		orch := setup.Orchestrator.(*Orchestrator)
+		setup.Require.Zero(orch.l1ELs.Len(), "time travel must be enabled before L1 nodes are created")
+		if orch.timeTravelClock == nil {
+			orch.timeTravelClock = clock.NewAdvancingClock(100 * time.Millisecond)
+		}
+	}
+}
+
 func (o *Orchestrator) Log() log.Logger {
 	return o.log
 }
@@ -63,3 +98,13 @@ func (o *Orchestrator) writeDefaultJWT() (jwtPath string, secret [32]byte) {
 	})
 	return o.jwtPath, o.jwtSecret
 }
+
+// serviceConfig is the config common to components that are backed by an in-process service:
+// the component is reached at the endpoint, to describe the system in a manifest,
+// and it is labeled with the name of the service, see stack.ServiceLabel.
+func serviceConfig(setup *stack.Setup, service string, endpoint string) shim.CommonConfig {
+	cfg := shim.CommonConfigFromSetup(setup)
+	cfg.Endpoint = endpoint
+	cfg.Labels = map[string]string{stack.ServiceLabel: service}
+	return cfg
+}
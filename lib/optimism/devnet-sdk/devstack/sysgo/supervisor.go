@@ -66,12 +66,13 @@ func WithSupervisor(supervisorID stack.SupervisorID, clusterID stack.ClusterID,
 		err = super.Start(context.Background())
 		setup.Require.NoError(err)
 
-		orch.t.Cleanup(func() {
+		orch.scopeT(setup).Cleanup(func() {
 			ctx, cancel := context.WithCancel(context.Background())
 			cancel() // force-quit
 			logger.Info("Closing supervisor")
 			closeErr := super.Stop(ctx)
 			logger.Info("Closed supervisor", "err", closeErr)
+			orch.supervisors.Delete(supervisorID)
 		})
 
 		supervisorNode := &Supervisor{
@@ -83,7 +84,7 @@ func WithSupervisor(supervisorID stack.SupervisorID, clusterID stack.ClusterID,
 		setup.Require.NoError(err)
 
 		setup.System.AddSupervisor(shim.NewSupervisor(shim.SupervisorConfig{
-			CommonConfig: shim.CommonConfigFromSetup(setup),
+			CommonConfig: serviceConfig(setup, "op-supervisor", supervisorNode.userRPC),
 			ID:           supervisorID,
 			Client:       supClient,
 		}))
@@ -1,6 +1,8 @@
 package sysgo
 
 import (
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -30,6 +32,9 @@ type DefaultInteropSystemIDs struct {
 
 	L2AProposer stack.L2ProposerID
 	L2BProposer stack.L2ProposerID
+
+	L2AFaucet stack.FaucetID
+	L2BFaucet stack.FaucetID
 }
 
 func DefaultInteropSystem(contractPaths ContractPaths) (DefaultInteropSystemIDs, stack.Option) {
@@ -53,6 +58,102 @@ func DefaultInteropSystem(contractPaths ContractPaths) (DefaultInteropSystemIDs,
 		L2BBatcher:  stack.L2BatcherID{Key: "main", ChainID: l2BID},
 		L2AProposer: stack.L2ProposerID{Key: "main", ChainID: l2AID},
 		L2BProposer: stack.L2ProposerID{Key: "main", ChainID: l2BID},
+		L2AFaucet:   stack.FaucetID{Key: "faucet", ChainID: l2AID},
+		L2BFaucet:   stack.FaucetID{Key: "faucet", ChainID: l2BID},
+	}
+
+	opt := stack.Option(func(setup *stack.Setup) {
+		setup.Log.Info("Setting up")
+	})
+
+	opt.Add(WithMnemonicKeys(devkeys.TestMnemonic))
+
+	// The options declare what they depend on, so they run in order of their dependencies.
+	opt.Add(stack.Ordered(
+		stack.OptionWithRequires(WithInteropGen(ids.L1, ids.Superchain, ids.Cluster,
+			[]stack.L2NetworkID{ids.L2A, ids.L2B}, contractPaths)).
+			WithProvides(stack.L1NetworkKind, stack.SuperchainKind, stack.ClusterKind, stack.L2NetworkKind),
+
+		stack.OptionWithRequires(WithL1Nodes(ids.L1EL, ids.L1CL), stack.L1NetworkKind).
+			WithProvides(stack.L1ELNodeKind, stack.L1CLNodeKind),
+
+		stack.OptionWithRequires(WithSupervisor(ids.Supervisor, ids.Cluster, ids.L1EL), stack.ClusterKind, stack.L1ELNodeKind).
+			WithProvides(stack.SupervisorKind),
+
+		// TODO(#15027): create L1 Faucet
+
+		stack.OptionWithRequires(WithL2ELNode(ids.L2AEL, &ids.Supervisor), stack.L2NetworkKind, stack.SupervisorKind).
+			WithProvides(stack.L2ELNodeKind),
+		stack.OptionWithRequires(WithL2ELNode(ids.L2BEL, &ids.Supervisor), stack.L2NetworkKind, stack.SupervisorKind).
+			WithProvides(stack.L2ELNodeKind),
+
+		stack.OptionWithRequires(WithFaucet(ids.L2AFaucet, ids.L2AEL), stack.L2ELNodeKind).
+			WithProvides(stack.FaucetKind),
+		stack.OptionWithRequires(WithFaucet(ids.L2BFaucet, ids.L2BEL), stack.L2ELNodeKind).
+			WithProvides(stack.FaucetKind),
+
+		stack.OptionWithRequires(WithL2CLNode(ids.L2ACL, true, ids.L1CL, ids.L1EL, ids.L2AEL),
+			stack.L1CLNodeKind, stack.L1ELNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2CLNodeKind),
+		stack.OptionWithRequires(WithL2CLNode(ids.L2BCL, true, ids.L1CL, ids.L1EL, ids.L2BEL),
+			stack.L1CLNodeKind, stack.L1ELNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2CLNodeKind),
+
+		stack.OptionWithRequires(WithBatcher(ids.L2ABatcher, ids.L1EL, ids.L2ACL, ids.L2AEL),
+			stack.L1ELNodeKind, stack.L2CLNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2BatcherKind),
+		stack.OptionWithRequires(WithBatcher(ids.L2BBatcher, ids.L1EL, ids.L2BCL, ids.L2BEL),
+			stack.L1ELNodeKind, stack.L2CLNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2BatcherKind),
+
+		stack.OptionWithRequires(WithManagedBySupervisor(ids.L2ACL, ids.Supervisor), stack.L2CLNodeKind, stack.SupervisorKind),
+		stack.OptionWithRequires(WithManagedBySupervisor(ids.L2BCL, ids.Supervisor), stack.L2CLNodeKind, stack.SupervisorKind),
+
+		stack.OptionWithRequires(WithProposer(ids.L2AProposer, ids.L1EL, nil, &ids.Supervisor), stack.L1ELNodeKind, stack.SupervisorKind).
+			WithProvides(stack.L2ProposerKind),
+		stack.OptionWithRequires(WithProposer(ids.L2BProposer, ids.L1EL, nil, &ids.Supervisor), stack.L1ELNodeKind, stack.SupervisorKind).
+			WithProvides(stack.L2ProposerKind),
+
+		// TODO(#15057): maybe L2 challenger
+	))
+
+	return ids, opt
+}
+
+// struct of the services of the minimal system, so we can access them later and do not have to guess their IDs.
+type DefaultMinimalSystemIDs struct {
+	L1   stack.L1NetworkID
+	L1EL stack.L1ELNodeID
+	L1CL stack.L1CLNodeID
+
+	Superchain stack.SuperchainID
+
+	L2   stack.L2NetworkID
+	L2CL stack.L2CLNodeID
+	L2EL stack.L2ELNodeID
+
+	L2Batcher  stack.L2BatcherID
+	L2Proposer stack.L2ProposerID
+
+	L2Faucet stack.FaucetID
+}
+
+// DefaultMinimalSystem creates a single L2 chain without interop:
+// one L1, and one L2 with a sequencer, batcher and proposer.
+func DefaultMinimalSystem(contractPaths ContractPaths) (DefaultMinimalSystemIDs, stack.Option) {
+	l1ID := eth.ChainIDFromUInt64(900)
+	l2ID := eth.ChainIDFromUInt64(901)
+	ids := DefaultMinimalSystemIDs{
+		L1:         stack.L1NetworkID{Key: "l1", ChainID: l1ID},
+		L1EL:       stack.L1ELNodeID{Key: "l1", ChainID: l1ID},
+		L1CL:       stack.L1CLNodeID{Key: "l1", ChainID: l1ID},
+		Superchain: "dev",
+		L2:         stack.L2NetworkID{Key: "l2", ChainID: l2ID},
+		L2CL:       stack.L2CLNodeID{Key: "sequencer", ChainID: l2ID},
+		L2EL:       stack.L2ELNodeID{Key: "sequencer", ChainID: l2ID},
+		L2Batcher:  stack.L2BatcherID{Key: "main", ChainID: l2ID},
+		L2Proposer: stack.L2ProposerID{Key: "main", ChainID: l2ID},
+		L2Faucet:   stack.FaucetID{Key: "faucet", ChainID: l2ID},
 	}
 
 	opt := stack.Option(func(setup *stack.Setup) {
@@ -61,33 +162,181 @@ func DefaultInteropSystem(contractPaths ContractPaths) (DefaultInteropSystemIDs,
 
 	opt.Add(WithMnemonicKeys(devkeys.TestMnemonic))
 
-	opt.Add(WithInteropGen(ids.L1, ids.Superchain, ids.Cluster,
-		[]stack.L2NetworkID{ids.L2A, ids.L2B}, contractPaths))
+	// The options declare what they depend on, so they run in order of their dependencies.
+	opt.Add(stack.Ordered(
+		stack.OptionWithRequires(WithChainGen(ids.L1, ids.Superchain, []stack.L2NetworkID{ids.L2}, contractPaths)).
+			WithProvides(stack.L1NetworkKind, stack.SuperchainKind, stack.L2NetworkKind),
+
+		stack.OptionWithRequires(WithL1Nodes(ids.L1EL, ids.L1CL), stack.L1NetworkKind).
+			WithProvides(stack.L1ELNodeKind, stack.L1CLNodeKind),
 
-	opt.Add(WithL1Nodes(ids.L1EL, ids.L1CL))
+		stack.OptionWithRequires(WithL2ELNode(ids.L2EL, nil), stack.L2NetworkKind).
+			WithProvides(stack.L2ELNodeKind),
 
-	opt.Add(WithSupervisor(ids.Supervisor, ids.Cluster, ids.L1EL))
+		stack.OptionWithRequires(WithFaucet(ids.L2Faucet, ids.L2EL), stack.L2ELNodeKind).
+			WithProvides(stack.FaucetKind),
 
-	// TODO(#15027): create L1 Faucet
+		stack.OptionWithRequires(WithL2CLNode(ids.L2CL, true, ids.L1CL, ids.L1EL, ids.L2EL),
+			stack.L1CLNodeKind, stack.L1ELNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2CLNodeKind),
 
-	opt.Add(WithL2ELNode(ids.L2AEL, &ids.Supervisor))
-	opt.Add(WithL2ELNode(ids.L2BEL, &ids.Supervisor))
+		stack.OptionWithRequires(WithBatcher(ids.L2Batcher, ids.L1EL, ids.L2CL, ids.L2EL),
+			stack.L1ELNodeKind, stack.L2CLNodeKind, stack.L2ELNodeKind).
+			WithProvides(stack.L2BatcherKind),
 
-	// TODO(#15027): create L2 faucet
+		stack.OptionWithRequires(WithProposer(ids.L2Proposer, ids.L1EL, &ids.L2CL, nil), stack.L1ELNodeKind, stack.L2CLNodeKind).
+			WithProvides(stack.L2ProposerKind),
+	))
 
-	opt.Add(WithL2CLNode(ids.L2ACL, true, ids.L1CL, ids.L1EL, ids.L2AEL))
-	opt.Add(WithL2CLNode(ids.L2BCL, true, ids.L1CL, ids.L1EL, ids.L2BEL))
+	return ids, opt
+}
+
+// MultiChainL2NodeIDs are the IDs of the services of a L2 node.
+type MultiChainL2NodeIDs struct {
+	CL stack.L2CLNodeID
+	EL stack.L2ELNodeID
+
+	// Supervisor is the supervisor that manages the node, nil if the system has no supervisors.
+	Supervisor *stack.SupervisorID
+}
+
+// MultiChainL2IDs are the IDs of the services of a L2 chain of a multi-chain system.
+type MultiChainL2IDs struct {
+	L2 stack.L2NetworkID
+
+	Sequencer MultiChainL2NodeIDs
+	Verifiers []MultiChainL2NodeIDs
+
+	L2Batcher  stack.L2BatcherID
+	L2Proposer stack.L2ProposerID
+}
+
+// struct of the services of a multi-chain system, so we can access them later and do not have to guess their IDs.
+type MultiChainSystemIDs struct {
+	L1   stack.L1NetworkID
+	L1EL stack.L1ELNodeID
+	L1CL stack.L1CLNodeID
+
+	Superchain stack.SuperchainID
+	// Cluster is empty if the system has no supervisors.
+	Cluster stack.ClusterID
+
+	Supervisors []stack.SupervisorID
+
+	L2s []MultiChainL2IDs
+}
 
-	opt.Add(WithBatcher(ids.L2ABatcher, ids.L1EL, ids.L2ACL, ids.L2AEL))
-	opt.Add(WithBatcher(ids.L2BBatcher, ids.L1EL, ids.L2BCL, ids.L2BEL))
+// MultiChainSystem creates a system of l2s L2 chains, each with a sequencer and verifiersPerChain verifier nodes.
+// The chains are interop chains of a cluster if there are supervisors, and stand-alone chains otherwise.
+// The nodes of each chain are managed by the supervisors in turn:
+// the sequencer by the first supervisor, the first verifier by the second supervisor, and so on.
+func MultiChainSystem(contractPaths ContractPaths, l2s int, verifiersPerChain int, supervisors int) (MultiChainSystemIDs, stack.Option) {
+	l1ID := eth.ChainIDFromUInt64(900)
+	ids := MultiChainSystemIDs{
+		L1:         stack.L1NetworkID{Key: "l1", ChainID: l1ID},
+		L1EL:       stack.L1ELNodeID{Key: "l1", ChainID: l1ID},
+		L1CL:       stack.L1CLNodeID{Key: "l1", ChainID: l1ID},
+		Superchain: "dev",
+	}
+	if supervisors > 0 {
+		ids.Cluster = "dev"
+	}
+	for i := 0; i < supervisors; i++ {
+		ids.Supervisors = append(ids.Supervisors, stack.SupervisorID(fmt.Sprintf("dev%d", i)))
+	}
+	nodeIDs := func(chainID eth.ChainID, key string, index int) MultiChainL2NodeIDs {
+		node := MultiChainL2NodeIDs{
+			CL: stack.L2CLNodeID{Key: key, ChainID: chainID},
+			EL: stack.L2ELNodeID{Key: key, ChainID: chainID},
+		}
+		if supervisors > 0 {
+			node.Supervisor = &ids.Supervisors[index%supervisors]
+		}
+		return node
+	}
+	var l2IDs []stack.L2NetworkID
+	for i := 0; i < l2s; i++ {
+		chainID := eth.ChainIDFromUInt64(901 + uint64(i))
+		l2 := MultiChainL2IDs{
+			L2:         stack.L2NetworkID{Key: fmt.Sprintf("l2%c", 'A'+i), ChainID: chainID},
+			Sequencer:  nodeIDs(chainID, "sequencer", 0),
+			L2Batcher:  stack.L2BatcherID{Key: "main", ChainID: chainID},
+			L2Proposer: stack.L2ProposerID{Key: "main", ChainID: chainID},
+		}
+		for j := 0; j < verifiersPerChain; j++ {
+			l2.Verifiers = append(l2.Verifiers, nodeIDs(chainID, fmt.Sprintf("verifier%d", j), j+1))
+		}
+		ids.L2s = append(ids.L2s, l2)
+		l2IDs = append(l2IDs, l2.L2)
+	}
+
+	opt := stack.Option(func(setup *stack.Setup) {
+		setup.Log.Info("Setting up", "l2s", l2s, "verifiersPerChain", verifiersPerChain, "supervisors", supervisors)
+		setup.Require.Positive(l2s, "need at least one L2 chain")
+		setup.Require.GreaterOrEqual(verifiersPerChain, 0, "cannot have a negative number of verifiers")
+		setup.Require.LessOrEqual(supervisors, 1+verifiersPerChain, "every supervisor needs a node of each chain to manage")
+	})
 
-	opt.Add(WithManagedBySupervisor(ids.L2ACL, ids.Supervisor))
-	opt.Add(WithManagedBySupervisor(ids.L2BCL, ids.Supervisor))
+	opt.Add(WithMnemonicKeys(devkeys.TestMnemonic))
+
+	var opts []stack.DependentOption
+	if supervisors > 0 {
+		opts = append(opts,
+			stack.OptionWithRequires(WithInteropGen(ids.L1, ids.Superchain, ids.Cluster, l2IDs, contractPaths)).
+				WithProvides(stack.L1NetworkKind, stack.SuperchainKind, stack.ClusterKind, stack.L2NetworkKind))
+	} else {
+		opts = append(opts,
+			stack.OptionWithRequires(WithChainGen(ids.L1, ids.Superchain, l2IDs, contractPaths)).
+				WithProvides(stack.L1NetworkKind, stack.SuperchainKind, stack.L2NetworkKind))
+	}
 
-	opt.Add(WithProposer(ids.L2AProposer, ids.L1EL, nil, &ids.Supervisor))
-	opt.Add(WithProposer(ids.L2BProposer, ids.L1EL, nil, &ids.Supervisor))
+	opts = append(opts,
+		stack.OptionWithRequires(WithL1Nodes(ids.L1EL, ids.L1CL), stack.L1NetworkKind).
+			WithProvides(stack.L1ELNodeKind, stack.L1CLNodeKind))
+
+	for _, supervisorID := range ids.Supervisors {
+		opts = append(opts,
+			stack.OptionWithRequires(WithSupervisor(supervisorID, ids.Cluster, ids.L1EL), stack.ClusterKind, stack.L1ELNodeKind).
+				WithProvides(stack.SupervisorKind))
+	}
+
+	// nodes that are managed by a supervisor depend on it
+	nodeRequires := []stack.Kind{stack.L2NetworkKind}
+	if supervisors > 0 {
+		nodeRequires = append(nodeRequires, stack.SupervisorKind)
+	}
+	for _, l2 := range ids.L2s {
+		nodes := append([]MultiChainL2NodeIDs{l2.Sequencer}, l2.Verifiers...)
+		for i, node := range nodes {
+			opts = append(opts,
+				stack.OptionWithRequires(WithL2ELNode(node.EL, node.Supervisor), nodeRequires...).
+					WithProvides(stack.L2ELNodeKind),
+				stack.OptionWithRequires(WithL2CLNode(node.CL, i == 0, ids.L1CL, ids.L1EL, node.EL),
+					stack.L1CLNodeKind, stack.L1ELNodeKind, stack.L2ELNodeKind).
+					WithProvides(stack.L2CLNodeKind))
+			if node.Supervisor != nil {
+				opts = append(opts,
+					stack.OptionWithRequires(WithManagedBySupervisor(node.CL, *node.Supervisor), stack.L2CLNodeKind, stack.SupervisorKind))
+			}
+		}
+
+		opts = append(opts,
+			stack.OptionWithRequires(WithBatcher(l2.L2Batcher, ids.L1EL, l2.Sequencer.CL, l2.Sequencer.EL),
+				stack.L1ELNodeKind, stack.L2CLNodeKind, stack.L2ELNodeKind).
+				WithProvides(stack.L2BatcherKind))
+		if l2.Sequencer.Supervisor != nil {
+			opts = append(opts,
+				stack.OptionWithRequires(WithProposer(l2.L2Proposer, ids.L1EL, nil, l2.Sequencer.Supervisor), stack.L1ELNodeKind, stack.SupervisorKind).
+					WithProvides(stack.L2ProposerKind))
+		} else {
+			opts = append(opts,
+				stack.OptionWithRequires(WithProposer(l2.L2Proposer, ids.L1EL, &l2.Sequencer.CL, nil), stack.L1ELNodeKind, stack.L2CLNodeKind).
+					WithProvides(stack.L2ProposerKind))
+		}
+	}
 
-	// TODO(#15057): maybe L2 challenger
+	// The options declare what they depend on, so they run in order of their dependencies.
+	opt.Add(stack.Ordered(opts...))
 
 	return ids, opt
 }
@@ -2,23 +2,29 @@ package sysgo
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/conformance"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/dsl"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestSystem(t *testing.T) {
-	ids, opt := DefaultInteropSystem(ContractPaths{
-		FoundryArtifacts: "../../../packages/contracts-bedrock/forge-artifacts",
-		SourceMap:        "../../../packages/contracts-bedrock",
-	})
+	ids, opt := DefaultInteropSystem(testContractPaths)
 	logger := testlog.Logger(t, log.LevelInfo)
 	orch := &Orchestrator{
 		t: t,
@@ -59,3 +65,213 @@ func TestSystem(t *testing.T) {
 	}
 	t.Fatalf("Expected to reach block %d on both chains", blocks)
 }
+
+func TestConformance(t *testing.T) {
+	_, opt := DefaultInteropSystem(testContractPaths)
+	conformance.Run(t, conformance.Backend{
+		NewOrchestrator: func(t stack.T, logger log.Logger) stack.Orchestrator {
+			return NewOrchestrator(t, logger)
+		},
+		Option: opt,
+	})
+}
+
+var testContractPaths = ContractPaths{
+	FoundryArtifacts: "../../../packages/contracts-bedrock/forge-artifacts",
+	SourceMap:        "../../../packages/contracts-bedrock",
+}
+
+// newMinimalSetup builds a minimal system, with its services running until the test cleans up.
+// The options are applied before the system is built.
+func newMinimalSetup(t *testing.T, opts ...stack.Option) (DefaultMinimalSystemIDs, *stack.Setup) {
+	ids, opt := DefaultMinimalSystem(testContractPaths)
+	logger := testlog.Logger(t, log.LevelInfo)
+	setup := &stack.Setup{
+		Ctx:          context.Background(),
+		Log:          logger,
+		T:            t,
+		Require:      require.New(t),
+		Orchestrator: NewOrchestrator(t, logger),
+	}
+	setup.System = shim.NewSystem(shim.SystemConfig{
+		CommonConfig: shim.CommonConfigFromSetup(setup),
+	})
+	for _, o := range opts {
+		o(setup)
+	}
+	opt(setup)
+	return ids, setup
+}
+
+func TestLifecycle(t *testing.T) {
+	ids, setup := newMinimalSetup(t)
+	l2 := setup.System.L2Network(ids.L2)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	batcher := l2.L2Batcher(ids.L2Batcher)
+	lifecycle, ok := batcher.(stack.Lifecycle)
+	require.True(t, ok, "batcher must support lifecycle")
+	require.NoError(t, lifecycle.Stop(ctx))
+	require.Error(t, batcher.StopBatcher(ctx), "batcher must be stopped")
+	require.NoError(t, lifecycle.Stop(ctx), "stopping a stopped batcher is a no-op")
+	require.NoError(t, lifecycle.Start(ctx))
+	require.Error(t, batcher.StartBatcher(ctx), "batcher must be running")
+	require.NoError(t, lifecycle.Start(ctx), "starting a running batcher is a no-op")
+	require.NoError(t, lifecycle.Healthy(ctx))
+
+	proposer, ok := l2.L2Proposer(ids.L2Proposer).(stack.Lifecycle)
+	require.True(t, ok, "proposer must support lifecycle")
+	require.NoError(t, proposer.Stop(ctx))
+	require.NoError(t, proposer.Start(ctx))
+	require.NoError(t, proposer.Healthy(ctx))
+}
+
+func TestSystemMetrics(t *testing.T) {
+	ids, setup := newMinimalSetup(t)
+	l2 := setup.System.L2Network(ids.L2)
+	for _, recorder := range []stack.MetricsRecorder{
+		l2.L2Batcher(ids.L2Batcher).(stack.MetricsRecorder),
+		l2.L2Proposer(ids.L2Proposer).(stack.MetricsRecorder),
+	} {
+		require.NotNil(t, recorder.MetricsRegistry(), "in-process services must record metrics")
+	}
+
+	srv, err := shim.ServeSystemMetrics(setup.System, "127.0.0.1", 0)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+	})
+	resp, err := http.Get("http://" + srv.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	require.NoError(t, err)
+
+	components := func(name string) []string {
+		family, ok := families[name]
+		require.True(t, ok, "metric %s must be served", name)
+		var out []string
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "component" {
+					out = append(out, label.GetValue())
+				}
+			}
+		}
+		return out
+	}
+	require.Equal(t, []string{ids.L2Batcher.String()}, components("op_batcher_default_up"))
+	require.Equal(t, []string{ids.L2Proposer.String()}, components("op_proposer_default_up"))
+}
+
+func TestManifest(t *testing.T) {
+	ids, setup := newMinimalSetup(t)
+	data, err := json.Marshal(setup.System)
+	require.NoError(t, err)
+	var manifest stack.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+
+	loaded := shim.NewSystemFromManifest(shim.CommonConfig{Log: setup.Log, T: t}, &manifest)
+	require.Equal(t, setup.System.(stack.ManifestSource).Manifest(), loaded.(stack.ManifestSource).Manifest(),
+		"loaded system must describe the same system")
+
+	// the loaded system reaches the services of the original system
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	status, err := loaded.L2Network(ids.L2).L2CLNode(ids.L2CL).RollupAPI().SyncStatus(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, status.UnsafeL2.Hash)
+	_, err = loaded.L2Network(ids.L2).L2ELNode(ids.L2EL).EthClient().InfoByLabel(ctx, "latest")
+	require.NoError(t, err)
+	require.NoError(t, loaded.L2Network(ids.L2).L2Batcher(ids.L2Batcher).(stack.Lifecycle).Healthy(ctx))
+}
+
+func TestBeaconHeader(t *testing.T) {
+	ids, setup := newMinimalSetup(t)
+	beacon := setup.System.L1Network(ids.L1).L1CLNode(ids.L1CL).Beacon()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	genesis, err := beacon.GenesisTime(ctx)
+	require.NoError(t, err)
+	slotTime, err := beacon.SecondsPerSlot(ctx)
+	require.NoError(t, err)
+	slot, err := beacon.SlotAt(ctx, genesis+3*slotTime)
+	require.NoError(t, err)
+	header, err := beacon.Header(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, slot, uint64(header.Message.Slot))
+}
+
+func TestLabels(t *testing.T) {
+	ids, setup := newMinimalSetup(t)
+	l2 := setup.System.L2Network(ids.L2)
+	require.Equal(t, []stack.L2CLNodeID{ids.L2CL}, l2.L2CLNodesWithLabel(stack.RoleLabel, stack.RoleSequencer))
+	require.Empty(t, l2.L2CLNodesWithLabel(stack.RoleLabel, stack.RoleVerifier))
+	require.Equal(t, []stack.L2BatcherID{ids.L2Batcher}, l2.L2BatchersWithLabel(stack.ServiceLabel, "op-batcher"))
+	require.Equal(t, []stack.L2ELNodeID{ids.L2EL}, l2.L2ELNodesWithLabel(stack.ServiceLabel, "op-geth"))
+}
+
+func TestWithdrawalTimeTravel(t *testing.T) {
+	ids, setup := newMinimalSetup(t, WithTimeTravel())
+	orch := setup.Orchestrator.(*Orchestrator)
+	require.NotNil(t, orch.timeTravelClock, "time travel must be enabled")
+
+	// the L1 has no faucet, so the withdrawal goes to a prefunded L1 user
+	l1Net := setup.System.L1Network(ids.L1).(stack.ExtensibleL1Network)
+	priv, err := orch.keys.Secret(devkeys.ChainUserKeys(ids.L1.ChainID.ToBig())(0))
+	require.NoError(t, err)
+	l1UserID := stack.UserID{Key: "withdrawer", ChainID: ids.L1.ChainID}
+	l1Net.AddUser(shim.NewUser(shim.UserConfig{
+		CommonConfig: shim.CommonConfigFromSetup(setup),
+		ID:           l1UserID,
+		Priv:         priv,
+		EL:           l1Net.L1ELNode(ids.L1EL),
+	}))
+
+	sys := dsl.Hydrate(setup)
+	l2 := sys.L2(ids.L2)
+	withdrawal := l2.NewWithdrawal(l2.NewUser(), sys.L1().User(l1UserID))
+	// the waiting periods of the withdrawal are passed with time travel
+	withdrawal.Complete(big.NewInt(params.GWei))
+}
+
+func TestMultiChainSystem(t *testing.T) {
+	for _, supervisors := range []int{0, 2} {
+		t.Run(fmt.Sprintf("supervisors=%d", supervisors), func(t *testing.T) {
+			ids, opt := MultiChainSystem(testContractPaths, 2, 1, supervisors)
+			logger := testlog.Logger(t, log.LevelInfo)
+			setup := &stack.Setup{
+				Ctx:          context.Background(),
+				Log:          logger,
+				T:            t,
+				Require:      require.New(t),
+				Orchestrator: NewOrchestrator(t, logger),
+			}
+			setup.System = shim.NewSystem(shim.SystemConfig{
+				CommonConfig: shim.CommonConfigFromSetup(setup),
+			})
+			opt(setup)
+
+			require.Len(t, setup.System.L2Networks(), 2)
+			require.Len(t, setup.System.Supervisors(), supervisors)
+			for _, l2 := range ids.L2s {
+				network := setup.System.L2Network(l2.L2)
+				require.Len(t, network.L2CLNodes(), 2, "chain must have a sequencer and a verifier")
+				require.Len(t, network.L2ELNodes(), 2)
+				require.NotNil(t, network.L2Batcher(l2.L2Batcher))
+				require.NotNil(t, network.L2Proposer(l2.L2Proposer))
+				if supervisors > 0 {
+					require.NotEqual(t, *l2.Sequencer.Supervisor, *l2.Verifiers[0].Supervisor,
+						"nodes of a chain must be spread over the supervisors")
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			seq := setup.System.L2Network(ids.L2s[0].L2).L2CLNode(ids.L2s[0].Sequencer.CL)
+			require.GreaterOrEqual(t, seq.WaitForUnsafeBlock(ctx, 3).Number, uint64(3), "sequencer must produce blocks")
+		})
+	}
+}
@@ -10,8 +10,13 @@ const (
 	ProtocolVersionsAddressName = "protocolVersionsProxy"
 	SuperchainConfigAddressName = "superchainConfigProxy"
 
-	SystemConfigAddressName = "systemConfigProxy"
-	DisputeGameFactoryName  = "disputeGameFactoryProxy"
+	SystemConfigAddressName           = "systemConfigProxy"
+	DisputeGameFactoryName            = "disputeGameFactoryProxy"
+	OptimismPortalAddressName         = "optimismPortalProxy"
+	L1StandardBridgeAddressName       = "l1StandardBridgeProxy"
+	L1CrossDomainMessengerAddressName = "l1CrossDomainMessengerProxy"
+	AddressManagerAddressName         = "addressManager"
+	AnchorStateRegistryAddressName    = "anchorStateRegistryProxy"
 )
 
 type l1AddressBook struct {
@@ -44,19 +49,30 @@ func (a *l1AddressBook) SuperchainConfigAddr() common.Address {
 var _ stack.SuperchainDeployment = (*l1AddressBook)(nil)
 
 type l2AddressBook struct {
-	systemConfig       common.Address
-	disputeGameFactory common.Address
+	systemConfig           common.Address
+	disputeGameFactory     common.Address
+	optimismPortal         common.Address
+	l1StandardBridge       common.Address
+	l1CrossDomainMessenger common.Address
+	addressManager         common.Address
+	anchorStateRegistry    common.Address
 }
 
 func newL2AddressBook(setup *stack.Setup, l1Addresses descriptors.AddressMap) *l2AddressBook {
-	systemConfig, ok := l1Addresses[SystemConfigAddressName]
-	setup.Require.True(ok)
-	disputeGameFactory, ok := l1Addresses[DisputeGameFactoryName]
-	setup.Require.True(ok)
+	lookup := func(name string) common.Address {
+		addr, ok := l1Addresses[name]
+		setup.Require.True(ok, "missing L1 address %q", name)
+		return addr
+	}
 
 	return &l2AddressBook{
-		systemConfig:       systemConfig,
-		disputeGameFactory: disputeGameFactory,
+		systemConfig:           lookup(SystemConfigAddressName),
+		disputeGameFactory:     lookup(DisputeGameFactoryName),
+		optimismPortal:         lookup(OptimismPortalAddressName),
+		l1StandardBridge:       lookup(L1StandardBridgeAddressName),
+		l1CrossDomainMessenger: lookup(L1CrossDomainMessengerAddressName),
+		addressManager:         lookup(AddressManagerAddressName),
+		anchorStateRegistry:    lookup(AnchorStateRegistryAddressName),
 	}
 }
 
@@ -68,4 +84,24 @@ func (a *l2AddressBook) DisputeGameFactoryProxyAddr() common.Address {
 	return a.disputeGameFactory
 }
 
+func (a *l2AddressBook) OptimismPortalProxyAddr() common.Address {
+	return a.optimismPortal
+}
+
+func (a *l2AddressBook) L1StandardBridgeProxyAddr() common.Address {
+	return a.l1StandardBridge
+}
+
+func (a *l2AddressBook) L1CrossDomainMessengerProxyAddr() common.Address {
+	return a.l1CrossDomainMessenger
+}
+
+func (a *l2AddressBook) AddressManagerAddr() common.Address {
+	return a.addressManager
+}
+
+func (a *l2AddressBook) AnchorStateRegistryProxyAddr() common.Address {
+	return a.anchorStateRegistry
+}
+
 var _ stack.L2Deployment = (*l2AddressBook)(nil)
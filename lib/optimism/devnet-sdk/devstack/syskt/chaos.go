@@ -0,0 +1,167 @@
+package syskt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/starlark_run_config"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// chaosState tracks the faults that were injected into the devnet.
+type chaosState struct {
+	mu sync.Mutex
+
+	partitions [][2]string
+	paused     []string
+}
+
+// Partition cuts the network traffic between two Kurtosis services, in both directions.
+// Both service containers need iptables, and the capability to change their network rules.
+// The partition lasts until Heal is called.
+func (o *Orchestrator) Partition(ctx context.Context, a, b string) error {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	if slices.Contains(o.chaos.partitions, [2]string{a, b}) || slices.Contains(o.chaos.partitions, [2]string{b, a}) {
+		return nil
+	}
+	if err := o.dropTraffic(ctx, a, b, "-A"); err != nil {
+		return err
+	}
+	if err := o.dropTraffic(ctx, b, a, "-A"); err != nil {
+		// undo the half-applied partition
+		return errors.Join(err, o.dropTraffic(ctx, a, b, "-D"))
+	}
+	o.chaos.partitions = append(o.chaos.partitions, [2]string{a, b})
+	o.log.Warn("Partitioned services", "a", a, "b", b)
+	return nil
+}
+
+// Pause stops the Kurtosis service, keeping its data, until Unpause or Heal is called.
+func (o *Orchestrator) Pause(ctx context.Context, service string) error {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	if slices.Contains(o.chaos.paused, service) {
+		return nil
+	}
+	if err := o.runPlanCommand(ctx, "stop_service", service); err != nil {
+		return err
+	}
+	o.chaos.paused = append(o.chaos.paused, service)
+	o.log.Warn("Paused service", "service", service)
+	return nil
+}
+
+// Unpause restarts a Kurtosis service that was stopped with Pause.
+func (o *Orchestrator) Unpause(ctx context.Context, service string) error {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+	return o.unpause(ctx, service)
+}
+
+func (o *Orchestrator) unpause(ctx context.Context, service string) error {
+	idx := slices.Index(o.chaos.paused, service)
+	if idx < 0 {
+		return nil
+	}
+	if err := o.runPlanCommand(ctx, "start_service", service); err != nil {
+		return err
+	}
+	o.chaos.paused = slices.Delete(o.chaos.paused, idx, idx+1)
+	o.log.Info("Unpaused service", "service", service)
+	return nil
+}
+
+// Heal undoes all partitions and restarts all paused services.
+// Faults that cannot be undone are kept, so Heal can be retried.
+func (o *Orchestrator) Heal(ctx context.Context) error {
+	o.chaos.mu.Lock()
+	defer o.chaos.mu.Unlock()
+
+	var result error
+	remaining := o.chaos.partitions[:0]
+	for _, p := range o.chaos.partitions {
+		err := errors.Join(o.dropTraffic(ctx, p[0], p[1], "-D"), o.dropTraffic(ctx, p[1], p[0], "-D"))
+		if err != nil {
+			result = errors.Join(result, err)
+			remaining = append(remaining, p)
+			continue
+		}
+		o.log.Info("Healed partition", "a", p[0], "b", p[1])
+	}
+	o.chaos.partitions = remaining
+
+	for _, service := range slices.Clone(o.chaos.paused) {
+		result = errors.Join(result, o.unpause(ctx, service))
+	}
+	return result
+}
+
+// dropTraffic adds (-A) or deletes (-D) the iptables rules in the "from" service
+// that drop all traffic to and from the "to" service.
+func (o *Orchestrator) dropTraffic(ctx context.Context, from, to string, action string) error {
+	_, enclaveCtx, err := o.enclave(ctx)
+	if err != nil {
+		return err
+	}
+	fromCtx, err := enclaveCtx.GetServiceContext(from)
+	if err != nil {
+		return fmt.Errorf("failed to find service %q: %w", from, err)
+	}
+	toCtx, err := enclaveCtx.GetServiceContext(to)
+	if err != nil {
+		return fmt.Errorf("failed to find service %q: %w", to, err)
+	}
+	ip := toCtx.GetPrivateIPAddress()
+	for _, rule := range [][]string{
+		{"iptables", action, "INPUT", "-s", ip, "-j", "DROP"},
+		{"iptables", action, "OUTPUT", "-d", ip, "-j", "DROP"},
+	} {
+		code, out, err := fromCtx.ExecCommand(rule)
+		if err != nil {
+			return fmt.Errorf("failed to run %v in service %q: %w", rule, from, err)
+		}
+		if code != 0 {
+			return fmt.Errorf("command %v in service %q exited with code %d: %s", rule, from, code, out)
+		}
+	}
+	return nil
+}
+
+// runPlanCommand runs a single Kurtosis plan instruction against a service of the enclave.
+func (o *Orchestrator) runPlanCommand(ctx context.Context, instruction string, service string) error {
+	_, enclaveCtx, err := o.enclave(ctx)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf("def run(plan):\n    plan.%s(name=%q)\n", instruction, service)
+	if _, err := enclaveCtx.RunStarlarkScriptBlocking(ctx, script, starlark_run_config.NewRunStarlarkConfig()); err != nil {
+		return fmt.Errorf("failed to %s %q: %w", instruction, service, err)
+	}
+	return nil
+}
+
+// serviceLifecycle controls a Kurtosis service as the lifecycle of a component:
+// stopping the component pauses the service, and starting it unpauses the service.
+// Services that the descriptor does not name cannot be controlled, and get no hooks.
+func serviceLifecycle(setup *stack.Setup, services descriptors.ServiceMap, svc string) shim.LifecycleHooks {
+	service, ok := services[svc]
+	if !ok || service.Name == "" {
+		return shim.LifecycleHooks{}
+	}
+	orchestrator := getOrchestrator(setup)
+	return shim.LifecycleHooks{
+		Start: func(ctx context.Context) error {
+			return orchestrator.Unpause(ctx, service.Name)
+		},
+		Stop: func(ctx context.Context) error {
+			return orchestrator.Pause(ctx, service.Name)
+		},
+	}
+}
@@ -1,13 +1,19 @@
 package syskt
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -15,9 +21,22 @@ const (
 	ELServiceName = "el"
 	CLServiceName = "cl"
 
-	HTTPProtocol    = "http"
-	RPCProtocol     = "rpc"
-	MetricsProtocol = "metrics"
+	ConductorServiceName  = "conductor"
+	SupervisorServiceName = "supervisor"
+
+	// ProposerWalletName is the L1 wallet of an L2 chain that its proposer creates dispute games from.
+	ProposerWalletName = "proposer"
+	// ChallengerWalletName is the L1 wallet of an L2 chain that its challenger makes claims from.
+	ChallengerWalletName = "challenger"
+
+	HTTPProtocol  = "http"
+	HTTPSProtocol = "https"
+	WSProtocol    = "ws"
+	WSSProtocol   = "wss"
+	RPCProtocol   = "rpc"
+	// EngineRPCProtocol is the authenticated engine API of an EL node.
+	EngineRPCProtocol = "engine-rpc"
+	MetricsProtocol   = "metrics"
 
 	FeatureInterop = "interop"
 )
@@ -28,6 +47,35 @@ func getOrchestrator(setup *stack.Setup) *Orchestrator {
 	return o
 }
 
+// withEndpoint returns a copy of the common config, for a component that is reached at the given endpoint.
+func withEndpoint(cfg shim.CommonConfig, endpoint string) shim.CommonConfig {
+	cfg.Endpoint = endpoint
+	return cfg
+}
+
+// serviceConfig returns a copy of the common config, for a component that is backed by the given service:
+// the component is reached at the endpoint, if any, its metrics are gathered from the service,
+// and it is labeled with the name of the service, see stack.ServiceLabel.
+func serviceConfig(setup *stack.Setup, cfg shim.CommonConfig, svc string, services descriptors.ServiceMap, endpoint string) shim.CommonConfig {
+	cfg = withMetrics(setup, withEndpoint(cfg, endpoint), svc, services)
+	if name := services[svc].Name; name != "" {
+		cfg.Labels = map[string]string{stack.ServiceLabel: name}
+	}
+	return cfg
+}
+
+// withMetrics sets the metrics endpoint of the service, if it has one,
+// and gathers the metrics of the component from it, see shim.SystemMetrics.
+func withMetrics(setup *stack.Setup, cfg shim.CommonConfig, svc string, services descriptors.ServiceMap) shim.CommonConfig {
+	endpoint, err := findProtocolService(setup, svc, MetricsProtocol, services)
+	if err != nil {
+		return cfg
+	}
+	cfg.MetricsEndpoint = endpoint
+	cfg.Registry = shim.NewScrapingRegistry(setup.Log, client.NewBasicHTTPClient(endpoint, setup.Log))
+	return cfg
+}
+
 func rpcClient(setup *stack.Setup, endpoint string) client.RPC {
 	orchestrator := getOrchestrator(setup)
 
@@ -51,7 +99,7 @@ func findProtocolService(setup *stack.Setup, svc string, protocol string, servic
 					if orchestrator.usePrivatePorts {
 						port = endpoint.PrivatePort
 					}
-					return fmt.Sprintf("http://%s:%d", endpoint.Host, port), nil
+					return endpointURL(protocolScheme(protocol, endpoint), endpoint.Host, port), nil
 				}
 			}
 		}
@@ -59,7 +107,127 @@ func findProtocolService(setup *stack.Setup, svc string, protocol string, servic
 	return "", fmt.Errorf("%s not found", svc)
 }
 
+// findL2 looks up the descriptor chain of the L2 by name,
+// and checks that it is the same chain as the L2 ID refers to.
+func findL2(setup *stack.Setup, id stack.L2NetworkID) *descriptors.L2Chain {
+	env := getOrchestrator(setup).env
+	for _, l2 := range env.L2 {
+		if l2.Name == id.Key {
+			setup.Require.Equal(id.ChainID, eth.ChainIDFromBig(l2.Config.ChainID),
+				"descriptor chain %s must match chain ID of %s", l2.Name, id)
+			return l2
+		}
+	}
+	setup.Require.Failf("unknown L2", "no chain named %q in descriptor for %s", id.Key, id)
+	return nil
+}
+
+// protocolScheme determines the URL scheme of an endpoint.
+// An explicit scheme in the descriptor takes precedence,
+// otherwise websocket and TLS protocols map onto their own scheme, and everything else is served over plain http.
+func protocolScheme(protocol string, endpoint descriptors.PortInfo) string {
+	if endpoint.Scheme != "" {
+		return endpoint.Scheme
+	}
+	switch protocol {
+	case HTTPSProtocol, WSProtocol, WSSProtocol:
+		return protocol
+	default:
+		return "http"
+	}
+}
+
+// endpointURL formats the endpoint as URL.
+// The port is omitted when unknown, e.g. for hosted endpoints behind a proxy on the default port of the scheme.
+func endpointURL(scheme string, host string, port int) string {
+	if port == 0 {
+		return fmt.Sprintf("%s://%s", scheme, host)
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
+}
+
+// awaitReady blocks until the probe succeeds,
+// retrying until the readiness timeout of the orchestrator expires.
+func awaitReady(setup *stack.Setup, name string, probe func(ctx context.Context) error) {
+	orchestrator := getOrchestrator(setup)
+	if orchestrator.readinessTimeout == 0 {
+		return
+	}
+	interval := orchestrator.readinessInterval
+	if interval <= 0 {
+		interval = defaultReadinessInterval
+	}
+	ctx, cancel := context.WithTimeout(setup.Ctx, orchestrator.readinessTimeout)
+	defer cancel()
+	attempts := int(orchestrator.readinessTimeout/interval) + 1
+	err := retry.Do0(ctx, attempts, retry.Fixed(interval), func() error {
+		probeCtx, probeCancel := context.WithTimeout(ctx, interval)
+		defer probeCancel()
+		if err := probe(probeCtx); err != nil {
+			setup.Log.Debug("Service not ready yet", "service", name, "err", err)
+			return err
+		}
+		return nil
+	})
+	setup.Require.NoError(err, "%s did not become ready within %s", name, orchestrator.readinessTimeout)
+}
+
+// rpcProbe checks that an RPC server answers requests.
+// Every geth-style RPC server serves the rpc_modules method.
+func rpcProbe(cl client.RPC) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var modules map[string]string
+		return cl.CallContext(ctx, &modules, "rpc_modules")
+	}
+}
+
+// elProbe checks that an execution-layer RPC answers, and serves the expected chain.
+func elProbe(cl client.RPC, chainID eth.ChainID) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var id hexutil.Big
+		if err := cl.CallContext(ctx, &id, "eth_chainId"); err != nil {
+			return err
+		}
+		if got := eth.ChainIDFromBig(id.ToInt()); got != chainID {
+			return fmt.Errorf("expected chain %s, but node serves chain %s", chainID, got)
+		}
+		return nil
+	}
+}
+
+// deploymentProbe checks that contract code is deployed at all of the addresses.
+func deploymentProbe(cl client.RPC, addresses descriptors.AddressMap) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for name, addr := range addresses {
+			var code hexutil.Bytes
+			if err := cl.CallContext(ctx, &code, "eth_getCode", addr, "latest"); err != nil {
+				return err
+			}
+			if len(code) == 0 {
+				return fmt.Errorf("contract %s is not deployed at %s yet", name, addr)
+			}
+		}
+		return nil
+	}
+}
+
+// beaconProbe checks that a beacon API answers requests.
+func beaconProbe(cl client.HTTP) func(ctx context.Context) error {
+	beacon := sources.NewBeaconHTTPClient(cl)
+	return func(ctx context.Context) error {
+		_, err := beacon.NodeVersion(ctx)
+		return err
+	}
+}
+
 func decodePrivateKey(key string) (*ecdsa.PrivateKey, error) {
 	b := common.FromHex(key)
 	return crypto.ToECDSA(b)
 }
+
+// decodeJWTSecret decodes the hex-encoded JWT secret of a descriptor.
+func decodeJWTSecret(setup *stack.Setup, secret string) [32]byte {
+	b := common.FromHex(secret)
+	setup.Require.Len(b, 32, "JWT secret must be 32 bytes")
+	return [32]byte(b)
+}
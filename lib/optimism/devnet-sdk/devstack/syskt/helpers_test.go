@@ -0,0 +1,119 @@
+package syskt
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFindL2(t *testing.T) {
+	l2Chain := func(name string, chainID uint64) *descriptors.L2Chain {
+		return &descriptors.L2Chain{Chain: descriptors.Chain{
+			Name:   name,
+			Config: &params.ChainConfig{ChainID: new(big.Int).SetUint64(chainID)},
+		}}
+	}
+	env := &descriptors.DevnetEnvironment{
+		Name: "devnet",
+		// Listed out of chain ID order, to check that the lookup does not depend on the position.
+		L2: []*descriptors.L2Chain{
+			l2Chain("op-kurtosis-2", 2152),
+			l2Chain("op-kurtosis-1", 2151),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		id       stack.L2NetworkID
+		expected string
+		err      string
+	}{
+		{
+			name:     "first descriptor",
+			id:       stack.L2NetworkID{Key: "op-kurtosis-2", ChainID: eth.ChainIDFromUInt64(2152)},
+			expected: "op-kurtosis-2",
+		},
+		{
+			name:     "reordered descriptor",
+			id:       stack.L2NetworkID{Key: "op-kurtosis-1", ChainID: eth.ChainIDFromUInt64(2151)},
+			expected: "op-kurtosis-1",
+		},
+		{
+			name: "unknown name",
+			id:   stack.L2NetworkID{Key: "op-kurtosis-3", ChainID: eth.ChainIDFromUInt64(2153)},
+			err:  `no chain named "op-kurtosis-3"`,
+		},
+		{
+			name: "chain ID mismatch",
+			id:   stack.L2NetworkID{Key: "op-kurtosis-1", ChainID: eth.ChainIDFromUInt64(2152)},
+			err:  "descriptor chain op-kurtosis-1 must match chain ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LevelInfo)
+			testT := stack.NewCollectingT(t.Name(), logger)
+			t.Cleanup(testT.RunCleanup)
+			setup := &stack.Setup{
+				Ctx:          context.Background(),
+				Log:          logger,
+				T:            testT,
+				Require:      require.New(testT),
+				Orchestrator: &Orchestrator{env: env},
+			}
+
+			var found *descriptors.L2Chain
+			ok := testT.Run(func() {
+				found = findL2(setup, tt.id)
+			})
+			if tt.err != "" {
+				require.False(t, ok)
+				require.ErrorContains(t, errors.Join(testT.Errors()...), tt.err)
+				return
+			}
+			require.True(t, ok, "unexpected errors: %v", testT.Errors())
+			require.Equal(t, tt.expected, found.Name)
+		})
+	}
+}
+
+func TestProtocolScheme(t *testing.T) {
+	tests := []struct {
+		protocol string
+		scheme   string
+		expected string
+	}{
+		{protocol: RPCProtocol, expected: "http"},
+		{protocol: HTTPProtocol, expected: "http"},
+		{protocol: HTTPSProtocol, expected: "https"},
+		{protocol: WSProtocol, expected: "ws"},
+		{protocol: WSSProtocol, expected: "wss"},
+		{protocol: EngineRPCProtocol, expected: "http"},
+		{protocol: MetricsProtocol, expected: "http"},
+		{protocol: RPCProtocol, scheme: "https", expected: "https"},
+		{protocol: WSProtocol, scheme: "wss", expected: "wss"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.protocol+"/"+tt.scheme, func(t *testing.T) {
+			endpoint := descriptors.PortInfo{Host: "localhost", Port: 8545, Scheme: tt.scheme}
+			require.Equal(t, tt.expected, protocolScheme(tt.protocol, endpoint))
+		})
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	require.Equal(t, "http://localhost:8545", endpointURL("http", "localhost", 8545))
+	require.Equal(t, "https://rpc.example.com", endpointURL("https", "rpc.example.com", 0))
+}
@@ -28,6 +28,8 @@ func collectSystemExtIDs(env *descriptors.DevnetEnvironment) DefaultSystemExtIDs
 			nodes[i] = DefaultSystemExtL2NodeIDs{
 				EL: stack.L2ELNodeID{Key: fmt.Sprintf("el-%s-%d", l2.Name, i), ChainID: l2ID},
 				CL: stack.L2CLNodeID{Key: fmt.Sprintf("cl-%s-%d", l2.Name, i), ChainID: l2ID},
+
+				Conductor: stack.L2ConductorID{Key: fmt.Sprintf("conductor-%s-%d", l2.Name, i), ChainID: l2ID},
 			}
 		}
 
@@ -38,6 +40,8 @@ func collectSystemExtIDs(env *descriptors.DevnetEnvironment) DefaultSystemExtIDs
 			L2Batcher:    stack.L2BatcherID{Key: fmt.Sprintf("batcher-%s", l2.Name), ChainID: l2ID},
 			L2Proposer:   stack.L2ProposerID{Key: fmt.Sprintf("proposer-%s", l2.Name), ChainID: l2ID},
 			L2Challenger: stack.L2ChallengerID{Key: fmt.Sprintf("challenger-%s", l2.Name), ChainID: l2ID},
+
+			Faucet: stack.FaucetID{Key: fmt.Sprintf("faucet-%s", l2.Name), ChainID: l2ID},
 		}
 	}
 
@@ -53,7 +57,23 @@ func collectSystemExtIDs(env *descriptors.DevnetEnvironment) DefaultSystemExtIDs
 	}
 
 	if isInterop(env) {
-		ids.Supervisor = stack.SupervisorID(env.Name)
+		groups := supervisorGroups(env)
+		for i, group := range groups {
+			// A single supervisor is named after the devnet, to keep IDs stable for the common topology.
+			id := stack.SupervisorID(env.Name)
+			if len(groups) > 1 {
+				id = stack.SupervisorID(fmt.Sprintf("%s-%d", env.Name, i))
+			}
+			managed := make([]stack.L2NetworkID, len(group.l2s))
+			for i, idx := range group.l2s {
+				ids.L2s[idx].Supervisor = id
+				managed[i] = ids.L2s[idx].L2
+			}
+			ids.Supervisors = append(ids.Supervisors, DefaultSystemExtSupervisorIDs{ID: id, L2s: managed})
+		}
+		if len(ids.Supervisors) > 0 {
+			ids.Supervisor = ids.Supervisors[0].ID
+		}
 	}
 
 	return ids
@@ -0,0 +1,53 @@
+package syskt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/enclaves"
+	"github.com/kurtosis-tech/kurtosis/api/golang/engine/lib/kurtosis_context"
+)
+
+// kurtosisEnclave lazily connects to the local Kurtosis engine, to access the enclave that runs the devnet.
+// Attaching to a devnet only needs the descriptor, the engine is only dialed when
+// an orchestrator feature needs to interact with the services directly.
+type kurtosisEnclave struct {
+	once sync.Once
+
+	kurtosisCtx *kurtosis_context.KurtosisContext
+	enclaveCtx  *enclaves.EnclaveContext
+	err         error
+}
+
+func (k *kurtosisEnclave) connect(ctx context.Context, enclave string) (*kurtosis_context.KurtosisContext, *enclaves.EnclaveContext, error) {
+	k.once.Do(func() {
+		kurtosisCtx, err := kurtosis_context.NewKurtosisContextFromLocalEngine()
+		if err != nil {
+			k.err = fmt.Errorf("failed to connect to kurtosis engine: %w", err)
+			return
+		}
+		enclaveCtx, err := kurtosisCtx.GetEnclaveContext(ctx, enclave)
+		if err != nil {
+			k.err = fmt.Errorf("failed to get kurtosis enclave %q: %w", enclave, err)
+			return
+		}
+		k.kurtosisCtx = kurtosisCtx
+		k.enclaveCtx = enclaveCtx
+	})
+	return k.kurtosisCtx, k.enclaveCtx, k.err
+}
+
+// enclave returns the Kurtosis contexts of the devnet enclave.
+func (o *Orchestrator) enclave(ctx context.Context) (*kurtosis_context.KurtosisContext, *enclaves.EnclaveContext, error) {
+	return o.kurtosis.connect(ctx, o.enclaveName())
+}
+
+// enclaveName is the name of the Kurtosis enclave running the devnet.
+// This defaults to the name of the devnet descriptor.
+func (o *Orchestrator) enclaveName() string {
+	if o.enclaveOverride != "" {
+		return o.enclaveOverride
+	}
+	return o.env.Name
+}
@@ -1,6 +1,8 @@
 package syskt
 
 import (
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -13,12 +15,18 @@ func WithL1(id stack.L1NetworkID, nodes []DefaultSystemExtL1NodeIDs) stack.Optio
 
 		commonConfig := shim.CommonConfigFromSetup(setup)
 		l1ID := eth.ChainIDFromBig(env.L1.Config.ChainID)
+		var snapshot *eth.BlockID
+		if s := env.L1.Snapshot; s != nil {
+			snapshot = &eth.BlockID{Hash: s.Hash, Number: s.Number}
+			setup.Log.Info("L1 started from snapshot", "block", snapshot)
+		}
 		l1 := shim.NewL1Network(shim.L1NetworkConfig{
 			NetworkConfig: shim.NetworkConfig{
 				CommonConfig: commonConfig,
 				ChainConfig:  env.L1.Config,
 			},
-			ID: id,
+			ID:       id,
+			Snapshot: snapshot,
 		})
 
 		for idx, node := range env.L1.Nodes {
@@ -27,21 +35,32 @@ func WithL1(id stack.L1NetworkID, nodes []DefaultSystemExtL1NodeIDs) stack.Optio
 			elRPC, err := findProtocolService(setup, ELServiceName, RPCProtocol, node.Services)
 			setup.Require.NoError(err)
 			elClient := rpcClient(setup, elRPC)
+			awaitReady(setup, ids.EL.String(), elProbe(elClient, l1ID))
+			// A snapshot includes the contract deployments, there is nothing to wait for.
+			if snapshot == nil {
+				awaitReady(setup, fmt.Sprintf("contract deployment on %s", ids.EL), deploymentProbe(elClient, env.L1.Addresses))
+			}
+			streamServiceLogs(setup, node.Services, ELServiceName, ids.EL)
 			l1.AddL1ELNode(shim.NewL1ELNode(shim.L1ELNodeConfig{
 				ELNodeConfig: shim.ELNodeConfig{
-					CommonConfig: commonConfig,
+					CommonConfig: serviceConfig(setup, commonConfig, ELServiceName, node.Services, elRPC),
 					Client:       elClient,
 					ChainID:      l1ID,
+					Lifecycle:    serviceLifecycle(setup, node.Services, ELServiceName),
 				},
 				ID: ids.EL,
 			}))
 
 			clHTTP, err := findProtocolService(setup, CLServiceName, HTTPProtocol, node.Services)
 			setup.Require.NoError(err)
+			clClient := client.NewBasicHTTPClient(clHTTP, setup.Log)
+			awaitReady(setup, ids.CL.String(), beaconProbe(clClient))
+			streamServiceLogs(setup, node.Services, CLServiceName, ids.CL)
 			l1.AddL1CLNode(shim.NewL1CLNode(shim.L1CLNodeConfig{
 				ID:           ids.CL,
-				CommonConfig: commonConfig,
-				Client:       client.NewBasicHTTPClient(clHTTP, setup.Log),
+				CommonConfig: serviceConfig(setup, commonConfig, CLServiceName, node.Services, clHTTP),
+				Client:       clClient,
+				Lifecycle:    serviceLifecycle(setup, node.Services, CLServiceName),
 			}))
 		}
 
@@ -1,22 +1,29 @@
 package syskt
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
-func WithL2(idx int, id stack.L2NetworkID, nodeIDs []DefaultSystemExtL2NodeIDs, l1ID stack.L1NetworkID) stack.Option {
+func WithL2(id stack.L2NetworkID, nodeIDs []DefaultSystemExtL2NodeIDs, l1ID stack.L1NetworkID) stack.Option {
 	return func(setup *stack.Setup) {
 		commonConfig := shim.CommonConfigFromSetup(setup)
 		orchestrator := getOrchestrator(setup)
 		env := orchestrator.env
-		net := env.L2[idx]
+		net := findL2(setup, id)
 
 		l1 := setup.System.L1Network(l1ID)
-		l1ChainID := l1.ChainID()
 		l2ID := eth.ChainIDFromBig(net.Config.ChainID)
 
 		cfg := shim.L2NetworkConfig{
@@ -24,15 +31,12 @@ func WithL2(idx int, id stack.L2NetworkID, nodeIDs []DefaultSystemExtL2NodeIDs,
 				CommonConfig: commonConfig,
 				ChainConfig:  net.Config,
 			},
-			ID: id,
-			RollupConfig: &rollup.Config{
-				L1ChainID: l1ChainID.ToBig(),
-				L2ChainID: l2ID.ToBig(),
-			},
-			Deployment: newL2AddressBook(setup, net.L1Addresses),
-			Keys:       defineSystemKeys(setup),
-			Superchain: setup.System.Superchain(stack.SuperchainID(env.Name)),
-			L1:         l1,
+			ID:           id,
+			RollupConfig: fetchRollupConfig(setup, net),
+			Deployment:   newL2AddressBook(setup, net.L1Addresses),
+			Keys:         defineSystemKeys(setup),
+			Superchain:   setup.System.Superchain(stack.SuperchainID(env.Name)),
+			L1:           l1,
 		}
 		if orchestrator.isInterop() {
 			cfg.Cluster = setup.System.Cluster(stack.ClusterID(env.Name))
@@ -46,23 +50,53 @@ func WithL2(idx int, id stack.L2NetworkID, nodeIDs []DefaultSystemExtL2NodeIDs,
 			elRPC, err := findProtocolService(setup, ELServiceName, RPCProtocol, node.Services)
 			setup.Require.NoError(err)
 			elClient := rpcClient(setup, elRPC)
-			l2.AddL2ELNode(shim.NewL2ELNode(shim.L2ELNodeConfig{
+			awaitReady(setup, ids.EL.String(), elProbe(elClient, l2ID))
+			streamServiceLogs(setup, node.Services, ELServiceName, ids.EL)
+			elConfig := shim.L2ELNodeConfig{
 				ELNodeConfig: shim.ELNodeConfig{
-					CommonConfig: commonConfig,
+					CommonConfig: serviceConfig(setup, commonConfig, ELServiceName, node.Services, elRPC),
 					Client:       elClient,
 					ChainID:      l2ID,
+					Lifecycle:    serviceLifecycle(setup, node.Services, ELServiceName),
 				},
 				ID: ids.EL,
-			}))
+			}
+			// The engine API is only usable if the descriptor shares the JWT secret.
+			if engineRPC, err := findProtocolService(setup, ELServiceName, EngineRPCProtocol, node.Services); err == nil && net.JWT != "" {
+				elConfig.AuthRPC = engineRPC
+				elConfig.JWTSecret = decodeJWTSecret(setup, net.JWT)
+				elConfig.RollupConfig = cfg.RollupConfig
+			}
+			l2.AddL2ELNode(shim.NewL2ELNode(elConfig))
 
 			clRPC, err := findProtocolService(setup, CLServiceName, HTTPProtocol, node.Services)
 			setup.Require.NoError(err)
 			clClient := rpcClient(setup, clRPC)
+			awaitReady(setup, ids.CL.String(), rpcProbe(clClient))
+			streamServiceLogs(setup, node.Services, CLServiceName, ids.CL)
 			l2.AddL2CLNode(shim.NewL2CLNode(shim.L2CLNodeConfig{
 				ID:           ids.CL,
-				CommonConfig: commonConfig,
+				EL:           ids.EL,
+				CommonConfig: serviceConfig(setup, commonConfig, CLServiceName, node.Services, clRPC),
 				Client:       clClient,
+				Lifecycle:    serviceLifecycle(setup, node.Services, CLServiceName),
 			}))
+
+			// Nodes with a conductor form the sequencer set of the L2.
+			if _, ok := node.Services[ConductorServiceName]; ok {
+				conductorRPC, err := findProtocolService(setup, ConductorServiceName, RPCProtocol, node.Services)
+				setup.Require.NoError(err)
+				conductorClient := rpcClient(setup, conductorRPC)
+				awaitReady(setup, ids.Conductor.String(), rpcProbe(conductorClient))
+				streamServiceLogs(setup, node.Services, ConductorServiceName, ids.Conductor)
+				l2.AddL2Conductor(shim.NewL2Conductor(shim.L2ConductorConfig{
+					CommonConfig: serviceConfig(setup, commonConfig, ConductorServiceName, node.Services, conductorRPC),
+					ID:           ids.Conductor,
+					Sequencer:    ids.CL,
+					Client:       conductorClient,
+					Lifecycle:    serviceLifecycle(setup, node.Services, ConductorServiceName),
+				}))
+			}
 		}
 
 		for name, wallet := range net.Wallets {
@@ -80,59 +114,131 @@ func WithL2(idx int, id stack.L2NetworkID, nodeIDs []DefaultSystemExtL2NodeIDs,
 	}
 }
 
-func WithBatcher(idx int, l2ID stack.L2NetworkID, id stack.L2BatcherID) stack.Option {
+func WithBatcher(l2ID stack.L2NetworkID, id stack.L2BatcherID) stack.Option {
 	return func(setup *stack.Setup) {
 		commonConfig := shim.CommonConfigFromSetup(setup)
-		env := getOrchestrator(setup).env
-		net := env.L2[idx]
+		net := findL2(setup, l2ID)
 
 		l2 := setup.System.L2Network(l2ID)
 
 		batcherRPC, err := findProtocolService(setup, "batcher", HTTPProtocol, net.Services)
 		setup.Require.NoError(err)
+		streamServiceLogs(setup, net.Services, "batcher", id)
 		l2.(stack.ExtensibleL2Network).AddL2Batcher(shim.NewL2Batcher(shim.L2BatcherConfig{
-			CommonConfig: commonConfig,
+			CommonConfig: serviceConfig(setup, commonConfig, "batcher", net.Services, batcherRPC),
 			ID:           id,
 			Client:       rpcClient(setup, batcherRPC),
+			Lifecycle:    serviceLifecycle(setup, net.Services, "batcher"),
 		}))
 	}
 }
 
-func WithProposer(idx int, l2ID stack.L2NetworkID, id stack.L2ProposerID) stack.Option {
+func WithProposer(l2ID stack.L2NetworkID, id stack.L2ProposerID) stack.Option {
 	return func(setup *stack.Setup) {
 		commonConfig := shim.CommonConfigFromSetup(setup)
-		env := getOrchestrator(setup).env
-		net := env.L2[idx]
+		net := findL2(setup, l2ID)
 
 		l2 := setup.System.L2Network(l2ID)
 
 		proposerRPC, err := findProtocolService(setup, "proposer", HTTPProtocol, net.Services)
 		setup.Require.NoError(err)
+		streamServiceLogs(setup, net.Services, "proposer", id)
+		// The proposal interval is not part of the devnet descriptor, and left unknown.
+		l1Client, address := disputeGamesClient(setup, net, l2, ProposerWalletName)
 		l2.(stack.ExtensibleL2Network).AddL2Proposer(shim.NewL2Proposer(shim.L2ProposerConfig{
-			CommonConfig: commonConfig,
-			ID:           id,
-			Client:       rpcClient(setup, proposerRPC),
+			CommonConfig:       serviceConfig(setup, commonConfig, "proposer", net.Services, proposerRPC),
+			ID:                 id,
+			Client:             rpcClient(setup, proposerRPC),
+			L1Client:           l1Client,
+			DisputeGameFactory: l2.Deployment().DisputeGameFactoryProxyAddr(),
+			Address:            address,
+			Lifecycle:          serviceLifecycle(setup, net.Services, "proposer"),
 		}))
 	}
 }
 
-func WithChallenger(idx int, l2ID stack.L2NetworkID, id stack.L2ChallengerID) stack.Option {
+func WithChallenger(l2ID stack.L2NetworkID, id stack.L2ChallengerID) stack.Option {
 	return func(setup *stack.Setup) {
 		commonConfig := shim.CommonConfigFromSetup(setup)
-		env := getOrchestrator(setup).env
-		net := env.L2[idx]
+		net := findL2(setup, l2ID)
 
 		l2 := setup.System.L2Network(l2ID)
 
-		_, err := findProtocolService(setup, "challenger", MetricsProtocol, net.Services)
-		setup.Require.NoError(err)
+		challengerConfig := serviceConfig(setup, commonConfig, "challenger", net.Services, "")
+		setup.Require.NotEmpty(challengerConfig.MetricsEndpoint, "challenger %s must expose metrics", id)
+		streamServiceLogs(setup, net.Services, "challenger", id)
+		// The dispute games are queried on L1, as the challenger does not serve an RPC of its own.
+		l1Client, address := disputeGamesClient(setup, net, l2, ChallengerWalletName)
 		l2.(stack.ExtensibleL2Network).AddL2Challenger(shim.NewL2Challenger(shim.L2ChallengerConfig{
-			CommonConfig: commonConfig,
+			CommonConfig:       challengerConfig,
+			ID:                 id,
+			L1Client:           l1Client,
+			DisputeGameFactory: l2.Deployment().DisputeGameFactoryProxyAddr(),
+			Address:            address,
+		}))
+	}
+}
+
+// disputeGamesClient returns a client of the first L1 EL node, to query the dispute games of the L2 with,
+// and the address of the given L1 wallet of the L2 that acts on the games.
+// A nil client is returned if the wallet or the L1 endpoint is unknown.
+func disputeGamesClient(setup *stack.Setup, net *descriptors.L2Chain, l2 stack.L2Network, walletName string) (client.RPC, common.Address) {
+	wallet, ok := net.L1Wallets[walletName]
+	if !ok {
+		return nil, common.Address{}
+	}
+	l1 := l2.L1()
+	if len(l1.L1ELNodes()) == 0 {
+		return nil, common.Address{}
+	}
+	endpoint := stack.ComponentEndpoint(l1.L1ELNode(l1.L1ELNodes()[0]))
+	if endpoint == "" {
+		return nil, common.Address{}
+	}
+	return rpcClient(setup, endpoint), wallet.Address
+}
+
+// WithFaucet registers a faucet on the L2 network, that funds accounts from a funded wallet of the descriptor.
+// The faucet sends funds as the user of the wallet, the first one by name that has a private key.
+func WithFaucet(l2ID stack.L2NetworkID, id stack.FaucetID) stack.Option {
+	return func(setup *stack.Setup) {
+		net := findL2(setup, l2ID)
+		names := make([]string, 0, len(net.Wallets))
+		for name, wallet := range net.Wallets {
+			if wallet.PrivateKey != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			setup.Log.Debug("No funded wallet in descriptor, skipping faucet", "id", id)
+			return
+		}
+		sort.Strings(names)
+
+		l2 := setup.System.L2Network(l2ID)
+		l2.(stack.ExtensibleL2Network).AddFaucet(shim.NewFaucet(shim.FaucetConfig{
+			CommonConfig: shim.CommonConfigFromSetup(setup),
 			ID:           id,
+			Funder:       l2.User(stack.UserID{Key: names[0], ChainID: l2ID.ChainID}),
 		}))
 	}
 }
 
+// fetchRollupConfig retrieves the rollup config of the L2 from the first CL node of the network.
+// The descriptor does not carry the rollup config, but every op-node serves it over RPC.
+func fetchRollupConfig(setup *stack.Setup, net *descriptors.L2Chain) *rollup.Config {
+	setup.Require.NotEmpty(net.Nodes, "need at least one node on L2 %s to fetch rollup config", net.Name)
+	clRPC, err := findProtocolService(setup, CLServiceName, HTTPProtocol, net.Nodes[0].Services)
+	setup.Require.NoError(err)
+	clClient := rpcClient(setup, clRPC)
+	awaitReady(setup, fmt.Sprintf("CL node of L2 %s", net.Name), rpcProbe(clClient))
+	rollupClient := sources.NewRollupClient(clClient)
+	defer rollupClient.Close()
+	cfg, err := rollupClient.RollupConfig(setup.Ctx)
+	setup.Require.NoError(err, "failed to fetch rollup config of L2 %s", net.Name)
+	return cfg
+}
+
 func defineSystemKeys(setup *stack.Setup) stack.L2Keys {
 	// TODO(#15040): get actual mnemonic from Kurtosis
 	keys, err := devkeys.NewMnemonicDevKeys(devkeys.TestMnemonic)
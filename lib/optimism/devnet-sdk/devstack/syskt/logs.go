@@ -0,0 +1,75 @@
+package syskt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kurtosis-tech/kurtosis/api/golang/core/lib/services"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// WithServiceLogs configures the orchestrator to stream the logs of the named Kurtosis services
+// into the setup logger, tagged with the ID of the component each service backs.
+// The service names are the Kurtosis service names, as found in the devnet descriptor.
+func WithServiceLogs(serviceNames ...string) OrchestratorOption {
+	return func(orchestrator *Orchestrator) {
+		orchestrator.serviceLogs = append(orchestrator.serviceLogs, serviceNames...)
+	}
+}
+
+// streamServiceLogs starts streaming the logs of the descriptor service into the setup logger,
+// if the service was selected with WithServiceLogs. Streaming stops when the setup test-handle is cleaned up.
+func streamServiceLogs(setup *stack.Setup, services descriptors.ServiceMap, svc string, id fmt.Stringer) {
+	orchestrator := getOrchestrator(setup)
+	service, ok := services[svc]
+	if !ok || !slices.Contains(orchestrator.serviceLogs, service.Name) {
+		return
+	}
+	logger := setup.Log.New("id", id, "service", service.Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop, err := followServiceLogs(ctx, orchestrator, service.Name, func(line string) {
+		logger.Info(line)
+	})
+	if err != nil {
+		cancel()
+		setup.Require.NoError(err, "failed to stream logs of service %s", service.Name)
+		return
+	}
+	setup.T.Cleanup(func() {
+		cancel()
+		stop()
+	})
+	logger.Info("Streaming service logs")
+}
+
+// followServiceLogs follows the logs of a Kurtosis service in the background,
+// and calls onLine for every new log line, until the context is canceled or the returned function is called.
+func followServiceLogs(ctx context.Context, orchestrator *Orchestrator, serviceName string, onLine func(line string)) (func(), error) {
+	kurtosisCtx, enclaveCtx, err := orchestrator.enclave(ctx)
+	if err != nil {
+		return nil, err
+	}
+	serviceCtx, err := enclaveCtx.GetServiceContext(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service %q: %w", serviceName, err)
+	}
+	uuid := serviceCtx.GetServiceUUID()
+	stream, stop, err := kurtosisCtx.GetServiceLogs(ctx, orchestrator.enclaveName(),
+		map[services.ServiceUUID]bool{uuid: true}, true, false, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow logs of service %q: %w", serviceName, err)
+	}
+	go func() {
+		for content := range stream {
+			for _, line := range content.GetServiceLogsByServiceUuids()[uuid] {
+				onLine(line.GetContent())
+			}
+		}
+		orchestrator.log.Debug("Service log stream closed", "service", serviceName)
+	}()
+	return stop, nil
+}
@@ -1,12 +1,19 @@
 package syskt
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
 )
 
+const (
+	defaultReadinessTimeout  = 2 * time.Minute
+	defaultReadinessInterval = 2 * time.Second
+)
+
 type OrchestratorOption func(*Orchestrator)
 
 type Orchestrator struct {
@@ -17,12 +24,33 @@ type Orchestrator struct {
 
 	usePrivatePorts    bool
 	useEagerRPCClients bool
+
+	// readinessTimeout bounds how long to wait for a service to answer before attaching it.
+	// Readiness probes are skipped when zero.
+	readinessTimeout time.Duration
+	// readinessInterval is the delay between readiness probe attempts.
+	readinessInterval time.Duration
+
+	// enclaveOverride replaces the descriptor name as Kurtosis enclave name, if not empty.
+	enclaveOverride string
+	kurtosis        kurtosisEnclave
+
+	// serviceLogs lists the Kurtosis services to stream logs of.
+	serviceLogs []string
+
+	// chaos tracks the faults injected into the devnet, to undo them with Heal.
+	chaos chaosState
 }
 
 var _ stack.Orchestrator = (*Orchestrator)(nil)
 
 func NewOrchestrator(t stack.T, log log.Logger) *Orchestrator {
-	return &Orchestrator{t: t, log: log}
+	return &Orchestrator{
+		t:                 t,
+		log:               log,
+		readinessTimeout:  defaultReadinessTimeout,
+		readinessInterval: defaultReadinessInterval,
+	}
 }
 
 func (o *Orchestrator) T() stack.T {
@@ -43,9 +71,7 @@ func isInterop(env *descriptors.DevnetEnvironment) bool {
 }
 
 func (o *Orchestrator) isInterop() bool {
-	// Ugly hack to ensure we can use L2[0] for supervisor
-	// Ultimately this should be removed.
-	return isInterop(o.env) && len(o.env.L2) > 0
+	return isInterop(o.env)
 }
 
 func WithPrivatePorts() OrchestratorOption {
@@ -59,3 +85,21 @@ func WithEagerRPCClients() OrchestratorOption {
 		orchestrator.useEagerRPCClients = true
 	}
 }
+
+// WithReadinessProbe configures how long to wait for each service to answer RPC requests
+// before it is attached to the system, and how often to retry in the meantime.
+// A zero timeout disables the readiness probes.
+func WithReadinessProbe(timeout time.Duration, interval time.Duration) OrchestratorOption {
+	return func(orchestrator *Orchestrator) {
+		orchestrator.readinessTimeout = timeout
+		orchestrator.readinessInterval = interval
+	}
+}
+
+// WithEnclave sets the name of the Kurtosis enclave that runs the devnet,
+// for when it differs from the name of the devnet descriptor.
+func WithEnclave(name string) OrchestratorOption {
+	return func(orchestrator *Orchestrator) {
+		orchestrator.enclaveOverride = name
+	}
+}
@@ -0,0 +1,80 @@
+package syskt
+
+import (
+	"maps"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/shim"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/devstack/stack"
+)
+
+// supervisorGroup is a supervisor service of the devnet, with the L2s that reference it.
+type supervisorGroup struct {
+	service descriptors.Service
+	l2s     []int
+}
+
+// supervisorGroups groups the L2s of the devnet by the supervisor that manages them.
+// Every L2 lists its supervisor in its services. Supervisors are matched by service name,
+// or by endpoints if the descriptor does not name them.
+// Groups are ordered by the first L2 that references them.
+func supervisorGroups(env *descriptors.DevnetEnvironment) []supervisorGroup {
+	var groups []supervisorGroup
+	for idx, l2 := range env.L2 {
+		svc, ok := l2.Services[SupervisorServiceName]
+		if !ok {
+			continue
+		}
+		found := false
+		for i := range groups {
+			if sameSupervisor(groups[i].service, svc) {
+				groups[i].l2s = append(groups[i].l2s, idx)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, supervisorGroup{service: svc, l2s: []int{idx}})
+		}
+	}
+	return groups
+}
+
+func sameSupervisor(a, b descriptors.Service) bool {
+	if a.Name != "" || b.Name != "" {
+		return a.Name == b.Name
+	}
+	return maps.Equal(a.Endpoints, b.Endpoints)
+}
+
+// WithSupervisor registers the supervisor that manages the given L2s.
+// All of these L2s must agree on the endpoints of the supervisor.
+func WithSupervisor(id stack.SupervisorID, l2IDs []stack.L2NetworkID) stack.Option {
+	return func(setup *stack.Setup) {
+		orchestrator := getOrchestrator(setup)
+		if !orchestrator.isInterop() {
+			return
+		}
+		setup.Require.NotEmpty(l2IDs, "supervisor %s must manage at least one L2", id)
+
+		first := findL2(setup, l2IDs[0])
+		services := first.Services
+		for _, l2ID := range l2IDs[1:] {
+			other := findL2(setup, l2ID)
+			setup.Require.Equal(services[SupervisorServiceName].Endpoints, other.Services[SupervisorServiceName].Endpoints,
+				"L2 %s and L2 %s disagree on endpoints of supervisor %s", first.Name, other.Name, id)
+		}
+
+		supervisorRPC, err := findProtocolService(setup, SupervisorServiceName, RPCProtocol, services)
+		setup.Require.NoError(err)
+		supervisorClient := rpcClient(setup, supervisorRPC)
+		awaitReady(setup, id.String(), rpcProbe(supervisorClient))
+		streamServiceLogs(setup, services, SupervisorServiceName, id)
+		setup.System.AddSupervisor(shim.NewSupervisor(shim.SupervisorConfig{
+			CommonConfig: serviceConfig(setup, shim.CommonConfigFromSetup(setup), SupervisorServiceName, services, supervisorRPC),
+			ID:           id,
+			Client:       supervisorClient,
+			Lifecycle:    serviceLifecycle(setup, services, SupervisorServiceName),
+		}))
+	}
+}
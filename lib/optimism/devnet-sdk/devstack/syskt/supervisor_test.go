@@ -0,0 +1,163 @@
+package syskt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+)
+
+func supervisorService(name string, host string) descriptors.Service {
+	return descriptors.Service{
+		Name: name,
+		Endpoints: descriptors.EndpointMap{
+			RPCProtocol: {Host: host, Port: 8545},
+		},
+	}
+}
+
+func l2WithSupervisor(name string, svc *descriptors.Service) *descriptors.L2Chain {
+	services := descriptors.ServiceMap{}
+	if svc != nil {
+		services[SupervisorServiceName] = *svc
+	}
+	return &descriptors.L2Chain{Chain: descriptors.Chain{Name: name, Services: services}}
+}
+
+func TestSupervisorGroups(t *testing.T) {
+	supA := supervisorService("op-supervisor-a", "supervisor-a")
+	supB := supervisorService("op-supervisor-b", "supervisor-b")
+	// The same supervisor, as seen through the private network of another L2.
+	supAElsewhere := supervisorService("op-supervisor-a", "10.0.0.2")
+	unnamedA := supervisorService("", "supervisor-a")
+	unnamedB := supervisorService("", "supervisor-b")
+
+	tests := []struct {
+		name     string
+		l2s      []*descriptors.L2Chain
+		expected [][]int
+	}{
+		{
+			name:     "no L2s",
+			l2s:      nil,
+			expected: nil,
+		},
+		{
+			name: "single supervisor",
+			l2s: []*descriptors.L2Chain{
+				l2WithSupervisor("l2-a", &supA),
+				l2WithSupervisor("l2-b", &supA),
+			},
+			expected: [][]int{{0, 1}},
+		},
+		{
+			name: "matches by name over endpoints",
+			l2s: []*descriptors.L2Chain{
+				l2WithSupervisor("l2-a", &supA),
+				l2WithSupervisor("l2-b", &supAElsewhere),
+			},
+			expected: [][]int{{0, 1}},
+		},
+		{
+			name: "matches unnamed supervisors by endpoints",
+			l2s: []*descriptors.L2Chain{
+				l2WithSupervisor("l2-a", &unnamedA),
+				l2WithSupervisor("l2-b", &unnamedA),
+				l2WithSupervisor("l2-c", &unnamedB),
+			},
+			expected: [][]int{{0, 1}, {2}},
+		},
+		{
+			name: "skips L2s without supervisor",
+			l2s: []*descriptors.L2Chain{
+				l2WithSupervisor("l2-a", nil),
+				l2WithSupervisor("l2-b", &supA),
+				l2WithSupervisor("l2-c", nil),
+			},
+			expected: [][]int{{1}},
+		},
+		{
+			name: "two supervisors ordered by first L2",
+			l2s: []*descriptors.L2Chain{
+				l2WithSupervisor("l2-a", &supB),
+				l2WithSupervisor("l2-b", &supA),
+				l2WithSupervisor("l2-c", &supB),
+				l2WithSupervisor("l2-d", &supA),
+			},
+			expected: [][]int{{0, 2}, {1, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := supervisorGroups(&descriptors.DevnetEnvironment{Name: "devnet", L2: tt.l2s})
+			var actual [][]int
+			for _, group := range groups {
+				actual = append(actual, group.l2s)
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+
+	t.Run("keeps the service of the first L2", func(t *testing.T) {
+		groups := supervisorGroups(&descriptors.DevnetEnvironment{L2: []*descriptors.L2Chain{
+			l2WithSupervisor("l2-a", &supA),
+			l2WithSupervisor("l2-b", &supAElsewhere),
+		}})
+		require.Len(t, groups, 1)
+		require.Equal(t, supA, groups[0].service)
+	})
+}
+
+func TestSameSupervisor(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     descriptors.Service
+		expected bool
+	}{
+		{
+			name:     "same name and endpoints",
+			a:        supervisorService("op-supervisor", "supervisor"),
+			b:        supervisorService("op-supervisor", "supervisor"),
+			expected: true,
+		},
+		{
+			name:     "same name, different endpoints",
+			a:        supervisorService("op-supervisor", "supervisor"),
+			b:        supervisorService("op-supervisor", "10.0.0.2"),
+			expected: true,
+		},
+		{
+			name:     "different names, same endpoints",
+			a:        supervisorService("op-supervisor-a", "supervisor"),
+			b:        supervisorService("op-supervisor-b", "supervisor"),
+			expected: false,
+		},
+		{
+			name:     "only one named",
+			a:        supervisorService("op-supervisor", "supervisor"),
+			b:        supervisorService("", "supervisor"),
+			expected: false,
+		},
+		{
+			name:     "unnamed, same endpoints",
+			a:        supervisorService("", "supervisor"),
+			b:        supervisorService("", "supervisor"),
+			expected: true,
+		},
+		{
+			name:     "unnamed, different endpoints",
+			a:        supervisorService("", "supervisor-a"),
+			b:        supervisorService("", "supervisor-b"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, sameSupervisor(tt.a, tt.b))
+			require.Equal(t, tt.expected, sameSupervisor(tt.b, tt.a))
+		})
+	}
+}
@@ -16,11 +16,19 @@ type DefaultSystemExtIDs struct {
 	Superchain stack.SuperchainID
 	Cluster    stack.ClusterID
 
-	Supervisor stack.SupervisorID
+	// Supervisor is the first supervisor of the devnet, kept for single-supervisor topologies.
+	Supervisor  stack.SupervisorID
+	Supervisors []DefaultSystemExtSupervisorIDs
 
 	L2s []DefaultSystemExtL2IDs
 }
 
+type DefaultSystemExtSupervisorIDs struct {
+	ID stack.SupervisorID
+	// L2s are the L2s managed by the supervisor.
+	L2s []stack.L2NetworkID
+}
+
 type DefaultSystemExtL1NodeIDs struct {
 	EL stack.L1ELNodeID
 	CL stack.L1CLNodeID
@@ -29,6 +37,10 @@ type DefaultSystemExtL1NodeIDs struct {
 type DefaultSystemExtL2NodeIDs struct {
 	EL stack.L2ELNodeID
 	CL stack.L2CLNodeID
+
+	// Conductor is only attached if the node runs a conductor,
+	// i.e. if it is part of a high-availability sequencer set.
+	Conductor stack.L2ConductorID
 }
 
 type DefaultSystemExtL2IDs struct {
@@ -39,6 +51,11 @@ type DefaultSystemExtL2IDs struct {
 	L2Batcher    stack.L2BatcherID
 	L2Proposer   stack.L2ProposerID
 	L2Challenger stack.L2ChallengerID
+
+	Faucet stack.FaucetID
+
+	// Supervisor is the supervisor that manages the L2, if any.
+	Supervisor stack.SupervisorID
 }
 
 func DefaultSystemExt(env *descriptors.DevnetEnvironment, opts ...OrchestratorOption) (DefaultSystemExtIDs, stack.Option) {
@@ -60,16 +77,18 @@ func DefaultSystemExt(env *descriptors.DevnetEnvironment, opts ...OrchestratorOp
 
 	opt.Add(WithL1(ids.L1, ids.Nodes))
 	opt.Add(WithSuperchain(ids.Superchain))
-	opt.Add(WithSupervisor(ids.Supervisor))
+	for _, supervisor := range ids.Supervisors {
+		opt.Add(WithSupervisor(supervisor.ID, supervisor.L2s))
+	}
 	opt.Add(WithCluster(ids.Cluster))
 
-	for idx := range env.L2 {
-		l2IDs := ids.L2s[idx]
-		opt.Add(WithL2(idx, l2IDs.L2, l2IDs.Nodes, ids.L1))
+	for _, l2IDs := range ids.L2s {
+		opt.Add(WithL2(l2IDs.L2, l2IDs.Nodes, ids.L1))
 
-		opt.Add(WithBatcher(idx, l2IDs.L2, l2IDs.L2Batcher))
-		opt.Add(WithProposer(idx, l2IDs.L2, l2IDs.L2Proposer))
-		opt.Add(WithChallenger(idx, l2IDs.L2, l2IDs.L2Challenger))
+		opt.Add(WithBatcher(l2IDs.L2, l2IDs.L2Batcher))
+		opt.Add(WithProposer(l2IDs.L2, l2IDs.L2Proposer))
+		opt.Add(WithChallenger(l2IDs.L2, l2IDs.L2Challenger))
+		opt.Add(WithFaucet(l2IDs.L2, l2IDs.Faucet))
 	}
 
 	return ids, opt
@@ -88,26 +107,6 @@ func WithSuperchain(id stack.SuperchainID) stack.Option {
 	}
 }
 
-func WithSupervisor(id stack.SupervisorID) stack.Option {
-	return func(setup *stack.Setup) {
-		orchestrator := getOrchestrator(setup)
-		if !orchestrator.isInterop() {
-			return
-		}
-
-		// ideally we should check supervisor is consistent across all L2s
-		// but that's what Kurtosis does.
-		supervisorRPC, err := findProtocolService(setup, "supervisor", RPCProtocol, orchestrator.env.L2[0].Services)
-		setup.Require.NoError(err)
-		supervisorClient := rpcClient(setup, supervisorRPC)
-		setup.System.AddSupervisor(shim.NewSupervisor(shim.SupervisorConfig{
-			CommonConfig: shim.CommonConfigFromSetup(setup),
-			ID:           id,
-			Client:       supervisorClient,
-		}))
-	}
-}
-
 func WithCluster(id stack.ClusterID) stack.Option {
 	return func(setup *stack.Setup) {
 		orchestrator := getOrchestrator(setup)
@@ -14,7 +14,7 @@ func run(ctx *cli.Context) error {
 	chainName := ctx.String("chain")
 	nodeIndex := ctx.Int("node-index")
 
-	devnetEnv, err := env.LoadDevnetFromURL(devnetURL)
+	devnetEnv, err := env.LoadDevnetFromURL(devnetURL, env.WithOffline(ctx.Bool("offline")))
 	if err != nil {
 		return err
 	}
@@ -82,6 +82,12 @@ func main() {
 				Required: false,
 				Value:    0,
 			},
+			&cli.BoolFlag{
+				Name:     "offline",
+				Usage:    "Load Kurtosis descriptors from the local cache, without reaching the Kurtosis engine or the nodes of the devnet",
+				EnvVars:  []string{env.OfflineVar},
+				Required: false,
+			},
 		},
 		Action: run,
 	}
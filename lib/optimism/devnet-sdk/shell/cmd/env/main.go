@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/shell/env"
+	"github.com/urfave/cli/v2"
+)
+
+func run(ctx *cli.Context) error {
+	devnetURL := ctx.String("devnet")
+	chainName := ctx.String("chain")
+
+	devnetEnv, err := env.LoadDevnetFromURL(devnetURL, env.WithOffline(ctx.Bool("offline")))
+	if err != nil {
+		return err
+	}
+
+	chain, err := devnetEnv.GetChain(chainName)
+	if err != nil {
+		return err
+	}
+
+	exports, err := chain.ExportEnv(ctx.String("prefix"),
+		env.WithExportNode(ctx.Int("node-index")),
+		env.WithExportWallet(ctx.String("wallet")),
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(exports.Shell())
+	return nil
+}
+
+func main() {
+	app := &cli.App{
+		Name:  "env",
+		Usage: "Print export statements of devnet environment variables, e.g. for eval $(env ...)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "devnet",
+				Usage:    "URL to devnet JSON file",
+				EnvVars:  []string{env.EnvURLVar},
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "chain",
+				Usage:    "Name of the chain to export the environment of",
+				EnvVars:  []string{env.ChainNameVar},
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:     "node-index",
+				Usage:    "Index of the node to export the RPC URL of (default: 0)",
+				EnvVars:  []string{env.NodeIndexVar},
+				Required: false,
+				Value:    0,
+			},
+			&cli.StringFlag{
+				Name:     "wallet",
+				Usage:    "Name of the wallet to export the address and private key of",
+				Required: false,
+			},
+			&cli.StringFlag{
+				Name:     "prefix",
+				Usage:    "Prefix of the exported variable names",
+				Required: false,
+			},
+			&cli.BoolFlag{
+				Name:     "offline",
+				Usage:    "Load Kurtosis descriptors from the local cache, without reaching the Kurtosis engine or the nodes of the devnet",
+				EnvVars:  []string{env.OfflineVar},
+				Required: false,
+			},
+		},
+		Action: run,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
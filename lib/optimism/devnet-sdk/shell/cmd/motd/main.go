@@ -12,7 +12,7 @@ func run(ctx *cli.Context) error {
 	devnetURL := ctx.String("devnet")
 	chainName := ctx.String("chain")
 
-	devnetEnv, err := env.LoadDevnetFromURL(devnetURL)
+	devnetEnv, err := env.LoadDevnetFromURL(devnetURL, env.WithOffline(ctx.Bool("offline")))
 	if err != nil {
 		return err
 	}
@@ -48,6 +48,12 @@ func main() {
 				EnvVars:  []string{env.ChainNameVar},
 				Required: true,
 			},
+			&cli.BoolFlag{
+				Name:     "offline",
+				Usage:    "Load Kurtosis descriptors from the local cache, without reaching the Kurtosis engine or the nodes of the devnet",
+				EnvVars:  []string{env.OfflineVar},
+				Required: false,
+			},
 		},
 		Action: run,
 	}
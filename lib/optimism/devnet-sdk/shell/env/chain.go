@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 const (
@@ -18,9 +20,22 @@ const (
 )
 
 type ChainConfig struct {
-	chain     *descriptors.Chain
-	devnetURL string
-	name      string
+	chain        *descriptors.Chain
+	rollupConfig *rollup.Config
+	devnetURL    string
+	name         string
+}
+
+// Params returns the chain config of the chain.
+// It is never nil for chains of a loaded devnet, but may only have a chain ID if the nodes of the chain are not reachable.
+func (c *ChainConfig) Params() *params.ChainConfig {
+	return c.chain.Config
+}
+
+// RollupConfig returns the rollup config of the chain, or nil if the chain is not an L2 chain,
+// or the rollup config is not available.
+func (c *ChainConfig) RollupConfig() *rollup.Config {
+	return c.rollupConfig
 }
 
 type ChainEnv struct {
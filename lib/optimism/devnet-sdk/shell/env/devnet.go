@@ -3,12 +3,11 @@ package env
 import (
 	"encoding/json"
 	"fmt"
-	"math/big"
 	"net/url"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
-	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
 type DevnetEnv struct {
@@ -24,12 +23,45 @@ type DataFetcher func(*url.URL) (string, []byte, error)
 var schemeToFetcher = map[string]DataFetcher{
 	"":         fetchFileData,
 	"file":     fetchFileData,
+	"http":     fetchHTTPData,
+	"https":    fetchHTTPData,
 	"kt":       fetchKurtosisData,
 	"ktnative": fetchKurtosisNativeData,
 }
 
+// DataWriter is a function type for writing data to a URL
+type DataWriter func(*url.URL, []byte) error
+
+// schemeToWriter maps URL schemes to their respective data writer functions
+var schemeToWriter = map[string]DataWriter{
+	"":     writeFileData,
+	"file": writeFileData,
+	"kt":   writeKurtosisData,
+}
+
+// schemeToOfflineFetcher maps URL schemes to the data fetcher functions that replace theirs in offline mode
+var schemeToOfflineFetcher = map[string]DataFetcher{
+	"kt": fetchCachedKurtosisData,
+}
+
+// LoadOption configures how LoadDevnetFromURL loads a devnet descriptor.
+type LoadOption func(*loadOpts)
+
+type loadOpts struct {
+	offline bool
+}
+
+// WithOffline loads Kurtosis descriptors from the local cache, without reaching the Kurtosis engine,
+// and does not query the nodes of the devnet for the configs that the descriptor lacks,
+// e.g. when the devnet is temporarily unreachable.
+func WithOffline(offline bool) LoadOption {
+	return func(o *loadOpts) {
+		o.offline = offline
+	}
+}
+
 // fetchDevnetData retrieves data from a URL based on its scheme
-func fetchDevnetData(devnetURL string) (string, []byte, error) {
+func fetchDevnetData(devnetURL string, offline bool) (string, []byte, error) {
 	parsedURL, err := url.Parse(devnetURL)
 	if err != nil {
 		return "", nil, fmt.Errorf("error parsing URL: %w", err)
@@ -40,12 +72,27 @@ func fetchDevnetData(devnetURL string) (string, []byte, error) {
 	if !ok {
 		return "", nil, fmt.Errorf("unsupported URL scheme: %s", scheme)
 	}
+	if offlineFetcher, ok := schemeToOfflineFetcher[scheme]; ok && offline {
+		fetcher = offlineFetcher
+	}
 
 	return fetcher(parsedURL)
 }
 
-func LoadDevnetFromURL(devnetURL string) (*DevnetEnv, error) {
-	name, data, err := fetchDevnetData(devnetURL)
+// LoadDevnetFromURL loads the devnet descriptor at a URL.
+// The URL can also be the name of a devnet of the registry, see RegisterDevnet.
+func LoadDevnetFromURL(devnetURL string, opts ...LoadOption) (*DevnetEnv, error) {
+	var o loadOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	devnetURL, err := resolveDevnetURL(devnetURL)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving devnet name: %w", err)
+	}
+
+	name, data, err := fetchDevnetData(devnetURL, o.offline)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching devnet data: %w", err)
 	}
@@ -55,7 +102,11 @@ func LoadDevnetFromURL(devnetURL string) (*DevnetEnv, error) {
 		return nil, fmt.Errorf("error parsing JSON: %w", err)
 	}
 
-	config, err = fixupDevnetConfig(config)
+	if err := validateDevnetConfig(&config); err != nil {
+		return nil, err
+	}
+
+	config, err = fixupDevnetConfig(config, o.offline)
 	if err != nil {
 		return nil, fmt.Errorf("error fixing up devnet config: %w", err)
 	}
@@ -67,14 +118,46 @@ func LoadDevnetFromURL(devnetURL string) (*DevnetEnv, error) {
 	}, nil
 }
 
+// Save writes the devnet descriptor to a URL, so changes to the descriptor can be loaded again with LoadDevnetFromURL.
+// Files are overwritten. Kurtosis descriptors are immutable artifacts, so a kt:// URL without artifact name
+// saves the descriptor as the next descriptor artifact of the enclave, which is the one that is loaded by default.
+func (d *DevnetEnv) Save(devnetURL string) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	parsedURL, err := url.Parse(devnetURL)
+	if err != nil {
+		return fmt.Errorf("error parsing URL: %w", err)
+	}
+
+	scheme := strings.ToLower(parsedURL.Scheme)
+	writer, ok := schemeToWriter[scheme]
+	if !ok {
+		return fmt.Errorf("unsupported URL scheme for saving: %s", scheme)
+	}
+
+	data, err := json.MarshalIndent(d.Config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+
+	if err := writer(parsedURL, data); err != nil {
+		return fmt.Errorf("error writing devnet data: %w", err)
+	}
+	return nil
+}
+
 func (d *DevnetEnv) GetChain(chainName string) (*ChainConfig, error) {
 	var chain *descriptors.Chain
+	var rollupConfig *rollup.Config
 	if d.Config.L1.Name == chainName {
 		chain = d.Config.L1
 	} else {
 		for _, l2Chain := range d.Config.L2 {
 			if l2Chain.Name == chainName {
 				chain = &l2Chain.Chain
+				rollupConfig = l2Chain.RollupConfig
 				break
 			}
 		}
@@ -85,22 +168,29 @@ func (d *DevnetEnv) GetChain(chainName string) (*ChainConfig, error) {
 	}
 
 	return &ChainConfig{
-		chain:     chain,
-		devnetURL: d.URL,
-		name:      chainName,
+		chain:        chain,
+		rollupConfig: rollupConfig,
+		devnetURL:    d.URL,
+		name:         chainName,
 	}, nil
 }
 
-func fixupDevnetConfig(config descriptors.DevnetEnvironment) (descriptors.DevnetEnvironment, error) {
-	// we should really get this from the kurtosis output, but the data doesn't exist yet, so craft a minimal one.
-	if config.L1.Config == nil {
-		l1ID := new(big.Int)
-		l1ID, ok := l1ID.SetString(config.L1.ID, 10)
-		if !ok {
-			return config, fmt.Errorf("invalid L1 ID: %s", config.L1.ID)
+// fixupDevnetConfig completes the chain configs that are missing from the descriptor,
+// with the chain and rollup configs served by the nodes of the chains. Offline, the nodes are not queried.
+func fixupDevnetConfig(config descriptors.DevnetEnvironment, offline bool) (descriptors.DevnetEnvironment, error) {
+	if err := fixupChainConfig(config.L1, offline); err != nil {
+		return config, err
+	}
+	for _, l2 := range config.L2 {
+		if err := fixupChainConfig(&l2.Chain, offline); err != nil {
+			return config, err
+		}
+		// the rollup config can only be fetched from the nodes
+		if offline {
+			continue
 		}
-		config.L1.Config = &params.ChainConfig{
-			ChainID: l1ID,
+		if err := fixupRollupConfig(l2); err != nil {
+			return config, err
 		}
 	}
 	return config, nil
@@ -0,0 +1,223 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+)
+
+// ChangeKind is the kind of change of a field between two devnet descriptors.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a difference of a field between two devnet descriptors.
+type Change struct {
+	// Path is the path of the field in the descriptor, e.g. "l2.op-kurtosis.addresses.SystemConfigProxy".
+	Path string
+	Kind ChangeKind
+	// Old is the value in the first descriptor, empty if the field was added.
+	Old string
+	// New is the value in the second descriptor, empty if the field was removed.
+	New string
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New)
+	}
+}
+
+// DevnetDiff is the structured difference between two devnet descriptors.
+type DevnetDiff struct {
+	Changes []Change
+}
+
+// Empty returns true if the descriptors do not differ.
+func (d *DevnetDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+func (d *DevnetDiff) String() string {
+	lines := make([]string, len(d.Changes))
+	for i, c := range d.Changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares the chains, services, addresses and wallets of two devnets,
+// e.g. a refreshed Kurtosis environment against a previously saved descriptor, to detect drift.
+// L2 chains are matched by name, and nodes by index.
+// Private keys are not part of the diff, only whether they changed.
+func Diff(a, b *DevnetEnv) *DevnetDiff {
+	d := &devnetDiffer{}
+	d.value("name", a.Config.Name, b.Config.Name)
+	d.value("features", strings.Join(a.Config.Features, ","), strings.Join(b.Config.Features, ","))
+	d.chain("l1", a.Config.L1, b.Config.L1)
+
+	aL2s := make(map[string]*descriptors.L2Chain)
+	for _, l2 := range a.Config.L2 {
+		aL2s[l2.Name] = l2
+	}
+	bL2s := make(map[string]*descriptors.L2Chain)
+	for _, l2 := range b.Config.L2 {
+		bL2s[l2.Name] = l2
+	}
+	for _, name := range sortedKeys(unionKeys(aL2s, bL2s)) {
+		path := "l2." + name
+		aL2, inA := aL2s[name]
+		bL2, inB := bL2s[name]
+		switch {
+		case !inA:
+			d.add(Change{Path: path, Kind: ChangeAdded, New: "chain " + bL2.ID})
+		case !inB:
+			d.add(Change{Path: path, Kind: ChangeRemoved, Old: "chain " + aL2.ID})
+		default:
+			d.chain(path, &aL2.Chain, &bL2.Chain)
+			d.addresses(path+".l1_addresses", aL2.L1Addresses, bL2.L1Addresses)
+			d.wallets(path+".l1_wallets", aL2.L1Wallets, bL2.L1Wallets)
+		}
+	}
+	return &DevnetDiff{Changes: d.changes}
+}
+
+type devnetDiffer struct {
+	changes []Change
+}
+
+func (d *devnetDiffer) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *devnetDiffer) value(path string, a, b string) {
+	switch {
+	case a == b:
+	case a == "":
+		d.add(Change{Path: path, Kind: ChangeAdded, New: b})
+	case b == "":
+		d.add(Change{Path: path, Kind: ChangeRemoved, Old: a})
+	default:
+		d.add(Change{Path: path, Kind: ChangeChanged, Old: a, New: b})
+	}
+}
+
+func (d *devnetDiffer) chain(path string, a, b *descriptors.Chain) {
+	if a == nil || b == nil {
+		if a != b {
+			d.value(path, chainSummary(a), chainSummary(b))
+		}
+		return
+	}
+	d.value(path+".id", a.ID, b.ID)
+	d.services(path+".services", a.Services, b.Services)
+	for i := 0; i < max(len(a.Nodes), len(b.Nodes)); i++ {
+		nodePath := fmt.Sprintf("%s.nodes[%d]", path, i)
+		switch {
+		case i >= len(a.Nodes):
+			d.add(Change{Path: nodePath, Kind: ChangeAdded, New: "node"})
+		case i >= len(b.Nodes):
+			d.add(Change{Path: nodePath, Kind: ChangeRemoved, Old: "node"})
+		default:
+			d.services(nodePath+".services", a.Nodes[i].Services, b.Nodes[i].Services)
+		}
+	}
+	d.addresses(path+".addresses", a.Addresses, b.Addresses)
+	d.wallets(path+".wallets", a.Wallets, b.Wallets)
+}
+
+func (d *devnetDiffer) services(path string, a, b descriptors.ServiceMap) {
+	for _, name := range sortedKeys(unionKeys(a, b)) {
+		aEndpoints, bEndpoints := a[name].Endpoints, b[name].Endpoints
+		for _, endpoint := range sortedKeys(unionKeys(aEndpoints, bEndpoints)) {
+			d.value(fmt.Sprintf("%s.%s.endpoints.%s", path, name, endpoint),
+				endpointSummary(aEndpoints, endpoint), endpointSummary(bEndpoints, endpoint))
+		}
+	}
+}
+
+func (d *devnetDiffer) addresses(path string, a, b descriptors.AddressMap) {
+	for _, name := range sortedKeys(unionKeys(a, b)) {
+		var aAddr, bAddr string
+		if addr, ok := a[name]; ok {
+			aAddr = addr.Hex()
+		}
+		if addr, ok := b[name]; ok {
+			bAddr = addr.Hex()
+		}
+		d.value(path+"."+name, aAddr, bAddr)
+	}
+}
+
+func (d *devnetDiffer) wallets(path string, a, b descriptors.WalletMap) {
+	for _, name := range sortedKeys(unionKeys(a, b)) {
+		aWallet, inA := a[name]
+		bWallet, inB := b[name]
+		walletPath := path + "." + name
+		switch {
+		case !inA:
+			d.add(Change{Path: walletPath, Kind: ChangeAdded, New: bWallet.Address.Hex()})
+		case !inB:
+			d.add(Change{Path: walletPath, Kind: ChangeRemoved, Old: aWallet.Address.Hex()})
+		default:
+			d.value(walletPath+".address", aWallet.Address.Hex(), bWallet.Address.Hex())
+			if aWallet.PrivateKey != bWallet.PrivateKey {
+				change := Change{Path: walletPath + ".private_key", Kind: ChangeChanged, Old: redacted(aWallet.PrivateKey), New: redacted(bWallet.PrivateKey)}
+				if change.Old == "" {
+					change.Kind = ChangeAdded
+				} else if change.New == "" {
+					change.Kind = ChangeRemoved
+				}
+				d.add(change)
+			}
+		}
+	}
+}
+
+// redacted hides a secret value, but keeps whether it is set.
+func redacted(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "<redacted>"
+}
+
+func chainSummary(c *descriptors.Chain) string {
+	if c == nil {
+		return ""
+	}
+	return "chain " + c.ID
+}
+
+func endpointSummary(endpoints descriptors.EndpointMap, name string) string {
+	endpoint, ok := endpoints[name]
+	if !ok {
+		return ""
+	}
+	if endpoint.Scheme != "" {
+		return fmt.Sprintf("%s://%s:%d", endpoint.Scheme, endpoint.Host, endpoint.Port)
+	}
+	return fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+}
+
+// unionKeys returns the keys of both maps.
+func unionKeys[V any](a, b map[string]V) map[string]struct{} {
+	out := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		out[k] = struct{}{}
+	}
+	for k := range b {
+		out[k] = struct{}{}
+	}
+	return out
+}
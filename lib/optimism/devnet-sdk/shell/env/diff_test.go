@@ -0,0 +1,68 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	devnet := func(rpcPort int, l2s ...*descriptors.L2Chain) *DevnetEnv {
+		return &DevnetEnv{
+			Config: descriptors.DevnetEnvironment{
+				Name: "devnet",
+				L1: &descriptors.Chain{
+					Name: "l1",
+					ID:   "900",
+					Nodes: []descriptors.Node{{
+						Services: descriptors.ServiceMap{
+							"el": {Endpoints: descriptors.EndpointMap{"rpc": {Host: "localhost", Port: rpcPort}}},
+						},
+					}},
+				},
+				L2: l2s,
+			},
+		}
+	}
+	l2 := func(name string, id string, proxy common.Address, key string) *descriptors.L2Chain {
+		return &descriptors.L2Chain{
+			Chain: descriptors.Chain{
+				Name:      name,
+				ID:        id,
+				Addresses: descriptors.AddressMap{"SystemConfigProxy": proxy},
+				Wallets: descriptors.WalletMap{
+					"user": {Address: common.HexToAddress("0x1"), PrivateKey: key},
+				},
+			},
+		}
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		a := devnet(8545, l2("op", "901", common.HexToAddress("0x10"), "0xaa"))
+		b := devnet(8545, l2("op", "901", common.HexToAddress("0x10"), "0xaa"))
+		assert.True(t, Diff(a, b).Empty())
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		a := devnet(8545,
+			l2("op", "901", common.HexToAddress("0x10"), "0xaa"),
+			l2("gone", "902", common.HexToAddress("0x20"), ""))
+		b := devnet(9545,
+			l2("op", "901", common.HexToAddress("0x11"), "0xbb"),
+			l2("new", "903", common.HexToAddress("0x30"), ""))
+
+		diff := Diff(a, b)
+		require.False(t, diff.Empty())
+		assert.Equal(t, []Change{
+			{Path: "l1.nodes[0].services.el.endpoints.rpc", Kind: ChangeChanged, Old: "localhost:8545", New: "localhost:9545"},
+			{Path: "l2.gone", Kind: ChangeRemoved, Old: "chain 902"},
+			{Path: "l2.new", Kind: ChangeAdded, New: "chain 903"},
+			{Path: "l2.op.addresses.SystemConfigProxy", Kind: ChangeChanged,
+				Old: common.HexToAddress("0x10").Hex(), New: common.HexToAddress("0x11").Hex()},
+			{Path: "l2.op.wallets.user.private_key", Kind: ChangeChanged, Old: "<redacted>", New: "<redacted>"},
+		}, diff.Changes)
+	})
+}
@@ -73,6 +73,21 @@ func TestLoadDevnetEnv(t *testing.T) {
 		assert.Equal(t, "op", env.Config.L2[0].Name)
 	})
 
+	// Test saving and loading again
+	t.Run("save round trip", func(t *testing.T) {
+		env, err := LoadDevnetFromURL(tmpfile.Name())
+		require.NoError(t, err)
+		env.Config.L2[0].Addresses["added"] = common.HexToAddress("0x3456789012345678901234567890123456789012")
+
+		saved := filepath.Join(t.TempDir(), "saved.json")
+		require.NoError(t, env.Save(saved))
+
+		reloaded, err := LoadDevnetFromURL(saved)
+		require.NoError(t, err)
+		assert.Equal(t, "saved", reloaded.Name)
+		assert.Equal(t, env.Config, reloaded.Config)
+	})
+
 	// Test loading non-existent file
 	t.Run("non-existent file", func(t *testing.T) {
 		_, err := LoadDevnetFromURL("non-existent.json")
@@ -271,3 +286,127 @@ func TestChainConfig(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestValidate(t *testing.T) {
+	node := descriptors.Node{
+		Services: descriptors.ServiceMap{
+			"el": {Endpoints: descriptors.EndpointMap{"rpc": {Host: "localhost", Port: 8545}}},
+		},
+	}
+	validConfig := func() descriptors.DevnetEnvironment {
+		return descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{Name: "l1", ID: "1", Nodes: []descriptors.Node{node}},
+			L2: []*descriptors.L2Chain{
+				{Chain: descriptors.Chain{Name: "op", ID: "2", Nodes: []descriptors.Node{node}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(config *descriptors.DevnetEnvironment)
+		wantPaths []string
+	}{
+		{
+			name:   "valid",
+			modify: func(config *descriptors.DevnetEnvironment) {},
+		},
+		{
+			name:      "missing L1",
+			modify:    func(config *descriptors.DevnetEnvironment) { config.L1 = nil },
+			wantPaths: []string{"l1"},
+		},
+		{
+			name:      "duplicate chain ID",
+			modify:    func(config *descriptors.DevnetEnvironment) { config.L2[0].ID = "1" },
+			wantPaths: []string{"l2[0].id"},
+		},
+		{
+			name: "node without execution layer",
+			modify: func(config *descriptors.DevnetEnvironment) {
+				config.L2[0].Nodes = []descriptors.Node{{Services: descriptors.ServiceMap{}}}
+			},
+			wantPaths: []string{"l2[0].nodes[0].services.el"},
+		},
+		{
+			name: "wallet with invalid key",
+			modify: func(config *descriptors.DevnetEnvironment) {
+				config.L2[0].L1Wallets = descriptors.WalletMap{
+					"owner": {Address: common.HexToAddress("0x1234"), PrivateKey: "0xinvalid"},
+				}
+			},
+			wantPaths: []string{"l2[0].l1_wallets.owner.private_key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig()
+			tt.modify(&config)
+			err := (&DevnetEnv{Config: config}).Validate()
+			if len(tt.wantPaths) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
+
+func TestExportEnv(t *testing.T) {
+	chain := &ChainConfig{
+		chain: &descriptors.Chain{
+			Name: "test",
+			ID:   "901",
+			Nodes: []descriptors.Node{
+				{
+					Services: descriptors.ServiceMap{
+						"el": {Endpoints: descriptors.EndpointMap{"rpc": {Host: "localhost", Port: 8545}}},
+					},
+				},
+			},
+			Wallets: descriptors.WalletMap{
+				"user": {
+					Address:    common.HexToAddress("0x1234567890123456789012345678901234567890"),
+					PrivateKey: "0xabcd",
+				},
+			},
+		},
+		devnetURL: "test.json",
+		name:      "test",
+	}
+
+	t.Run("without wallet", func(t *testing.T) {
+		exports, err := chain.ExportEnv("")
+		require.NoError(t, err)
+		assert.Equal(t, "export ETH_RPC_URL='http://localhost:8545'\nexport CHAIN_ID='901'\n", exports.Shell())
+	})
+
+	t.Run("with wallet and prefix", func(t *testing.T) {
+		exports, err := chain.ExportEnv("L2_", WithExportWallet("user"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"L2_ETH_RPC_URL": "http://localhost:8545",
+			"L2_CHAIN_ID":    "901",
+			"L2_ETH_FROM":    "0x1234567890123456789012345678901234567890",
+			"L2_PRIVATE_KEY": "0xabcd",
+		}, exports.Map())
+	})
+
+	t.Run("unknown wallet", func(t *testing.T) {
+		_, err := chain.ExportEnv("", WithExportWallet("unknown"))
+		assert.Error(t, err)
+	})
+
+	t.Run("quoting", func(t *testing.T) {
+		exports := EnvExports{{Key: "VALUE", Value: "it's $HOME"}}
+		assert.Equal(t, "export VALUE='it'\\''s $HOME'\n", exports.Shell())
+	})
+}
@@ -0,0 +1,93 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnvExport is an environment variable to export.
+type EnvExport struct {
+	Key   string
+	Value string
+}
+
+// EnvExports are environment variables to export, in order.
+type EnvExports []EnvExport
+
+// Shell formats the exports as POSIX shell export statements, one per line,
+// so they can be applied with e.g. `eval $(devnet-env ...)`.
+func (e EnvExports) Shell() string {
+	var b strings.Builder
+	for _, export := range e {
+		fmt.Fprintf(&b, "export %s=%s\n", export.Key, shellQuote(export.Value))
+	}
+	return b.String()
+}
+
+// Map returns the exports as a map of keys to values.
+func (e EnvExports) Map() map[string]string {
+	out := make(map[string]string, len(e))
+	for _, export := range e {
+		out[export.Key] = export.Value
+	}
+	return out
+}
+
+// shellQuote quotes a value for a POSIX shell, with single quotes, so no part of the value is expanded.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+type ExportOption func(*exportOpts)
+
+type exportOpts struct {
+	nodeIndex int
+	wallet    string
+}
+
+// WithExportNode selects the node of the chain to export the RPC URL of. Defaults to the first node.
+func WithExportNode(nodeIndex int) ExportOption {
+	return func(o *exportOpts) {
+		o.nodeIndex = nodeIndex
+	}
+}
+
+// WithExportWallet selects the wallet of the chain to export the address and private key of.
+// No wallet is exported by default.
+func WithExportWallet(name string) ExportOption {
+	return func(o *exportOpts) {
+		o.wallet = name
+	}
+}
+
+// ExportEnv returns the environment variables to interact with the chain:
+// ETH_RPC_URL and CHAIN_ID, and ETH_FROM and PRIVATE_KEY if a wallet is selected.
+// Every key is prefixed with the given prefix, which may be empty.
+func (c *ChainConfig) ExportEnv(prefix string, opts ...ExportOption) (EnvExports, error) {
+	o := &exportOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rpcURL, err := c.getRpcUrl(o.nodeIndex)()
+	if err != nil {
+		return nil, err
+	}
+	exports := EnvExports{
+		{Key: prefix + "ETH_RPC_URL", Value: rpcURL},
+		{Key: prefix + "CHAIN_ID", Value: c.chain.ID},
+	}
+
+	if o.wallet != "" {
+		wallet, ok := c.chain.Wallets[o.wallet]
+		if !ok {
+			return nil, fmt.Errorf("wallet '%s' not found in chain '%s'", o.wallet, c.name)
+		}
+		exports = append(exports, EnvExport{Key: prefix + "ETH_FROM", Value: wallet.Address.Hex()})
+		if wallet.PrivateKey == "" {
+			return nil, fmt.Errorf("wallet '%s' of chain '%s' has no private key", o.wallet, c.name)
+		}
+		exports = append(exports, EnvExport{Key: prefix + "PRIVATE_KEY", Value: wallet.PrivateKey})
+	}
+	return exports, nil
+}
@@ -37,3 +37,11 @@ func fetchFileDataFromOS(u *url.URL, os osInterface) (string, []byte, error) {
 func fetchFileData(u *url.URL) (string, []byte, error) {
 	return fetchFileDataFromOS(u, &defaultOS{})
 }
+
+// writeFileData writes data to a local file, replacing its contents
+func writeFileData(u *url.URL, data []byte) error {
+	if err := os.WriteFile(u.Path, data, 0644); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// EnvTokenVar is the env var of the bearer token to authenticate with, when fetching a devnet descriptor over HTTP(S).
+const EnvTokenVar = "DEVNET_ENV_TOKEN"
+
+const httpFetchTimeout = 30 * time.Second
+
+// httpClient describes the subset of http.Client used to fetch descriptors, for ease of testing
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// fetchHTTPDataFromClient reads data from a HTTP(S) endpoint.
+// If a token is given, it is sent as bearer token in the Authorization header.
+func fetchHTTPDataFromClient(u *url.URL, client httpClient, token string) (string, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error fetching descriptor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("error fetching descriptor: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	// like for files, the devnet is named after the descriptor, e.g. https://example.com/devnets/alpha.json is alpha
+	basename := path.Base(u.Path)
+	if lastDot := strings.LastIndex(basename, "."); lastDot >= 0 {
+		basename = basename[:lastDot]
+	}
+	if basename == "" || basename == "/" || basename == "." {
+		basename = u.Hostname()
+	}
+	return basename, body, nil
+}
+
+func fetchHTTPData(u *url.URL) (string, []byte, error) {
+	client := &http.Client{Timeout: httpFetchTimeout}
+	return fetchHTTPDataFromClient(u, client, os.Getenv(EnvTokenVar))
+}
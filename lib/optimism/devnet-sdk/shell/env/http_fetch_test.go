@@ -0,0 +1,73 @@
+package env
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchHTTPData(t *testing.T) {
+	content := []byte(`{"name": "alpha"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/private/alpha.json" && r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/missing.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		token     string
+		wantName  string
+		wantError bool
+	}{
+		{
+			name:     "public descriptor",
+			path:     "/devnets/alpha.json",
+			wantName: "alpha",
+		},
+		{
+			name:     "private descriptor with token",
+			path:     "/private/alpha.json",
+			token:    "secret",
+			wantName: "alpha",
+		},
+		{
+			name:      "private descriptor without token",
+			path:      "/private/alpha.json",
+			wantError: true,
+		},
+		{
+			name:      "missing descriptor",
+			path:      "/missing.json",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(server.URL + tt.path)
+			require.NoError(t, err)
+			name, data, err := fetchHTTPDataFromClient(u, server.Client(), tt.token)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, content, data)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package env
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// OfflineVar is the env var of the offline flag of the shell commands, which load devnets WithOffline.
+	OfflineVar = "DEVNET_OFFLINE"
+	// CacheDirVar is the env var of the directory Kurtosis descriptors are cached in.
+	// Defaults to devnet-sdk/kurtosis in the user cache directory.
+	CacheDirVar = "DEVNET_CACHE_DIR"
+)
+
+// EnclaveIdentity identifies an instance of an enclave.
+// Enclaves can be recreated with the same name, so artifacts are cached by enclave identity, not by enclave name.
+type EnclaveIdentity struct {
+	UUID    string    `json:"uuid"`
+	Created time.Time `json:"created"`
+}
+
+func (id EnclaveIdentity) equal(other EnclaveIdentity) bool {
+	return id.UUID == other.UUID && id.Created.Equal(other.Created)
+}
+
+// IdentifiedEnclaveFS is an EnclaveFS that knows the identity of its enclave, so its artifacts can be cached.
+type IdentifiedEnclaveFS interface {
+	EnclaveFS
+	Identity() EnclaveIdentity
+}
+
+// ktCacheIndex records the cached files of an enclave, so they can be found by enclave name when offline.
+type ktCacheIndex struct {
+	Enclave EnclaveIdentity `json:"enclave"`
+	// Default is the default descriptor artifact, as of the last fetch of the default descriptor.
+	Default string `json:"default,omitempty"`
+	// Entries maps artifact/file names to the cache file with their content.
+	Entries map[string]string `json:"entries"`
+}
+
+// ktCache caches the files of Kurtosis artifacts on disk.
+// Files are content-addressed by the identity of their enclave and the name of their artifact,
+// since artifacts of an enclave are immutable.
+type ktCache struct {
+	dir string
+}
+
+func newKtCache() (*ktCache, error) {
+	dir := os.Getenv(CacheDirVar)
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("error getting user cache dir: %w", err)
+		}
+		dir = filepath.Join(userDir, "devnet-sdk", "kurtosis")
+	}
+	return &ktCache{dir: dir}, nil
+}
+
+func ktCacheEntryName(artifactName, fileName string) string {
+	return artifactName + "/" + fileName
+}
+
+// ktCacheKey is the name of the cache file of a file of an artifact of an enclave instance.
+func ktCacheKey(id EnclaveIdentity, artifactName, fileName string) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%s", id.UUID, id.Created.UnixNano(), ktCacheEntryName(artifactName, fileName))))
+	return hex.EncodeToString(h[:]) + ".json"
+}
+
+func (c *ktCache) indexPath(enclave string) string {
+	return filepath.Join(c.dir, "enclaves", enclave+".json")
+}
+
+func (c *ktCache) readIndex(enclave string) (*ktCacheIndex, error) {
+	data, err := os.ReadFile(c.indexPath(enclave))
+	if err != nil {
+		return nil, err
+	}
+	var index ktCacheIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("error parsing cache index of enclave %s: %w", enclave, err)
+	}
+	return &index, nil
+}
+
+// get returns the cached content of a file of an artifact of the enclave instance, if any.
+func (c *ktCache) get(id EnclaveIdentity, artifactName, fileName string) ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, ktCacheKey(id, artifactName, fileName)))
+	return data, err == nil
+}
+
+// put caches the content of a file of an artifact of the enclave instance,
+// and makes it the cached file of the enclave name, for offline use.
+// isDefault marks the artifact as the default descriptor of the enclave.
+func (c *ktCache) put(enclave string, id EnclaveIdentity, artifactName, fileName string, isDefault bool, data []byte) error {
+	if err := os.MkdirAll(filepath.Join(c.dir, "enclaves"), 0o755); err != nil {
+		return fmt.Errorf("error creating cache dir: %w", err)
+	}
+	key := ktCacheKey(id, artifactName, fileName)
+	if err := os.WriteFile(filepath.Join(c.dir, key), data, 0o644); err != nil {
+		return fmt.Errorf("error writing cache file: %w", err)
+	}
+
+	index, err := c.readIndex(enclave)
+	if err != nil || !index.Enclave.equal(id) {
+		// the enclave was recreated, the files of the previous instance are stale
+		index = &ktCacheIndex{Enclave: id, Entries: make(map[string]string)}
+	}
+	index.Entries[ktCacheEntryName(artifactName, fileName)] = key
+	if isDefault {
+		index.Default = artifactName
+	}
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cache index: %w", err)
+	}
+	if err := os.WriteFile(c.indexPath(enclave), indexData, 0o644); err != nil {
+		return fmt.Errorf("error writing cache index: %w", err)
+	}
+	return nil
+}
+
+// getOffline returns the cached content of a file of an artifact of the last fetched instance of the enclave.
+// If no artifact name is given, the file of the default descriptor is returned.
+func (c *ktCache) getOffline(enclave, artifactName, fileName string) ([]byte, error) {
+	index, err := c.readIndex(enclave)
+	if err != nil {
+		return nil, fmt.Errorf("no cached artifacts of enclave %s: %w", enclave, err)
+	}
+	if artifactName == "" {
+		if index.Default == "" {
+			return nil, fmt.Errorf("no cached default descriptor of enclave %s", enclave)
+		}
+		artifactName = index.Default
+	}
+	key, ok := index.Entries[ktCacheEntryName(artifactName, fileName)]
+	if !ok {
+		return nil, fmt.Errorf("file %s of artifact %s of enclave %s is not cached", fileName, artifactName, enclave)
+	}
+	return os.ReadFile(filepath.Join(c.dir, key))
+}
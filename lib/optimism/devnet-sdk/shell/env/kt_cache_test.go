@@ -0,0 +1,100 @@
+package env
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// identifiedTestFS is a testFS of an identified enclave, so its artifacts are cached
+type identifiedTestFS struct {
+	testFS
+	id EnclaveIdentity
+}
+
+func (m *identifiedTestFS) Identity() EnclaveIdentity {
+	return m.id
+}
+
+var _ IdentifiedEnclaveFS = (*identifiedTestFS)(nil)
+
+func TestKtCache(t *testing.T) {
+	cache := &ktCache{dir: t.TempDir()}
+	first := EnclaveIdentity{UUID: "uuid-1", Created: time.Unix(1000, 0)}
+	recreated := EnclaveIdentity{UUID: "uuid-2", Created: time.Unix(2000, 0)}
+
+	_, ok := cache.get(first, "devnet-descriptor-0", "env.json")
+	assert.False(t, ok)
+	_, err := cache.getOffline("myenclave", "", "env.json")
+	assert.Error(t, err)
+
+	require.NoError(t, cache.put("myenclave", first, "devnet-descriptor-0", "env.json", true, []byte("zero")))
+	require.NoError(t, cache.put("myenclave", first, "devnet-descriptor-1", "env.json", true, []byte("one")))
+	require.NoError(t, cache.put("myenclave", first, "custom", "env.json", false, []byte("custom")))
+
+	data, ok := cache.get(first, "devnet-descriptor-0", "env.json")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("zero"), data)
+	_, ok = cache.get(recreated, "devnet-descriptor-0", "env.json")
+	assert.False(t, ok, "artifacts of another instance of the enclave must not be shared")
+
+	data, err = cache.getOffline("myenclave", "", "env.json")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), data, "offline default must be the latest default descriptor")
+	data, err = cache.getOffline("myenclave", "custom", "env.json")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("custom"), data)
+	_, err = cache.getOffline("otherenclave", "", "env.json")
+	assert.Error(t, err)
+
+	// a recreated enclave replaces the cached artifacts of the previous instance
+	require.NoError(t, cache.put("myenclave", recreated, "devnet-descriptor-0", "env.json", true, []byte("new")))
+	data, err = cache.getOffline("myenclave", "", "env.json")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), data)
+	_, err = cache.getOffline("myenclave", "custom", "env.json")
+	assert.Error(t, err)
+}
+
+func TestFetchKurtosisDataCached(t *testing.T) {
+	t.Setenv(CacheDirVar, t.TempDir())
+	id := EnclaveIdentity{UUID: "uuid-1", Created: time.Unix(1000, 0)}
+	cache, err := newKtCache()
+	require.NoError(t, err)
+	require.NoError(t, cache.put("myenclave", id, "devnet-descriptor-1", "env.json", true, []byte(`{"name": "cached"}`)))
+
+	origNewEnclaveFS := NewEnclaveFS
+	defer func() { NewEnclaveFS = origNewEnclaveFS }()
+
+	u, err := url.Parse("kt://myenclave")
+	require.NoError(t, err)
+
+	t.Run("online", func(t *testing.T) {
+		// the artifact cannot be downloaded, so it must come from the cache
+		NewEnclaveFS = func(_ context.Context, _ string) (EnclaveFS, error) {
+			return &identifiedTestFS{testFS: testFS{artifacts: map[string]bool{"devnet-descriptor-1": false}}, id: id}, nil
+		}
+		name, data, err := fetchKurtosisData(u)
+		require.NoError(t, err)
+		assert.Equal(t, "myenclave", name)
+		assert.Equal(t, []byte(`{"name": "cached"}`), data)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		NewEnclaveFS = func(_ context.Context, _ string) (EnclaveFS, error) {
+			t.Fatal("offline mode must not reach the Kurtosis engine")
+			return nil, nil
+		}
+		name, data, err := fetchDevnetData(u.String(), true)
+		require.NoError(t, err)
+		assert.Equal(t, "myenclave", name)
+		assert.Equal(t, []byte(`{"name": "cached"}`), data)
+
+		_, _, err = fetchDevnetData("kt://otherenclave", true)
+		assert.Error(t, err)
+	})
+}
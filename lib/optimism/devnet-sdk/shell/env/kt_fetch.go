@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
 
 	ktfs "github.com/ethereum-optimism/optimism/devnet-sdk/kt/fs"
+	"github.com/kurtosis-tech/kurtosis/api/golang/engine/lib/kurtosis_context"
 )
 
 const (
@@ -22,9 +24,20 @@ type EnclaveFS interface {
 	Close() error
 }
 
+// EnclaveWriteFS is an EnclaveFS that can also upload artifacts
+type EnclaveWriteFS interface {
+	EnclaveFS
+	PutArtifact(ctx context.Context, name string, readers ...*ktfs.ArtifactFileReader) error
+}
+
 // enclaveFSWrapper wraps the artifact.EnclaveFS to implement our EnclaveFS interface
 type enclaveFSWrapper struct {
 	fs *ktfs.EnclaveFS
+	id EnclaveIdentity
+}
+
+func (w *enclaveFSWrapper) Identity() EnclaveIdentity {
+	return w.id
 }
 
 func (w *enclaveFSWrapper) GetArtifact(ctx context.Context, name string) (*ktfs.Artifact, error) {
@@ -35,6 +48,10 @@ func (w *enclaveFSWrapper) GetAllArtifactNames(ctx context.Context) ([]string, e
 	return w.fs.GetAllArtifactNames(ctx)
 }
 
+func (w *enclaveFSWrapper) PutArtifact(ctx context.Context, name string, readers ...*ktfs.ArtifactFileReader) error {
+	return w.fs.PutArtifact(ctx, name, readers...)
+}
+
 func (w *enclaveFSWrapper) Close() error {
 	// The underlying EnclaveFS doesn't have a Close method, but we need it for our interface
 	return nil
@@ -46,11 +63,26 @@ type NewEnclaveFSFunc func(ctx context.Context, enclave string) (EnclaveFS, erro
 // NewEnclaveFS is a variable that holds the function to create a new enclave filesystem
 // It can be replaced in tests
 var NewEnclaveFS NewEnclaveFSFunc = func(ctx context.Context, enclave string) (EnclaveFS, error) {
-	fs, err := ktfs.NewEnclaveFS(ctx, enclave)
+	kurtosisCtx, err := kurtosis_context.NewKurtosisContextFromLocalEngine()
+	if err != nil {
+		return nil, err
+	}
+	info, err := kurtosisCtx.GetEnclave(ctx, enclave)
 	if err != nil {
 		return nil, err
 	}
-	return &enclaveFSWrapper{fs: fs}, nil
+	enclaveCtx, err := kurtosisCtx.GetEnclaveContext(ctx, enclave)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := ktfs.NewEnclaveFS(ctx, enclave, ktfs.WithEnclaveCtx(enclaveCtx))
+	if err != nil {
+		return nil, err
+	}
+	return &enclaveFSWrapper{
+		fs: fs,
+		id: EnclaveIdentity{UUID: info.GetEnclaveUuid(), Created: info.GetCreationTime().AsTime()},
+	}, nil
 }
 
 // parseKurtosisURL parses a Kurtosis URL of the form kt://enclave/artifact/file
@@ -74,6 +106,21 @@ func parseKurtosisURL(u *url.URL) (enclave, artifactName, fileName string) {
 	return
 }
 
+// getNextDescriptor returns the name of the artifact for a new descriptor,
+// which follows the descriptor with the largest numerical suffix.
+func getNextDescriptor(ctx context.Context, fs EnclaveFS) (string, error) {
+	current, err := getDefaultDescriptor(ctx, fs)
+	if err != nil {
+		// no descriptor yet
+		return KurtosisDevnetEnvArtifactNamePrefix + "0", nil
+	}
+	var num int
+	if _, err := fmt.Sscanf(current[len(KurtosisDevnetEnvArtifactNamePrefix):], "%d", &num); err != nil {
+		return "", fmt.Errorf("invalid descriptor name %s: %w", current, err)
+	}
+	return fmt.Sprintf("%s%d", KurtosisDevnetEnvArtifactNamePrefix, num+1), nil
+}
+
 func getDefaultDescriptor(ctx context.Context, fs EnclaveFS) (string, error) {
 	prefix := KurtosisDevnetEnvArtifactNamePrefix
 
@@ -111,16 +158,39 @@ func getDefaultDescriptor(ctx context.Context, fs EnclaveFS) (string, error) {
 	return maxName, nil
 }
 
-// fetchKurtosisData reads data from a Kurtosis artifact
+// fetchCachedKurtosisData reads data of a Kurtosis artifact from the cache, without reaching the Kurtosis engine.
+func fetchCachedKurtosisData(u *url.URL) (string, []byte, error) {
+	enclave, artifactName, fileName := parseKurtosisURL(u)
+
+	cache, err := newKtCache()
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating cache: %w", err)
+	}
+	data, err := cache.getOffline(enclave, artifactName, fileName)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading cached artifact in offline mode: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Using cached descriptor of enclave %s (offline)\n", enclave)
+	return enclave, data, nil
+}
+
+// fetchKurtosisData reads data from a Kurtosis artifact.
+// Artifacts are cached, so they can be read offline with fetchCachedKurtosisData.
 func fetchKurtosisData(u *url.URL) (string, []byte, error) {
 	enclave, artifactName, fileName := parseKurtosisURL(u)
 
+	cache, err := newKtCache()
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating cache: %w", err)
+	}
+
 	fs, err := NewEnclaveFS(context.Background(), enclave)
 	if err != nil {
-		return "", nil, fmt.Errorf("error creating enclave fs: %w", err)
+		return "", nil, fmt.Errorf("error creating enclave fs (load offline to use the cached descriptor): %w", err)
 	}
 
-	if artifactName == "" {
+	isDefault := artifactName == ""
+	if isDefault {
 		artifactName, err = getDefaultDescriptor(context.Background(), fs)
 		if err != nil {
 			return "", nil, fmt.Errorf("error getting default descriptor: %w", err)
@@ -128,6 +198,17 @@ func fetchKurtosisData(u *url.URL) (string, []byte, error) {
 		fmt.Printf("Using default descriptor: %s\n", artifactName)
 	}
 
+	identifiedFS, cacheable := fs.(IdentifiedEnclaveFS)
+	if cacheable {
+		if data, ok := cache.get(identifiedFS.Identity(), artifactName, fileName); ok {
+			// keep the index up to date, so the latest default descriptor is used offline
+			if err := cache.put(enclave, identifiedFS.Identity(), artifactName, fileName, isDefault, data); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to cache descriptor: %v\n", err)
+			}
+			return enclave, data, nil
+		}
+	}
+
 	art, err := fs.GetArtifact(context.Background(), artifactName)
 	if err != nil {
 		return "", nil, fmt.Errorf("error getting artifact: %w", err)
@@ -140,5 +221,38 @@ func fetchKurtosisData(u *url.URL) (string, []byte, error) {
 		return "", nil, fmt.Errorf("error extracting file from artifact: %w", err)
 	}
 
+	if cacheable {
+		if err := cache.put(enclave, identifiedFS.Identity(), artifactName, fileName, isDefault, buf.Bytes()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache descriptor: %v\n", err)
+		}
+	}
+
 	return enclave, buf.Bytes(), nil
 }
+
+// writeKurtosisData uploads data as a Kurtosis artifact
+func writeKurtosisData(u *url.URL, data []byte) error {
+	enclave, artifactName, fileName := parseKurtosisURL(u)
+
+	fs, err := NewEnclaveFS(context.Background(), enclave)
+	if err != nil {
+		return fmt.Errorf("error creating enclave fs: %w", err)
+	}
+	writeFS, ok := fs.(EnclaveWriteFS)
+	if !ok {
+		return fmt.Errorf("enclave fs of %s cannot upload artifacts", enclave)
+	}
+
+	if artifactName == "" {
+		artifactName, err = getNextDescriptor(context.Background(), fs)
+		if err != nil {
+			return fmt.Errorf("error getting next descriptor: %w", err)
+		}
+		fmt.Printf("Saving descriptor as: %s\n", artifactName)
+	}
+
+	if err := writeFS.PutArtifact(context.Background(), artifactName, ktfs.NewArtifactFileReader(fileName, bytes.NewReader(data))); err != nil {
+		return fmt.Errorf("error putting artifact: %w", err)
+	}
+	return nil
+}
@@ -214,3 +214,34 @@ func TestGetDefaultDescriptor(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNextDescriptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		artifacts map[string]bool
+		wantName  string
+	}{
+		{
+			name:     "no descriptors",
+			wantName: "devnet-descriptor-0",
+		},
+		{
+			name: "after largest descriptor",
+			artifacts: map[string]bool{
+				"devnet-descriptor-1":  true,
+				"devnet-descriptor-10": true,
+				"other-artifact":       true,
+			},
+			wantName: "devnet-descriptor-11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &testFS{artifacts: tt.artifacts}
+			got, err := getNextDescriptor(context.Background(), fs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, got)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// nodeConfigTimeout bounds how long a node may take to serve its config, so unreachable devnets still load.
+const nodeConfigTimeout = 5 * time.Second
+
+// fixupChainConfig sets the chain config of a chain that has none in its descriptor.
+// The config is fetched from the first execution layer node that serves it,
+// or, if no node is reachable or the devnet is loaded offline, a minimal config with just the chain ID is crafted.
+func fixupChainConfig(chain *descriptors.Chain, offline bool) error {
+	if chain.Config != nil {
+		return nil
+	}
+	chainID, ok := new(big.Int).SetString(chain.ID, 10)
+	if !ok {
+		return fmt.Errorf("invalid chain ID of chain '%s': %s", chain.Name, chain.ID)
+	}
+
+	// offline, unreachable nodes would each take the timeout to fail, so they are not queried
+	nodes := chain.Nodes
+	if offline {
+		nodes = nil
+	}
+	for i, node := range nodes {
+		endpoint, err := nodeRPCURL(node, "el")
+		if err != nil {
+			continue
+		}
+		var config params.ChainConfig
+		if err := callNode(endpoint, &config, "debug_chainConfig"); err != nil {
+			continue
+		}
+		if config.ChainID == nil || config.ChainID.Cmp(chainID) != 0 {
+			return fmt.Errorf("node %d of chain '%s' serves chain %v, expected chain %s", i, chain.Name, config.ChainID, chainID)
+		}
+		chain.Config = &config
+		return nil
+	}
+
+	// the chain config is not available, e.g. because the devnet is not running, so craft a minimal one.
+	chain.Config = &params.ChainConfig{
+		ChainID: chainID,
+	}
+	return nil
+}
+
+// fixupRollupConfig sets the rollup config of an L2 chain that has none in its descriptor,
+// from the first consensus layer node that serves it.
+// The rollup config is left unset if no node is reachable.
+func fixupRollupConfig(chain *descriptors.L2Chain) error {
+	if chain.RollupConfig != nil {
+		return nil
+	}
+	for i, node := range chain.Nodes {
+		endpoint, err := nodeRPCURL(node, "cl")
+		if err != nil {
+			continue
+		}
+		var config rollup.Config
+		if err := callNode(endpoint, &config, "optimism_rollupConfig"); err != nil {
+			continue
+		}
+		if config.L2ChainID == nil || config.L2ChainID.String() != chain.ID {
+			return fmt.Errorf("node %d of chain '%s' serves rollup config of chain %v, expected chain %s", i, chain.Name, config.L2ChainID, chain.ID)
+		}
+		chain.RollupConfig = &config
+		return nil
+	}
+	return nil
+}
+
+// nodeRPCURL returns the URL of the RPC endpoint of a service of the node.
+func nodeRPCURL(node descriptors.Node, service string) (string, error) {
+	svc, ok := node.Services[service]
+	if !ok {
+		return "", fmt.Errorf("no %s service", service)
+	}
+	endpoint, ok := svc.Endpoints["rpc"]
+	if !ok {
+		return "", fmt.Errorf("no RPC endpoint for %s service", service)
+	}
+	scheme := endpoint.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	if endpoint.Port == 0 {
+		return fmt.Sprintf("%s://%s", scheme, endpoint.Host), nil
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, endpoint.Host, endpoint.Port), nil
+}
+
+// callNode calls a single RPC method of a node.
+func callNode(endpoint string, result any, method string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), nodeConfigTimeout)
+	defer cancel()
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("error dialing %s: %w", endpoint, err)
+	}
+	defer client.Close()
+	if err := client.CallContext(ctx, result, method); err != nil {
+		return fmt.Errorf("error calling %s on %s: %w", method, endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package env
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDebugAPI struct {
+	config *params.ChainConfig
+}
+
+func (api *testDebugAPI) ChainConfig() *params.ChainConfig {
+	return api.config
+}
+
+type testOptimismAPI struct {
+	config *rollup.Config
+}
+
+func (api *testOptimismAPI) RollupConfig() *rollup.Config {
+	return api.config
+}
+
+// newTestNode serves the chain config and rollup config like the EL and CL nodes of a chain,
+// and returns the RPC endpoint of the server.
+func newTestNode(t *testing.T, chainID int64) descriptors.PortInfo {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("debug", &testDebugAPI{
+		config: &params.ChainConfig{ChainID: big.NewInt(chainID), LondonBlock: big.NewInt(0)},
+	}))
+	require.NoError(t, server.RegisterName("optimism", &testOptimismAPI{
+		config: &rollup.Config{L2ChainID: big.NewInt(chainID), BlockTime: 2},
+	}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	u, err := url.Parse(httpServer.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return descriptors.PortInfo{Host: u.Hostname(), Port: port}
+}
+
+func nodeWithServices(endpoint descriptors.PortInfo, services ...string) descriptors.Node {
+	node := descriptors.Node{Services: descriptors.ServiceMap{}}
+	for _, name := range services {
+		node.Services[name] = descriptors.Service{
+			Name:      name,
+			Endpoints: descriptors.EndpointMap{"rpc": endpoint},
+		}
+	}
+	return node
+}
+
+func TestFixupDevnetConfig(t *testing.T) {
+	l1Node := newTestNode(t, 900)
+	l2Node := newTestNode(t, 901)
+	// nothing listens on port 1, like on the nodes of a devnet that is down
+	unreachable := descriptors.PortInfo{Host: "127.0.0.1", Port: 1}
+
+	t.Run("fetches configs from nodes", func(t *testing.T) {
+		config := descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{ID: "900", Nodes: []descriptors.Node{nodeWithServices(l1Node, "el")}},
+			L2: []*descriptors.L2Chain{{Chain: descriptors.Chain{
+				ID:    "901",
+				Nodes: []descriptors.Node{nodeWithServices(unreachable, "el", "cl"), nodeWithServices(l2Node, "el", "cl")},
+			}}},
+		}
+		config, err := fixupDevnetConfig(config, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(900), config.L1.Config.ChainID)
+		assert.Equal(t, big.NewInt(0), config.L1.Config.LondonBlock)
+		assert.Equal(t, big.NewInt(901), config.L2[0].Config.ChainID)
+		assert.Equal(t, big.NewInt(0), config.L2[0].Config.LondonBlock)
+		require.NotNil(t, config.L2[0].RollupConfig)
+		assert.Equal(t, uint64(2), config.L2[0].RollupConfig.BlockTime)
+	})
+
+	t.Run("crafts minimal config if nodes are unreachable", func(t *testing.T) {
+		config := descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{ID: "900", Nodes: []descriptors.Node{nodeWithServices(unreachable, "el")}},
+			L2: []*descriptors.L2Chain{{Chain: descriptors.Chain{
+				ID:    "901",
+				Nodes: []descriptors.Node{nodeWithServices(unreachable, "el", "cl")},
+			}}},
+		}
+		config, err := fixupDevnetConfig(config, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, &params.ChainConfig{ChainID: big.NewInt(900)}, config.L1.Config)
+		assert.Equal(t, &params.ChainConfig{ChainID: big.NewInt(901)}, config.L2[0].Config)
+		assert.Nil(t, config.L2[0].RollupConfig)
+	})
+
+	t.Run("does not query nodes offline", func(t *testing.T) {
+		config := descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{ID: "900", Nodes: []descriptors.Node{nodeWithServices(l1Node, "el")}},
+			L2: []*descriptors.L2Chain{{Chain: descriptors.Chain{
+				ID:    "901",
+				Nodes: []descriptors.Node{nodeWithServices(l2Node, "el", "cl")},
+			}}},
+		}
+		config, err := fixupDevnetConfig(config, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, &params.ChainConfig{ChainID: big.NewInt(900)}, config.L1.Config)
+		assert.Equal(t, &params.ChainConfig{ChainID: big.NewInt(901)}, config.L2[0].Config)
+		assert.Nil(t, config.L2[0].RollupConfig)
+	})
+
+	t.Run("keeps configs of descriptor", func(t *testing.T) {
+		l2Config := &params.ChainConfig{ChainID: big.NewInt(901)}
+		config := descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{ID: "900", Nodes: []descriptors.Node{nodeWithServices(l1Node, "el")}},
+			L2: []*descriptors.L2Chain{{
+				Chain:        descriptors.Chain{ID: "901", Config: l2Config, Nodes: []descriptors.Node{nodeWithServices(l2Node, "el")}},
+				RollupConfig: &rollup.Config{L2ChainID: big.NewInt(901), BlockTime: 1},
+			}},
+		}
+		config, err := fixupDevnetConfig(config, false)
+		require.NoError(t, err)
+
+		assert.Same(t, l2Config, config.L2[0].Config)
+		assert.Equal(t, uint64(1), config.L2[0].RollupConfig.BlockTime)
+	})
+
+	t.Run("fails if node serves another chain", func(t *testing.T) {
+		config := descriptors.DevnetEnvironment{
+			L1: &descriptors.Chain{ID: "900", Nodes: []descriptors.Node{nodeWithServices(l1Node, "el")}},
+			L2: []*descriptors.L2Chain{{Chain: descriptors.Chain{
+				ID:    "902",
+				Nodes: []descriptors.Node{nodeWithServices(l2Node, "el")},
+			}}},
+		}
+		_, err := fixupDevnetConfig(config, false)
+		require.ErrorContains(t, err, "serves chain 901, expected chain 902")
+	})
+}
@@ -0,0 +1,172 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// RegistryVar is the env var of the path of the devnet registry file.
+// Defaults to devnet-sdk/registry.json in the user config directory, e.g. ~/.config/devnet-sdk/registry.json.
+const RegistryVar = "DEVNET_REGISTRY"
+
+// devnetNameRegexp matches valid names of registered devnets.
+// Names cannot contain path separators, dots or colons, so they are never confused with file paths or URLs.
+var devnetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// registry is the content of the devnet registry file.
+type registry struct {
+	// Default is the name of the default devnet, if any.
+	Default string `json:"default,omitempty"`
+	// Devnets maps the names of the devnets to their URLs.
+	Devnets map[string]string `json:"devnets"`
+}
+
+// RegisteredDevnet is a devnet of the registry.
+type RegisteredDevnet struct {
+	Name    string
+	URL     string
+	Default bool
+}
+
+func registryPath() (string, error) {
+	if path := os.Getenv(RegistryVar); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting user config dir: %w", err)
+	}
+	return filepath.Join(dir, "devnet-sdk", "registry.json"), nil
+}
+
+// loadRegistry reads the registry file. A missing file is an empty registry.
+func loadRegistry() (*registry, error) {
+	path, err := registryPath()
+	if err != nil {
+		return nil, err
+	}
+	reg := &registry{Devnets: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return reg, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading registry: %w", err)
+	}
+	if err := json.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("error parsing registry %s: %w", path, err)
+	}
+	if reg.Devnets == nil {
+		reg.Devnets = make(map[string]string)
+	}
+	return reg, nil
+}
+
+func (r *registry) save() error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating registry dir: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding registry: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing registry: %w", err)
+	}
+	return nil
+}
+
+// RegisterDevnet adds a devnet to the registry, or changes the URL of a registered devnet,
+// so it can be loaded by name.
+func RegisterDevnet(name string, devnetURL string) error {
+	if !devnetNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid devnet name %q: only letters, digits, '-' and '_' are allowed", name)
+	}
+	reg, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	reg.Devnets[name] = devnetURL
+	return reg.save()
+}
+
+// UnregisterDevnet removes a devnet from the registry. It is no longer the default devnet, if it was.
+func UnregisterDevnet(name string) error {
+	reg, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	if _, ok := reg.Devnets[name]; !ok {
+		return fmt.Errorf("devnet '%s' is not registered", name)
+	}
+	delete(reg.Devnets, name)
+	if reg.Default == name {
+		reg.Default = ""
+	}
+	return reg.save()
+}
+
+// ListDevnets returns the registered devnets, sorted by name.
+func ListDevnets() ([]RegisteredDevnet, error) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	devnets := make([]RegisteredDevnet, 0, len(reg.Devnets))
+	for _, name := range sortedKeys(reg.Devnets) {
+		devnets = append(devnets, RegisteredDevnet{
+			Name:    name,
+			URL:     reg.Devnets[name],
+			Default: name == reg.Default,
+		})
+	}
+	return devnets, nil
+}
+
+// SetDefault makes a registered devnet the default devnet, which LoadDefault loads.
+func SetDefault(name string) error {
+	reg, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	if _, ok := reg.Devnets[name]; !ok {
+		return fmt.Errorf("devnet '%s' is not registered", name)
+	}
+	reg.Default = name
+	return reg.save()
+}
+
+// LoadDefault loads the default devnet of the registry, with the options of LoadDevnetFromURL.
+func LoadDefault(opts ...LoadOption) (*DevnetEnv, error) {
+	reg, err := loadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	if reg.Default == "" {
+		return nil, fmt.Errorf("no default devnet registered")
+	}
+	return LoadDevnetFromURL(reg.Devnets[reg.Default], opts...)
+}
+
+// resolveDevnetURL returns the URL of a registered devnet, if the URL is the name of one.
+// Anything else, e.g. a path to a descriptor file, is returned as is.
+func resolveDevnetURL(devnetURL string) (string, error) {
+	if !devnetNameRegexp.MatchString(devnetURL) {
+		return devnetURL, nil
+	}
+	reg, err := loadRegistry()
+	if err != nil {
+		return "", err
+	}
+	if registered, ok := reg.Devnets[devnetURL]; ok {
+		return registered, nil
+	}
+	return devnetURL, nil
+}
@@ -0,0 +1,60 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(RegistryVar, filepath.Join(dir, "config", "registry.json"))
+
+	content := `{
+		"name": "alpha",
+		"l1": {"name": "l1", "id": "1", "nodes": [{"services": {"el": {"endpoints": {"rpc": {"host": "localhost", "port": 8545}}}}}]},
+		"l2": [{"name": "op", "id": "2", "nodes": [{"services": {"el": {"endpoints": {"rpc": {"host": "localhost", "port": 9545}}}}}]}]
+	}`
+	alphaPath := filepath.Join(dir, "alpha.json")
+	require.NoError(t, os.WriteFile(alphaPath, []byte(content), 0o644))
+
+	// an empty registry has no devnets
+	devnets, err := ListDevnets()
+	require.NoError(t, err)
+	assert.Empty(t, devnets)
+	_, err = LoadDefault()
+	assert.Error(t, err)
+
+	require.NoError(t, RegisterDevnet("alpha", alphaPath))
+	require.NoError(t, RegisterDevnet("beta", "kt://beta"))
+	assert.Error(t, RegisterDevnet("not/a/name", alphaPath))
+	assert.Error(t, SetDefault("gamma"), "unregistered devnets cannot be the default")
+	require.NoError(t, SetDefault("alpha"))
+
+	devnets, err = ListDevnets()
+	require.NoError(t, err)
+	assert.Equal(t, []RegisteredDevnet{
+		{Name: "alpha", URL: alphaPath, Default: true},
+		{Name: "beta", URL: "kt://beta"},
+	}, devnets)
+
+	devnet, err := LoadDefault()
+	require.NoError(t, err)
+	assert.Equal(t, alphaPath, devnet.URL)
+	assert.Equal(t, "l1", devnet.Config.L1.Name)
+
+	// registered devnets can be loaded by name
+	devnet, err = LoadDevnetFromURL("alpha")
+	require.NoError(t, err)
+	assert.Equal(t, alphaPath, devnet.URL)
+
+	require.NoError(t, UnregisterDevnet("alpha"))
+	devnets, err = ListDevnets()
+	require.NoError(t, err)
+	assert.Equal(t, []RegisteredDevnet{{Name: "beta", URL: "kt://beta"}}, devnets)
+	_, err = LoadDefault()
+	assert.Error(t, err, "unregistered devnet must no longer be the default")
+}
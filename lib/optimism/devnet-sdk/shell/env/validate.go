@@ -0,0 +1,151 @@
+package env
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ValidationError is a problem with a field of a devnet descriptor.
+type ValidationError struct {
+	// Path is the path of the field in the descriptor, e.g. "l2[0].nodes[1].services.el".
+	Path string
+	Msg  string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+// ValidationErrors are all the problems found in a devnet descriptor.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid devnet descriptor:\n  %s", strings.Join(msgs, "\n  "))
+}
+
+// Validate checks that the devnet descriptor is complete and consistent:
+// that the chains have unique chain IDs and nodes with an execution layer service,
+// that the endpoints of the services have a host and a valid port,
+// and that the wallets have an address and a valid key, if any.
+// It returns ValidationErrors with the path of every invalid field.
+func (d *DevnetEnv) Validate() error {
+	return validateDevnetConfig(&d.Config)
+}
+
+func validateDevnetConfig(config *descriptors.DevnetEnvironment) error {
+	v := &validator{chainIDs: make(map[string]string)}
+	if config.L1 == nil {
+		v.fail("l1", "missing L1 chain")
+	} else {
+		v.chain("l1", config.L1)
+	}
+	if len(config.L2) == 0 {
+		v.fail("l2", "missing L2 chains")
+	}
+	for i, l2 := range config.L2 {
+		path := fmt.Sprintf("l2[%d]", i)
+		if l2 == nil {
+			v.fail(path, "missing L2 chain")
+			continue
+		}
+		v.chain(path, &l2.Chain)
+		v.wallets(path+".l1_wallets", l2.L1Wallets)
+	}
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+type validator struct {
+	errs ValidationErrors
+	// chainIDs maps the IDs of the chains validated so far to their path, to detect duplicates.
+	chainIDs map[string]string
+}
+
+func (v *validator) fail(path string, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) chain(path string, chain *descriptors.Chain) {
+	id, ok := new(big.Int).SetString(chain.ID, 10)
+	switch {
+	case chain.ID == "":
+		v.fail(path+".id", "missing chain ID")
+	case !ok:
+		v.fail(path+".id", "chain ID %q is not a decimal number", chain.ID)
+	default:
+		if other, dup := v.chainIDs[id.String()]; dup {
+			v.fail(path+".id", "chain ID %s is also used by %s", id, other)
+		}
+		v.chainIDs[id.String()] = path
+		if chain.Config != nil && chain.Config.ChainID != nil && chain.Config.ChainID.Cmp(id) != 0 {
+			v.fail(path+".config.chainId", "chain ID %s does not match chain ID %s of the chain", chain.Config.ChainID, id)
+		}
+	}
+
+	if len(chain.Nodes) == 0 {
+		v.fail(path+".nodes", "missing nodes")
+	}
+	for i, node := range chain.Nodes {
+		nodePath := fmt.Sprintf("%s.nodes[%d]", path, i)
+		if _, ok := node.Services["el"]; !ok {
+			v.fail(nodePath+".services.el", "missing execution layer service")
+		}
+		v.services(nodePath+".services", node.Services)
+	}
+	v.services(path+".services", chain.Services)
+	v.wallets(path+".wallets", chain.Wallets)
+}
+
+func (v *validator) services(path string, services descriptors.ServiceMap) {
+	for _, name := range sortedKeys(services) {
+		service := services[name]
+		for _, endpointName := range sortedKeys(service.Endpoints) {
+			endpoint := service.Endpoints[endpointName]
+			endpointPath := fmt.Sprintf("%s.%s.endpoints.%s", path, name, endpointName)
+			if endpoint.Host == "" {
+				v.fail(endpointPath+".host", "missing host")
+			}
+			if endpoint.Port < 0 || endpoint.Port > 65535 {
+				v.fail(endpointPath+".port", "port %d is out of range", endpoint.Port)
+			}
+		}
+	}
+}
+
+func (v *validator) wallets(path string, wallets descriptors.WalletMap) {
+	for _, name := range sortedKeys(wallets) {
+		wallet := wallets[name]
+		walletPath := fmt.Sprintf("%s.%s", path, name)
+		if wallet.Address == (common.Address{}) {
+			v.fail(walletPath+".address", "missing address")
+		}
+		if wallet.PrivateKey == "" {
+			continue
+		}
+		if _, err := crypto.HexToECDSA(strings.TrimPrefix(wallet.PrivateKey, "0x")); err != nil {
+			v.fail(walletPath+".private_key", "invalid private key: %v", err)
+		}
+	}
+}
+
+// sortedKeys returns the keys of the map in order, so errors are reported in a stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -40,17 +42,41 @@ var (
 // clientManager handles ethclient connections
 type clientManager struct {
 	mu          sync.RWMutex
+	rpcClients  map[string]client.RPC
 	clients     map[string]*sources.EthClient
 	gethClients map[string]*ethclient.Client
 }
 
 func newClientManager() *clientManager {
 	return &clientManager{
+		rpcClients:  make(map[string]client.RPC),
 		clients:     make(map[string]*sources.EthClient),
 		gethClients: make(map[string]*ethclient.Client),
 	}
 }
 
+// rpcClientLocked returns the RPC client of the URL, dialing it if needed.
+// The caller must hold the write lock.
+func (m *clientManager) rpcClientLocked(rpcURL string) (client.RPC, error) {
+	if rpcCl, ok := m.rpcClients[rpcURL]; ok {
+		return rpcCl, nil
+	}
+	rpcClient, err := rpc.DialContext(context.Background(), rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	rpcCl := client.NewBaseRPCClient(rpcClient)
+	m.rpcClients[rpcURL] = rpcCl
+	return rpcCl, nil
+}
+
+// RPCClient returns the RPC client of the URL, e.g. to batch requests.
+func (m *clientManager) RPCClient(rpcURL string) (client.RPC, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rpcClientLocked(rpcURL)
+}
+
 func (m *clientManager) Client(rpcURL string) (*sources.EthClient, error) {
 	m.mu.RLock()
 	if client, ok := m.clients[rpcURL]; ok {
@@ -80,11 +106,11 @@ func (m *clientManager) Client(rpcURL string) (*sources.EthClient, error) {
 		RPCProviderKind:       sources.RPCKindStandard,
 		MethodResetDuration:   time.Minute,
 	}
-	rpcClient, err := rpc.DialContext(context.Background(), rpcURL)
+	rpcCl, err := m.rpcClientLocked(rpcURL)
 	if err != nil {
 		return nil, err
 	}
-	ethCl, err := sources.NewEthClient(client.NewBaseRPCClient(rpcClient), log.Root(), nil, &ethClCfg)
+	ethCl, err := sources.NewEthClient(rpcCl, log.Root(), nil, &ethClCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -122,12 +148,18 @@ type chain struct {
 	nodes       []Node
 	chainConfig *params.ChainConfig
 	addresses   AddressMap
+	// services are the names of the services of the chain in the devnet, if they are known.
+	services []string
 }
 
 func (c *chain) Nodes() []Node {
 	return c.nodes
 }
 
+func (c *chain) Services() []string {
+	return c.services
+}
+
 // Wallet returns the first wallet which meets all provided constraints, or an
 // error.
 // Typically this will be one of the pre-funded wallets associated with
@@ -154,6 +186,15 @@ func (c *chain) Config() (*params.ChainConfig, error) {
 	return c.chainConfig, nil
 }
 
+// ForkSchedule returns the schedule of the timestamp-activated forks of the chain, from its chain config.
+func (c *chain) ForkSchedule() (*ForkSchedule, error) {
+	cfg, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+	return l1ForkSchedule(cfg), nil
+}
+
 func (c *chain) Addresses() AddressMap {
 	return c.addresses
 }
@@ -179,7 +220,11 @@ func newNodesFromDescriptor(d *descriptors.Chain) []Node {
 	nodes := make([]Node, len(d.Nodes))
 	for i, node := range d.Nodes {
 		rpc := node.Services["el"].Endpoints["rpc"]
-		nodes[i] = newNode(fmt.Sprintf("http://%s:%d", rpc.Host, rpc.Port), clients)
+		n := newNode(fmt.Sprintf("http://%s:%d", rpc.Host, rpc.Port), clients)
+		if ws, ok := node.Services["el"].Endpoints["ws"]; ok {
+			n.wsUrl = fmt.Sprintf("ws://%s:%d", ws.Host, ws.Port)
+		}
+		nodes[i] = n
 	}
 	return nodes
 }
@@ -189,6 +234,7 @@ func newChainFromDescriptor(d *descriptors.Chain) (*chain, error) {
 
 	nodes := newNodesFromDescriptor(d)
 	c := newChain(d.ID, nil, d.Config, AddressMap(d.Addresses), nodes) // Create chain first
+	c.services = serviceNames(d.Services)
 
 	wallets, err := newWalletMapFromDescriptorWalletMap(d.Wallets, c)
 	if err != nil {
@@ -199,6 +245,16 @@ func newChainFromDescriptor(d *descriptors.Chain) (*chain, error) {
 	return c, nil
 }
 
+// serviceNames returns the sorted names of the services.
+func serviceNames(services descriptors.ServiceMap) []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func newChain(chainID string, wallets WalletMap, chainConfig *params.ChainConfig, addresses AddressMap, nodes []Node) *chain {
 	chain := &chain{
 		id:          chainID,
@@ -215,6 +271,7 @@ func newL2ChainFromDescriptor(d *descriptors.L2Chain) (*l2Chain, error) {
 
 	nodes := newNodesFromDescriptor(&d.Chain)
 	c := newL2Chain(d.ID, nil, nil, d.Config, AddressMap(d.L1Addresses), AddressMap(d.Addresses), nodes) // Create chain first
+	c.services = serviceNames(d.Services)
 
 	l2Wallets, err := newWalletMapFromDescriptorWalletMap(d.Wallets, c)
 	if err != nil {
@@ -227,6 +284,7 @@ func newL2ChainFromDescriptor(d *descriptors.L2Chain) (*l2Chain, error) {
 		return nil, err
 	}
 	c.l1Wallets = l1Wallets
+	c.rollupConfig = d.RollupConfig
 
 	return c, nil
 }
@@ -244,6 +302,27 @@ type l2Chain struct {
 	*chain
 	l1Addresses AddressMap
 	l1Wallets   WalletMap
+	// rollupConfig is the rollup config of the chain, if it is known.
+	rollupConfig *rollup.Config
+	// l1 is the chain that the L2 settles to, if it is known.
+	l1 Chain
+}
+
+func (c *l2Chain) l1Chain() Chain {
+	return c.l1
+}
+
+// ForkSchedule returns the schedule of the forks of the L2 chain,
+// from its rollup config if known, or else from its chain config.
+func (c *l2Chain) ForkSchedule() (*ForkSchedule, error) {
+	if c.rollupConfig != nil {
+		return l2ForkSchedule(c.chainConfig, c.rollupConfig), nil
+	}
+	cfg, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+	return l2ForkSchedule(cfg, nil), nil
 }
 
 func (c *l2Chain) L1Addresses() AddressMap {
@@ -3,12 +3,22 @@ package system
 import (
 	"context"
 	"math/big"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/registry/empty"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClientManager(t *testing.T) {
@@ -110,6 +120,11 @@ func TestL2ChainFromDescriptor(t *testing.T) {
 			Addresses: descriptors.AddressMap{
 				"user2": common.HexToAddress("0x1234567890123456789012345678901234567891"),
 			},
+			Services: descriptors.ServiceMap{
+				"proposer":   descriptors.Service{Name: "op-proposer"},
+				"batcher":    descriptors.Service{Name: "op-batcher"},
+				"challenger": descriptors.Service{Name: "op-challenger"},
+			},
 		},
 		L1Addresses: descriptors.AddressMap{
 			"user1": common.HexToAddress("0x1234567890123456789012345678901234567890"),
@@ -126,6 +141,7 @@ func TestL2ChainFromDescriptor(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, chain)
 	assert.Equal(t, "http://localhost:8545", chain.Nodes()[0].RPCURL())
+	assert.Equal(t, []string{"batcher", "challenger", "proposer"}, chain.Services())
 
 	// Compare the underlying big.Int values
 	chainID := chain.ID()
@@ -247,3 +263,93 @@ func TestContractsRegistry(t *testing.T) {
 		assert.Same(t, registry1, registry2)
 	})
 }
+
+// balanceService serves the balances of accounts, in eth_getBalance requests
+type balanceService struct {
+	balances map[common.Address]*big.Int
+	blocks   []rpc.BlockNumberOrHash
+}
+
+func (s *balanceService) GetBalance(addr common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	s.blocks = append(s.blocks, block)
+	return (*hexutil.Big)(s.balances[addr])
+}
+
+func TestNodeMultiCall(t *testing.T) {
+	alice, bob := common.HexToAddress("0xa11ce"), common.HexToAddress("0xb0b")
+	service := &balanceService{balances: map[common.Address]*big.Int{
+		alice: big.NewInt(1),
+		bob:   big.NewInt(2),
+	}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	node := newNode(httpServer.URL, newClientManager())
+	results, err := node.MultiCall(context.Background(), rpcblock.ByNumber(7),
+		batching.NewBalanceCall(alice), batching.NewBalanceCall(bob))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, big.NewInt(1), results[0].GetBigInt(0))
+	assert.Equal(t, big.NewInt(2), results[1].GetBigInt(0))
+	for _, block := range service.blocks {
+		number, ok := block.Number()
+		require.True(t, ok)
+		assert.Equal(t, rpc.BlockNumber(7), number, "all calls must be executed at the same block")
+	}
+}
+
+// headsService notifies subscribers of a single new head
+type headsService struct {
+	head *coreTypes.Header
+}
+
+func (s *headsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		_ = notifier.Notify(sub.ID, s.head)
+	}()
+	return sub, nil
+}
+
+func TestNodeSubscriptions(t *testing.T) {
+	t.Run("subscribes to new heads", func(t *testing.T) {
+		head := &coreTypes.Header{Number: big.NewInt(42), Difficulty: big.NewInt(0)}
+		server := rpc.NewServer()
+		require.NoError(t, server.RegisterName("eth", &headsService{head: head}))
+		httpServer := httptest.NewServer(server.WebsocketHandler(nil))
+		t.Cleanup(httpServer.Close)
+
+		node := newNode("http://unused", newClientManager())
+		node.wsUrl = "ws" + strings.TrimPrefix(httpServer.URL, "http")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		heads := make(chan *coreTypes.Header, 1)
+		sub, err := node.SubscribeNewHeads(ctx, heads)
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		select {
+		case got := <-heads:
+			assert.Equal(t, head.Hash(), got.Hash())
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for new head")
+		}
+	})
+
+	t.Run("requires a websocket endpoint", func(t *testing.T) {
+		node := newNode("http://unused", newClientManager())
+		_, err := node.SubscribeNewHeads(context.Background(), make(chan *coreTypes.Header))
+		assert.ErrorIs(t, err, ErrNoWebsocket)
+		_, err = node.SubscribeLogs(context.Background(), ethereum.FilterQuery{}, make(chan coreTypes.Log))
+		assert.ErrorIs(t, err, ErrNoWebsocket)
+	})
+}
@@ -0,0 +1,76 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	SystemConfigAddressName   = "systemConfigProxy"
+	OptimismPortalAddressName = "optimismPortalProxy"
+)
+
+// ChainContracts are the contracts of a L2 chain, bound to its deployment.
+// The L1 contracts are bound to the sequencer node of the L1, the L2 predeploys to the sequencer node of the L2.
+type ChainContracts struct {
+	SystemConfig   *bindings.SystemConfig
+	OptimismPortal *bindings.OptimismPortal
+
+	L1Block        *bindings.L1Block
+	GasPriceOracle *bindings.GasPriceOracle
+}
+
+// settledChain is implemented by L2 chains that know the L1 chain they settle to.
+type settledChain interface {
+	l1Chain() Chain
+}
+
+// Contracts returns the contracts of the L2 chain, bound to the addresses of its deployment.
+func Contracts(chain L2Chain) (*ChainContracts, error) {
+	settled, ok := chain.(settledChain)
+	if !ok || settled.l1Chain() == nil {
+		return nil, fmt.Errorf("L1 chain of chain %s is unknown", chain.ID())
+	}
+	l1Client, err := sequencerClient(settled.l1Chain())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 client: %w", err)
+	}
+	l2Client, err := sequencerClient(chain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 client: %w", err)
+	}
+
+	c := &ChainContracts{}
+	systemConfigAddr, ok := chain.L1Addresses()[SystemConfigAddressName]
+	if !ok {
+		return nil, fmt.Errorf("address %s not found", SystemConfigAddressName)
+	}
+	if c.SystemConfig, err = bindings.NewSystemConfig(systemConfigAddr, l1Client); err != nil {
+		return nil, fmt.Errorf("failed to bind SystemConfig: %w", err)
+	}
+	portalAddr, ok := chain.L1Addresses()[OptimismPortalAddressName]
+	if !ok {
+		return nil, fmt.Errorf("address %s not found", OptimismPortalAddressName)
+	}
+	if c.OptimismPortal, err = bindings.NewOptimismPortal(portalAddr, l1Client); err != nil {
+		return nil, fmt.Errorf("failed to bind OptimismPortal: %w", err)
+	}
+	if c.L1Block, err = bindings.NewL1Block(predeploys.L1BlockAddr, l2Client); err != nil {
+		return nil, fmt.Errorf("failed to bind L1Block: %w", err)
+	}
+	if c.GasPriceOracle, err = bindings.NewGasPriceOracle(predeploys.GasPriceOracleAddr, l2Client); err != nil {
+		return nil, fmt.Errorf("failed to bind GasPriceOracle: %w", err)
+	}
+	return c, nil
+}
+
+func sequencerClient(chain Chain) (*ethclient.Client, error) {
+	nodes := chain.Nodes()
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("chain %s has no nodes", chain.ID())
+	}
+	return nodes[0].GethClient()
+}
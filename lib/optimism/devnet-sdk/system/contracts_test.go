@@ -0,0 +1,43 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContracts(t *testing.T) {
+	clients := newClientManager()
+	l1 := newChain("1", WalletMap{}, nil, AddressMap{}, []Node{newNode("http://localhost:8545", clients)})
+	l1Addresses := AddressMap{
+		SystemConfigAddressName:   common.HexToAddress("0x5c"),
+		OptimismPortalAddressName: common.HexToAddress("0x0b"),
+	}
+
+	t.Run("binds contracts", func(t *testing.T) {
+		l2 := newL2Chain("2", WalletMap{}, WalletMap{}, nil, l1Addresses, AddressMap{}, []Node{newNode("http://localhost:9545", clients)})
+		l2.l1 = l1
+
+		contracts, err := Contracts(l2)
+		require.NoError(t, err)
+		assert.NotNil(t, contracts.SystemConfig)
+		assert.NotNil(t, contracts.OptimismPortal)
+		assert.NotNil(t, contracts.L1Block)
+		assert.NotNil(t, contracts.GasPriceOracle)
+	})
+
+	t.Run("requires the L1 chain", func(t *testing.T) {
+		l2 := newL2Chain("2", WalletMap{}, WalletMap{}, nil, l1Addresses, AddressMap{}, []Node{newNode("http://localhost:9545", clients)})
+		_, err := Contracts(l2)
+		assert.Error(t, err)
+	})
+
+	t.Run("requires the addresses", func(t *testing.T) {
+		l2 := newL2Chain("2", WalletMap{}, WalletMap{}, nil, AddressMap{}, AddressMap{}, []Node{newNode("http://localhost:9545", clients)})
+		l2.l1 = l1
+		_, err := Contracts(l2)
+		assert.ErrorContains(t, err, SystemConfigAddressName)
+	})
+}
@@ -0,0 +1,99 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// FeeEstimate is the maximum cost of a transaction on a chain.
+// On L2 chains, transactions pay the L1 data fee and the operator fee on top of the execution fee.
+type FeeEstimate struct {
+	// ExecutionFee is the gas limit times the fee cap of the transaction, blob gas included.
+	ExecutionFee *big.Int
+	L1Fee        *big.Int
+	OperatorFee  *big.Int
+}
+
+// Total returns the sum of all fees.
+func (f *FeeEstimate) Total() *big.Int {
+	total := new(big.Int).Add(f.ExecutionFee, f.L1Fee)
+	return total.Add(total, f.OperatorFee)
+}
+
+// nodeStateGetter reads the storage of a node at a block, for the fee functions of op-geth.
+// The fee functions cannot return errors, so the first error is kept to be checked afterwards.
+type nodeStateGetter struct {
+	ctx    context.Context
+	client *ethclient.Client
+	block  *big.Int
+	err    error
+}
+
+func (g *nodeStateGetter) GetState(addr common.Address, key common.Hash) common.Hash {
+	val, err := g.client.StorageAt(g.ctx, addr, key, g.block)
+	if err != nil {
+		if g.err == nil {
+			g.err = fmt.Errorf("failed to read slot %s of %s: %w", key, addr, err)
+		}
+		return common.Hash{}
+	}
+	return common.BytesToHash(val)
+}
+
+// EstimateFees estimates the maximum fees of the transaction, if it was included in the next block of the chain.
+// The L1 data fee depends on the size of the transaction, so it is only exact for signed transactions.
+func EstimateFees(ctx context.Context, chain Chain, tx *coreTypes.Transaction) (*FeeEstimate, error) {
+	cfg, err := chain.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain config: %w", err)
+	}
+	node := chain.Nodes()[0]
+	head, err := node.BlockByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head block: %w", err)
+	}
+	client, err := node.GethClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	state := &nodeStateGetter{ctx: ctx, client: client, block: new(big.Int).SetUint64(head.NumberU64())}
+	fees := estimateFees(cfg, state, head.Time(), tx)
+	if state.err != nil {
+		return nil, state.err
+	}
+	return fees, nil
+}
+
+// RequiredBalance returns the balance that the sender of the transaction needs to send it: its value plus its maximum fees.
+func RequiredBalance(ctx context.Context, chain Chain, tx *coreTypes.Transaction) (*big.Int, error) {
+	fees, err := EstimateFees(ctx, chain, tx)
+	if err != nil {
+		return nil, err
+	}
+	total := fees.Total()
+	return total.Add(total, tx.Value()), nil
+}
+
+func estimateFees(cfg *params.ChainConfig, state coreTypes.StateGetter, blockTime uint64, tx *coreTypes.Transaction) *FeeEstimate {
+	fees := &FeeEstimate{
+		ExecutionFee: new(big.Int).Sub(tx.Cost(), tx.Value()),
+		L1Fee:        new(big.Int),
+		OperatorFee:  new(big.Int),
+	}
+	// the cost functions are nil for chains that are not L2 chains
+	if l1CostFn := coreTypes.NewL1CostFunc(cfg, state); l1CostFn != nil {
+		if l1Fee := l1CostFn(tx.RollupCostData(), blockTime); l1Fee != nil {
+			fees.L1Fee = l1Fee
+		}
+	}
+	if operatorCostFn := coreTypes.NewOperatorCostFunc(cfg, state); operatorCostFn != nil {
+		fees.OperatorFee = operatorCostFn(tx.Gas(), blockTime).ToBig()
+	}
+	return fees
+}
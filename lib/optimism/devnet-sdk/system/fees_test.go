@@ -0,0 +1,75 @@
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapStateGetter is the storage of the L1Block predeploy
+type mapStateGetter map[common.Hash]common.Hash
+
+func (m mapStateGetter) GetState(_ common.Address, key common.Hash) common.Hash {
+	return m[key]
+}
+
+func TestEstimateFees(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1234")
+	tx, err := coreTypes.SignNewTx(priv, coreTypes.LatestSignerForChainID(big.NewInt(901)), &coreTypes.DynamicFeeTx{
+		ChainID:   big.NewInt(901),
+		Gas:       21000,
+		GasFeeCap: big.NewInt(10),
+		GasTipCap: big.NewInt(1),
+		To:        &to,
+		Value:     big.NewInt(1000),
+		Data:      []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	t.Run("L1 chain", func(t *testing.T) {
+		fees := estimateFees(&params.ChainConfig{ChainID: big.NewInt(1)}, mapStateGetter{}, 0, tx)
+		assert.Equal(t, big.NewInt(210000), fees.ExecutionFee)
+		assert.Equal(t, big.NewInt(210000), fees.Total(), "L1 chains charge no L1 or operator fees")
+	})
+
+	t.Run("L2 chain", func(t *testing.T) {
+		zero := uint64(0)
+		cfg := &params.ChainConfig{
+			ChainID:      big.NewInt(901),
+			RegolithTime: &zero,
+			EcotoneTime:  &zero,
+			FjordTime:    &zero,
+			IsthmusTime:  &zero,
+			Optimism:     &params.OptimismConfig{},
+		}
+		var scalars, operatorFeeParams common.Hash
+		// base fee scalar 1000 and blob base fee scalar 0
+		baseFeeScalarStart := 32 - coreTypes.BaseFeeScalarSlotOffset - 4
+		copy(scalars[baseFeeScalarStart:baseFeeScalarStart+4], big.NewInt(1000).FillBytes(make([]byte, 4)))
+		// operator fee scalar 2_000_000 and operator fee constant 7
+		copy(operatorFeeParams[20:24], big.NewInt(2_000_000).FillBytes(make([]byte, 4)))
+		operatorFeeParams[31] = 7
+		state := mapStateGetter{
+			coreTypes.L1BaseFeeSlot:         common.BigToHash(big.NewInt(1e9)),
+			coreTypes.L1FeeScalarsSlot:      scalars,
+			coreTypes.OperatorFeeParamsSlot: operatorFeeParams,
+		}
+
+		fees := estimateFees(cfg, state, 0, tx)
+		assert.Equal(t, big.NewInt(210000), fees.ExecutionFee)
+		assert.Equal(t, big.NewInt(21000*2+7), fees.OperatorFee)
+		assert.Positive(t, fees.L1Fee.Sign(), "L2 transactions pay an L1 data fee")
+
+		total := new(big.Int).Add(fees.ExecutionFee, fees.L1Fee)
+		total.Add(total, fees.OperatorFee)
+		assert.Equal(t, total, fees.Total())
+	})
+}
@@ -0,0 +1,174 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DefaultForkWatcherInterval is how often a fork watcher compares the nodes of a chain, by default.
+const DefaultForkWatcherInterval = 2 * time.Second
+
+// Divergence is a block that the nodes of a chain disagree on.
+type Divergence struct {
+	Number uint64
+	// Hashes are the hashes of the block, by index of the node.
+	Hashes map[int]common.Hash
+}
+
+func (d Divergence) String() string {
+	nodes := make([]int, 0, len(d.Hashes))
+	for i := range d.Hashes {
+		nodes = append(nodes, i)
+	}
+	sort.Ints(nodes)
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = fmt.Sprintf("node%d=%s", node, d.Hashes[node])
+	}
+	return fmt.Sprintf("chain split at block #%d: %s", d.Number, strings.Join(parts, ", "))
+}
+
+// headerSource is the subset of a node client used by the fork watcher, for ease of testing
+type headerSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*coreTypes.Header, error)
+}
+
+// ForkWatcher compares the blocks of all nodes of a chain in the background, and reports the blocks they disagree on.
+// At every interval, the nodes are compared at the highest block that all of them have.
+type ForkWatcher struct {
+	sources  []headerSource
+	interval time.Duration
+	log      log.Logger
+
+	divergences chan Divergence
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	// reported is the highest block that a divergence was reported for, to report each divergence once.
+	reported *uint64
+}
+
+// NewForkWatcher creates a fork watcher of the nodes of the chain. It does not watch until it is started.
+func NewForkWatcher(chain Chain, interval time.Duration, logger log.Logger) (*ForkWatcher, error) {
+	sources := make([]headerSource, 0, len(chain.Nodes()))
+	for i, node := range chain.Nodes() {
+		client, err := node.GethClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client of node %d: %w", i, err)
+		}
+		sources = append(sources, client)
+	}
+	return newForkWatcher(sources, interval, logger), nil
+}
+
+func newForkWatcher(sources []headerSource, interval time.Duration, logger log.Logger) *ForkWatcher {
+	return &ForkWatcher{
+		sources:     sources,
+		interval:    interval,
+		log:         logger,
+		divergences: make(chan Divergence, 16),
+	}
+}
+
+// Divergences returns the channel that divergences are reported on.
+// Divergences are dropped, with a warning, if the channel is not drained.
+func (w *ForkWatcher) Divergences() <-chan Divergence {
+	return w.divergences
+}
+
+// Start starts watching in a background goroutine, until the watcher is stopped or the context is done.
+func (w *ForkWatcher) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go w.loop(ctx, w.done)
+}
+
+// Stop stops watching, and waits for the background goroutine to exit.
+func (w *ForkWatcher) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (w *ForkWatcher) loop(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.check(ctx); err != nil && ctx.Err() == nil {
+			w.log.Warn("Failed to compare nodes", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check compares the nodes at the highest block that all of them have, and reports a divergence if they disagree.
+func (w *ForkWatcher) check(ctx context.Context) error {
+	if len(w.sources) < 2 {
+		return nil
+	}
+	var lowest *uint64
+	for i, source := range w.sources {
+		head, err := source.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get head of node %d: %w", i, err)
+		}
+		if number := head.Number.Uint64(); lowest == nil || number < *lowest {
+			lowest = &number
+		}
+	}
+	number := *lowest
+	if w.reported != nil && number <= *w.reported {
+		return nil
+	}
+
+	hashes := make(map[int]common.Hash, len(w.sources))
+	diverged := false
+	for i, source := range w.sources {
+		header, err := source.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d of node %d: %w", number, i, err)
+		}
+		hashes[i] = header.Hash()
+		if hashes[i] != hashes[0] {
+			diverged = true
+		}
+	}
+	if !diverged {
+		return nil
+	}
+
+	w.reported = &number
+	divergence := Divergence{Number: number, Hashes: hashes}
+	select {
+	case w.divergences <- divergence:
+	default:
+		w.log.Warn("Dropped divergence, divergences are not drained", "divergence", divergence)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package system
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHeaderSource is a node whose chain can be extended while it is watched
+type fakeHeaderSource struct {
+	mu      sync.Mutex
+	headers []*coreTypes.Header
+}
+
+func (s *fakeHeaderSource) extend(txHash common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers = append(s.headers, &coreTypes.Header{Number: big.NewInt(int64(len(s.headers))), TxHash: txHash})
+}
+
+func (s *fakeHeaderSource) HeaderByNumber(_ context.Context, number *big.Int) (*coreTypes.Header, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if number == nil {
+		return s.headers[len(s.headers)-1], nil
+	}
+	return s.headers[number.Uint64()], nil
+}
+
+func TestForkWatcher(t *testing.T) {
+	a, b := &fakeHeaderSource{}, &fakeHeaderSource{}
+	a.extend(common.Hash{})
+	b.extend(common.Hash{})
+	b.extend(common.Hash{1}) // b is ahead of a, which does not make it diverge
+
+	w := newForkWatcher([]headerSource{a, b}, 10*time.Millisecond, testlog.Logger(t, log.LevelDebug))
+	w.Start(context.Background())
+	t.Cleanup(w.Stop)
+
+	select {
+	case d := <-w.Divergences():
+		t.Fatalf("unexpected divergence: %s", d)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	a.extend(common.Hash{2}) // a forks off from b at block 1
+	select {
+	case d := <-w.Divergences():
+		assert.Equal(t, uint64(1), d.Number)
+		require.Len(t, d.Hashes, 2)
+		assert.NotEqual(t, d.Hashes[0], d.Hashes[1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("divergence not reported")
+	}
+
+	select {
+	case d := <-w.Divergences():
+		t.Fatalf("divergence reported more than once: %s", d)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
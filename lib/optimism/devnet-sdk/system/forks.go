@@ -0,0 +1,118 @@
+package system
+
+import (
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Fork is a network upgrade of a chain, scheduled at a timestamp.
+type Fork struct {
+	// Name is the name of the fork, e.g. "cancun" for L1 chains, or a rollup.ForkName for L2 chains.
+	Name string
+	Time uint64
+}
+
+// ForkSchedule is the schedule of the timestamp-activated forks of a chain.
+// Forks that are not scheduled are not part of the schedule.
+type ForkSchedule struct {
+	// forks are ordered by activation time, and in upgrade order for forks at the same time.
+	forks []Fork
+}
+
+type scheduledFork struct {
+	name string
+	time *uint64
+}
+
+func newForkSchedule(forks ...scheduledFork) *ForkSchedule {
+	s := &ForkSchedule{}
+	for _, f := range forks {
+		if f.time != nil {
+			s.forks = append(s.forks, Fork{Name: f.name, Time: *f.time})
+		}
+	}
+	sort.SliceStable(s.forks, func(i, j int) bool {
+		return s.forks[i].Time < s.forks[j].Time
+	})
+	return s
+}
+
+// l1ForkSchedule is the schedule of the timestamp-activated forks of an L1 chain.
+func l1ForkSchedule(cfg *params.ChainConfig) *ForkSchedule {
+	return newForkSchedule(
+		scheduledFork{"shanghai", cfg.ShanghaiTime},
+		scheduledFork{"cancun", cfg.CancunTime},
+		scheduledFork{"prague", cfg.PragueTime},
+		scheduledFork{"osaka", cfg.OsakaTime},
+	)
+}
+
+// l2ForkSchedule is the schedule of the forks of an L2 chain.
+// The rollup config is preferred, since the chain config lacks forks that do not affect the execution layer.
+func l2ForkSchedule(cfg *params.ChainConfig, rollupCfg *rollup.Config) *ForkSchedule {
+	if rollupCfg != nil {
+		// bedrock is block-activated, all later forks are scheduled by timestamp.
+		var forks []scheduledFork
+		for _, f := range rollup.AllForks {
+			if f != rollup.Bedrock {
+				forks = append(forks, scheduledFork{string(f), rollupCfg.ActivationTime(f)})
+			}
+		}
+		return newForkSchedule(forks...)
+	}
+	return newForkSchedule(
+		scheduledFork{string(rollup.Regolith), cfg.RegolithTime},
+		scheduledFork{string(rollup.Canyon), cfg.CanyonTime},
+		scheduledFork{string(rollup.Ecotone), cfg.EcotoneTime},
+		scheduledFork{string(rollup.Fjord), cfg.FjordTime},
+		scheduledFork{string(rollup.Granite), cfg.GraniteTime},
+		scheduledFork{string(rollup.Holocene), cfg.HoloceneTime},
+		scheduledFork{string(rollup.Isthmus), cfg.IsthmusTime},
+		scheduledFork{string(rollup.Jovian), cfg.JovianTime},
+		scheduledFork{string(rollup.Interop), cfg.InteropTime},
+	)
+}
+
+// Forks returns the scheduled forks, ordered by activation time.
+func (s *ForkSchedule) Forks() []Fork {
+	return append([]Fork(nil), s.forks...)
+}
+
+// Fork returns the fork with the given name, if it is scheduled.
+func (s *ForkSchedule) Fork(name string) (Fork, bool) {
+	for _, f := range s.forks {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Fork{}, false
+}
+
+// IsActive returns true if the fork is scheduled, and active at the timestamp.
+func (s *ForkSchedule) IsActive(name string, timestamp uint64) bool {
+	f, ok := s.Fork(name)
+	return ok && f.Time <= timestamp
+}
+
+// ActiveAt returns the forks that are active at the timestamp, ordered by activation time.
+func (s *ForkSchedule) ActiveAt(timestamp uint64) []Fork {
+	var active []Fork
+	for _, f := range s.forks {
+		if f.Time <= timestamp {
+			active = append(active, f)
+		}
+	}
+	return active
+}
+
+// Next returns the first fork that activates after the timestamp, if any.
+func (s *ForkSchedule) Next(timestamp uint64) (Fork, bool) {
+	for _, f := range s.forks {
+		if f.Time > timestamp {
+			return f, true
+		}
+	}
+	return Fork{}, false
+}
@@ -0,0 +1,77 @@
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkSchedule(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+
+	t.Run("l1", func(t *testing.T) {
+		s := l1ForkSchedule(&params.ChainConfig{
+			ChainID:      big.NewInt(1),
+			ShanghaiTime: u64(0),
+			CancunTime:   u64(0),
+			PragueTime:   u64(100),
+		})
+		assert.Equal(t, []Fork{{"shanghai", 0}, {"cancun", 0}, {"prague", 100}}, s.Forks())
+		assert.True(t, s.IsActive("cancun", 0))
+		assert.False(t, s.IsActive("prague", 99))
+		assert.True(t, s.IsActive("prague", 100))
+		assert.False(t, s.IsActive("osaka", 1000), "unscheduled forks are never active")
+
+		next, ok := s.Next(0)
+		require.True(t, ok)
+		assert.Equal(t, Fork{"prague", 100}, next)
+		_, ok = s.Next(100)
+		assert.False(t, ok)
+	})
+
+	t.Run("l2 from rollup config", func(t *testing.T) {
+		s := l2ForkSchedule(nil, &rollup.Config{
+			RegolithTime: u64(0),
+			CanyonTime:   u64(0),
+			DeltaTime:    u64(0),
+			EcotoneTime:  u64(0),
+			IsthmusTime:  u64(50),
+		})
+		assert.Equal(t, []Fork{
+			{string(rollup.Regolith), 0},
+			{string(rollup.Canyon), 0},
+			{string(rollup.Delta), 0},
+			{string(rollup.Ecotone), 0},
+			{string(rollup.Isthmus), 50},
+		}, s.Forks())
+		assert.Len(t, s.ActiveAt(49), 4)
+		assert.Len(t, s.ActiveAt(50), 5)
+	})
+
+	t.Run("l2 from rollup config schedules every fork", func(t *testing.T) {
+		cfg := &rollup.Config{}
+		cfg.ActivateAtGenesis(rollup.AllForks[len(rollup.AllForks)-1])
+		forks := l2ForkSchedule(nil, cfg).Forks()
+		require.Len(t, forks, len(rollup.AllForks)-1, "all forks but the block-activated bedrock")
+		for i, f := range forks {
+			assert.Equal(t, Fork{string(rollup.AllForks[i+1]), 0}, f)
+		}
+	})
+
+	t.Run("l2 from chain config", func(t *testing.T) {
+		s := l2ForkSchedule(&params.ChainConfig{
+			ChainID:      big.NewInt(901),
+			RegolithTime: u64(0),
+			IsthmusTime:  u64(10),
+		}, nil)
+		f, ok := s.Fork(string(rollup.Isthmus))
+		require.True(t, ok)
+		assert.Equal(t, uint64(10), f.Time)
+		_, ok = s.Fork(string(rollup.Delta))
+		assert.False(t, ok, "delta is not part of the chain config")
+	})
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	supervisorTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	coreTypes "github.com/ethereum/go-ethereum/core/types"
@@ -30,6 +33,8 @@ type Chain interface {
 	ID() types.ChainID
 	Nodes() []Node // The node at index 0 will always be the sequencer node
 	Config() (*params.ChainConfig, error)
+	// ForkSchedule returns the scheduled forks of the chain, to check which forks are active at a timestamp.
+	ForkSchedule() (*ForkSchedule, error)
 
 	// The wallets and addresses below are for use on the chain that the instance represents.
 	// If the instance also implements L2Chain, then the wallets and addresses below are still for the L2.
@@ -37,6 +42,12 @@ type Chain interface {
 	Addresses() AddressMap
 }
 
+// ServicesProvider is implemented by chains that know which services run for them in the devnet,
+// e.g. "batcher", "proposer" or "challenger".
+type ServicesProvider interface {
+	Services() []string
+}
+
 type L2Chain interface {
 	Chain
 
@@ -53,8 +64,16 @@ type Node interface {
 	ContractsRegistry() interfaces.ContractsRegistry
 	SupportsEIP(ctx context.Context, eip uint64) bool
 	RPCURL() string
+	// WSURL returns the websocket endpoint of the node, or an empty string if the node has none.
+	WSURL() string
 	Client() (*sources.EthClient, error)
 	GethClient() (*ethclient.Client, error)
+	// MultiCall batches the calls, e.g. contract reads and balances, and executes them against the state of the block.
+	MultiCall(ctx context.Context, block rpcblock.Block, calls ...batching.Call) ([]*batching.CallResult, error)
+	// SubscribeNewHeads and SubscribeLogs subscribe to events of the node over its websocket endpoint.
+	// They return ErrNoWebsocket if the node has no websocket endpoint.
+	SubscribeNewHeads(ctx context.Context, ch chan<- *coreTypes.Header) (ethereum.Subscription, error)
+	SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- coreTypes.Log) (ethereum.Subscription, error)
 }
 
 type WalletMap map[string]Wallet
@@ -70,6 +89,8 @@ type Wallet interface {
 	ExecuteMessage(identifier bindings.Identifier, sentMessage []byte) types.WriteInvocation[any]
 	Balance() types.Balance
 	Nonce() uint64
+	// Derive returns the child wallet at the given index. A wallet always derives the same child wallets.
+	Derive(index uint64) (Wallet, error)
 
 	TransactionProcessor
 }
@@ -79,6 +100,14 @@ type WalletV2 interface {
 	PrivateKey() *ecdsa.PrivateKey
 	Client() *sources.EthClient
 	Ctx() context.Context
+
+	Address() common.Address
+	// Send sends a transaction, and waits for it to be included successfully.
+	Send(ctx context.Context, to common.Address, value *big.Int, data []byte) (*coreTypes.Receipt, error)
+	// Deploy creates a contract, and waits for the creation to be included successfully.
+	Deploy(ctx context.Context, code []byte) (common.Address, *coreTypes.Receipt, error)
+	// Call executes a call against the latest state, without sending a transaction.
+	Call(ctx context.Context, to common.Address, data []byte) ([]byte, error)
 }
 
 // TransactionProcessor is a helper interface for signing and sending transactions.
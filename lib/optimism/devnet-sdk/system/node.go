@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -10,8 +11,11 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/interfaces"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	coreTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -20,8 +24,16 @@ var (
 	_ Node = (*node)(nil)
 )
 
+// ErrNoWebsocket is returned by subscriptions to nodes without a websocket endpoint.
+var ErrNoWebsocket = errors.New("node has no websocket endpoint")
+
+// multiCallBatchSize is the maximum number of calls in a single RPC batch request.
+const multiCallBatchSize = 100
+
 type node struct {
-	rpcUrl   string
+	rpcUrl string
+	// wsUrl is the websocket endpoint of the node, empty if the node has none.
+	wsUrl    string
 	clients  *clientManager
 	mu       sync.Mutex
 	registry interfaces.ContractsRegistry
@@ -93,6 +105,27 @@ func (n *node) GethClient() (*ethclient.Client, error) {
 	return n.clients.GethClient(n.rpcUrl)
 }
 
+// MultiCall executes the calls in RPC batches, all against the state of the same block.
+// Calls at the latest block are pinned to the hash of the current head,
+// so the results are consistent even if the chain advances between batches.
+func (n *node) MultiCall(ctx context.Context, block rpcblock.Block, calls ...batching.Call) ([]*batching.CallResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	if block == rpcblock.Latest {
+		head, err := n.BlockByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get head block: %w", err)
+		}
+		block = rpcblock.ByHash(head.Hash())
+	}
+	rpcCl, err := n.clients.RPCClient(n.rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	return batching.NewMultiCaller(rpcCl, multiCallBatchSize).Call(ctx, block, calls...)
+}
+
 func (n *node) ContractsRegistry() interfaces.ContractsRegistry {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -113,6 +146,35 @@ func (n *node) RPCURL() string {
 	return n.rpcUrl
 }
 
+func (n *node) WSURL() string {
+	return n.wsUrl
+}
+
+func (n *node) wsClient() (*ethclient.Client, error) {
+	if n.wsUrl == "" {
+		return nil, ErrNoWebsocket
+	}
+	return n.clients.GethClient(n.wsUrl)
+}
+
+// SubscribeNewHeads sends the headers of new blocks of the node to the channel, until the subscription is unsubscribed.
+func (n *node) SubscribeNewHeads(ctx context.Context, ch chan<- *coreTypes.Header) (ethereum.Subscription, error) {
+	client, err := n.wsClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get websocket client: %w", err)
+	}
+	return client.SubscribeNewHead(ctx, ch)
+}
+
+// SubscribeLogs sends the logs matching the query to the channel, until the subscription is unsubscribed.
+func (n *node) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- coreTypes.Log) (ethereum.Subscription, error) {
+	client, err := n.wsClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get websocket client: %w", err)
+	}
+	return client.SubscribeFilterLogs(ctx, q, ch)
+}
+
 func (n *node) SupportsEIP(ctx context.Context, eip uint64) bool {
 	client, err := n.Client()
 	if err != nil {
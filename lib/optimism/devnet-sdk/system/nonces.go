@@ -0,0 +1,98 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/protolambda/ctxlock"
+)
+
+// accountNonces coordinates the nonces of the accounts of all wallets and transaction senders,
+// so senders of the same account, e.g. funding wallets shared by concurrent tests, never send transactions with the same nonce.
+var accountNonces = newNonceTracker()
+
+// NonceClient is the client that the pending nonce of an account is read from.
+type NonceClient interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// LockNonce locks the account on the chain, and returns the nonce of its next transaction.
+// The nonces are shared with all wallets of the account, so transactions that are not sent through a wallet
+// must take their nonces from here too.
+// The account stays locked until release is called, with whether a transaction with the nonce was sent.
+// Waiting for the lock is abandoned with the error of the context, once the context is done.
+func LockNonce(ctx context.Context, chainID *big.Int, client NonceClient, address common.Address) (nonce uint64, release func(sent bool), err error) {
+	return accountNonces.lock(ctx, chainID, client, address)
+}
+
+type accountKey struct {
+	chainID string
+	address common.Address
+}
+
+type accountNonce struct {
+	// mu is held while a transaction of the account is built and sent.
+	mu ctxlock.Lock
+	// next is the next nonce of the account, nil until a transaction of the account is sent.
+	next *uint64
+}
+
+// nonceTracker serializes the transactions of each account, and caches the pending nonces of the accounts.
+type nonceTracker struct {
+	mu       sync.Mutex
+	accounts map[accountKey]*accountNonce
+}
+
+func newNonceTracker() *nonceTracker {
+	return &nonceTracker{
+		accounts: make(map[accountKey]*accountNonce),
+	}
+}
+
+func (t *nonceTracker) account(key accountKey) *accountNonce {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	account, ok := t.accounts[key]
+	if !ok {
+		account = &accountNonce{}
+		t.accounts[key] = account
+	}
+	return account
+}
+
+// lock locks the account on the chain, and returns the nonce of its next transaction.
+// The account stays locked until release is called, with whether a transaction with the nonce was sent.
+// Waiting for the lock is abandoned once the context is done, so a stuck sender does not block all others forever.
+func (t *nonceTracker) lock(ctx context.Context, chainID *big.Int, client NonceClient, address common.Address) (uint64, func(sent bool), error) {
+	account := t.account(accountKey{chainID: chainID.String(), address: address})
+	if err := account.mu.LockCtx(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	// The node may not have seen the latest transaction of the account yet, and the account may be used
+	// by transactions that are not sent through a wallet, so the highest of both nonces is used.
+	pending, err := client.PendingNonceAt(ctx, address)
+	if err != nil {
+		account.mu.Unlock()
+		return 0, nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	nonce := pending
+	if account.next != nil && *account.next > nonce {
+		nonce = *account.next
+	}
+
+	release := func(sent bool) {
+		defer account.mu.Unlock()
+		if sent {
+			next := nonce + 1
+			account.next = &next
+		} else {
+			// the nonce may be ahead of the chain if previous transactions were dropped
+			account.next = nil
+		}
+	}
+	return nonce, release, nil
+}
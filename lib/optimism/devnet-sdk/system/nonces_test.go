@@ -0,0 +1,104 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceTracker(t *testing.T) {
+	ctx := context.Background()
+	addr := common.HexToAddress("0x1234")
+
+	chainID := big.NewInt(1)
+	newNode := func(pending uint64) *mockNode {
+		node := new(mockNode)
+		node.On("PendingNonceAt", mock.Anything, addr).Return(pending, nil)
+		return node
+	}
+
+	t.Run("concurrent senders get distinct nonces", func(t *testing.T) {
+		tracker := newNonceTracker()
+		node := newNode(5)
+
+		var mu sync.Mutex
+		nonces := make(map[uint64]bool)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nonce, release, err := tracker.lock(ctx, chainID, node, addr)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				nonces[nonce] = true
+				mu.Unlock()
+				release(true)
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, nonces, 10)
+		for nonce := uint64(5); nonce < 15; nonce++ {
+			assert.True(t, nonces[nonce], "nonce %d must be used", nonce)
+		}
+	})
+
+	t.Run("unsent nonces are reused", func(t *testing.T) {
+		tracker := newNonceTracker()
+		node := newNode(3)
+
+		nonce, release, err := tracker.lock(ctx, chainID, node, addr)
+		require.NoError(t, err)
+		release(false)
+
+		reused, release, err := tracker.lock(ctx, chainID, node, addr)
+		require.NoError(t, err)
+		release(true)
+		assert.Equal(t, nonce, reused)
+	})
+
+	t.Run("fails without pending nonce", func(t *testing.T) {
+		tracker := newNonceTracker()
+		node := new(mockNode)
+		node.On("PendingNonceAt", mock.Anything, addr).Return(uint64(0), fmt.Errorf("unavailable"))
+
+		_, _, err := tracker.lock(ctx, chainID, node, addr)
+		require.Error(t, err)
+
+		// a failed lock must not keep the account locked
+		_, release, err := tracker.lock(ctx, chainID, newNode(0), addr)
+		require.NoError(t, err)
+		release(true)
+	})
+
+	t.Run("abandons the wait once the context is done", func(t *testing.T) {
+		tracker := newNonceTracker()
+		node := newNode(0)
+
+		_, release, err := tracker.lock(ctx, chainID, node, addr)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, _, err = tracker.lock(waitCtx, chainID, node, addr)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the abandoned wait must not take the lock once it is released
+		release(true)
+		nonce, release, err := tracker.lock(ctx, chainID, node, addr)
+		require.NoError(t, err)
+		release(true)
+		assert.Equal(t, uint64(1), nonce)
+	})
+}
@@ -0,0 +1,106 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountState is the balance and nonce of an account.
+type AccountState struct {
+	Balance *big.Int
+	Nonce   uint64
+	// Withdrawn is the total the account has withdrawn, e.g. the total processed by a fee vault, if it was read.
+	// It is nil otherwise.
+	Withdrawn *big.Int
+}
+
+// AccountDiff is the change of the state of an account between two snapshots.
+// Positive values are increases, negative values are decreases.
+type AccountDiff struct {
+	Balance *big.Int
+	Nonce   int64
+}
+
+// AccountSnapshot is the state of a set of accounts, all at the same block.
+type AccountSnapshot struct {
+	Block    *big.Int
+	Accounts map[common.Address]AccountState
+}
+
+// Snapshot reads the balances and nonces of the accounts at the block, or at the head block if the block is nil.
+// The accounts are read in batched requests, all against the state of the same block.
+func Snapshot(ctx context.Context, node Node, block *big.Int, addresses ...common.Address) (*AccountSnapshot, error) {
+	var ref rpcblock.Block
+	if block == nil {
+		head, err := node.BlockByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get head block: %w", err)
+		}
+		block = new(big.Int).SetUint64(head.NumberU64())
+		ref = rpcblock.ByHash(head.Hash())
+	} else {
+		ref = rpcblock.ByNumber(block.Uint64())
+	}
+
+	calls := make([]batching.Call, 0, 2*len(addresses))
+	for _, addr := range addresses {
+		calls = append(calls, batching.NewBalanceCall(addr), batching.NewNonceCall(addr))
+	}
+	results, err := node.MultiCall(ctx, ref, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accounts at block %v: %w", block, err)
+	}
+
+	s := &AccountSnapshot{
+		Block:    block,
+		Accounts: make(map[common.Address]AccountState, len(addresses)),
+	}
+	for i, addr := range addresses {
+		s.Accounts[addr] = AccountState{
+			Balance: results[2*i].GetBigInt(0),
+			Nonce:   results[2*i+1].GetUint64(0),
+		}
+	}
+	return s, nil
+}
+
+// Balance returns the balance of the account, or zero if the account is not part of the snapshot.
+func (s *AccountSnapshot) Balance(addr common.Address) *big.Int {
+	if state, ok := s.Accounts[addr]; ok && state.Balance != nil {
+		return state.Balance
+	}
+	return new(big.Int)
+}
+
+// Accrued returns the total the account has been credited: its balance, and what it has withdrawn, if it was read.
+// Unlike the balance, the accrued total only increases with credits, even if the account withdraws in between snapshots.
+func (s *AccountSnapshot) Accrued(addr common.Address) *big.Int {
+	accrued := new(big.Int).Set(s.Balance(addr))
+	if withdrawn := s.Accounts[addr].Withdrawn; withdrawn != nil {
+		accrued.Add(accrued, withdrawn)
+	}
+	return accrued
+}
+
+// Nonce returns the nonce of the account, or zero if the account is not part of the snapshot.
+func (s *AccountSnapshot) Nonce(addr common.Address) uint64 {
+	return s.Accounts[addr].Nonce
+}
+
+// Diff returns the changes of the accounts of this snapshot since the start snapshot.
+// Accounts that are not part of the start snapshot are compared to an empty account.
+func (s *AccountSnapshot) Diff(start *AccountSnapshot) map[common.Address]AccountDiff {
+	diff := make(map[common.Address]AccountDiff, len(s.Accounts))
+	for addr := range s.Accounts {
+		diff[addr] = AccountDiff{
+			Balance: new(big.Int).Sub(s.Balance(addr), start.Balance(addr)),
+			Nonce:   int64(s.Nonce(addr)) - int64(start.Nonce(addr)),
+		}
+	}
+	return diff
+}
@@ -0,0 +1,86 @@
+package system
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountsService serves the accounts of a chain, by block number
+type accountsService struct {
+	accounts map[rpc.BlockNumber]map[common.Address]AccountState
+}
+
+func (s *accountsService) state(addr common.Address, block rpc.BlockNumberOrHash) AccountState {
+	number, _ := block.Number()
+	return s.accounts[number][addr]
+}
+
+func (s *accountsService) GetBalance(addr common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	balance := s.state(addr, block).Balance
+	if balance == nil {
+		balance = new(big.Int)
+	}
+	return (*hexutil.Big)(balance)
+}
+
+func (s *accountsService) GetTransactionCount(addr common.Address, block rpc.BlockNumberOrHash) hexutil.Uint64 {
+	return hexutil.Uint64(s.state(addr, block).Nonce)
+}
+
+func TestSnapshot(t *testing.T) {
+	alice, bob := common.HexToAddress("0xa11ce"), common.HexToAddress("0xb0b")
+	service := &accountsService{accounts: map[rpc.BlockNumber]map[common.Address]AccountState{
+		1: {
+			alice: {Balance: big.NewInt(100), Nonce: 3},
+			bob:   {Balance: big.NewInt(5)},
+		},
+		2: {
+			alice: {Balance: big.NewInt(60), Nonce: 4},
+			bob:   {Balance: big.NewInt(45)},
+		},
+	}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	node := newNode(httpServer.URL, newClientManager())
+
+	ctx := context.Background()
+	start, err := Snapshot(ctx, node, big.NewInt(1), alice, bob)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), start.Balance(alice))
+	assert.Equal(t, uint64(3), start.Nonce(alice))
+
+	end, err := Snapshot(ctx, node, big.NewInt(2), alice, bob)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), end.Block)
+
+	diff := end.Diff(start)
+	assert.Equal(t, AccountDiff{Balance: big.NewInt(-40), Nonce: 1}, diff[alice])
+	assert.Equal(t, AccountDiff{Balance: big.NewInt(40), Nonce: 0}, diff[bob])
+}
+
+func TestAccountSnapshotAccrued(t *testing.T) {
+	vault, user := common.HexToAddress("0xfee"), common.HexToAddress("0xa11ce")
+	start := &AccountSnapshot{Accounts: map[common.Address]AccountState{
+		vault: {Balance: big.NewInt(50), Withdrawn: big.NewInt(100)},
+		user:  {Balance: big.NewInt(7)},
+	}}
+	assert.Equal(t, big.NewInt(150), start.Accrued(vault))
+	assert.Equal(t, big.NewInt(7), start.Accrued(user), "accounts without withdrawals accrue their balance")
+	assert.Equal(t, big.NewInt(0), start.Accrued(common.HexToAddress("0xb0b")))
+
+	// the vault was credited 30, and withdrew its balance of 80
+	end := &AccountSnapshot{Accounts: map[common.Address]AccountState{
+		vault: {Balance: big.NewInt(0), Withdrawn: big.NewInt(180)},
+	}}
+	assert.Equal(t, big.NewInt(30), new(big.Int).Sub(end.Accrued(vault), start.Accrued(vault)))
+}
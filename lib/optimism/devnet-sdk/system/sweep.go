@@ -0,0 +1,68 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+)
+
+// Sweep sends the balance of the wallet on the chain to the address, minus the fees of the transaction,
+// and waits for the transaction to be included. It returns the swept amount, which is zero if the balance
+// does not cover the fees. The fees are estimated at the fee cap of the transaction,
+// so the difference to the fees actually paid stays in the wallet.
+func Sweep(ctx context.Context, chain Chain, from Wallet, to types.Address) (*big.Int, error) {
+	balance := from.Balance()
+	if balance.Int == nil || balance.Sign() <= 0 {
+		return new(big.Int), nil
+	}
+
+	builder := NewTxBuilder(ctx, chain)
+	options := []TxOption{
+		WithFrom(from.Address()),
+		WithTo(to),
+		WithData(nil),
+	}
+	// the fees are estimated with a transaction of the whole balance, the gas limit is kept for the sweep
+	tx, err := builder.BuildTx(append(options, WithValue(balance.Int))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+	tx, err = from.Sign(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	rt, ok := tx.(RawTransaction)
+	if !ok {
+		return nil, fmt.Errorf("transaction is not a raw transaction")
+	}
+	fees, err := EstimateFees(ctx, chain, rt.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate fees: %w", err)
+	}
+	value := sweepValue(balance.Int, fees)
+	if value.Sign() <= 0 {
+		return new(big.Int), nil
+	}
+
+	buildTx := func(ctx context.Context, opts ...TxOption) (Transaction, error) {
+		tx, err := NewTxBuilder(ctx, chain).BuildTx(append(append(options, WithValue(value), WithGasLimit(rt.Raw().Gas())), opts...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build transaction: %w", err)
+		}
+		return from.Sign(tx)
+	}
+	if err := sendWithNonce(ctx, chain, from, from.Address(), buildTx).Wait(); err != nil {
+		return nil, fmt.Errorf("failed to sweep %s: %w", value, err)
+	}
+	return value, nil
+}
+
+// sweepValue returns the value that can be sent from the balance, after the fees are paid.
+// A tenth of the fees is kept as margin, in case the gas price rises before the sweep is sent.
+func sweepValue(balance *big.Int, fees *FeeEstimate) *big.Int {
+	total := fees.Total()
+	total.Add(total, new(big.Int).Div(total, big.NewInt(10)))
+	return new(big.Int).Sub(balance, total)
+}
@@ -0,0 +1,24 @@
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepValue(t *testing.T) {
+	fees := &FeeEstimate{
+		ExecutionFee: big.NewInt(600),
+		L1Fee:        big.NewInt(300),
+		OperatorFee:  big.NewInt(100),
+	}
+
+	// the fees and a tenth of the fees are kept in the wallet
+	require.Equal(t, uint64(8900), sweepValue(big.NewInt(10000), fees).Uint64())
+	require.Zero(t, sweepValue(big.NewInt(1100), fees).Sign())
+	require.Equal(t, -1, sweepValue(big.NewInt(1000), fees).Sign())
+
+	// the estimate is not modified
+	require.Equal(t, uint64(1000), fees.Total().Uint64())
+}
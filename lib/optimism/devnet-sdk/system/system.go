@@ -54,10 +54,12 @@ func systemFromDevnet(dn descriptors.DevnetEnvironment, identifier string) (Syst
 
 	l2s := make([]L2Chain, len(dn.L2))
 	for i, l2 := range dn.L2 {
-		l2s[i], err = newL2ChainFromDescriptor(l2)
+		l2Chain, err := newL2ChainFromDescriptor(l2)
 		if err != nil {
 			return nil, fmt.Errorf("failed to add L2 chain: %w", err)
 		}
+		l2Chain.l1 = l1
+		l2s[i] = l2Chain
 	}
 
 	sys := &system{
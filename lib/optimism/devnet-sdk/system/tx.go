@@ -15,7 +15,8 @@ type TxOpts struct {
 	to          *common.Address
 	value       *big.Int
 	data        []byte
-	gasLimit    uint64 // Optional: if 0, will be estimated
+	gasLimit    uint64  // Optional: if 0, will be estimated
+	nonce       *uint64 // Optional: if nil, the pending nonce of the sender is used
 	accessList  types.AccessList
 	blobHashes  []common.Hash
 	blobs       []kzg4844.Blob
@@ -133,6 +134,13 @@ func WithGasLimit(gasLimit uint64) TxOption {
 	}
 }
 
+// WithNonce sets an explicit nonce
+func WithNonce(nonce uint64) TxOption {
+	return func(opts *TxOpts) {
+		opts.nonce = &nonce
+	}
+}
+
 // WithAccessList sets the access list for EIP-2930 transactions
 func WithAccessList(accessList types.AccessList) TxOption {
 	return func(opts *TxOpts) {
@@ -7,7 +7,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/holiman/uint256"
 )
@@ -178,9 +177,12 @@ func (b *TxBuilder) chooseTxType(hasAccessList bool, hasBlobs bool) uint8 {
 	return types.LegacyTxType
 }
 
-// getNonce gets the next nonce for the given address
-func (b *TxBuilder) getNonce(from common.Address) (uint64, error) {
-	nonce, err := b.chain.Nodes()[0].PendingNonceAt(b.ctx, from)
+// getNonce gets the explicit nonce of the transaction, or else the next nonce of its sender
+func (b *TxBuilder) getNonce(opts *TxOpts) (uint64, error) {
+	if opts.nonce != nil {
+		return *opts.nonce, nil
+	}
+	nonce, err := b.chain.Nodes()[0].PendingNonceAt(b.ctx, opts.from)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get nonce: %w", err)
 	}
@@ -212,7 +214,7 @@ func (b *TxBuilder) calculateGasLimit(opts *TxOpts) (uint64, error) {
 
 // buildDynamicFeeTx creates a new EIP-1559 transaction with the given parameters
 func (b *TxBuilder) buildDynamicFeeTx(opts *TxOpts) (*types.Transaction, error) {
-	nonce, err := b.getNonce(opts.from)
+	nonce, err := b.getNonce(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +245,7 @@ func (b *TxBuilder) buildDynamicFeeTx(opts *TxOpts) (*types.Transaction, error)
 
 // buildLegacyTx creates a new legacy (pre-EIP-1559) transaction
 func (b *TxBuilder) buildLegacyTx(opts *TxOpts) (*types.Transaction, error) {
-	nonce, err := b.getNonce(opts.from)
+	nonce, err := b.getNonce(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -270,7 +272,7 @@ func (b *TxBuilder) buildLegacyTx(opts *TxOpts) (*types.Transaction, error) {
 
 // buildAccessListTx creates a new EIP-2930 transaction with access list
 func (b *TxBuilder) buildAccessListTx(opts *TxOpts) (*types.Transaction, error) {
-	nonce, err := b.getNonce(opts.from)
+	nonce, err := b.getNonce(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -301,7 +303,7 @@ func (b *TxBuilder) buildAccessListTx(opts *TxOpts) (*types.Transaction, error)
 
 // buildBlobTx creates a new EIP-4844 blob transaction
 func (b *TxBuilder) buildBlobTx(opts *TxOpts) (*types.Transaction, error) {
-	nonce, err := b.getNonce(opts.from)
+	nonce, err := b.getNonce(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,9 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -75,6 +78,14 @@ func (m *mockWallet) Nonce() uint64 {
 	return args.Get(0).(uint64)
 }
 
+func (m *mockWallet) Derive(index uint64) (Wallet, error) {
+	args := m.Called(index)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(Wallet), args.Error(1)
+}
+
 func (m *mockWallet) Transactor() *bind.TransactOpts {
 	return nil
 }
@@ -129,6 +140,10 @@ func (m *mockChain) Config() (*params.ChainConfig, error) {
 	return nil, fmt.Errorf("not implemented for mock chain")
 }
 
+func (m *mockChain) ForkSchedule() (*ForkSchedule, error) {
+	return nil, fmt.Errorf("not implemented for mock chain")
+}
+
 func (m *mockChain) Addresses() AddressMap {
 	args := m.Called()
 	return args.Get(0).(AddressMap)
@@ -177,11 +192,31 @@ func (m *mockNode) GethClient() (*ethclient.Client, error) {
 	return args.Get(0).(*ethclient.Client), args.Error(1)
 }
 
+func (m *mockNode) MultiCall(ctx context.Context, block rpcblock.Block, calls ...batching.Call) ([]*batching.CallResult, error) {
+	args := m.Called(ctx, block, calls)
+	return args.Get(0).([]*batching.CallResult), args.Error(1)
+}
+
 func (m *mockNode) RPCURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
 }
 
+func (m *mockNode) WSURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockNode) SubscribeNewHeads(ctx context.Context, ch chan<- *ethtypes.Header) (ethereum.Subscription, error) {
+	args := m.Called(ctx, ch)
+	return args.Get(0).(ethereum.Subscription), args.Error(1)
+}
+
+func (m *mockNode) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	args := m.Called(ctx, q, ch)
+	return args.Get(0).(ethereum.Subscription), args.Error(1)
+}
+
 func (m *mockNode) SupportsEIP(ctx context.Context, eip uint64) bool {
 	args := m.Called(ctx, eip)
 	return args.Bool(0)
@@ -0,0 +1,226 @@
+package system
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// txManagerClient is the subset of the EthClient used by the tx manager, for ease of testing
+type txManagerClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	Call(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
+}
+
+type txManagerConfig struct {
+	// receiptTimeout bounds how long to wait for a transaction to be included, fee bumps included.
+	receiptTimeout time.Duration
+	// resubmitInterval is how long to wait for a receipt, before the fees are bumped and the transaction is resubmitted.
+	resubmitInterval time.Duration
+	// pollInterval is how often to check for a receipt.
+	pollInterval time.Duration
+	// feeBumpPercent is how much the fees are bumped by on resubmission.
+	// Nodes only accept replacement transactions with at least 10% higher fees.
+	feeBumpPercent int64
+	// gasTipCap is the initial tip of a transaction.
+	gasTipCap *big.Int
+}
+
+func defaultTxManagerConfig() txManagerConfig {
+	return txManagerConfig{
+		receiptTimeout:   2 * time.Minute,
+		resubmitInterval: 24 * time.Second,
+		pollInterval:     time.Second,
+		feeBumpPercent:   20,
+		gasTipCap:        big.NewInt(1e9), // 1 gwei
+	}
+}
+
+// txManager sends the transactions of a single account.
+// It takes the nonces of the account from the nonce tracker that all senders share, so concurrent transactions get distinct nonces,
+// and resubmits transactions with bumped EIP-1559 fees until they are included.
+type txManager struct {
+	client txManagerClient
+	cfg    txManagerConfig
+	nonces *nonceTracker
+
+	mu      sync.Mutex
+	chainID *big.Int
+}
+
+func newTxManager(client txManagerClient, cfg txManagerConfig) *txManager {
+	return &txManager{
+		client: client,
+		cfg:    cfg,
+		nonces: accountNonces,
+	}
+}
+
+func (m *txManager) getChainID(ctx context.Context) (*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.chainID == nil {
+		chainID, err := m.client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		m.chainID = chainID
+	}
+	return m.chainID, nil
+}
+
+// send signs and sends a transaction, and waits for its receipt.
+// A nil to address creates a contract.
+// An error is returned if the transaction is not included within the receipt timeout, or if it reverted.
+func (m *txManager) send(ctx context.Context, priv *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	from := crypto.PubkeyToAddress(priv.PublicKey)
+	chainID, err := m.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := m.client.InfoByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head block: %w", err)
+	}
+	tipCap := new(big.Int).Set(m.cfg.gasTipCap)
+	// leave room for the base fee to double before the transaction is included
+	feeCap := new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee(), big.NewInt(2)))
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	gas, err := m.client.EstimateGas(ctx, ethereum.CallMsg{
+		From:      from,
+		To:        to,
+		GasFeeCap: feeCap,
+		GasTipCap: tipCap,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	nonce, release, err := m.nonces.lock(ctx, chainID, m.client, from)
+	if err != nil {
+		return nil, err
+	}
+	// the account is locked until the first submission of the transaction, later submissions only replace it
+	defer func() {
+		if release != nil {
+			release(false)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.receiptTimeout)
+	defer cancel()
+	signer := types.LatestSignerForChainID(chainID)
+	// every submission of the transaction may be included, so the receipts of all of them are checked
+	var hashes []common.Hash
+	for {
+		tx, err := types.SignNewTx(priv, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: tipCap,
+			GasFeeCap: feeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		if err := m.client.SendTransaction(ctx, tx); err != nil && !isKnownTxError(err) {
+			if len(hashes) == 0 {
+				// the nonce was not used, and may be behind the chain, so the deferred release fetches it again for the next transaction
+				return nil, fmt.Errorf("failed to send transaction: %w", err)
+			}
+			// a previous submission may still be included
+		} else {
+			hashes = append(hashes, tx.Hash())
+		}
+		if release != nil {
+			release(true)
+			release = nil
+		}
+
+		receipt, err := m.awaitReceipt(ctx, hashes)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("transaction %s reverted", receipt.TxHash)
+			}
+			return receipt, nil
+		}
+		tipCap = BumpFee(tipCap, m.cfg.feeBumpPercent)
+		feeCap = BumpFee(feeCap, m.cfg.feeBumpPercent)
+	}
+}
+
+// awaitReceipt waits up to the resubmit interval for the receipt of any of the transactions.
+// It returns a nil receipt if none of the transactions is included by then.
+func (m *txManager) awaitReceipt(ctx context.Context, hashes []common.Hash) (*types.Receipt, error) {
+	resubmit := time.NewTimer(m.cfg.resubmitInterval)
+	defer resubmit.Stop()
+	ticker := time.NewTicker(m.cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		for _, hash := range hashes {
+			if receipt, err := m.client.TransactionReceipt(ctx, hash); err == nil && receipt != nil {
+				return receipt, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for receipt of transactions %v: %w", hashes, ctx.Err())
+		case <-resubmit.C:
+			return nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// call executes a message call against the latest state of the chain, without creating a transaction.
+func (m *txManager) call(ctx context.Context, from common.Address, to common.Address, data []byte) ([]byte, error) {
+	return m.client.Call(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &to,
+		Data: data,
+	})
+}
+
+// isKnownTxError returns true if the node already has the transaction, or a replacement of it, in its pool.
+func isKnownTxError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "replacement transaction underpriced")
+}
+
+// BumpFee returns the fee increased by the percentage, and by at least 1 wei,
+// so a transaction with the bumped fees replaces the transaction with the same nonce in the pool.
+func BumpFee(fee *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	bumped.Div(bumped, big.NewInt(100))
+	// tiny fees do not change by a percentage, but replacements must pay more
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
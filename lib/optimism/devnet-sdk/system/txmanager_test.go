@@ -0,0 +1,183 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTxClient includes the sent transactions whose tip is at least the minimum tip
+type fakeTxClient struct {
+	mu           sync.Mutex
+	minTip       *big.Int
+	pendingNonce uint64
+	sendErr      error
+	sent         []*types.Transaction
+	included     map[common.Hash]*types.Receipt
+}
+
+func (c *fakeTxClient) ChainID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(901), nil
+}
+
+func (c *fakeTxClient) InfoByLabel(_ context.Context, _ eth.BlockLabel) (eth.BlockInfo, error) {
+	return &testutils.MockBlockInfo{InfoBaseFee: big.NewInt(1e9)}, nil
+}
+
+func (c *fakeTxClient) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pendingNonce, nil
+}
+
+func (c *fakeTxClient) EstimateGas(_ context.Context, _ ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (c *fakeTxClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	if tx.GasTipCap().Cmp(c.minTip) >= 0 {
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash()}
+		if tx.To() == nil {
+			receipt.ContractAddress = common.HexToAddress("0xc0ffee")
+		}
+		c.included[tx.Hash()] = receipt
+	}
+	return nil
+}
+
+func (c *fakeTxClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	receipt, ok := c.included[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *fakeTxClient) Call(_ context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return append([]byte{}, msg.Data...), nil
+}
+
+func testTxManagerConfig() txManagerConfig {
+	return txManagerConfig{
+		receiptTimeout:   time.Second,
+		resubmitInterval: 20 * time.Millisecond,
+		pollInterval:     5 * time.Millisecond,
+		feeBumpPercent:   50,
+		gasTipCap:        big.NewInt(100),
+	}
+}
+
+// newTestTxManager returns a tx manager with its own nonce tracker, so the nonces of the tests do not depend on each other.
+func newTestTxManager(client txManagerClient, cfg txManagerConfig) *txManager {
+	m := newTxManager(client, cfg)
+	m.nonces = newNonceTracker()
+	return m
+}
+
+func TestTxManager(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1234")
+
+	t.Run("tracks nonces", func(t *testing.T) {
+		client := &fakeTxClient{minTip: big.NewInt(0), pendingNonce: 5, included: make(map[common.Hash]*types.Receipt)}
+		m := newTestTxManager(client, testTxManagerConfig())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := m.send(context.Background(), priv, &to, big.NewInt(1), nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		nonces := make(map[uint64]bool)
+		for _, tx := range client.sent {
+			nonces[tx.Nonce()] = true
+		}
+		assert.Equal(t, map[uint64]bool{5: true, 6: true, 7: true}, nonces)
+	})
+
+	t.Run("shares nonces with other senders of the account", func(t *testing.T) {
+		client := &fakeTxClient{minTip: big.NewInt(0), pendingNonce: 2, included: make(map[common.Hash]*types.Receipt)}
+		a, b := newTestTxManager(client, testTxManagerConfig()), newTxManager(client, testTxManagerConfig())
+		b.nonces = a.nonces
+
+		_, err := a.send(context.Background(), priv, &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		_, err = b.send(context.Background(), priv, &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		require.Len(t, client.sent, 2)
+		assert.Equal(t, uint64(3), client.sent[1].Nonce(), "the node may not report the pending transaction of the other sender yet")
+	})
+
+	t.Run("bumps fees until included", func(t *testing.T) {
+		client := &fakeTxClient{minTip: big.NewInt(200), included: make(map[common.Hash]*types.Receipt)}
+		m := newTestTxManager(client, testTxManagerConfig())
+
+		receipt, err := m.send(context.Background(), priv, nil, nil, []byte{0x60})
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0xc0ffee"), receipt.ContractAddress)
+		require.Len(t, client.sent, 3, "tip of 100 must be bumped twice to reach 200")
+		assert.Equal(t, big.NewInt(225), client.sent[2].GasTipCap())
+		for _, tx := range client.sent {
+			assert.Equal(t, uint64(0), tx.Nonce(), "resubmissions must replace the transaction")
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		client := &fakeTxClient{minTip: big.NewInt(1e18), included: make(map[common.Hash]*types.Receipt)}
+		cfg := testTxManagerConfig()
+		cfg.receiptTimeout = 100 * time.Millisecond
+		m := newTestTxManager(client, cfg)
+
+		_, err := m.send(context.Background(), priv, &to, nil, nil)
+		require.ErrorContains(t, err, "timed out waiting for receipt")
+	})
+
+	t.Run("refetches nonce after failed send", func(t *testing.T) {
+		client := &fakeTxClient{minTip: big.NewInt(0), pendingNonce: 3, included: make(map[common.Hash]*types.Receipt)}
+		m := newTestTxManager(client, testTxManagerConfig())
+
+		client.sendErr = fmt.Errorf("insufficient funds")
+		_, err := m.send(context.Background(), priv, &to, nil, nil)
+		require.ErrorContains(t, err, "insufficient funds")
+
+		client.sendErr = nil
+		_, err = m.send(context.Background(), priv, &to, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, client.sent, 1)
+		assert.Equal(t, uint64(3), client.sent[0].Nonce(), "unused nonce must be reused")
+	})
+
+	t.Run("calls", func(t *testing.T) {
+		client := &fakeTxClient{}
+		m := newTestTxManager(client, testTxManagerConfig())
+		out, err := m.call(context.Background(), crypto.PubkeyToAddress(priv.PublicKey), to, []byte{1, 2, 3})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, out)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/constants"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	supervisorTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
@@ -30,6 +31,8 @@ type wallet struct {
 	privateKey types.Key
 	address    types.Address
 	chain      Chain
+	// hd derives the child wallets of a wallet of a mnemonic, nil for wallets of a single key.
+	hd *devkeys.MnemonicDevKeys
 }
 
 func newWalletMapFromDescriptorWalletMap(descriptorWalletMap descriptors.WalletMap, chain Chain) (WalletMap, error) {
@@ -57,6 +60,57 @@ func NewWallet(pk string, addr types.Address, chain Chain) (*wallet, error) {
 	}, nil
 }
 
+// NewHDWalletFromMnemonic creates the wallet of the first account of the mnemonic, at m/44'/60'/0'/0/0.
+// Its child wallets are the other accounts of the mnemonic, e.g. the accounts that a devnet prefunds.
+func NewHDWalletFromMnemonic(mnemonic string, chain Chain) (*wallet, error) {
+	hd, err := devkeys.NewMnemonicDevKeys(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return newHDWallet(hd, 0, chain)
+}
+
+func newHDWallet(hd *devkeys.MnemonicDevKeys, index uint64, chain Chain) (*wallet, error) {
+	key := devkeys.UserKey(index)
+	privateKey, err := hd.Secret(key)
+	if err != nil {
+		return nil, err
+	}
+	return &wallet{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		chain:      chain,
+		hd:         hd,
+	}, nil
+}
+
+// Derive returns the child wallet of the wallet at the given index, on the same chain.
+// The child wallet of a mnemonic wallet is the account at m/44'/60'/0'/0/index of the mnemonic.
+// The child wallet of any other wallet has a key derived from the key of the wallet,
+// so the same wallet always has the same children.
+func (w *wallet) Derive(index uint64) (Wallet, error) {
+	if w.hd != nil {
+		return newHDWallet(w.hd, index, w.chain)
+	}
+	if w.privateKey == nil {
+		return nil, fmt.Errorf("cannot derive child wallet of wallet %s without private key", w.address)
+	}
+	seed := crypto.Keccak256(
+		[]byte("devnet-sdk child wallet"),
+		crypto.FromECDSA(w.privateKey),
+		new(big.Int).SetUint64(index).Bytes(),
+	)
+	privateKey, err := crypto.ToECDSA(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive child wallet %d of wallet %s: %w", index, w.address, err)
+	}
+	return &wallet{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		chain:      w.chain,
+	}, nil
+}
+
 func privateKeyFromString(pk string) (types.Key, error) {
 	var privateKey types.Key
 	if pk != "" {
@@ -142,6 +196,10 @@ type initiateMessageImpl struct {
 }
 
 func (i *initiateMessageImpl) Call(ctx context.Context) (any, error) {
+	return i.buildTx(ctx)
+}
+
+func (i *initiateMessageImpl) buildTx(ctx context.Context, options ...TxOption) (Transaction, error) {
 	builder := NewTxBuilder(ctx, i.chain)
 	messenger, err := i.chain.Nodes()[0].ContractsRegistry().L2ToL2CrossDomainMessenger(constants.L2ToL2CrossDomainMessenger)
 	if err != nil {
@@ -151,12 +209,12 @@ func (i *initiateMessageImpl) Call(ctx context.Context) (any, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to build calldata: %w", err)
 	}
-	tx, err := builder.BuildTx(
+	tx, err := builder.BuildTx(append([]TxOption{
 		WithFrom(i.from),
 		WithTo(constants.L2ToL2CrossDomainMessenger),
 		WithValue(big.NewInt(0)),
 		WithData(data),
-	)
+	}, options...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
@@ -170,20 +228,7 @@ func (i *initiateMessageImpl) Call(ctx context.Context) (any, error) {
 }
 
 func (i *initiateMessageImpl) Send(ctx context.Context) types.InvocationResult {
-	result, err := i.Call(ctx)
-	if err != nil {
-		return &sendResult{chain: i.chain, tx: nil, err: err}
-	}
-	tx, ok := result.(Transaction)
-	if !ok {
-		return &sendResult{chain: i.chain, tx: nil, err: fmt.Errorf("unexpected return type")}
-	}
-	err = i.processor.Send(ctx, tx)
-	return &sendResult{
-		chain: i.chain,
-		tx:    tx,
-		err:   err,
-	}
+	return sendWithNonce(ctx, i.chain, i.processor, i.from, i.buildTx)
 }
 
 type executeMessageImpl struct {
@@ -196,6 +241,10 @@ type executeMessageImpl struct {
 }
 
 func (i *executeMessageImpl) Call(ctx context.Context) (any, error) {
+	return i.buildTx(ctx)
+}
+
+func (i *executeMessageImpl) buildTx(ctx context.Context, options ...TxOption) (Transaction, error) {
 	builder := NewTxBuilder(ctx, i.chain)
 	messenger, err := i.chain.Nodes()[0].ContractsRegistry().L2ToL2CrossDomainMessenger(constants.L2ToL2CrossDomainMessenger)
 	if err != nil {
@@ -221,13 +270,13 @@ func (i *executeMessageImpl) Call(ctx context.Context) (any, error) {
 		Address:     constants.CrossL2Inbox,
 		StorageKeys: supervisorTypes.EncodeAccessList([]supervisorTypes.Access{access}),
 	}}
-	tx, err := builder.BuildTx(
+	tx, err := builder.BuildTx(append([]TxOption{
 		WithFrom(i.from),
 		WithTo(constants.L2ToL2CrossDomainMessenger),
 		WithValue(big.NewInt(0)),
 		WithData(data),
 		WithAccessList(accessList),
-	)
+	}, options...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
@@ -239,20 +288,7 @@ func (i *executeMessageImpl) Call(ctx context.Context) (any, error) {
 }
 
 func (i *executeMessageImpl) Send(ctx context.Context) types.InvocationResult {
-	result, err := i.Call(ctx)
-	if err != nil {
-		return &sendResult{chain: i.chain, tx: nil, err: err}
-	}
-	tx, ok := result.(Transaction)
-	if !ok {
-		return &sendResult{chain: i.chain, tx: nil, err: fmt.Errorf("unexpected return type")}
-	}
-	err = i.processor.Send(ctx, tx)
-	return &sendResult{
-		chain: i.chain,
-		tx:    tx,
-		err:   err,
-	}
+	return sendWithNonce(ctx, i.chain, i.processor, i.from, i.buildTx)
 }
 
 func (w *wallet) Nonce() uint64 {
@@ -333,13 +369,17 @@ type sendImpl struct {
 }
 
 func (i *sendImpl) Call(ctx context.Context) (any, error) {
+	return i.buildTx(ctx)
+}
+
+func (i *sendImpl) buildTx(ctx context.Context, options ...TxOption) (Transaction, error) {
 	builder := NewTxBuilder(ctx, i.chain)
-	tx, err := builder.BuildTx(
+	tx, err := builder.BuildTx(append([]TxOption{
 		WithFrom(i.from),
 		WithTo(i.to),
 		WithValue(i.amount.Int),
 		WithData(nil),
-	)
+	}, options...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
@@ -353,26 +393,25 @@ func (i *sendImpl) Call(ctx context.Context) (any, error) {
 }
 
 func (i *sendImpl) Send(ctx context.Context) types.InvocationResult {
-	builder := NewTxBuilder(ctx, i.chain)
-	tx, err := builder.BuildTx(
-		WithFrom(i.from),
-		WithTo(i.to),
-		WithValue(i.amount.Int),
-		WithData(nil),
-	)
+	return sendWithNonce(ctx, i.chain, i.processor, i.from, i.buildTx)
+}
 
-	// Sign the transaction if it's built okay
-	if err == nil {
-		tx, err = i.processor.Sign(tx)
+// sendWithNonce builds, signs and sends a transaction of the account, with the next nonce of the account.
+// Transactions of the same account are sent one at a time, so concurrent senders never reuse a nonce.
+func sendWithNonce(ctx context.Context, chain Chain, processor TransactionProcessor, from types.Address, buildTx func(ctx context.Context, options ...TxOption) (Transaction, error)) types.InvocationResult {
+	nonce, release, err := accountNonces.lock(ctx, chain.ID(), chain.Nodes()[0], from)
+	if err != nil {
+		return &sendResult{chain: chain, tx: nil, err: err}
 	}
-
-	// Send the transaction if it's signed okay
+	tx, err := buildTx(ctx, WithNonce(nonce))
+	// Send the transaction if it's built and signed okay
 	if err == nil {
-		err = i.processor.Send(ctx, tx)
+		err = processor.Send(ctx, tx)
 	}
+	release(err == nil)
 
 	return &sendResult{
-		chain: i.chain,
+		chain: chain,
 		tx:    tx,
 		err:   err,
 	}
@@ -3,22 +3,31 @@ package system
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
 	_ WalletV2 = (*walletV2)(nil)
+
+	// errNoContractAddress is returned if the receipt of a contract creation has no contract address.
+	errNoContractAddress = errors.New("receipt has no contract address")
 )
 
 type walletV2 struct {
 	priv   *ecdsa.PrivateKey
 	client *sources.EthClient
 	ctx    context.Context
+	txs    *txManager
 }
 
 func NewWalletV2FromWalletAndChain(ctx context.Context, wallet Wallet, chain Chain) (WalletV2, error) {
@@ -33,6 +42,7 @@ func NewWalletV2FromWalletAndChain(ctx context.Context, wallet Wallet, chain Cha
 		priv:   wallet.PrivateKey(),
 		client: client,
 		ctx:    ctx,
+		txs:    newTxManager(client, defaultTxManagerConfig()),
 	}, nil
 }
 
@@ -52,6 +62,7 @@ func NewWalletV2(ctx context.Context, rpcURL string, priv *ecdsa.PrivateKey, clC
 		client: cl,
 		priv:   priv,
 		ctx:    ctx,
+		txs:    newTxManager(cl, defaultTxManagerConfig()),
 	}, nil
 }
 
@@ -66,3 +77,31 @@ func (w *walletV2) Client() *sources.EthClient {
 func (w *walletV2) Ctx() context.Context {
 	return w.ctx
 }
+
+func (w *walletV2) Address() common.Address {
+	return crypto.PubkeyToAddress(w.priv.PublicKey)
+}
+
+// Send sends a transaction from the wallet, and waits for it to be included successfully.
+// Concurrent transactions of the wallet get distinct nonces, and the fees of
+// transactions that are not included in time are bumped.
+func (w *walletV2) Send(ctx context.Context, to common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	return w.txs.send(ctx, w.priv, &to, value, data)
+}
+
+// Deploy creates a contract with the given init code, and returns its address once the creation is included.
+func (w *walletV2) Deploy(ctx context.Context, code []byte) (common.Address, *types.Receipt, error) {
+	receipt, err := w.txs.send(ctx, w.priv, nil, nil, code)
+	if err != nil {
+		return common.Address{}, receipt, err
+	}
+	if receipt.ContractAddress == (common.Address{}) {
+		return common.Address{}, receipt, errNoContractAddress
+	}
+	return receipt.ContractAddress, receipt, nil
+}
+
+// Call executes a call from the wallet against the latest state, without sending a transaction.
+func (w *walletV2) Call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	return w.txs.call(ctx, w.Address(), to, data)
+}
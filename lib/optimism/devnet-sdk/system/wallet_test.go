@@ -235,3 +235,47 @@ func TestWallet_Nonce(t *testing.T) {
 	nonce := w.Nonce()
 	assert.Equal(t, uint64(0), nonce)
 }
+
+func TestWallet_Derive(t *testing.T) {
+	chain := &chain{}
+
+	t.Run("mnemonic wallet", func(t *testing.T) {
+		w, err := NewHDWalletFromMnemonic("test test test test test test test test test test test junk", chain)
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), w.Address())
+
+		child, err := w.Derive(1)
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), child.Address())
+		assert.Equal(t, crypto.PubkeyToAddress(child.PrivateKey().PublicKey), child.Address())
+
+		// children of a mnemonic wallet are accounts of the mnemonic, not of the child
+		grandchild, err := child.Derive(0)
+		assert.NoError(t, err)
+		assert.Equal(t, w.Address(), grandchild.Address())
+	})
+
+	t.Run("key wallet", func(t *testing.T) {
+		pk := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+		w, err := NewWallet(pk, types.Address(common.HexToAddress("0x5678")), chain)
+		assert.NoError(t, err)
+
+		child0, err := w.Derive(0)
+		assert.NoError(t, err)
+		child1, err := w.Derive(1)
+		assert.NoError(t, err)
+		again, err := w.Derive(0)
+		assert.NoError(t, err)
+
+		assert.Equal(t, child0.Address(), again.Address(), "derivation must be deterministic")
+		assert.NotEqual(t, child0.Address(), child1.Address())
+		assert.NotEqual(t, w.Address(), child0.Address())
+		assert.Equal(t, crypto.PubkeyToAddress(child1.PrivateKey().PublicKey), child1.Address())
+	})
+
+	t.Run("wallet without key", func(t *testing.T) {
+		w := &wallet{address: types.Address(common.HexToAddress("0x5678"))}
+		_, err := w.Derive(0)
+		assert.Error(t, err)
+	})
+}
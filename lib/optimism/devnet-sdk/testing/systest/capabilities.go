@@ -0,0 +1,90 @@
+package systest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+)
+
+// Names of the services of chains that tests commonly depend on.
+const (
+	SupervisorService = "supervisor"
+	ChallengerService = "challenger"
+	FaucetService     = "faucet"
+)
+
+// ChainCapabilities describes what a chain of a system supports.
+type ChainCapabilities struct {
+	ID types.ChainID
+	// Nodes is the number of nodes of the chain.
+	Nodes int
+	// ActiveForks are the names of the forks that are active, ordered by activation time.
+	ActiveForks []string
+	// Services are the names of the services of the chain, if they are known.
+	Services []string
+	// Faucet is true if the chain has a faucet service, or wallets that can fund test wallets.
+	Faucet bool
+}
+
+// HasFork returns true if the fork is active on the chain.
+func (c *ChainCapabilities) HasFork(name string) bool {
+	return slices.Contains(c.ActiveForks, name)
+}
+
+// HasService returns true if the service runs for the chain.
+func (c *ChainCapabilities) HasService(name string) bool {
+	return slices.Contains(c.Services, name)
+}
+
+// SystemCapabilities describes what a system supports, so tests and validators can branch on it.
+type SystemCapabilities struct {
+	L1  ChainCapabilities
+	L2s []ChainCapabilities
+	// Interop is true if the system is an interop system.
+	Interop bool
+	// Supervisor is true if the system has a supervisor.
+	Supervisor bool
+	// Challenger is true if any L2 chain has a challenger.
+	Challenger bool
+}
+
+// Capabilities inspects the system, from its descriptors only, without querying its nodes.
+// Forks are active if they are scheduled before the current time.
+func Capabilities(sys system.System) *SystemCapabilities {
+	timestamp := uint64(time.Now().Unix())
+	caps := &SystemCapabilities{
+		L1:  chainCapabilities(sys.L1(), timestamp),
+		L2s: make([]ChainCapabilities, 0, len(sys.L2s())),
+	}
+	for _, chain := range sys.L2s() {
+		chainCaps := chainCapabilities(chain, timestamp)
+		caps.Supervisor = caps.Supervisor || chainCaps.HasService(SupervisorService)
+		caps.Challenger = caps.Challenger || chainCaps.HasService(ChallengerService)
+		caps.L2s = append(caps.L2s, chainCaps)
+	}
+	if _, ok := sys.(system.InteropSystem); ok {
+		caps.Interop = true
+		// interop systems always have a supervisor
+		caps.Supervisor = true
+	}
+	return caps
+}
+
+func chainCapabilities(chain system.Chain, timestamp uint64) ChainCapabilities {
+	caps := ChainCapabilities{
+		ID:    chain.ID(),
+		Nodes: len(chain.Nodes()),
+	}
+	if schedule, err := chain.ForkSchedule(); err == nil {
+		for _, fork := range schedule.ActiveAt(timestamp) {
+			caps.ActiveForks = append(caps.ActiveForks, fork.Name)
+		}
+	}
+	if provider, ok := chain.(system.ServicesProvider); ok {
+		caps.Services = provider.Services()
+	}
+	caps.Faucet = caps.HasService(FaucetService) || len(chain.Wallets()) > 0
+	return caps
+}
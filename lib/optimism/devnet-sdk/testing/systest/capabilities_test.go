@@ -0,0 +1,69 @@
+package systest
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/stretchr/testify/require"
+)
+
+// servicesChain is an L2 chain with known services and wallets
+type servicesChain struct {
+	mockL2Chain[system.Node]
+	services []string
+	wallets  system.WalletMap
+}
+
+func (m *servicesChain) Services() []string        { return m.services }
+func (m *servicesChain) Wallets() system.WalletMap { return m.wallets }
+
+// servicesSystem is a system of chains with known services
+type servicesSystem struct {
+	mockSystem
+	l2s []system.L2Chain
+}
+
+func (m *servicesSystem) L2s() []system.L2Chain { return m.l2s }
+
+func TestCapabilities(t *testing.T) {
+	t.Run("system", func(t *testing.T) {
+		sys := &servicesSystem{
+			l2s: []system.L2Chain{
+				&servicesChain{
+					mockL2Chain: mockL2Chain[system.Node]{mockChain[system.Node]{nodes: []system.Node{nil, nil}}},
+					services:    []string{"batcher", ChallengerService},
+				},
+				&servicesChain{
+					services: []string{"batcher"},
+					wallets:  system.WalletMap{"user": nil},
+				},
+			},
+		}
+
+		caps := Capabilities(sys)
+		require.False(t, caps.Interop)
+		require.False(t, caps.Supervisor)
+		require.True(t, caps.Challenger)
+		require.False(t, caps.L1.Faucet)
+		require.Empty(t, caps.L1.Services)
+
+		require.Len(t, caps.L2s, 2)
+		require.Equal(t, 2, caps.L2s[0].Nodes)
+		require.True(t, caps.L2s[0].HasService(ChallengerService))
+		require.False(t, caps.L2s[0].Faucet)
+		require.False(t, caps.L2s[1].HasService(ChallengerService))
+		require.True(t, caps.L2s[1].Faucet)
+
+		// the fork schedules of the mocks are not known
+		require.Empty(t, caps.L2s[0].ActiveForks)
+		require.False(t, caps.L2s[0].HasFork("isthmus"))
+	})
+
+	t.Run("interop system", func(t *testing.T) {
+		caps := Capabilities(newMockInteropSystem())
+		require.True(t, caps.Interop)
+		require.True(t, caps.Supervisor)
+		require.False(t, caps.Challenger)
+		require.Len(t, caps.L2s, 1)
+	})
+}
@@ -0,0 +1,48 @@
+package systest
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+)
+
+// returnFundsTimeout is how long returning the funds of a wallet may take, once the test is done.
+const returnFundsTimeout = 2 * time.Minute
+
+// ReturnFunds registers the wallet of the chain to return its remaining funds to the funder, e.g. the faucet
+// that funded it, once the test is done. The recovered amounts are logged. Failures to return funds are logged
+// too, but do not fail the test.
+func ReturnFunds(t T, chain system.Chain, wallet system.Wallet, funder types.Address) {
+	t.Helper()
+	// the context of the test may be done by the time cleanups run
+	ctx := context.WithoutCancel(t.Context())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(ctx, returnFundsTimeout)
+		defer cancel()
+		amount, err := system.Sweep(ctx, chain, wallet, funder)
+		if err != nil {
+			t.Logf("failed to return funds of wallet %s to %s: %v", wallet.Address(), funder, err)
+			return
+		}
+		t.Logf("returned %s of wallet %s to %s", types.NewBalance(amount), wallet.Address(), funder)
+	})
+}
+
+// NewWallet creates a wallet of the chain with a new random key. The wallet is registered with ReturnFunds,
+// to return any funds it receives during the test to the funder once the test is done.
+func NewWallet(t T, chain system.Chain, funder types.Address) system.Wallet {
+	t.Helper()
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err, "failed to generate wallet key")
+	wallet, err := system.NewWallet(hex.EncodeToString(crypto.FromECDSA(priv)), crypto.PubkeyToAddress(priv.PublicKey), chain)
+	require.NoError(t, err, "failed to create wallet")
+	ReturnFunds(t, chain, wallet, funder)
+	return wallet
+}
@@ -0,0 +1,44 @@
+package systest
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// testLogLevel is the level of the loggers of system tests.
+const testLogLevel = log.LevelDebug
+
+type loggerKey struct{}
+
+// WithLogger returns a context that carries the logger, for LoggerFrom.
+func WithLogger(ctx context.Context, logger log.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// WithChainLogger returns a context that carries the logger of the context, scoped to the chain.
+func WithChainLogger(ctx context.Context, chain system.Chain) context.Context {
+	return WithLogger(ctx, LoggerFrom(ctx).New("chain", chain.ID()))
+}
+
+// LoggerFrom returns the logger that the context carries, or the root logger if it carries none.
+// The contexts of system tests, and of their sub-tests, carry a logger of the test.
+func LoggerFrom(ctx context.Context) log.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(log.Logger); ok {
+		return logger
+	}
+	return log.Root()
+}
+
+// withTestLogger returns a context that carries a logger of the test.
+func withTestLogger(ctx context.Context, t testlog.Testing) context.Context {
+	return WithLogger(ctx, testlog.Logger(t, testLogLevel))
+}
+
+// hasLogger returns true if the context carries a logger.
+func hasLogger(ctx context.Context) bool {
+	_, ok := ctx.Value(loggerKey{}).(log.Logger)
+	return ok
+}
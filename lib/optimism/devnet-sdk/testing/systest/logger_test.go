@@ -0,0 +1,40 @@
+package systest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerFrom(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, log.Root(), LoggerFrom(ctx))
+	require.False(t, hasLogger(ctx))
+
+	logger := log.NewLogger(log.DiscardHandler())
+	ctx = WithLogger(ctx, logger)
+	require.Equal(t, logger, LoggerFrom(ctx))
+	require.True(t, hasLogger(ctx))
+
+	chainCtx := WithChainLogger(ctx, &mockChain[system.Node]{})
+	require.NotSame(t, logger, LoggerFrom(chainCtx))
+}
+
+func TestSystemTestLogger(t *testing.T) {
+	helper := newBasicSystemTestHelper(&mockEnvGetter{}).WithAcquirers([]SystemAcquirer{
+		func(t BasicT) (system.System, error) { return newMockSystem(), nil },
+	})
+
+	helper.SystemTest(t, func(t T, sys system.System) {
+		require.True(t, hasLogger(t.Context()))
+		LoggerFrom(t.Context()).Info("Logging from the system test")
+
+		t.Run("sub-test", func(t T) {
+			require.True(t, hasLogger(t.Context()))
+			LoggerFrom(t.Context()).Info("Logging from the sub-test")
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
@@ -41,13 +42,93 @@ func getEthClients(chain system.Chain) ([]HeaderProvider, error) {
 // CheckForChainFork checks that the L2 chain has not forked now, and returns a
 // function that check again (to be called at the end of the test). An error is
 // returned from this function (and the returned function) if a chain fork has
-// been detected.
+// been detected. In between, the nodes are watched in the background, so chain
+// forks that are resolved by the end of the test are detected as well.
 func CheckForChainFork(ctx context.Context, chain system.L2Chain, logger log.Logger) (func() error, error) {
+	check, err := startChainForkCheck(ctx, chain, logger)
+	if err != nil {
+		return nil, err
+	}
+	return check.finish, nil
+}
+
+// CheckForChainForks is like CheckForChainFork, for several chains at once, e.g.
+// the L1 and the L2s of an interop test. The chains are checked and watched
+// concurrently, and errors are attributed to the chains that they occurred on.
+func CheckForChainForks(ctx context.Context, chains []system.Chain, logger log.Logger) (func() error, error) {
+	checks := make([]*chainForkCheck, len(chains))
+	errs := make([]error, len(chains))
+	var wg sync.WaitGroup
+	for i, chain := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i], errs[i] = startChainForkCheck(ctx, chain, logger.New("chain", chain.ID()))
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("chain %s: %w", chain.ID(), errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		for _, check := range checks {
+			if check != nil {
+				check.watcher.Stop()
+			}
+		}
+		return nil, err
+	}
+
+	return func() error {
+		errs := make([]error, len(chains))
+		var wg sync.WaitGroup
+		for i, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := check.finish(); err != nil {
+					errs[i] = fmt.Errorf("chain %s: %w", chains[i].ID(), err)
+				}
+			}()
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}, nil
+}
+
+// chainForkCheck is a running chain fork check of a chain.
+type chainForkCheck struct {
+	watcher     *system.ForkWatcher
+	secondCheck func() error
+}
+
+// startChainForkCheck checks that the chain has not forked now, and starts watching its nodes.
+func startChainForkCheck(ctx context.Context, chain system.Chain, logger log.Logger) (*chainForkCheck, error) {
 	clients, err := getEthClients(chain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get eth clients: %w", err)
 	}
-	return checkForChainFork(ctx, clients, logger)
+	secondCheck, err := checkForChainFork(ctx, clients, logger)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := system.NewForkWatcher(chain, system.DefaultForkWatcherInterval, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fork watcher: %w", err)
+	}
+	watcher.Start(ctx)
+	return &chainForkCheck{watcher: watcher, secondCheck: secondCheck}, nil
+}
+
+// finish stops watching the nodes of the chain, and checks the chain again.
+func (c *chainForkCheck) finish() error {
+	c.watcher.Stop()
+	select {
+	case divergence := <-c.watcher.Divergences():
+		return errors.New(divergence.String())
+	default:
+	}
+	return c.secondCheck()
 }
 
 // checkForChainFork checks that the L2 chain has not forked now, and returns a
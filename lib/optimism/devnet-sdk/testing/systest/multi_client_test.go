@@ -2,12 +2,15 @@ package systest
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -71,3 +74,31 @@ func TestRequireNoChainFork(t *testing.T) {
 
 	require.Error(t, secondCheck(), "expected chain split error")
 }
+
+// clientlessNode is a node without clients
+type clientlessNode struct {
+	system.Node
+}
+
+func (clientlessNode) GethClient() (*ethclient.Client, error) {
+	return nil, errors.New("no client")
+}
+
+// idChain is a chain with an ID
+type idChain struct {
+	mockChain[system.Node]
+	id int64
+}
+
+func (m *idChain) ID() *big.Int { return big.NewInt(m.id) }
+
+func TestCheckForChainForks(t *testing.T) {
+	chains := []system.Chain{
+		&idChain{id: 900},
+		&idChain{id: 901, mockChain: mockChain[system.Node]{nodes: []system.Node{clientlessNode{}}}},
+	}
+
+	_, err := CheckForChainForks(context.Background(), chains, testlog.Logger(t, log.LevelDebug))
+	require.ErrorContains(t, err, "chain 900: failed to get L2 start block: no clients configured")
+	require.ErrorContains(t, err, "chain 901: failed to get eth clients")
+}
@@ -0,0 +1,416 @@
+package systest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FlakeReportVar is the environment variable of the file that flake reports are appended to, as JSON lines.
+const FlakeReportVar = "DEVNET_FLAKE_REPORT"
+
+// Outcomes of a system test that is run with retries.
+const (
+	// OutcomePassed is the outcome of a test that passed on the first attempt.
+	OutcomePassed = "passed"
+	// OutcomeFlaky is the outcome of a test that passed after transient failures.
+	OutcomeFlaky = "flaky"
+	// OutcomeInfrastructure is the outcome of a test that failed with transient failures only, on all attempts.
+	OutcomeInfrastructure = "infrastructure"
+	// OutcomeRegression is the outcome of a test that failed with a failure that is not transient.
+	OutcomeRegression = "regression"
+)
+
+// RetryPolicy decides which failures of a system test are transient, and how often the test is retried on them.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the test is run.
+	MaxAttempts int
+	// Delay is the time to wait between attempts.
+	Delay time.Duration
+	// TransientErrors are substrings of failure messages that indicate transient failures, matched case-insensitively.
+	// Any failure that contains one of them is retried, so they must not match the messages of assertions.
+	TransientErrors []string
+	// IsTransientError returns true if an error that a failure is reported with is transient.
+	// It is checked for the errors that are passed to Error, Errorf, Fatal and Fatalf.
+	IsTransientError func(err error) bool
+}
+
+// DefaultRetryPolicy retries tests up to 3 times on network errors: RPC transport timeouts, refused or reset connections,
+// and rate limits, and on transactions that the node does not know, e.g. as they did not propagate to it yet.
+// Timeouts of waits, e.g. for a transaction to be included, are not transient, as regressions surface as such timeouts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		Delay:       5 * time.Second,
+		// messages of the errors that are wrapped into failure messages
+		TransientErrors: []string{
+			"i/o timeout",
+			"connection refused",
+			"connection reset by peer",
+			"429 too many requests",
+			"transaction not found",
+		},
+		IsTransientError: func(err error) bool {
+			return IsNetworkError(err) || IsNotFoundError(err)
+		},
+	}
+}
+
+// IsNetworkError returns true if the error is, or wraps, an error of the connection to a node:
+// a timeout of the RPC transport, a refused or reset connection, or a rate limit of the node.
+// Timeouts of contexts are not network errors, as they are the errors of waits that time out.
+func IsNetworkError(err error) bool {
+	// context.DeadlineExceeded is a net.Error that times out too
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// IsNotFoundError returns true if the error is, or wraps, the error of a node that does not know a transaction
+// or receipt, e.g. as the transaction was sent to another node that it did not propagate from yet.
+func IsNotFoundError(err error) bool {
+	return errors.Is(err, ethereum.NotFound)
+}
+
+// failure is a failure of an attempt, with the errors it is reported with.
+type failure struct {
+	message string
+	errs    []error
+}
+
+// isTransient returns true if all failures are transient. Attempts without failures are not transient.
+func (p RetryPolicy) isTransient(failures []failure) bool {
+	if len(failures) == 0 {
+		return false
+	}
+	for _, f := range failures {
+		if !p.isTransientFailure(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p RetryPolicy) isTransientFailure(f failure) bool {
+	if p.IsTransientError != nil {
+		for _, err := range f.errs {
+			if p.IsTransientError(err) {
+				return true
+			}
+		}
+	}
+	message := strings.ToLower(f.message)
+	for _, pattern := range p.TransientErrors {
+		if strings.Contains(message, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttemptReport is the result of a single attempt of a system test.
+type AttemptReport struct {
+	Attempt   int           `json:"attempt"`
+	Duration  time.Duration `json:"duration"`
+	Failures  []string      `json:"failures,omitempty"`
+	Transient bool          `json:"transient"`
+}
+
+// FlakeReport is the report of a system test that is run with retries,
+// separating infrastructure flakes from regressions.
+type FlakeReport struct {
+	Test     string          `json:"test"`
+	Outcome  string          `json:"outcome"`
+	Attempts []AttemptReport `json:"attempts"`
+}
+
+// SystemTestWithRetry runs a system test like SystemTest, but retries the test function on transient failures of the policy.
+// Failures of attempts that are retried do not fail the test. A flake report is logged,
+// and appended to the file in FlakeReportVar if it is set.
+func SystemTestWithRetry(t BasicT, f SystemTestFunc, policy RetryPolicy, validators ...PreconditionValidator) {
+	t.Helper()
+	SystemTest(t, func(t T, sys system.System) {
+		t.Helper()
+		runWithRetry(t, policy, func(t T) {
+			f(t, sys)
+		})
+	}, validators...)
+}
+
+func runWithRetry(t T, policy RetryPolicy, f func(t T)) {
+	t.Helper()
+	report := FlakeReport{Test: t.Name()}
+	var last *attemptT
+	for attempt := 1; ; attempt++ {
+		last = newAttemptT(t)
+		start := time.Now()
+		last.run(f)
+		attemptReport := AttemptReport{
+			Attempt:   attempt,
+			Duration:  time.Since(start),
+			Failures:  last.messages(),
+			Transient: policy.isTransient(last.failures),
+		}
+		report.Attempts = append(report.Attempts, attemptReport)
+
+		// an attempt that failed before it was skipped failed, the skip does not hide its failures
+		if !last.failed {
+			report.Outcome = OutcomePassed
+			if attempt > 1 {
+				report.Outcome = OutcomeFlaky
+			}
+			break
+		}
+		if !attemptReport.Transient {
+			report.Outcome = OutcomeRegression
+			break
+		}
+		if attempt >= policy.MaxAttempts {
+			report.Outcome = OutcomeInfrastructure
+			break
+		}
+		t.Logf("attempt %d failed with transient failures, retrying: %v", attempt, last.messages())
+		select {
+		case <-t.Context().Done():
+			report.Outcome = OutcomeInfrastructure
+			writeFlakeReport(t, report)
+			t.Fatalf("test context done before retry: %v", t.Context().Err())
+		case <-time.After(policy.Delay):
+		}
+	}
+
+	writeFlakeReport(t, report)
+	switch {
+	case last.failed:
+		for _, message := range last.messages() {
+			t.Error(message)
+		}
+		t.FailNow()
+	case last.skipped:
+		t.Skip(last.skipReason)
+	}
+}
+
+var flakeReportMu sync.Mutex
+
+func writeFlakeReport(t T, report FlakeReport) {
+	t.Helper()
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Logf("failed to encode flake report: %v", err)
+		return
+	}
+	t.Logf("flake report: %s", data)
+
+	path := os.Getenv(FlakeReportVar)
+	if path == "" {
+		return
+	}
+	flakeReportMu.Lock()
+	defer flakeReportMu.Unlock()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Logf("failed to open flake report file: %v", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		t.Logf("failed to write flake report: %v", err)
+	}
+}
+
+// attemptT records the failures of a single attempt of a test, instead of failing the test.
+// FailNow and SkipNow end the attempt, so it must be run in its own goroutine.
+type attemptT struct {
+	T
+	ctx context.Context
+
+	mu         sync.Mutex
+	failed     bool
+	skipped    bool
+	skipReason string
+	failures   []failure
+	cleanups   []func()
+}
+
+var _ T = (*attemptT)(nil)
+
+func newAttemptT(t T) *attemptT {
+	return &attemptT{T: t, ctx: t.Context()}
+}
+
+// run runs the attempt in a goroutine, and runs its cleanups once it is done.
+func (a *attemptT) run(f func(t T)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer a.runCleanups()
+		defer func() {
+			if r := recover(); r != nil {
+				a.record(fmt.Sprintf("panic: %v", r))
+			}
+		}()
+		f(a)
+	}()
+	<-done
+}
+
+func (a *attemptT) runCleanups() {
+	a.mu.Lock()
+	cleanups := a.cleanups
+	a.cleanups = nil
+	a.mu.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+func (a *attemptT) record(message string, args ...any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failed = true
+	if message == "" {
+		return
+	}
+	f := failure{message: message}
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			f.errs = append(f.errs, err)
+		}
+	}
+	a.failures = append(a.failures, f)
+}
+
+// messages returns the messages of the failures of the attempt.
+func (a *attemptT) messages() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var messages []string
+	for _, f := range a.failures {
+		messages = append(messages, f.message)
+	}
+	return messages
+}
+
+func (a *attemptT) Cleanup(f func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cleanups = append(a.cleanups, f)
+}
+
+func (a *attemptT) Error(args ...any) {
+	a.record(strings.TrimSuffix(fmt.Sprintln(args...), "\n"), args...)
+}
+
+func (a *attemptT) Errorf(format string, args ...any) {
+	a.record(fmt.Sprintf(format, args...), args...)
+}
+
+func (a *attemptT) Fail() {
+	a.record("")
+}
+
+func (a *attemptT) Failed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.failed
+}
+
+func (a *attemptT) FailNow() {
+	a.record("")
+	runtime.Goexit()
+}
+
+func (a *attemptT) Fatal(args ...any) {
+	a.Error(args...)
+	runtime.Goexit()
+}
+
+func (a *attemptT) Fatalf(format string, args ...any) {
+	a.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func (a *attemptT) Skip(args ...any) {
+	a.skip(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (a *attemptT) Skipf(format string, args ...any) {
+	a.skip(fmt.Sprintf(format, args...))
+}
+
+func (a *attemptT) SkipNow() {
+	a.skip("")
+}
+
+func (a *attemptT) skip(reason string) {
+	a.mu.Lock()
+	a.skipped = true
+	a.skipReason = reason
+	a.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (a *attemptT) Skipped() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.skipped
+}
+
+func (a *attemptT) Context() context.Context {
+	return a.ctx
+}
+
+func (a *attemptT) WithContext(ctx context.Context) T {
+	return &attemptContextT{attemptT: a, ctx: ctx}
+}
+
+// Parallel is a no-op, attempts run sequentially.
+func (a *attemptT) Parallel() {}
+
+// Run runs the sub-test as part of the attempt, so its failures are failures of the attempt.
+func (a *attemptT) Run(name string, fn func(t T)) {
+	a.Logf("running %s", name)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(a)
+	}()
+	<-done
+}
+
+// attemptContextT is an attempt with another context.
+type attemptContextT struct {
+	*attemptT
+	ctx context.Context
+}
+
+func (a *attemptContextT) Context() context.Context {
+	return a.ctx
+}
+
+func (a *attemptContextT) WithContext(ctx context.Context) T {
+	return &attemptContextT{attemptT: a.attemptT, ctx: ctx}
+}
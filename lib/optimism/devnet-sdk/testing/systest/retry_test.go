@@ -0,0 +1,191 @@
+package systest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// retryRecorder records the failures of a test that runs with retries, instead of failing the test
+type retryRecorder struct {
+	T
+	errors  []string
+	failed  bool
+	skipped bool
+}
+
+func (r *retryRecorder) Error(args ...any) { r.errors = append(r.errors, args[0].(string)) }
+func (r *retryRecorder) FailNow()          { r.failed = true }
+func (r *retryRecorder) Skip(args ...any)  { r.skipped = true }
+
+func failures(messages ...string) []failure {
+	var out []failure
+	for _, message := range messages {
+		out = append(out, failure{message: message})
+	}
+	return out
+}
+
+func TestRetryPolicyIsTransient(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	require.False(t, policy.isTransient(nil))
+	require.True(t, policy.isTransient(failures("read tcp 127.0.0.1:8545: i/o timeout", "dial tcp: Connection Refused")))
+	require.True(t, policy.isTransient(failures("failed to trace tx: transaction not found")))
+	require.False(t, policy.isTransient(failures("i/o timeout", "expected balance 1, got 2")))
+
+	// assertion failures that merely mention timeouts, missing data or EOF are not transient
+	require.False(t, policy.isTransient(failures("receipt not found")))
+	require.False(t, policy.isTransient(failures("block timeout must be 2s")))
+	require.False(t, policy.isTransient(failures("expected EOF marker in output")))
+	// waits that time out are how regressions surface, e.g. a tx that is never included
+	require.False(t, policy.isTransient(failures("tx not included: context deadline exceeded")))
+
+	// errors that failures are reported with are classified by type, whatever the message
+	require.True(t, policy.isTransient([]failure{{message: "failed to send", errs: []error{fmt.Errorf("send: %w", syscall.ECONNRESET)}}}))
+	require.True(t, policy.isTransient([]failure{{message: "failed to send", errs: []error{rpc.HTTPError{StatusCode: http.StatusTooManyRequests}}}}))
+	require.False(t, policy.isTransient([]failure{{message: "failed to send", errs: []error{errors.New("nonce too low")}}}))
+	require.True(t, policy.isTransient([]failure{{message: "failed to get tx", errs: []error{fmt.Errorf("get: %w", ethereum.NotFound)}}}))
+	require.False(t, policy.isTransient([]failure{{message: "tx not included", errs: []error{fmt.Errorf("wait: %w", context.DeadlineExceeded)}}}))
+}
+
+func TestIsNetworkError(t *testing.T) {
+	require.False(t, IsNetworkError(fmt.Errorf("call: %w", context.DeadlineExceeded)), "timeouts of waits are not network errors")
+	require.True(t, IsNetworkError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}))
+	require.True(t, IsNetworkError(&net.DNSError{IsTimeout: true}))
+	require.False(t, IsNetworkError(&net.DNSError{IsNotFound: true}))
+	require.False(t, IsNetworkError(rpc.HTTPError{StatusCode: http.StatusBadRequest}))
+	require.False(t, IsNetworkError(errors.New("not found")))
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	require.True(t, IsNotFoundError(fmt.Errorf("receipt: %w", ethereum.NotFound)))
+	require.False(t, IsNotFoundError(errors.New("nonce too low")))
+}
+
+func TestRunWithRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, TransientErrors: []string{"timeout"}}
+
+	tests := []struct {
+		name     string
+		failures []string
+		outcome  string
+		attempts int
+	}{
+		{name: "passes", outcome: OutcomePassed, attempts: 1},
+		{name: "flaky", failures: []string{"rpc timeout", "rpc timeout"}, outcome: OutcomeFlaky, attempts: 3},
+		{name: "infrastructure", failures: []string{"rpc timeout", "rpc timeout", "rpc timeout"}, outcome: OutcomeInfrastructure, attempts: 3},
+		{name: "regression", failures: []string{"rpc timeout", "wrong balance"}, outcome: OutcomeRegression, attempts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportFile := filepath.Join(t.TempDir(), "flakes.jsonl")
+			t.Setenv(FlakeReportVar, reportFile)
+
+			recorder := &retryRecorder{T: NewT(t)}
+			attempts := 0
+			cleanups := 0
+			runWithRetry(recorder, policy, func(t T) {
+				t.Cleanup(func() { cleanups++ })
+				attempt := attempts
+				attempts++
+				if attempt < len(tt.failures) {
+					t.Fatal(tt.failures[attempt])
+				}
+			})
+
+			require.Equal(t, tt.attempts, attempts)
+			require.Equal(t, tt.attempts, cleanups)
+
+			data, err := os.ReadFile(reportFile)
+			require.NoError(t, err)
+			var report FlakeReport
+			require.NoError(t, json.Unmarshal(data, &report))
+			require.Equal(t, tt.outcome, report.Outcome)
+			require.Len(t, report.Attempts, tt.attempts)
+
+			// only the failures of the last attempt fail the test
+			if tt.outcome == OutcomeInfrastructure || tt.outcome == OutcomeRegression {
+				require.True(t, recorder.failed)
+				require.Equal(t, []string{tt.failures[tt.attempts-1]}, recorder.errors)
+			} else {
+				require.False(t, recorder.failed)
+				require.Empty(t, recorder.errors)
+			}
+		})
+	}
+}
+
+func TestRunWithRetryFailureBeforeSkip(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, TransientErrors: []string{"timeout"}}
+
+	recorder := &retryRecorder{T: NewT(t)}
+	attempts := 0
+	runWithRetry(recorder, policy, func(t T) {
+		attempts++
+		t.Error("expected balance 1, got 2")
+		t.Run("sub", func(t T) {
+			t.Skip("not supported")
+		})
+	})
+
+	require.Equal(t, 1, attempts, "failures that are not transient must not be retried")
+	require.True(t, recorder.failed, "a skip must not hide the failures of the attempt")
+	require.False(t, recorder.skipped)
+	require.Equal(t, []string{"expected balance 1, got 2"}, recorder.errors)
+}
+
+func TestRunWithRetryWaitTimeout(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.Delay = 0
+	reportFile := filepath.Join(t.TempDir(), "flakes.jsonl")
+	t.Setenv(FlakeReportVar, reportFile)
+
+	recorder := &retryRecorder{T: NewT(t)}
+	attempts := 0
+	runWithRetry(recorder, policy, func(t T) {
+		attempts++
+		err := fmt.Errorf("failed to wait for tx: %w", context.DeadlineExceeded)
+		t.Fatalf("tx not included: %v", err)
+	})
+
+	require.Equal(t, 1, attempts, "waits that time out must not be retried")
+	require.True(t, recorder.failed)
+	data, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	var report FlakeReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Equal(t, OutcomeRegression, report.Outcome)
+}
+
+func TestRunWithRetrySubtestsAndPanics(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, TransientErrors: []string{"connection refused"}}
+
+	recorder := &retryRecorder{T: NewT(t)}
+	attempts := 0
+	runWithRetry(recorder, policy, func(t T) {
+		attempts++
+		if attempts == 1 {
+			t.Run("sub", func(t T) {
+				t.Error(errors.New("dial tcp: connection refused"))
+			})
+			return
+		}
+		panic("boom")
+	})
+
+	require.Equal(t, 2, attempts)
+	require.True(t, recorder.failed)
+	require.Equal(t, []string{"panic: boom"}, recorder.errors)
+}
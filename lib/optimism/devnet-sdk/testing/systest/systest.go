@@ -107,14 +107,25 @@ func (h *basicSystemTestHelper) handlePreconditionError(t BasicT, err error) {
 	}
 }
 
+// SystemTest runs the test function against the acquired system, once all validators pass.
+// The context of the test carries a logger of the test, see LoggerFrom.
+// The durations of the phases of the test are reported as attributes of the test.
 func (h *basicSystemTestHelper) SystemTest(t BasicT, f SystemTestFunc, validators ...PreconditionValidator) {
 	wt := NewT(t)
 	wt.Helper()
 
+	// the first cleanup runs last, once the cleanups of the test are done
+	timer := newPhaseTimer()
+	t.Cleanup(func() {
+		timer.end()
+		timer.report(t)
+	})
+	timer.begin(PhaseAcquire)
+
 	ctx, cancel := context.WithCancel(wt.Context())
 	defer cancel()
 
-	wt = wt.WithContext(ctx)
+	wt = wt.WithContext(withTestLogger(ctx, t))
 
 	sys, err := tryAcquirers(t, h.acquirers)
 	if err != nil {
@@ -122,6 +133,7 @@ func (h *basicSystemTestHelper) SystemTest(t BasicT, f SystemTestFunc, validator
 		return
 	}
 
+	timer.begin(PhaseValidators)
 	for _, validator := range validators {
 		ctx, err := validator(wt, sys)
 		if err != nil {
@@ -130,6 +142,11 @@ func (h *basicSystemTestHelper) SystemTest(t BasicT, f SystemTestFunc, validator
 		wt = wt.WithContext(ctx)
 	}
 
+	timer.begin(PhaseScenario)
+	// the cleanup registered once the test function returns runs before the cleanups that the test function registered
+	defer t.Cleanup(func() {
+		timer.begin(PhaseCleanup)
+	})
 	f(wt, sys)
 }
 
@@ -47,8 +47,13 @@ func (t *tbWrapper) Parallel() {
 func (t *tbWrapper) Run(name string, fn func(t T)) {
 	t.Helper()
 	if tt, ok := t.testingTB.(*testing.T); ok {
-		tt.Run(name, func(t *testing.T) {
-			fn(NewT(t))
+		tt.Run(name, func(tt *testing.T) {
+			st := NewT(tt)
+			// sub-tests of tests with a logger get a logger of their own
+			if hasLogger(t.ctx) {
+				st = st.WithContext(withTestLogger(st.Context(), tt))
+			}
+			fn(st)
 		})
 	} else {
 		// TODO: implement proper sub-tests reporting
@@ -89,6 +89,9 @@ func (m *mockChain[T]) Wallets() system.WalletMap {
 func (m *mockChain[T]) Config() (*params.ChainConfig, error) {
 	return nil, fmt.Errorf("not implemented on lowLevelMockChain")
 }
+func (m *mockChain[T]) ForkSchedule() (*system.ForkSchedule, error) {
+	return nil, fmt.Errorf("not implemented on lowLevelMockChain")
+}
 func (m *mockChain[T]) Addresses() system.AddressMap {
 	return system.AddressMap{}
 }
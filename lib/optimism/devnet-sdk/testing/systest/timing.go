@@ -0,0 +1,85 @@
+package systest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Phases of a system test, as reported in its timing attributes.
+const (
+	PhaseAcquire    = "acquire"
+	PhaseValidators = "validators"
+	PhaseScenario   = "scenario"
+	PhaseCleanup    = "cleanup"
+)
+
+// timingAttrPrefix is the prefix of the keys of the timing attributes of a system test.
+// The attribute of each phase is its duration in seconds, e.g. systest.duration.scenario=12.345.
+const timingAttrPrefix = "systest.duration."
+
+// attrT is implemented by tests that support attributes, which test2json reports,
+// so that CI can turn them into JUnit properties.
+type attrT interface {
+	Attr(key, value string)
+}
+
+type phaseDuration struct {
+	name     string
+	duration time.Duration
+}
+
+// phaseTimer records the durations of the phases of a system test.
+type phaseTimer struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	current string
+	start   time.Time
+	phases  []phaseDuration
+}
+
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{now: time.Now}
+}
+
+// begin ends the current phase, if any, and begins the next phase.
+func (p *phaseTimer) begin(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.endLocked()
+	p.current = name
+	p.start = p.now()
+}
+
+// end ends the current phase, if any.
+func (p *phaseTimer) end() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.endLocked()
+}
+
+func (p *phaseTimer) endLocked() {
+	if p.current == "" {
+		return
+	}
+	p.phases = append(p.phases, phaseDuration{name: p.current, duration: p.now().Sub(p.start)})
+	p.current = ""
+}
+
+// report emits the durations of the ended phases as attributes of the test,
+// or logs them if the test does not support attributes.
+func (p *phaseTimer) report(t BasicT) {
+	p.mu.Lock()
+	phases := p.phases
+	p.mu.Unlock()
+
+	for _, phase := range phases {
+		key := timingAttrPrefix + phase.name
+		value := fmt.Sprintf("%.3f", phase.duration.Seconds())
+		if at, ok := t.(attrT); ok {
+			at.Attr(key, value)
+		} else {
+			t.Logf("%s=%s", key, value)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package systest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/stretchr/testify/require"
+)
+
+// attrRecorder records the attributes and cleanups of a test
+type attrRecorder struct {
+	mockTB
+	attrs    map[string]string
+	cleanups []func()
+}
+
+func (m *attrRecorder) Context() context.Context { return context.Background() }
+func (m *attrRecorder) Cleanup(f func())         { m.cleanups = append(m.cleanups, f) }
+func (m *attrRecorder) Attr(key, value string)   { m.attrs[key] = value }
+
+func (m *attrRecorder) runCleanups() {
+	for i := len(m.cleanups) - 1; i >= 0; i-- {
+		m.cleanups[i]()
+	}
+}
+
+func TestPhaseTimer(t *testing.T) {
+	now := time.Unix(0, 0)
+	timer := newPhaseTimer()
+	timer.now = func() time.Time { return now }
+
+	timer.begin(PhaseAcquire)
+	now = now.Add(1500 * time.Millisecond)
+	timer.begin(PhaseScenario)
+	now = now.Add(12 * time.Second)
+	timer.end()
+	// ending without a current phase does nothing
+	timer.end()
+
+	recorder := &attrRecorder{attrs: make(map[string]string)}
+	timer.report(recorder)
+	require.Equal(t, map[string]string{
+		"systest.duration.acquire":  "1.500",
+		"systest.duration.scenario": "12.000",
+	}, recorder.attrs)
+}
+
+func TestSystemTestTiming(t *testing.T) {
+	helper := newBasicSystemTestHelper(&mockEnvGetter{}).WithAcquirers([]SystemAcquirer{
+		func(t BasicT) (system.System, error) { return newMockSystem(), nil },
+	})
+	validator := func(t T, sys system.System) (context.Context, error) {
+		return t.Context(), nil
+	}
+
+	recorder := &attrRecorder{mockTB: mockTB{name: "test"}, attrs: make(map[string]string)}
+	var order []string
+	helper.SystemTest(recorder, func(t T, sys system.System) {
+		order = append(order, "scenario")
+		t.Cleanup(func() { order = append(order, "test cleanup") })
+	}, validator)
+	recorder.runCleanups()
+
+	require.Equal(t, []string{"scenario", "test cleanup"}, order)
+	for _, phase := range []string{PhaseAcquire, PhaseValidators, PhaseScenario, PhaseCleanup} {
+		require.Contains(t, recorder.attrs, timingAttrPrefix+phase)
+	}
+}
@@ -0,0 +1,55 @@
+package fees
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var feeVaultNames = map[common.Address]string{
+	predeploys.BaseFeeVaultAddr:      "BaseFeeVault",
+	predeploys.L1FeeVaultAddr:        "L1FeeVault",
+	predeploys.SequencerFeeVaultAddr: "SequencerFeeVault",
+	predeploys.OperatorFeeVaultAddr:  "OperatorFeeVault",
+}
+
+func accountName(addr common.Address) string {
+	if name, ok := feeVaultNames[addr]; ok {
+		return name
+	}
+	return addr.Hex()
+}
+
+// addChanges adds the balance changes to the totals, where missing changes count as zero
+func addChanges(totals, changes map[common.Address]*big.Int) {
+	for addr, change := range changes {
+		if total, ok := totals[addr]; ok {
+			total.Add(total, change)
+		} else {
+			totals[addr] = new(big.Int).Set(change)
+		}
+	}
+}
+
+// AssertBalanceChanges compares the balance changes of the accounts between the snapshots with the expected changes,
+// and reports differences. The changes are compared with what the accounts have withdrawn, if sampled,
+// so withdrawals of the fee vaults do not cause mismatches. Accounts that are not expected to change are not compared.
+func AssertBalanceChanges(t systest.T, start, end *system.AccountSnapshot, expected map[common.Address]*big.Int) {
+	require.NotNil(t, start, "Start snapshot should not be nil")
+	require.NotNil(t, end, "End snapshot should not be nil")
+	for addr, change := range expected {
+		_, inStart := start.Accounts[addr]
+		_, inEnd := end.Accounts[addr]
+		if !assert.True(t, inStart && inEnd, "%s is not part of the snapshots", accountName(addr)) {
+			continue
+		}
+		actual := new(big.Int).Sub(end.Accrued(addr), start.Accrued(addr))
+		assert.True(t, change.Cmp(actual) == 0,
+			"%s balance change mismatch: expected %v, got %v (diff: %v)", accountName(addr), change, actual, new(big.Int).Sub(actual, change))
+	}
+}
@@ -0,0 +1,101 @@
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTB is a minimal testing.TB implementation for checking assertion failures
+// without failing the actual test.
+type mockTB struct {
+	testing.TB // Embed standard testing.TB for most methods (like Logf)
+	failed     bool
+}
+
+func (m *mockTB) Helper()                         { m.TB.Helper() }
+func (m *mockTB) Errorf(string, ...any)           { m.failed = true }                        // Just record failure
+func (m *mockTB) Fatalf(string, ...any)           { m.failed = true; panic("mock Fatalf") }  // Record failure and panic
+func (m *mockTB) FailNow()                        { m.failed = true; panic("mock FailNow") } // Record failure and panic
+func (m *mockTB) Fail()                           { m.failed = true }                        // Just record failure
+func (m *mockTB) Name() string                    { return m.TB.Name() }
+func (m *mockTB) Logf(format string, args ...any) { m.TB.Logf(format, args...) }
+
+// Add other testing.TB methods if needed by systest.NewT or AssertBalanceChanges
+func (m *mockTB) Cleanup(f func())                 { m.TB.Cleanup(f) }
+func (m *mockTB) Error(args ...any)                { m.failed = true }
+func (m *mockTB) Failed() bool                     { return m.failed } // Reflect our recorded state
+func (m *mockTB) Fatal(args ...any)                { m.failed = true; panic("mock Fatal") }
+func (m *mockTB) Log(args ...any)                  { m.TB.Log(args...) }
+func (m *mockTB) Setenv(key, value string)         { m.TB.Setenv(key, value) }
+func (m *mockTB) Skip(args ...any)                 { m.TB.Skip(args...) }
+func (m *mockTB) SkipNow()                         { m.TB.SkipNow() }
+func (m *mockTB) Skipf(format string, args ...any) { m.TB.Skipf(format, args...) }
+func (m *mockTB) Skipped() bool                    { return m.TB.Skipped() }
+func (m *mockTB) TempDir() string                  { return m.TB.TempDir() }
+
+func TestAssertBalanceChanges(t *testing.T) {
+	from := common.HexToAddress("0x5678")
+	snapshot := func(baseFee, operatorFee, operatorFeeWithdrawn, fromBalance int64) *system.AccountSnapshot {
+		return &system.AccountSnapshot{Accounts: map[common.Address]system.AccountState{
+			predeploys.BaseFeeVaultAddr:     {Balance: big.NewInt(baseFee), Withdrawn: big.NewInt(0)},
+			predeploys.OperatorFeeVaultAddr: {Balance: big.NewInt(operatorFee), Withdrawn: big.NewInt(operatorFeeWithdrawn)},
+			from:                            {Balance: big.NewInt(fromBalance)},
+		}}
+	}
+	start := snapshot(10, 40, 0, 500)
+	expected := map[common.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:     big.NewInt(5),
+		predeploys.OperatorFeeVaultAddr: big.NewInt(20),
+		from:                            big.NewInt(-25),
+	}
+
+	t.Run("EqualChanges", func(t *testing.T) {
+		mockT := &mockTB{TB: t}
+		AssertBalanceChanges(systest.NewT(mockT), start, snapshot(15, 60, 0, 475), expected)
+		assert.False(t, mockT.failed, "AssertBalanceChanges should not fail for the expected changes")
+	})
+
+	t.Run("DifferentChange", func(t *testing.T) {
+		mockT := &mockTB{TB: t}
+		AssertBalanceChanges(systest.NewT(mockT), start, snapshot(16, 60, 0, 474), expected)
+		assert.True(t, mockT.failed, "AssertBalanceChanges should fail for a different change")
+	})
+
+	t.Run("WithdrawalMidTest", func(t *testing.T) {
+		// the operator fee vault was withdrawn, after it was credited
+		mockT := &mockTB{TB: t}
+		AssertBalanceChanges(systest.NewT(mockT), start, snapshot(15, 0, 60, 475), expected)
+		assert.False(t, mockT.failed, "withdrawals should be accounted for")
+
+		end := snapshot(15, 0, 60, 475)
+		state := end.Accounts[predeploys.OperatorFeeVaultAddr]
+		state.Withdrawn = nil
+		end.Accounts[predeploys.OperatorFeeVaultAddr] = state
+		mockT = &mockTB{TB: t}
+		AssertBalanceChanges(systest.NewT(mockT), start, end, expected)
+		assert.True(t, mockT.failed, "unaccounted withdrawals should be reported")
+	})
+
+	t.Run("UntrackedAccount", func(t *testing.T) {
+		mockT := &mockTB{TB: t}
+		AssertBalanceChanges(systest.NewT(mockT), start, snapshot(15, 60, 0, 475), map[common.Address]*big.Int{
+			predeploys.L1FeeVaultAddr: big.NewInt(0),
+		})
+		assert.True(t, mockT.failed, "AssertBalanceChanges should fail for accounts that are not part of the snapshots")
+	})
+
+	t.Run("NilSnapshot", func(t *testing.T) {
+		mockT := &mockTB{TB: t}
+		// Use assert.Panics because require.NotNil calls t.FailNow() which our mock makes panic
+		assert.Panics(t, func() {
+			AssertBalanceChanges(systest.NewT(mockT), nil, start, expected)
+		}, "AssertBalanceChanges should panic via FailNow when a snapshot is nil")
+		assert.True(t, mockT.failed)
+	})
+}
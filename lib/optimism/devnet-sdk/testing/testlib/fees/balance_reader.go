@@ -0,0 +1,88 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// BalanceReader provides methods to read balances from the chain
+type BalanceReader struct {
+	node   system.Node
+	t      systest.T
+	logger log.Logger
+}
+
+// NewBalanceReader creates a new BalanceReader instance
+func NewBalanceReader(t systest.T, node system.Node) *BalanceReader {
+	return &BalanceReader{
+		node:   node,
+		t:      t,
+		logger: systest.LoggerFrom(t.Context()),
+	}
+}
+
+// FeeVaults are the vaults that the fees of L2 transactions are credited to.
+var FeeVaults = []common.Address{
+	predeploys.BaseFeeVaultAddr,
+	predeploys.L1FeeVaultAddr,
+	predeploys.SequencerFeeVaultAddr,
+	predeploys.OperatorFeeVaultAddr,
+}
+
+// SampleBalances reads the balances of the fee vaults and the accounts at the given block number.
+// The snapshot includes the totals the fee vaults have withdrawn, so their accrued fees add up across withdrawals.
+func (br *BalanceReader) SampleBalances(ctx context.Context, blockNumber *big.Int, accounts ...common.Address) *system.AccountSnapshot {
+	br.logger.Debug("Sampling balances",
+		"block", blockNumber,
+		"accounts", accounts)
+
+	// Read all balances at once, so they are consistent
+	snapshot, err := system.Snapshot(ctx, br.node, blockNumber, slices.Concat(FeeVaults, accounts)...)
+	require.NoError(br.t, err)
+
+	// Read the totals the vaults have withdrawn, in the same block, so withdrawals do not throw off the balances
+	processed, err := br.sampleProcessed(ctx, snapshot.Block)
+	require.NoError(br.t, err)
+	for i, vault := range FeeVaults {
+		state := snapshot.Accounts[vault]
+		state.Withdrawn = processed[i]
+		snapshot.Accounts[vault] = state
+	}
+
+	br.logger.Debug("Sampled balances", "block", snapshot.Block, "accounts", snapshot.Accounts)
+	return snapshot
+}
+
+// sampleProcessed reads the totalProcessed of each fee vault at the block, which is the total it has withdrawn
+func (br *BalanceReader) sampleProcessed(ctx context.Context, blockNumber *big.Int) ([]*big.Int, error) {
+	// All fee vaults share the FeeVault interface
+	vaultABI, err := bindings.SequencerFeeVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee vault ABI: %w", err)
+	}
+	calls := make([]batching.Call, len(FeeVaults))
+	for i, vault := range FeeVaults {
+		calls[i] = batching.NewBoundContract(vaultABI, vault).Call("totalProcessed")
+	}
+	results, err := br.node.MultiCall(ctx, rpcblock.ByNumber(blockNumber.Uint64()), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read total processed of fee vaults at block %v: %w", blockNumber, err)
+	}
+	processed := make([]*big.Int, len(results))
+	for i, result := range results {
+		processed[i] = result.GetBigInt(0)
+	}
+	return processed, nil
+}
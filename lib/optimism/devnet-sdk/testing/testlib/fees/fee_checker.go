@@ -0,0 +1,219 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// stateGetterAdapter adapts the ethclient to implement the StateGetter interface
+type stateGetterAdapter struct {
+	t      systest.T
+	client *ethclient.Client
+	ctx    context.Context
+}
+
+// GetState implements the StateGetter interface
+func (sga *stateGetterAdapter) GetState(addr common.Address, key common.Hash) common.Hash {
+	var result common.Hash
+	val, err := sga.client.StorageAt(sga.ctx, addr, key, nil)
+	require.NoError(sga.t, err)
+	copy(result[:], val)
+	return result
+}
+
+// blockSource is the subset of the client used to read the transactions of blocks, for ease of testing
+type blockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*gethTypes.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethTypes.Receipt, error)
+}
+
+// FeeChecker provides methods to calculate various types of fees,
+// with the fee formula of the chain config at the time of each block.
+type FeeChecker struct {
+	client        blockSource
+	config        *params.ChainConfig
+	l1CostFn      gethTypes.L1CostFunc
+	operatorFeeFn gethTypes.OperatorCostFunc
+	logger        log.Logger
+}
+
+// NewFeeChecker creates a new FeeChecker instance
+func NewFeeChecker(t systest.T, client *ethclient.Client, chainConfig *params.ChainConfig) *FeeChecker {
+	// Create state getter adapter for L1 cost function
+	sga := &stateGetterAdapter{
+		t:      t,
+		client: client,
+		ctx:    t.Context(),
+	}
+	return newFeeChecker(systest.LoggerFrom(t.Context()), client, chainConfig, sga)
+}
+
+// NewFeeCheckerWithState creates a FeeChecker that reads the fee parameters from the given state,
+// e.g. the state at the block of the transactions to check, instead of the latest state of the chain.
+// It cannot read blocks, so it only calculates the expected changes of single transactions.
+func NewFeeCheckerWithState(logger log.Logger, chainConfig *params.ChainConfig, state gethTypes.StateGetter) *FeeChecker {
+	return newFeeChecker(logger, nil, chainConfig, state)
+}
+
+func newFeeChecker(logger log.Logger, client blockSource, chainConfig *params.ChainConfig, state gethTypes.StateGetter) *FeeChecker {
+	logger.Debug("Creating fee checker", "chainID", chainConfig.ChainID, "optimism", chainConfig.IsOptimism())
+
+	// Create L1 cost function
+	l1CostFn := gethTypes.NewL1CostFunc(chainConfig, state)
+
+	// Create operator fee function
+	operatorFeeFn := gethTypes.NewOperatorCostFunc(chainConfig, state)
+
+	return &FeeChecker{
+		client:        client,
+		config:        chainConfig,
+		l1CostFn:      l1CostFn,
+		operatorFeeFn: operatorFeeFn,
+		logger:        logger,
+	}
+}
+
+// Formula returns the fee formula at the block time.
+func (fc *FeeChecker) Formula(blockTime uint64) Formula {
+	return FormulaAt(fc.config, blockTime)
+}
+
+// L1Cost calculates the L1 fee for a transaction, which is zero on chains that are not OP chains
+func (fc *FeeChecker) L1Cost(rcd gethTypes.RollupCostData, blockTime uint64) *big.Int {
+	if !fc.Formula(blockTime).HasL1Fee() {
+		return new(big.Int)
+	}
+	return fc.l1CostFn(rcd, blockTime)
+}
+
+// OperatorCost calculates the operator fee for the gas used by a transaction, which is zero before Isthmus
+func (fc *FeeChecker) OperatorCost(gasUsed uint64, blockTime uint64) *big.Int {
+	if !fc.Formula(blockTime).HasOperatorFee() {
+		return new(big.Int)
+	}
+	return fc.operatorFeeFn(gasUsed, blockTime).ToBig()
+}
+
+// CalculateExpectedFees returns the fees the transaction is expected to credit to each of the FeeVaults.
+// Calculates all fees internally from raw inputs, with the fee formula at the time of the block
+func (fc *FeeChecker) CalculateExpectedFees(
+	gasUsedUint64 uint64,
+	header *gethTypes.Header,
+	tx *gethTypes.Transaction,
+) map[common.Address]*big.Int {
+	// Deposits buy their gas on L1, so they are charged no fees on L2
+	if tx.IsDepositTx() {
+		return map[common.Address]*big.Int{
+			predeploys.BaseFeeVaultAddr:      new(big.Int),
+			predeploys.L1FeeVaultAddr:        new(big.Int),
+			predeploys.SequencerFeeVaultAddr: new(big.Int),
+			predeploys.OperatorFeeVaultAddr:  new(big.Int),
+		}
+	}
+
+	// Convert the gas used (uint64) to a big.Int.
+	gasUsed := new(big.Int).SetUint64(gasUsedUint64)
+
+	// 1. Base Fee Burned: header.BaseFee * gasUsed
+	baseFee := new(big.Int).Mul(header.BaseFee, gasUsed)
+
+	// 2. Calculate the effective tip.
+	// Effective tip is the minimum of:
+	//   a) tx.GasTipCap() and
+	//   b) tx.GasFeeCap() - header.BaseFee
+	tipCap := tx.GasTipCap() // maximum priority fee per gas offered by the user
+	feeCap := tx.GasFeeCap() // maximum fee per gas the user is willing to pay
+
+	// Compute feeCap minus the base fee.
+	diff := new(big.Int).Sub(feeCap, header.BaseFee)
+
+	// effectiveTip = min(tipCap, diff)
+	effectiveTip := new(big.Int)
+	if tipCap.Cmp(diff) < 0 {
+		effectiveTip.Set(tipCap)
+	} else {
+		effectiveTip.Set(diff)
+	}
+
+	// 3. Coinbase Fee Credit: effectiveTip * gasUsed.
+	l2Fee := new(big.Int).Mul(effectiveTip, gasUsed)
+
+	// Calculate L1 fee
+	l1Fee := fc.L1Cost(tx.RollupCostData(), header.Time)
+
+	// Calculate operator fee
+	fc.logger.Debug("Calculating operator fee", "gasUsed", gasUsedUint64, "blockTime", header.Time, "formula", fc.Formula(header.Time))
+	operatorFee := fc.OperatorCost(gasUsedUint64, header.Time)
+
+	return map[common.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:      baseFee,
+		predeploys.L1FeeVaultAddr:        l1Fee,
+		predeploys.SequencerFeeVaultAddr: l2Fee,
+		predeploys.OperatorFeeVaultAddr:  operatorFee,
+	}
+}
+
+// CalculateExpectedBalanceChanges returns the expected balance changes of the FeeVaults and of the sender of the transaction.
+// The sender pays the fees and the value of the transaction, and is credited the minted value of a deposit.
+func (fc *FeeChecker) CalculateExpectedBalanceChanges(
+	gasUsed uint64,
+	header *gethTypes.Header,
+	tx *gethTypes.Transaction,
+	from common.Address,
+) map[common.Address]*big.Int {
+	changes := fc.CalculateExpectedFees(gasUsed, header, tx)
+	fromBalance := new(big.Int).Neg(tx.Value())
+	for _, fee := range changes {
+		fromBalance.Sub(fromBalance, fee)
+	}
+	if mint := tx.Mint(); mint != nil {
+		fromBalance.Add(fromBalance, mint)
+	}
+	// the sender may be a fee vault itself
+	addChanges(changes, map[common.Address]*big.Int{from: fromBalance})
+	return changes
+}
+
+// CalculateExpectedBlockChanges returns the expected changes of the FeeVaults balances from all transactions
+// of the blocks after the from block, up to and including the to block. Unlike the changes of single transactions,
+// these hold while other transactions are sent concurrently. The changes of the senders are not included.
+// The L1 fees are taken from the receipts, as the L1 fee parameters of past blocks may differ from the latest,
+// so they should be verified per transaction. The other fees require unchanged fee parameters across the blocks.
+func (fc *FeeChecker) CalculateExpectedBlockChanges(ctx context.Context, from, to *big.Int) (map[common.Address]*big.Int, error) {
+	if fc.client == nil {
+		return nil, errors.New("fee checker cannot read blocks")
+	}
+	changes := make(map[common.Address]*big.Int, len(FeeVaults))
+	for _, vault := range FeeVaults {
+		changes[vault] = new(big.Int)
+	}
+	for number := new(big.Int).Add(from, big.NewInt(1)); number.Cmp(to) <= 0; number.Add(number, big.NewInt(1)) {
+		block, err := fc.client.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+		for _, tx := range block.Transactions() {
+			receipt, err := fc.client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get receipt of transaction %s: %w", tx.Hash(), err)
+			}
+			txFees := fc.CalculateExpectedFees(receipt.GasUsed, block.Header(), tx)
+			if receipt.L1Fee != nil && !tx.IsDepositTx() {
+				txFees[predeploys.L1FeeVaultAddr] = receipt.L1Fee
+			}
+			addChanges(changes, txFees)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,113 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateExpectedBalanceChanges_Deposit(t *testing.T) {
+	fc := &FeeChecker{logger: log.Root()}
+	to := common.HexToAddress("0x1234")
+	tx := gethTypes.NewTx(&gethTypes.DepositTx{
+		From:  common.HexToAddress("0x5678"),
+		To:    &to,
+		Mint:  big.NewInt(1000),
+		Value: big.NewInt(400),
+		Gas:   100_000,
+	})
+	header := &gethTypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(7), Time: 10}
+
+	from := common.HexToAddress("0x5678")
+	changes := fc.CalculateExpectedBalanceChanges(50_000, header, tx, from)
+	assert.Equal(t, 0, changes[predeploys.BaseFeeVaultAddr].Sign(), "deposits burn no base fee")
+	assert.Equal(t, 0, changes[predeploys.L1FeeVaultAddr].Sign(), "deposits pay no L1 fee")
+	assert.Equal(t, 0, changes[predeploys.SequencerFeeVaultAddr].Sign(), "deposits pay no priority fee")
+	assert.Equal(t, 0, changes[predeploys.OperatorFeeVaultAddr].Sign(), "deposits pay no operator fee")
+	assert.Equal(t, int64(600), changes[from].Int64(), "sender is credited the mint, less the value")
+}
+
+func TestCalculateExpectedBalanceChanges(t *testing.T) {
+	fc := &FeeChecker{config: &params.ChainConfig{ChainID: big.NewInt(1)}, logger: log.Root()}
+	to := common.HexToAddress("0x1234")
+	tx := gethTypes.NewTx(&gethTypes.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(3),
+		GasFeeCap: big.NewInt(20),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+	})
+	header := &gethTypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(7), Time: 10}
+
+	from := common.HexToAddress("0x5678")
+	changes := fc.CalculateExpectedBalanceChanges(50_000, header, tx, from)
+	assert.Equal(t, map[common.Address]*big.Int{
+		predeploys.BaseFeeVaultAddr:      big.NewInt(7 * 50_000),
+		predeploys.L1FeeVaultAddr:        big.NewInt(0),
+		predeploys.SequencerFeeVaultAddr: big.NewInt(3 * 50_000),
+		predeploys.OperatorFeeVaultAddr:  big.NewInt(0),
+		from:                             big.NewInt(-(10*50_000 + 1000)),
+	}, changes, "sender pays the fees and the value")
+
+	// a fee vault that sends a transaction pays its fees, and is credited the fees of its own kind
+	changes = fc.CalculateExpectedBalanceChanges(50_000, header, tx, predeploys.SequencerFeeVaultAddr)
+	assert.Equal(t, big.NewInt(-(7*50_000 + 1000)), changes[predeploys.SequencerFeeVaultAddr])
+}
+
+type mockBlockSource struct {
+	blocks   map[uint64]*gethTypes.Block
+	receipts map[common.Hash]*gethTypes.Receipt
+}
+
+func (m *mockBlockSource) BlockByNumber(_ context.Context, number *big.Int) (*gethTypes.Block, error) {
+	block, ok := m.blocks[number.Uint64()]
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return block, nil
+}
+
+func (m *mockBlockSource) TransactionReceipt(_ context.Context, txHash common.Hash) (*gethTypes.Receipt, error) {
+	receipt, ok := m.receipts[txHash]
+	if !ok {
+		return nil, fmt.Errorf("receipt of %s not found", txHash)
+	}
+	return receipt, nil
+}
+
+func TestCalculateExpectedBlockChanges(t *testing.T) {
+	to := common.HexToAddress("0x1234")
+	src := &mockBlockSource{blocks: map[uint64]*gethTypes.Block{}, receipts: map[common.Hash]*gethTypes.Receipt{}}
+	for number := uint64(1); number <= 3; number++ {
+		tx := gethTypes.NewTx(&gethTypes.DepositTx{
+			SourceHash: common.BigToHash(new(big.Int).SetUint64(number)),
+			From:       common.HexToAddress("0x5678"),
+			To:         &to,
+			Mint:       big.NewInt(1000),
+			Gas:        100_000,
+		})
+		header := &gethTypes.Header{Number: new(big.Int).SetUint64(number), BaseFee: big.NewInt(7), Time: 10 * number}
+		src.blocks[number] = gethTypes.NewBlockWithHeader(header).WithBody(gethTypes.Body{Transactions: []*gethTypes.Transaction{tx}})
+		src.receipts[tx.Hash()] = &gethTypes.Receipt{GasUsed: 50_000}
+	}
+	fc := &FeeChecker{client: src, logger: log.Root()}
+
+	changes, err := fc.CalculateExpectedBlockChanges(context.Background(), big.NewInt(1), big.NewInt(3))
+	require.NoError(t, err)
+	assert.Equal(t, 0, changes[predeploys.BaseFeeVaultAddr].Sign(), "deposits burn no base fee")
+	assert.Equal(t, 0, changes[predeploys.OperatorFeeVaultAddr].Sign(), "deposits pay no operator fee")
+	assert.Len(t, changes, len(FeeVaults), "block changes have no sender")
+
+	_, err = fc.CalculateExpectedBlockChanges(context.Background(), big.NewInt(2), big.NewInt(4))
+	require.ErrorContains(t, err, "failed to get block 4")
+}
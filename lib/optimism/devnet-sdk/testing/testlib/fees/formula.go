@@ -0,0 +1,47 @@
+package fees
+
+import (
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Formula is the formula that the fees of transactions are charged with, which depends on the active forks of the chain.
+type Formula string
+
+const (
+	// FormulaL1 charges the base fee and the priority fee only, on chains that are not OP chains.
+	FormulaL1 Formula = "l1"
+	// FormulaLegacy charges the L1 fee from the L1 base fee, fee overhead and fee scalar, before Ecotone.
+	FormulaLegacy Formula = "legacy"
+	// FormulaEcotone charges the L1 fee from the L1 base fee and blob base fee, and their scalars.
+	FormulaEcotone Formula = "ecotone"
+	// FormulaFjord charges the Ecotone L1 fee on the estimated compressed size of the transaction.
+	FormulaFjord Formula = "fjord"
+	// FormulaIsthmus charges the operator fee on top of the Fjord L1 fee.
+	FormulaIsthmus Formula = "isthmus"
+)
+
+// FormulaAt returns the fee formula of the chain config at the block time.
+func FormulaAt(cfg *params.ChainConfig, blockTime uint64) Formula {
+	switch {
+	case !cfg.IsOptimism():
+		return FormulaL1
+	case cfg.IsOptimismIsthmus(blockTime):
+		return FormulaIsthmus
+	case cfg.IsOptimismFjord(blockTime):
+		return FormulaFjord
+	case cfg.IsOptimismEcotone(blockTime):
+		return FormulaEcotone
+	default:
+		return FormulaLegacy
+	}
+}
+
+// HasL1Fee returns true if transactions are charged an L1 fee with the formula.
+func (f Formula) HasL1Fee() bool {
+	return f != FormulaL1
+}
+
+// HasOperatorFee returns true if transactions are charged an operator fee with the formula.
+func (f Formula) HasOperatorFee() bool {
+	return f == FormulaIsthmus
+}
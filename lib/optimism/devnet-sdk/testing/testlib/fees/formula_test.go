@@ -0,0 +1,42 @@
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormulaAt(t *testing.T) {
+	ecotone, fjord, isthmus := uint64(10), uint64(20), uint64(30)
+	opConfig := &params.ChainConfig{
+		ChainID:      big.NewInt(10),
+		BedrockBlock: big.NewInt(0),
+		EcotoneTime:  &ecotone,
+		FjordTime:    &fjord,
+		IsthmusTime:  &isthmus,
+		Optimism:     &params.OptimismConfig{},
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *params.ChainConfig
+		blockTime uint64
+		expected  Formula
+	}{
+		{"L1", &params.ChainConfig{ChainID: big.NewInt(1)}, 100, FormulaL1},
+		{"Legacy", opConfig, 5, FormulaLegacy},
+		{"Ecotone", opConfig, 10, FormulaEcotone},
+		{"Fjord", opConfig, 25, FormulaFjord},
+		{"Isthmus", opConfig, 30, FormulaIsthmus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formula := FormulaAt(tt.cfg, tt.blockTime)
+			assert.Equal(t, tt.expected, formula)
+			assert.Equal(t, tt.expected != FormulaL1, formula.HasL1Fee())
+			assert.Equal(t, tt.expected == FormulaIsthmus, formula.HasOperatorFee())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package sysconfig
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/txutils"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Client is the subset of an ethclient.Client that the SystemConfig is administered with.
+type Client interface {
+	txutils.Client
+	bind.ContractCaller
+}
+
+// Admin sends the setters of a SystemConfig as its owner. Every setter waits for its transaction to succeed,
+// and verifies that the SystemConfig reads the new values at the block of the receipt.
+type Admin struct {
+	chainID  *big.Int
+	client   Client
+	address  common.Address
+	owner    system.Wallet
+	abi      *abi.ABI
+	contract *bindings.SystemConfigCaller
+}
+
+// NewAdmin creates an Admin of the SystemConfig at the address on the L1 chain, which sends as the owner wallet.
+func NewAdmin(chainID *big.Int, client Client, address common.Address, owner system.Wallet) (*Admin, error) {
+	systemConfigABI, err := bindings.SystemConfigMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SystemConfig ABI: %w", err)
+	}
+	contract, err := bindings.NewSystemConfigCaller(address, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind SystemConfig: %w", err)
+	}
+	return &Admin{
+		chainID:  chainID,
+		client:   client,
+		address:  address,
+		owner:    owner,
+		abi:      systemConfigABI,
+		contract: contract,
+	}, nil
+}
+
+// Address returns the address of the SystemConfig.
+func (a *Admin) Address() common.Address {
+	return a.address
+}
+
+// Owner returns the wallet that the setters are sent as.
+func (a *Admin) Owner() system.Wallet {
+	return a.owner
+}
+
+// Caller returns the bindings that the SystemConfig is read with.
+func (a *Admin) Caller() *bindings.SystemConfigCaller {
+	return a.contract
+}
+
+// SetGasLimit sets the gas limit of L2 blocks.
+func (a *Admin) SetGasLimit(ctx context.Context, gasLimit uint64) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setGasLimit", func(opts *bind.CallOpts) error {
+		return verify(a.contract.GasLimit(opts))(gasLimit, "gas limit")
+	}, gasLimit)
+}
+
+// SetUnsafeBlockSigner sets the address that signs unsafe blocks.
+func (a *Admin) SetUnsafeBlockSigner(ctx context.Context, signer common.Address) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setUnsafeBlockSigner", func(opts *bind.CallOpts) error {
+		return verify(a.contract.UnsafeBlockSigner(opts))(signer, "unsafe block signer")
+	}, signer)
+}
+
+// SetBatcherHash sets the versioned hash of the batcher, which is the address of the batcher for version 0.
+func (a *Admin) SetBatcherHash(ctx context.Context, batcherHash common.Hash) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setBatcherHash", func(opts *bind.CallOpts) error {
+		return verify(a.contract.BatcherHash(opts))(batcherHash, "batcher hash")
+	}, batcherHash)
+}
+
+// SetEIP1559Params sets the EIP-1559 denominator and elasticity of L2 blocks, which apply from Holocene.
+func (a *Admin) SetEIP1559Params(ctx context.Context, denominator uint32, elasticity uint32) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setEIP1559Params", func(opts *bind.CallOpts) error {
+		if err := verify(a.contract.Eip1559Denominator(opts))(denominator, "EIP-1559 denominator"); err != nil {
+			return err
+		}
+		return verify(a.contract.Eip1559Elasticity(opts))(elasticity, "EIP-1559 elasticity")
+	}, denominator, elasticity)
+}
+
+// SetGasConfigEcotone sets the scalars of the L1 base fee and the L1 blob base fee of the L1 fee.
+func (a *Admin) SetGasConfigEcotone(ctx context.Context, basefeeScalar uint32, blobbasefeeScalar uint32) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setGasConfigEcotone", func(opts *bind.CallOpts) error {
+		if err := verify(a.contract.BasefeeScalar(opts))(basefeeScalar, "base fee scalar"); err != nil {
+			return err
+		}
+		return verify(a.contract.BlobbasefeeScalar(opts))(blobbasefeeScalar, "blob base fee scalar")
+	}, basefeeScalar, blobbasefeeScalar)
+}
+
+// SetOperatorFeeScalars sets the scalar and the constant of the operator fee, which apply from Isthmus.
+func (a *Admin) SetOperatorFeeScalars(ctx context.Context, operatorFeeScalar uint32, operatorFeeConstant uint64) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	return a.set(ctx, "setOperatorFeeScalars", func(opts *bind.CallOpts) error {
+		if err := verify(a.contract.OperatorFeeScalar(opts))(operatorFeeScalar, "operator fee scalar"); err != nil {
+			return err
+		}
+		return verify(a.contract.OperatorFeeConstant(opts))(operatorFeeConstant, "operator fee constant")
+	}, operatorFeeScalar, operatorFeeConstant)
+}
+
+// set sends the call of the setter with the arguments, and runs the check of the new values at the block of the receipt.
+func (a *Admin) set(ctx context.Context, method string, check func(opts *bind.CallOpts) error, args ...interface{}) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	data, err := a.abi.Pack(method, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+	receipt, tx, err := txutils.SendTx(ctx, a.chainID, a.client, a.owner, &a.address, big.NewInt(0), data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to %s: %w", method, err)
+	}
+	if err := check(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}); err != nil {
+		return receipt, tx, fmt.Errorf("failed to verify %s at block %v: %w", method, receipt.BlockNumber, err)
+	}
+	return receipt, tx, nil
+}
+
+// verify returns a check that the value read from the SystemConfig is the expected value.
+func verify[V comparable](actual V, err error) func(expected V, name string) error {
+	return func(expected V, name string) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if actual != expected {
+			return fmt.Errorf("%s is %v, expected %v", name, actual, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package sysconfig
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setterGetters are the getters of the values that the setters of the SystemConfig set, in the order of their arguments.
+var setterGetters = map[string][]string{
+	"setGasLimit":           {"gasLimit"},
+	"setUnsafeBlockSigner":  {"unsafeBlockSigner"},
+	"setBatcherHash":        {"batcherHash"},
+	"setEIP1559Params":      {"eip1559Denominator", "eip1559Elasticity"},
+	"setGasConfigEcotone":   {"basefeeScalar", "blobbasefeeScalar"},
+	"setOperatorFeeScalars": {"operatorFeeScalar", "operatorFeeConstant"},
+}
+
+// mockSystemConfig includes the transactions it is sent at once, and applies the setters they call,
+// unless it ignores them, so that the getters of the SystemConfig read the values that were set.
+type mockSystemConfig struct {
+	abi      *abi.ABI
+	ignore   bool
+	state    map[string]interface{}
+	receipts map[common.Hash]*gethTypes.Receipt
+}
+
+func newMockSystemConfig(t *testing.T) *mockSystemConfig {
+	systemConfigABI, err := bindings.SystemConfigMetaData.GetAbi()
+	require.NoError(t, err)
+	return &mockSystemConfig{
+		abi:      systemConfigABI,
+		state:    make(map[string]interface{}),
+		receipts: make(map[common.Hash]*gethTypes.Receipt),
+	}
+}
+
+func (m *mockSystemConfig) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	return &gethTypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(100)}, nil
+}
+
+func (m *mockSystemConfig) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(10), nil
+}
+
+func (m *mockSystemConfig) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 50_000, nil
+}
+
+func (m *mockSystemConfig) BlockNumber(ctx context.Context) (uint64, error) {
+	return 1, nil
+}
+
+func (m *mockSystemConfig) TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethTypes.Receipt, error) {
+	if receipt, ok := m.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (m *mockSystemConfig) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockSystemConfig) SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error {
+	method, err := m.abi.MethodById(tx.Data())
+	if err != nil {
+		return err
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return err
+	}
+	if !m.ignore {
+		for i, getter := range setterGetters[method.Name] {
+			m.state[getter] = args[i]
+		}
+	}
+	m.receipts[tx.Hash()] = &gethTypes.Receipt{TxHash: tx.Hash(), Status: gethTypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}
+	return nil
+}
+
+func (m *mockSystemConfig) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x00}, nil
+}
+
+func (m *mockSystemConfig) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := m.abi.MethodById(call.Data)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := m.state[method.Name]
+	if !ok {
+		// unset values are zero
+		return make([]byte, 32*len(method.Outputs)), nil
+	}
+	return method.Outputs.Pack(value)
+}
+
+func newTestWallet(t *testing.T) system.Wallet {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet, err := system.NewWallet(common.Bytes2Hex(crypto.FromECDSA(key)), types.Address(crypto.PubkeyToAddress(key.PublicKey)), nil)
+	require.NoError(t, err)
+	return wallet
+}
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x1234")
+	owner := newTestWallet(t)
+	mock := newMockSystemConfig(t)
+	admin, err := NewAdmin(big.NewInt(900), mock, address, owner)
+	require.NoError(t, err)
+
+	setters := []struct {
+		name string
+		set  func() (*gethTypes.Receipt, *gethTypes.Transaction, error)
+	}{
+		{"setGasLimit", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetGasLimit(ctx, 60_000_000)
+		}},
+		{"setUnsafeBlockSigner", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetUnsafeBlockSigner(ctx, common.HexToAddress("0x5678"))
+		}},
+		{"setBatcherHash", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetBatcherHash(ctx, common.HexToHash("0x9abc"))
+		}},
+		{"setEIP1559Params", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetEIP1559Params(ctx, 250, 6)
+		}},
+		{"setGasConfigEcotone", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetGasConfigEcotone(ctx, 1368, 810949)
+		}},
+		{"setOperatorFeeScalars", func() (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+			return admin.SetOperatorFeeScalars(ctx, 7, 1_000_000)
+		}},
+	}
+	for _, setter := range setters {
+		t.Run(setter.name, func(t *testing.T) {
+			mock.ignore = false
+			receipt, tx, err := setter.set()
+			require.NoError(t, err)
+			assert.Equal(t, tx.Hash(), receipt.TxHash)
+			assert.Equal(t, address, *tx.To(), "setters are sent to the SystemConfig")
+			method, err := mock.abi.MethodById(tx.Data())
+			require.NoError(t, err)
+			assert.Equal(t, setter.name, method.Name)
+
+			// a SystemConfig that ignores the setter still reads the zero values
+			mock.ignore = true
+			mock.state = make(map[string]interface{})
+			_, _, err = setter.set()
+			require.ErrorContains(t, err, fmt.Sprintf("failed to verify %s", setter.name), "setters that did not apply are reported")
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package txutils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlastStats are the statistics of the transactions sent by Blast.
+type BlastStats struct {
+	// Sent is the number of transactions that were accepted by the node.
+	Sent int
+	// Included is the number of transactions that were included, successful or not.
+	Included int
+	// Failed is the number of transactions that were rejected by the node, or not included within the ReceiptTimeout.
+	Failed int
+	// Latencies are the times from sending to inclusion of the included transactions, in ascending order.
+	Latencies []time.Duration
+	// Duration is the time from sending the first transaction until the last transaction was included or failed.
+	Duration time.Duration
+}
+
+// TPS returns the rate of included transactions per second.
+func (s *BlastStats) TPS() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Included) / s.Duration.Seconds()
+}
+
+// Latency returns the latency of the percentile (0-100) of included transactions, or zero if none were included.
+func (s *BlastStats) Latency(percentile float64) time.Duration {
+	if len(s.Latencies) == 0 {
+		return 0
+	}
+	i := int(percentile / 100 * float64(len(s.Latencies)-1))
+	i = max(0, min(i, len(s.Latencies)-1))
+	return s.Latencies[i]
+}
+
+func (s *BlastStats) String() string {
+	return fmt.Sprintf("sent=%d included=%d failed=%d tps=%.2f p50=%s p99=%s",
+		s.Sent, s.Included, s.Failed, s.TPS(), s.Latency(50), s.Latency(99))
+}
+
+// blaster is a wallet that streams transactions, which are pipelined without waiting for the inclusion of earlier ones.
+type blaster struct {
+	wallet system.Wallet
+	txData *gethTypes.DynamicFeeTx
+}
+
+// Blast streams value transfers from the wallets to themselves at the rate of transactions per second, for the duration,
+// taking turns between the wallets. It waits for the inclusion of all transactions and returns their statistics.
+// Errors are only returned if the blast could not start, failed transactions are counted in the statistics.
+// Each transaction is priced with the latest base fee, so rising base fees under load do not starve later transactions,
+// and takes its nonce from the nonces shared with all senders of the wallet, see system.LockNonce.
+func Blast(ctx context.Context, chainID *big.Int, client Client, wallets []system.Wallet, rate float64, duration time.Duration) (*BlastStats, error) {
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no wallets to blast from")
+	}
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", rate)
+	}
+
+	blasters := make([]*blaster, len(wallets))
+	for i, wallet := range wallets {
+		to := wallet.Address()
+		txData, err := NewDynamicFeeTx(ctx, chainID, client, wallet.Address(), &to, big.NewInt(0), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price transactions of wallet %s: %w", wallet.Address(), err)
+		}
+		blasters[i] = &blaster{wallet: wallet, txData: txData}
+	}
+
+	var (
+		mu    sync.Mutex
+		stats BlastStats
+		wg    sync.WaitGroup
+	)
+	record := func(latency *time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if latency == nil {
+			stats.Failed++
+			return
+		}
+		stats.Included++
+		stats.Latencies = append(stats.Latencies, *latency)
+	}
+
+	signer := gethTypes.LatestSignerForChainID(chainID)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+	deadline := time.NewTimer(duration)
+	defer deadline.Stop()
+
+	start := time.Now()
+loop:
+	for i := 0; ; i++ {
+		b := blasters[i%len(blasters)]
+		tx, sent, err := b.send(ctx, chainID, client, signer)
+		if err != nil {
+			record(nil)
+		} else {
+			mu.Lock()
+			stats.Sent++
+			mu.Unlock()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				receiptCtx, cancel := context.WithTimeout(ctx, ReceiptTimeout)
+				defer cancel()
+				if _, err := WaitForTransaction(receiptCtx, client, tx.Hash()); err != nil {
+					record(nil)
+					return
+				}
+				latency := time.Since(sent)
+				record(&latency)
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-deadline.C:
+			break loop
+		case <-ticker.C:
+		}
+	}
+	wg.Wait()
+
+	stats.Duration = time.Since(start)
+	sort.Slice(stats.Latencies, func(i, j int) bool { return stats.Latencies[i] < stats.Latencies[j] })
+	return &stats, nil
+}
+
+// send prices, signs and sends the next transaction of the blaster, and returns it with the time it was sent.
+// If the node rejects the transaction, its nonce is not used, so later transactions do not leave a gap.
+func (b *blaster) send(ctx context.Context, chainID *big.Int, client Client, signer gethTypes.Signer) (tx *gethTypes.Transaction, sent time.Time, err error) {
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get header: %w", err)
+	}
+	nonce, release, err := system.LockNonce(ctx, chainID, client, b.wallet.Address())
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer func() { release(err == nil) }()
+
+	txData := *b.txData
+	txData.Nonce = nonce
+	txData.GasFeeCap = dynamicFeeCap(header.BaseFee, txData.GasTipCap)
+	tx, err = gethTypes.SignNewTx(b.wallet.PrivateKey(), signer, &txData)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	sent = time.Now()
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return tx, sent, nil
+}
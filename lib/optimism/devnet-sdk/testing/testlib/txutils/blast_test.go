@@ -0,0 +1,104 @@
+package txutils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlast(t *testing.T) {
+	chainID := big.NewInt(901)
+
+	t.Run("streams transactions from all wallets", func(t *testing.T) {
+		client := &mockClient{}
+		wallets := []system.Wallet{newTestWallet(t), newTestWallet(t)}
+		stats, err := Blast(context.Background(), chainID, client, wallets, 100, 100*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.GreaterOrEqual(t, stats.Sent, 4)
+		assert.Equal(t, stats.Sent, stats.Included)
+		assert.Zero(t, stats.Failed)
+		assert.Len(t, stats.Latencies, stats.Included)
+		assert.Positive(t, stats.TPS())
+		assert.LessOrEqual(t, stats.Latency(50), stats.Latency(99))
+
+		// the transactions of each wallet have consecutive nonces
+		nonces := make(map[string]uint64)
+		for _, tx := range client.sent {
+			from := tx.To().Hex()
+			if next, ok := nonces[from]; ok {
+				assert.Equal(t, next, tx.Nonce())
+			}
+			nonces[from] = tx.Nonce() + 1
+		}
+		assert.Len(t, nonces, 2)
+	})
+
+	t.Run("counts rejected transactions as failed", func(t *testing.T) {
+		client := &mockClient{rejections: []error{errors.New("txpool is full")}}
+		stats, err := Blast(context.Background(), chainID, client, []system.Wallet{newTestWallet(t)}, 100, 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Failed)
+		assert.Equal(t, stats.Sent, stats.Included)
+		// the nonce of the rejected transaction is not used, so it is reused
+		require.GreaterOrEqual(t, len(client.sent), 2)
+		assert.Equal(t, client.sent[0].Nonce(), client.sent[1].Nonce())
+	})
+
+	t.Run("prices each transaction with the latest base fee", func(t *testing.T) {
+		client := &risingBaseFeeClient{mockClient: &mockClient{}}
+		stats, err := Blast(context.Background(), chainID, client, []system.Wallet{newTestWallet(t)}, 100, 50*time.Millisecond)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, stats.Sent, 2)
+		for i := 1; i < len(client.sent); i++ {
+			assert.Greater(t, client.sent[i].GasFeeCap().Int64(), client.sent[i-1].GasFeeCap().Int64(), "tx %d", i)
+		}
+	})
+
+	t.Run("shares nonces with other senders of the wallets", func(t *testing.T) {
+		client := &mockClient{}
+		wallet := newTestWallet(t)
+		_, first, err := SendTx(context.Background(), chainID, client, wallet, nil, big.NewInt(1), nil)
+		require.NoError(t, err)
+
+		_, err = Blast(context.Background(), chainID, client, []system.Wallet{wallet}, 100, 50*time.Millisecond)
+		require.NoError(t, err)
+		// the node does not report the pending transactions, but their nonces were used
+		require.Greater(t, len(client.sent), 1)
+		assert.Equal(t, first.Nonce()+1, client.sent[1].Nonce())
+
+		nonce, release, err := system.LockNonce(context.Background(), chainID, client, wallet.Address())
+		require.NoError(t, err)
+		release(false)
+		assert.Equal(t, client.sent[len(client.sent)-1].Nonce()+1, nonce)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := Blast(context.Background(), chainID, &mockClient{}, nil, 100, time.Second)
+		require.Error(t, err)
+		_, err = Blast(context.Background(), chainID, &mockClient{}, []system.Wallet{newTestWallet(t)}, 0, time.Second)
+		require.Error(t, err)
+	})
+}
+
+// risingBaseFeeClient raises the base fee with every header it serves, like a chain under sustained load.
+type risingBaseFeeClient struct {
+	*mockClient
+	mu      sync.Mutex
+	baseFee int64
+}
+
+func (c *risingBaseFeeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseFee += 100
+	return &gethTypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(c.baseFee)}, nil
+}
@@ -0,0 +1,77 @@
+package txutils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fallbackGasLimit is the gas limit returned along with gas estimation errors, for callers that want to continue.
+const fallbackGasLimit = 300000
+
+// gasClient is the subset of a client used to price transactions.
+type gasClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// rpcBackend is implemented by clients that expose their RPC client, like the ethclient.Client.
+type rpcBackend interface {
+	Client() *rpc.Client
+}
+
+// CalculateGasParams calculates appropriate gas parameters for a transaction
+func CalculateGasParams(ctx context.Context, client gasClient, msg ethereum.CallMsg) (estimatedGas uint64, gasTipCap *big.Int, gasFeeCap *big.Int, err error) {
+	// Get current block header for base fee
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to get header: %w", err)
+	}
+
+	// Get suggested gas tip
+	gasTipCap, err = client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to get suggested gas tip: %w", err)
+	}
+
+	gasFeeCap = dynamicFeeCap(header.BaseFee, gasTipCap)
+
+	// Estimate gas limit
+	estimatedGas, err = client.EstimateGas(ctx, msg)
+	if err != nil {
+		// Return error but also provide a fallback estimated gas in case caller wants to continue
+		return fallbackGasLimit, gasTipCap, gasFeeCap, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	return estimatedGas, gasTipCap, gasFeeCap, nil
+}
+
+// dynamicFeeCap returns the gas fee cap of 2 * baseFee + tip, which leaves room for the base fee to double.
+func dynamicFeeCap(baseFee *big.Int, gasTipCap *big.Int) *big.Int {
+	return new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), gasTipCap)
+}
+
+// blobFeeCap returns a blob fee cap that leaves room for the blob base fee to double, and is at least 1 gwei.
+// The blob base fee is only known to clients that expose their RPC client.
+func blobFeeCap(ctx context.Context, client any) (*big.Int, error) {
+	feeCap := big.NewInt(params.GWei)
+	backend, ok := client.(rpcBackend)
+	if !ok {
+		return feeCap, nil
+	}
+	var blobBaseFee hexutil.Big
+	if err := backend.Client().CallContext(ctx, &blobBaseFee, "eth_blobBaseFee"); err != nil {
+		return nil, fmt.Errorf("failed to get blob base fee: %w", err)
+	}
+	if doubled := new(big.Int).Mul(blobBaseFee.ToInt(), big.NewInt(2)); doubled.Cmp(feeCap) > 0 {
+		feeCap = doubled
+	}
+	return feeCap, nil
+}
@@ -0,0 +1,314 @@
+package txutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// MaxFeeBumps is how many times the fees of a transaction are bumped and the transaction resubmitted,
+// when it is not included in time.
+const MaxFeeBumps = 5
+
+// Fee bumps of resubmitted transactions. Nodes only accept replacement transactions with at least 10% higher fees,
+// and blob transactions with at least 100% higher fees.
+const (
+	feeBumpPercent     = 20
+	blobFeeBumpPercent = 100
+)
+
+// resubmitInterval is how long to wait for the receipt of a transaction, before its fees are bumped and it is resubmitted.
+var resubmitInterval = 12 * time.Second
+
+// Client is the subset of an ethclient.Client that transactions are sent with.
+type Client interface {
+	gasClient
+	receiptClient
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error
+}
+
+// Send signs the transaction with the next nonce of the sender, sends it, and waits up to ReceiptTimeout for it to succeed.
+// The nonces are shared with all wallets of the sender, so concurrent transactions of the sender never use the same nonce.
+// If the transaction is not included in time, its fees are bumped and it is resubmitted, up to MaxFeeBumps times.
+// Only the transaction that Send sent itself is replaced: if it is rejected, e.g. as underpriced because of
+// another transaction of the sender with the same nonce, an error is returned.
+func Send(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, txData gethTypes.TxData) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	signer := gethTypes.LatestSignerForChainID(chainID)
+	signedTx, err := sendWithNonce(ctx, chainID, client, from, signer, txData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ReceiptTimeout)
+	defer cancel()
+	// every submission of the transaction may be included, so the receipts of all of them are checked
+	submitted := []*gethTypes.Transaction{signedTx}
+	for bumps := 0; ; bumps++ {
+		wait := resubmitInterval
+		if bumps >= MaxFeeBumps {
+			wait = ReceiptTimeout
+		}
+		receipt, tx, err := awaitReceipt(ctx, client, submitted, wait)
+		if err != nil {
+			return nil, nil, err
+		}
+		if receipt != nil {
+			if receipt.Status != gethTypes.ReceiptStatusSuccessful {
+				return receipt, tx, fmt.Errorf("expected successful transaction (1), instead got status: %d", receipt.Status)
+			}
+			return receipt, tx, nil
+		}
+
+		if err := bumpFees(txData); err != nil {
+			return nil, nil, err
+		}
+		signedTx, err = gethTypes.SignNewTx(from.PrivateKey(), signer, txData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		// if the replacement is rejected, e.g. because a previous submission was included meanwhile, the previous submissions are awaited
+		if err := client.SendTransaction(ctx, signedTx); err == nil {
+			submitted = append(submitted, signedTx)
+		}
+	}
+}
+
+// sendWithNonce sets the next nonce of the sender on the transaction, and signs and sends it.
+func sendWithNonce(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, signer gethTypes.Signer, txData gethTypes.TxData) (*gethTypes.Transaction, error) {
+	nonce, release, err := system.LockNonce(ctx, chainID, client, from.Address())
+	if err != nil {
+		return nil, err
+	}
+	sent := false
+	defer func() {
+		release(sent)
+	}()
+
+	if err := setNonce(txData, nonce); err != nil {
+		return nil, err
+	}
+	signedTx, err := gethTypes.SignNewTx(from.PrivateKey(), signer, txData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	sent = true
+	return signedTx, nil
+}
+
+// awaitReceipt waits up to the wait time for the receipt of any of the transactions.
+// It returns a nil receipt if none of the transactions is included by then.
+func awaitReceipt(ctx context.Context, client receiptClient, txs []*gethTypes.Transaction, wait time.Duration) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		for _, tx := range txs {
+			receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+			if receipt != nil && err == nil {
+				return receipt, tx, nil
+			} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return nil, nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("failed to wait for transaction %s: %w", txs[len(txs)-1].Hash(), ctx.Err())
+		case <-timer.C:
+			return nil, nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendTx sends a dynamic fee transaction with the value and data, and waits for it to succeed.
+// The transaction creates a contract if to is nil.
+func SendTx(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, to *common.Address, value *big.Int, data []byte) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	txData, err := NewDynamicFeeTx(ctx, chainID, client, from.Address(), to, value, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Send(ctx, chainID, client, from, txData)
+}
+
+// SendLegacyTx sends a legacy transaction with the value and data, and waits for it to succeed.
+// The transaction creates a contract if to is nil.
+func SendLegacyTx(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, to *common.Address, value *big.Int, data []byte) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	txData, err := NewLegacyTx(ctx, client, from.Address(), to, value, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Send(ctx, chainID, client, from, txData)
+}
+
+// SendBlobTx sends a transaction to the sender itself that carries the data in blobs, and waits for it to succeed.
+func SendBlobTx(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, data eth.Data) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	txData, err := NewBlobTx(ctx, chainID, client, from.Address(), from.Address(), data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Send(ctx, chainID, client, from, txData)
+}
+
+// SendValueTx sends the value to the account in a dynamic fee transaction, and waits for it to succeed.
+// If send is false, the signed transaction is returned without being sent.
+func SendValueTx(ctx context.Context, chainID *big.Int, client Client, from system.Wallet, to common.Address, value *big.Int, send bool) (receipt *gethTypes.Receipt, tx *gethTypes.Transaction, err error) {
+	if value.Sign() == 0 || value.Sign() == -1 {
+		return nil, nil, fmt.Errorf("value is 0 or negative")
+	}
+	txData, err := NewDynamicFeeTx(ctx, chainID, client, from.Address(), &to, value, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if send {
+		return Send(ctx, chainID, client, from, txData)
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, from.Address())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	txData.Nonce = nonce
+	tx, err = gethTypes.SignNewTx(from.PrivateKey(), gethTypes.LatestSignerForChainID(chainID), txData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return nil, tx, nil
+}
+
+// NewDynamicFeeTx returns a dynamic fee transaction with estimated gas and fees. Its nonce is set when it is sent.
+func NewDynamicFeeTx(ctx context.Context, chainID *big.Int, client gasClient, from common.Address, to *common.Address, value *big.Int, data []byte) (*gethTypes.DynamicFeeTx, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+	gasLimit, gasTipCap, gasFeeCap, err := CalculateGasParams(ctx, client, ethereum.CallMsg{
+		From:  from,
+		To:    to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate gas parameters: %w", err)
+	}
+	return &gethTypes.DynamicFeeTx{
+		ChainID:   chainID,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gasLimit,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	}, nil
+}
+
+// NewLegacyTx returns a legacy transaction with estimated gas, and a gas price that covers
+// the fee cap of an equivalent dynamic fee transaction. Its nonce is set when it is sent.
+func NewLegacyTx(ctx context.Context, client gasClient, from common.Address, to *common.Address, value *big.Int, data []byte) (*gethTypes.LegacyTx, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+	gasLimit, _, gasFeeCap, err := CalculateGasParams(ctx, client, ethereum.CallMsg{
+		From:  from,
+		To:    to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate gas parameters: %w", err)
+	}
+	return &gethTypes.LegacyTx{
+		GasPrice: gasFeeCap,
+		Gas:      gasLimit,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	}, nil
+}
+
+// NewBlobTx returns a blob transaction that carries the data in blobs, with its sidecar. Its nonce is set when it is sent.
+func NewBlobTx(ctx context.Context, chainID *big.Int, client gasClient, from common.Address, to common.Address, data eth.Data) (*gethTypes.BlobTx, error) {
+	var blobs []*eth.Blob
+	for len(data) > 0 {
+		n := min(len(data), eth.MaxBlobDataSize)
+		var blob eth.Blob
+		if err := blob.FromData(data[:n]); err != nil {
+			return nil, fmt.Errorf("failed to encode blob %d: %w", len(blobs), err)
+		}
+		blobs = append(blobs, &blob)
+		data = data[n:]
+	}
+	if len(blobs) == 0 {
+		return nil, fmt.Errorf("blob transactions must carry data")
+	}
+	sidecar, blobHashes, err := txmgr.MakeSidecar(blobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make blob sidecar: %w", err)
+	}
+
+	_, gasTipCap, gasFeeCap, err := CalculateGasParams(ctx, client, ethereum.CallMsg{From: from, To: &to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate gas parameters: %w", err)
+	}
+	feeCap, err := blobFeeCap(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return &gethTypes.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		GasTipCap:  uint256.MustFromBig(gasTipCap),
+		GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+		Gas:        params.TxGas,
+		To:         to,
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.MustFromBig(feeCap),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	}, nil
+}
+
+func setNonce(txData gethTypes.TxData, nonce uint64) error {
+	switch txData := txData.(type) {
+	case *gethTypes.LegacyTx:
+		txData.Nonce = nonce
+	case *gethTypes.DynamicFeeTx:
+		txData.Nonce = nonce
+	case *gethTypes.BlobTx:
+		txData.Nonce = nonce
+	default:
+		return fmt.Errorf("unsupported transaction type %T", txData)
+	}
+	return nil
+}
+
+// bumpFees bumps the fees of the transaction, so it replaces its previous submission in the pool.
+func bumpFees(txData gethTypes.TxData) error {
+	switch txData := txData.(type) {
+	case *gethTypes.LegacyTx:
+		txData.GasPrice = system.BumpFee(txData.GasPrice, feeBumpPercent)
+	case *gethTypes.DynamicFeeTx:
+		txData.GasTipCap = system.BumpFee(txData.GasTipCap, feeBumpPercent)
+		txData.GasFeeCap = system.BumpFee(txData.GasFeeCap, feeBumpPercent)
+	case *gethTypes.BlobTx:
+		txData.GasTipCap = uint256.MustFromBig(system.BumpFee(txData.GasTipCap.ToBig(), blobFeeBumpPercent))
+		txData.GasFeeCap = uint256.MustFromBig(system.BumpFee(txData.GasFeeCap.ToBig(), blobFeeBumpPercent))
+		txData.BlobFeeCap = uint256.MustFromBig(system.BumpFee(txData.BlobFeeCap.ToBig(), blobFeeBumpPercent))
+	default:
+		return fmt.Errorf("unsupported transaction type %T", txData)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package txutils
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClient rejects the first transactions it is sent with the rejection errors, and includes the others at once,
+// if their tip is at least the minimum tip.
+type mockClient struct {
+	mu         sync.Mutex
+	rejections []error
+	minTip     *big.Int
+	sent       []*gethTypes.Transaction
+	receipts   map[common.Hash]*gethTypes.Receipt
+}
+
+func (m *mockClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	return &gethTypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(100)}, nil
+}
+
+func (m *mockClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(10), nil
+}
+
+func (m *mockClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (m *mockClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return 1, nil
+}
+
+func (m *mockClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethTypes.Receipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if receipt, ok := m.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (m *mockClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 7, nil
+}
+
+func (m *mockClient) SendTransaction(ctx context.Context, tx *gethTypes.Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, tx)
+	if len(m.rejections) > 0 {
+		err := m.rejections[0]
+		m.rejections = m.rejections[1:]
+		return err
+	}
+	if m.minTip != nil && tx.GasTipCap().Cmp(m.minTip) < 0 {
+		return nil
+	}
+	if m.receipts == nil {
+		m.receipts = make(map[common.Hash]*gethTypes.Receipt)
+	}
+	m.receipts[tx.Hash()] = &gethTypes.Receipt{TxHash: tx.Hash(), Status: gethTypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}
+	return nil
+}
+
+func newTestWallet(t *testing.T) system.Wallet {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet, err := system.NewWallet(common.Bytes2Hex(crypto.FromECDSA(key)), types.Address(crypto.PubkeyToAddress(key.PublicKey)), nil)
+	require.NoError(t, err)
+	return wallet
+}
+
+func TestSend(t *testing.T) {
+	chainID := big.NewInt(901)
+	to := common.HexToAddress("0x1234")
+
+	t.Run("dynamic fee", func(t *testing.T) {
+		client := &mockClient{}
+		receipt, tx, err := SendTx(context.Background(), chainID, client, newTestWallet(t), &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), receipt.TxHash)
+		assert.Equal(t, uint8(gethTypes.DynamicFeeTxType), tx.Type())
+		assert.Equal(t, uint64(7), tx.Nonce())
+		assert.Equal(t, big.NewInt(210), tx.GasFeeCap())
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		client := &mockClient{}
+		_, tx, err := SendLegacyTx(context.Background(), chainID, client, newTestWallet(t), &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(gethTypes.LegacyTxType), tx.Type())
+		assert.Equal(t, big.NewInt(210), tx.GasPrice())
+	})
+
+	t.Run("bumps fees of its own transactions until included", func(t *testing.T) {
+		defer func(interval time.Duration) { resubmitInterval = interval }(resubmitInterval)
+		resubmitInterval = 10 * time.Millisecond
+
+		client := &mockClient{minTip: big.NewInt(14)}
+		_, tx, err := SendTx(context.Background(), chainID, client, newTestWallet(t), &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		require.Len(t, client.sent, 3, "tip of 10 must be bumped twice to reach 14")
+		assert.Equal(t, client.sent[2].Hash(), tx.Hash())
+		assert.Equal(t, uint64(7), tx.Nonce(), "resubmissions replace the transaction with the same nonce")
+		assert.Equal(t, big.NewInt(14), tx.GasTipCap())
+		assert.Equal(t, big.NewInt(302), tx.GasFeeCap())
+	})
+
+	t.Run("gives up after max fee bumps", func(t *testing.T) {
+		defer func(interval time.Duration) { resubmitInterval = interval }(resubmitInterval)
+		resubmitInterval = 10 * time.Millisecond
+
+		client := &mockClient{minTip: big.NewInt(1e18)}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, _, err := SendTx(ctx, chainID, client, newTestWallet(t), &to, big.NewInt(1), nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Len(t, client.sent, MaxFeeBumps+1)
+	})
+
+	t.Run("does not replace transactions it did not send", func(t *testing.T) {
+		client := &mockClient{rejections: []error{errors.New("replacement transaction underpriced")}}
+		wallet := newTestWallet(t)
+		_, _, err := SendTx(context.Background(), chainID, client, wallet, &to, big.NewInt(1), nil)
+		require.ErrorContains(t, err, "underpriced")
+		assert.Len(t, client.sent, 1)
+
+		// the nonce was not used, so the next transaction takes it
+		_, tx, err := SendTx(context.Background(), chainID, client, wallet, &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), tx.Nonce())
+	})
+
+	t.Run("shares nonces with other senders of the account", func(t *testing.T) {
+		client := &mockClient{}
+		wallet := newTestWallet(t)
+		_, first, err := SendTx(context.Background(), chainID, client, wallet, &to, big.NewInt(1), nil)
+		require.NoError(t, err)
+		nonce, release, err := system.LockNonce(context.Background(), chainID, client, wallet.Address())
+		require.NoError(t, err)
+		release(false)
+		// the node does not report the pending transaction, but the nonce was used
+		assert.Equal(t, first.Nonce()+1, nonce)
+	})
+
+	t.Run("does not resubmit on other errors", func(t *testing.T) {
+		client := &mockClient{rejections: []error{errors.New("nonce too low")}}
+		_, _, err := SendTx(context.Background(), chainID, client, newTestWallet(t), &to, big.NewInt(1), nil)
+		require.ErrorContains(t, err, "nonce too low")
+		assert.Len(t, client.sent, 1)
+	})
+
+	t.Run("unsent value transaction", func(t *testing.T) {
+		client := &mockClient{}
+		receipt, tx, err := SendValueTx(context.Background(), chainID, client, newTestWallet(t), to, big.NewInt(1), false)
+		require.NoError(t, err)
+		assert.Nil(t, receipt)
+		assert.NotNil(t, tx)
+		assert.Empty(t, client.sent)
+	})
+}
@@ -0,0 +1,79 @@
+package txutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReceiptTimeout is how long Confirm waits for the receipt of a transaction.
+const ReceiptTimeout = 1 * time.Minute
+
+// receiptPollInterval is how often the receipt of a transaction is polled for.
+const receiptPollInterval = 500 * time.Millisecond
+
+// receiptClient is the subset of a client used to wait for receipts.
+type receiptClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*gethTypes.Receipt, error)
+}
+
+// Confirm waits up to ReceiptTimeout for the receipt of the transaction, and returns an error if it failed.
+func Confirm(ctx context.Context, client receiptClient, hash common.Hash) (*gethTypes.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, ReceiptTimeout)
+	defer cancel()
+	receipt, err := WaitForTransaction(ctx, client, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction: %w", err)
+	}
+	if receipt.Status != gethTypes.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("expected successful transaction (1), instead got status: %d", receipt.Status)
+	}
+	return receipt, nil
+}
+
+// WaitForTransaction polls for a transaction receipt until it is available or the context is canceled.
+// It's a simpler version of the functionality in SimpleTxManager.
+func WaitForTransaction(ctx context.Context, client receiptClient, hash common.Hash) (*gethTypes.Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	// Record starting block number
+	startBlockNum, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get starting block number: %w", err)
+	}
+
+	for {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if receipt != nil && err == nil {
+			return receipt, nil
+		} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			// Get current block number to calculate progress
+			// Create a new context for this query since the original is canceled
+			queryCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			currentBlockNum, blockErr := client.BlockNumber(queryCtx)
+			cancel() // Always cancel the context to avoid leaks
+
+			if blockErr != nil {
+				// If we can't get the current block number, just return the original error
+				return nil, fmt.Errorf("context error: %w (could not determine block progress: %w)", ctx.Err(), blockErr)
+			}
+
+			blockProgress := int64(currentBlockNum) - int64(startBlockNum)
+			return nil, fmt.Errorf("transaction %s not found after %d blocks: %w", hash.Hex(), blockProgress, ctx.Err())
+		case <-ticker.C:
+			// Continue polling
+		}
+	}
+}
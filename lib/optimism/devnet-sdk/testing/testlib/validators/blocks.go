@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+)
+
+// blockRateSamples is the number of blocks that a chain producing at the minimum rate is expected
+// to produce while its block production rate is measured.
+const blockRateSamples = 3
+
+// ChainProducingBlocks returns a validator that ensures a specific L2 chain produces blocks at least at
+// the specified rate, in blocks per second. The rate is measured on the first node of the chain,
+// over the time in which the chain should produce a few blocks at the minimum rate.
+func ChainProducingBlocks(chainIdx uint64, minRate float64) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		if len(sys.L2s()) <= int(chainIdx) {
+			return nil, fmt.Errorf("chain index %d out of range, only %d L2 chains available", chainIdx, len(sys.L2s()))
+		}
+		if minRate <= 0 {
+			return nil, fmt.Errorf("invalid minimum block rate %v", minRate)
+		}
+
+		chain := sys.L2s()[chainIdx]
+		if len(chain.Nodes()) == 0 {
+			return nil, fmt.Errorf("no nodes for L2 chain %d", chainIdx)
+		}
+		node := chain.Nodes()[0]
+
+		ctx := t.Context()
+		start, err := node.BlockByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get head of L2 chain %d: %w", chainIdx, err)
+		}
+		startTime := time.Now()
+
+		window := time.Duration(blockRateSamples / minRate * float64(time.Second))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(window):
+		}
+
+		end, err := node.BlockByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get head of L2 chain %d: %w", chainIdx, err)
+		}
+		elapsed := time.Since(startTime)
+
+		var produced uint64
+		if end.NumberU64() > start.NumberU64() {
+			produced = end.NumberU64() - start.NumberU64()
+		}
+		rate := float64(produced) / elapsed.Seconds()
+		if rate < minRate {
+			return nil, fmt.Errorf("L2 chain %d is producing blocks too slowly: %.2f blocks/s over %v, requires %.2f blocks/s", chainIdx, rate, elapsed.Round(time.Millisecond), minRate)
+		}
+
+		return ctx, nil
+	}
+}
@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+)
+
+// UnmetPreconditions is the error of a validator returned by Any, when none of its validators pass.
+type UnmetPreconditions struct {
+	Failures []error
+}
+
+func (e *UnmetPreconditions) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, err := range e.Failures {
+		reasons[i] = err.Error()
+	}
+	return fmt.Sprintf("none of the preconditions are met: [%s]", strings.Join(reasons, "; "))
+}
+
+func (e *UnmetPreconditions) Unwrap() []error {
+	return e.Failures
+}
+
+// All returns a validator that ensures all validators pass. The validators are applied in order,
+// each with the context of the previous one, so the getters of all validators work with the resulting context.
+// It fails with the error of the first validator that fails.
+func All(validators ...systest.PreconditionValidator) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		for _, validator := range validators {
+			ctx, err := validator(t, sys)
+			if err != nil {
+				return nil, err
+			}
+			t = t.WithContext(ctx)
+		}
+		return t.Context(), nil
+	}
+}
+
+// Any returns a validator that ensures at least one of the validators passes. The validators are applied in order,
+// until one passes, and the resulting context is the context of that validator.
+// Otherwise it fails with an UnmetPreconditions error, with the errors of all validators.
+func Any(validators ...systest.PreconditionValidator) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		var failures []error
+		for _, validator := range validators {
+			ctx, err := validator(t, sys)
+			if err == nil {
+				return ctx, nil
+			}
+			failures = append(failures, err)
+		}
+		return nil, &UnmetPreconditions{Failures: failures}
+	}
+}
+
+// Not returns a validator that ensures the validator does not pass, and fails with the reason otherwise.
+// The context of the validator is discarded, so its getters do not work with the resulting context.
+func Not(validator systest.PreconditionValidator, reason string) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		if _, err := validator(t, sys); err != nil {
+			return t.Context(), nil
+		}
+		return nil, errors.New(reason)
+	}
+}
+
+// WithReason returns a validator that explains why the validator is required, in its errors,
+// e.g. WithReason("operator fees require Isthmus", forkValidator).
+func WithReason(reason string, validator systest.PreconditionValidator) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		ctx, err := validator(t, sys)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reason, err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,86 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+)
+
+// HasContract returns a validator that ensures a contract of a specific L2 chain is deployed, with at least the specified version.
+// The contract is looked up by name in the address book of the L2 chain, then in its address book on L1,
+// e.g. system.SystemConfigAddressName, and its version is read from its ISemver interface.
+// An empty minVersion only requires the contract to be deployed.
+func HasContract(chainIdx uint64, name string, minVersion string) systest.PreconditionValidator {
+	return func(t systest.T, sys system.System) (context.Context, error) {
+		if len(sys.L2s()) <= int(chainIdx) {
+			return nil, fmt.Errorf("chain index %d out of range, only %d L2 chains available", chainIdx, len(sys.L2s()))
+		}
+		var minVer *semver.Version
+		if minVersion != "" {
+			v, err := semver.NewVersion(minVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid minimum version %q of contract %s: %w", minVersion, name, err)
+			}
+			minVer = v
+		}
+
+		chain := sys.L2s()[chainIdx]
+		var node system.Node
+		addr, ok := chain.Addresses()[name]
+		if ok {
+			node = chain.Nodes()[0]
+		} else if addr, ok = chain.L1Addresses()[name]; ok {
+			node = sys.L1().Nodes()[0]
+		} else {
+			return nil, fmt.Errorf("contract %s of L2 chain %d not found in address book", name, chainIdx)
+		}
+
+		client, err := node.GethClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client: %w", err)
+		}
+		code, err := client.CodeAt(t.Context(), addr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code of contract %s at %s: %w", name, addr, err)
+		}
+		if len(code) == 0 {
+			return nil, fmt.Errorf("contract %s of L2 chain %d is not deployed at %s", name, chainIdx, addr)
+		}
+		if minVer == nil {
+			return t.Context(), nil
+		}
+
+		caller, err := bindings.NewISemverCaller(addr, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind contract %s: %w", name, err)
+		}
+		version, err := caller.Version(&bind.CallOpts{Context: t.Context()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version of contract %s: %w", name, err)
+		}
+		if err := checkContractVersion(name, version, minVer); err != nil {
+			return nil, err
+		}
+
+		return t.Context(), nil
+	}
+}
+
+// checkContractVersion ensures that the version of the contract is at least the minimum version
+func checkContractVersion(name string, version string, minVersion *semver.Version) error {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("invalid version %q of contract %s: %w", version, name, err)
+	}
+	if v.LessThan(minVersion) {
+		return fmt.Errorf("contract %s has version %s, requires at least %s", name, v, minVersion)
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package validators
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/bindings"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/interfaces"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
@@ -14,6 +17,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -229,6 +235,152 @@ func TestValidators(t *testing.T) {
 		require.Error(t, err, "Validator should fail when chain index is out of range")
 		require.Contains(t, err.Error(), "chain index 0 out of range", "Error message should indicate chain index out of range")
 	})
+
+	t.Run("test ChainProducingBlocks", func(t *testing.T) {
+		newSystem := func(rate float64) *mockSystem {
+			return &mockSystem{
+				l2s: []system.L2Chain{
+					&mockL2Chain{
+						mockChain: mockChain{
+							nodes: []system.Node{
+								&producingNode{start: time.Now(), rate: rate},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		// The chain produces blocks faster than required
+		_, err := ChainProducingBlocks(0, 50)(systest.NewT(t), newSystem(200))
+		require.NoError(t, err, "Validator should pass when the chain produces blocks fast enough")
+
+		// The chain produces blocks slower than required
+		_, err = ChainProducingBlocks(0, 50)(systest.NewT(t), newSystem(10))
+		require.Error(t, err, "Validator should fail when the chain produces blocks too slowly")
+		require.Contains(t, err.Error(), "producing blocks too slowly")
+
+		// The chain does not exist
+		_, err = ChainProducingBlocks(1, 50)(systest.NewT(t), newSystem(200))
+		require.Error(t, err, "Validator should fail when chain index is out of range")
+		require.Contains(t, err.Error(), "chain index 1 out of range")
+	})
+
+	t.Run("test HasContract", func(t *testing.T) {
+		systestSystem := &mockSystem{
+			l2s: []system.L2Chain{
+				&mockL2Chain{
+					mockChain: mockChain{
+						nodes: []system.Node{
+							&mockNode{},
+						},
+					},
+				},
+			},
+		}
+
+		_, err := HasContract(0, system.SystemConfigAddressName, "2.0.0")(systest.NewT(t), systestSystem)
+		require.Error(t, err, "Validator should fail when the contract is not in the address book")
+		require.Contains(t, err.Error(), "not found in address book")
+
+		_, err = HasContract(0, system.SystemConfigAddressName, "two")(systest.NewT(t), systestSystem)
+		require.Error(t, err, "Validator should fail when the minimum version is invalid")
+		require.Contains(t, err.Error(), "invalid minimum version")
+
+		minVersion := semver.MustParse("2.3.0")
+		require.NoError(t, checkContractVersion("SystemConfig", "2.3.0", minVersion))
+		require.NoError(t, checkContractVersion("SystemConfig", "3.0.0-beta.1", minVersion))
+		require.ErrorContains(t, checkContractVersion("SystemConfig", "2.2.9", minVersion), "requires at least 2.3.0")
+		require.ErrorContains(t, checkContractVersion("SystemConfig", "latest", minVersion), "invalid version")
+	})
+
+	t.Run("test AcquireL2Wallets", func(t *testing.T) {
+		faucet := &faucetWallet{
+			mockWallet: mockWallet{
+				address: types.Address(common.HexToAddress("0xfa")),
+				balance: types.NewBalance(big.NewInt(100)),
+			},
+			funded: make(map[types.Address]types.Balance),
+		}
+		systestSystem := &mockSystem{
+			l2s: []system.L2Chain{
+				&mockL2Chain{
+					mockChain: mockChain{
+						wallets: system.WalletMap{
+							"poor":   &mockWallet{balance: types.NewBalance(big.NewInt(5))},
+							"faucet": faucet,
+						},
+						nodes: []system.Node{
+							&mockNode{},
+						},
+					},
+				},
+			},
+		}
+
+		walletsGetter, validator := AcquireL2Wallets(0, 3, types.NewBalance(big.NewInt(10)))
+		ctx, err := validator(systest.NewT(t), systestSystem)
+		require.NoError(t, err)
+
+		wallets := walletsGetter(ctx)
+		require.Len(t, wallets, 3)
+		for _, wallet := range wallets {
+			require.True(t, wallet.Balance().Equal(types.NewBalance(big.NewInt(10))))
+		}
+		require.Len(t, faucet.funded, 3, "Each wallet should be distinct and funded")
+
+		// The faucet cannot fund more wallets than its balance allows
+		_, validator = AcquireL2Wallets(0, 20, types.NewBalance(big.NewInt(10)))
+		_, err = validator(systest.NewT(t), systestSystem)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no available wallet to fund 20 wallets")
+	})
+
+	t.Run("test combinators", func(t *testing.T) {
+		type key string
+		pass := func(k key) systest.PreconditionValidator {
+			return func(t systest.T, sys system.System) (context.Context, error) {
+				return context.WithValue(t.Context(), k, true), nil
+			}
+		}
+		fail := func(reason string) systest.PreconditionValidator {
+			return func(t systest.T, sys system.System) (context.Context, error) {
+				return nil, errors.New(reason)
+			}
+		}
+		systestSystem := &mockSystem{}
+
+		// All accumulates the contexts of all validators
+		ctx, err := All(pass("a"), pass("b"))(systest.NewT(t), systestSystem)
+		require.NoError(t, err)
+		require.Equal(t, true, ctx.Value(key("a")))
+		require.Equal(t, true, ctx.Value(key("b")))
+
+		_, err = All(pass("a"), fail("no faucet"), fail("not reached"))(systest.NewT(t), systestSystem)
+		require.EqualError(t, err, "no faucet")
+
+		// Any uses the context of the first validator that passes
+		ctx, err = Any(fail("no isthmus"), pass("b"), pass("c"))(systest.NewT(t), systestSystem)
+		require.NoError(t, err)
+		require.Equal(t, true, ctx.Value(key("b")))
+		require.Nil(t, ctx.Value(key("c")))
+
+		_, err = Any(fail("no isthmus"), fail("no interop"))(systest.NewT(t), systestSystem)
+		var unmet *UnmetPreconditions
+		require.ErrorAs(t, err, &unmet)
+		require.Len(t, unmet.Failures, 2)
+		require.EqualError(t, err, "none of the preconditions are met: [no isthmus; no interop]")
+
+		// Not inverts validators
+		_, err = Not(fail("no interop"), "interop is active")(systest.NewT(t), systestSystem)
+		require.NoError(t, err)
+		_, err = Not(pass("a"), "interop is active")(systest.NewT(t), systestSystem)
+		require.EqualError(t, err, "interop is active")
+
+		// WithReason explains failures
+		_, err = WithReason("operator fees require 2 nodes", All(pass("a"), fail("only 1 node")))(systest.NewT(t), systestSystem)
+		require.EqualError(t, err, "operator fees require 2 nodes: only 1 node")
+	})
 }
 
 type mockSystem struct {
@@ -265,6 +417,10 @@ func (m *mockChain) Config() (*params.ChainConfig, error) {
 	return m.config, nil
 }
 
+func (m *mockChain) ForkSchedule() (*system.ForkSchedule, error) {
+	return nil, fmt.Errorf("fork schedule not implemented")
+}
+
 func (m *mockChain) Nodes() []system.Node {
 	return m.nodes
 }
@@ -320,10 +476,83 @@ func (m *mockNode) RPCURL() string {
 	return ""
 }
 
+func (m *mockNode) WSURL() string {
+	return ""
+}
+
+func (m *mockNode) SubscribeNewHeads(ctx context.Context, ch chan<- *ethtypes.Header) (ethereum.Subscription, error) {
+	return nil, system.ErrNoWebsocket
+}
+
+func (m *mockNode) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	return nil, system.ErrNoWebsocket
+}
+
 func (m *mockNode) ContractsRegistry() interfaces.ContractsRegistry {
 	return nil
 }
 
+func (m *mockNode) MultiCall(ctx context.Context, block rpcblock.Block, calls ...batching.Call) ([]*batching.CallResult, error) {
+	return nil, fmt.Errorf("multicall not implemented")
+}
+
+// producingNode is a node that produces blocks at a fixed rate, in blocks per second
+type producingNode struct {
+	mockNode
+	start time.Time
+	rate  float64
+}
+
+func (m *producingNode) BlockByNumber(ctx context.Context, number *big.Int) (eth.BlockInfo, error) {
+	header := ethtypes.Header{Number: big.NewInt(int64(time.Since(m.start).Seconds() * m.rate))}
+	return eth.HeaderBlockInfo(&header), nil
+}
+
+// faucetWallet is a wallet that derives unfunded wallets, and records the funds it sends
+type faucetWallet struct {
+	mockWallet
+	funded map[types.Address]types.Balance
+}
+
+func (m *faucetWallet) Derive(index uint64) (system.Wallet, error) {
+	return &fundedWallet{
+		mockWallet: mockWallet{address: common.BigToAddress(new(big.Int).SetUint64(index))},
+		faucet:     m,
+	}, nil
+}
+
+func (m *faucetWallet) SendETH(to types.Address, amount types.Balance) types.WriteInvocation[any] {
+	return &mockInvocation{send: func() { m.funded[to] = amount }}
+}
+
+type fundedWallet struct {
+	mockWallet
+	faucet *faucetWallet
+}
+
+func (m *fundedWallet) Balance() types.Balance {
+	return m.faucet.funded[m.address]
+}
+
+type mockInvocation struct {
+	send func()
+}
+
+func (m *mockInvocation) Call(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (m *mockInvocation) Send(ctx context.Context) types.InvocationResult {
+	m.send()
+	return &mockInvocationResult{}
+}
+
+type mockInvocationResult struct{}
+
+func (m *mockInvocationResult) Error() error { return nil }
+func (m *mockInvocationResult) Wait() error  { return nil }
+func (m *mockInvocationResult) Info() any    { return nil }
+
 type mockWallet struct {
 	balance types.Balance
 	address types.Address
@@ -358,6 +587,10 @@ func (m mockWallet) Nonce() uint64 {
 	return 0
 }
 
+func (m mockWallet) Derive(index uint64) (system.Wallet, error) {
+	return nil, nil
+}
+
 func (m mockWallet) Sign(tx system.Transaction) (system.Transaction, error) {
 	return tx, nil
 }
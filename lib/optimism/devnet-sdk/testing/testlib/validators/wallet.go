@@ -3,6 +3,8 @@ package validators
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/constraints"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
@@ -12,6 +14,21 @@ import (
 
 type WalletGetter = func(context.Context) system.Wallet
 
+// WalletsGetter is a function type that retrieves several wallets from a context.
+type WalletsGetter = func(context.Context) []system.Wallet
+
+// derivedWalletsBase is the lowest index of the wallets derived by AcquireL2Wallets,
+// above the indices of the accounts that devnets prefund.
+const derivedWalletsBase = 1000
+
+// nextDerivedWallet is the index of the next wallet derived by AcquireL2Wallets.
+// It starts at a random index, so concurrent test processes derive different wallets.
+var nextDerivedWallet atomic.Uint64
+
+func init() {
+	nextDerivedWallet.Store(derivedWalletsBase + uint64(rand.Uint32())*1024)
+}
+
 func walletFundsValidator(chain system.Chain, minFunds types.Balance, userMarker interface{}) systest.PreconditionValidator {
 	constraint := constraints.WithBalance(minFunds)
 	return func(t systest.T, sys system.System) (context.Context, error) {
@@ -49,3 +66,54 @@ func AcquireL1WalletWithFunds(minFunds types.Balance) (WalletGetter, systest.Pre
 			return walletFundsValidator(chain, minFunds, walletMarker)(t, sys)
 		}
 }
+
+// AcquireL2Wallets returns a wallets getter and a validator that provisions n wallets of an L2 chain,
+// each with a balance of at least balanceEach, e.g. for load tests that need many senders.
+// The wallets are derived from a funded wallet of the chain, which acts as a faucet and funds the wallets
+// that lack balance. Each call of the validator derives new wallets, so tests never share them.
+// The remaining funds of the wallets are returned to the faucet once the test is done.
+func AcquireL2Wallets(chainIdx uint64, n int, balanceEach types.Balance) (WalletsGetter, systest.PreconditionValidator) {
+	walletsMarker := new(byte)
+	return func(ctx context.Context) []system.Wallet {
+			return ctx.Value(walletsMarker).([]system.Wallet)
+		}, func(t systest.T, sys system.System) (context.Context, error) {
+			if len(sys.L2s()) <= int(chainIdx) {
+				return nil, fmt.Errorf("chain index %d out of range, only %d L2 chains available", chainIdx, len(sys.L2s()))
+			}
+			chain := sys.L2s()[chainIdx]
+
+			var faucet system.Wallet
+			constraint := constraints.WithBalance(balanceEach.Mul(float64(n)))
+			for _, wallet := range chain.Wallets() {
+				if constraint.CheckWallet(wallet) {
+					faucet = wallet
+					break
+				}
+			}
+			if faucet == nil {
+				return nil, fmt.Errorf("no available wallet to fund %d wallets with balance of at least %s", n, balanceEach)
+			}
+
+			wallets := make([]system.Wallet, 0, n)
+			for i := 0; i < n; i++ {
+				index := nextDerivedWallet.Add(1) - 1
+				wallet, err := faucet.Derive(index)
+				if err != nil {
+					return nil, fmt.Errorf("failed to derive wallet %d: %w", index, err)
+				}
+				if balance := wallet.Balance(); balance.LessThan(balanceEach) {
+					amount := balanceEach
+					if balance.Int != nil {
+						amount = balanceEach.Sub(balance)
+					}
+					if err := faucet.SendETH(wallet.Address(), amount).Send(t.Context()).Wait(); err != nil {
+						return nil, fmt.Errorf("failed to fund wallet %s: %w", wallet.Address(), err)
+					}
+				}
+				systest.ReturnFunds(t, chain, wallet, faucet.Address())
+				wallets = append(wallets, wallet)
+			}
+
+			return context.WithValue(t.Context(), walletsMarker, wallets), nil
+		}
+}
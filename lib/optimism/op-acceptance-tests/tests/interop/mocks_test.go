@@ -17,8 +17,12 @@ import (
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -97,6 +101,10 @@ func (m *mockFailingWallet) Nonce() uint64 {
 	return 0
 }
 
+func (m *mockFailingWallet) Derive(index uint64) (system.Wallet, error) {
+	return nil, fmt.Errorf("derive failed")
+}
+
 func (m *mockFailingWallet) Sign(tx system.Transaction) (system.Transaction, error) {
 	return tx, nil
 }
@@ -161,6 +169,9 @@ func (m *mockFailingChain) Wallets() system.WalletMap {
 func (m *mockFailingChain) Config() (*params.ChainConfig, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+func (m *mockFailingChain) ForkSchedule() (*system.ForkSchedule, error) {
+	return nil, fmt.Errorf("not implemented")
+}
 func (m *mockFailingChain) Addresses() system.AddressMap {
 	return map[string]common.Address{}
 }
@@ -187,6 +198,7 @@ func (m *mockFailingNode) SupportsEIP(ctx context.Context, eip uint64) bool {
 	return true
 }
 func (m *mockFailingNode) RPCURL() string                                  { return "mock://failing" }
+func (m *mockFailingNode) WSURL() string                                   { return "" }
 func (m *mockFailingNode) ContractsRegistry() interfaces.ContractsRegistry { return m.reg }
 func (m *mockFailingNode) GethClient() (*ethclient.Client, error) {
 	return nil, fmt.Errorf("not implemented")
@@ -194,6 +206,15 @@ func (m *mockFailingNode) GethClient() (*ethclient.Client, error) {
 func (m *mockFailingNode) BlockByNumber(ctx context.Context, number *big.Int) (eth.BlockInfo, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+func (m *mockFailingNode) MultiCall(ctx context.Context, block rpcblock.Block, calls ...batching.Call) ([]*batching.CallResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (m *mockFailingNode) SubscribeNewHeads(ctx context.Context, ch chan<- *ethtypes.Header) (ethereum.Subscription, error) {
+	return nil, system.ErrNoWebsocket
+}
+func (m *mockFailingNode) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	return nil, system.ErrNoWebsocket
+}
 
 // mockFailingChain implements system.Chain with a failing SendETH
 type mockFailingL2Chain struct {
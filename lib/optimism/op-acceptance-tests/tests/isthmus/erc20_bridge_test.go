@@ -170,8 +170,9 @@ func erc20BridgeTestScenario(chainIdx uint64, l1WalletGetter validators.WalletGe
 		logger.Info("Deposit transaction confirmed on L1", "tx", tx.Hash().Hex())
 
 		// Get the OptimismPortal contract to find the deposit event
-		optimismPortal, err := bindings.NewOptimismPortal(l2Chain.L1Addresses()["optimismPortalProxy"], l1Client)
+		contracts, err := system.Contracts(l2Chain)
 		require.NoError(t, err)
+		optimismPortal := contracts.OptimismPortal
 
 		// Find the TransactionDeposited event from the logs
 		var depositFound bool
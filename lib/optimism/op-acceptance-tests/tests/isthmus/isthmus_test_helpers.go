@@ -37,14 +37,6 @@ func DefaultTxOpts(w system.WalletV2) txplan.Option {
 
 func DeployProgram(ctx context.Context, wallet system.WalletV2, code []byte) (common.Address, error) {
 	deployProgram := program.New().ReturnViaCodeCopy(code)
-
-	opts := DefaultTxOpts(wallet)
-	deployTx := txplan.NewPlannedTx(opts, txplan.WithData(deployProgram.Bytes()))
-
-	res, err := deployTx.Included.Eval(ctx)
-	if err != nil {
-		return common.Address{}, err
-	}
-	ctrctAddr := res.ContractAddress
+	ctrctAddr, _, err := wallet.Deploy(ctx, deployProgram.Bytes())
 	return ctrctAddr, err
 }
@@ -0,0 +1,140 @@
+package operatorfee
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/txutils"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+// Withdrawal networks of fee vaults, as in Types.WithdrawalNetwork.
+const (
+	WithdrawalNetworkL1 uint8 = 0
+	WithdrawalNetworkL2 uint8 = 1
+)
+
+// FeeVaultWithdrawal is a withdrawal of a fee vault.
+type FeeVaultWithdrawal struct {
+	Vault     common.Address
+	Value     *big.Int
+	Recipient common.Address
+	Network   uint8
+	Receipt   *gethTypes.Receipt
+}
+
+// VerifyFeeVaultWithdrawal withdraws the fee vault, and verifies that the withdrawal reached its recipient:
+// directly on L2, or through the L2StandardBridge to L1. If the vault holds less than its minimum withdrawal amount,
+// it is topped up by the wallet first, unless that takes more than the top-up limit, in which case the test is skipped.
+// Withdrawals to L1 are verified once they are initiated, as proving and finalizing them takes the finalization period.
+func VerifyFeeVaultWithdrawal(t systest.T, chainID *big.Int, client *ethclient.Client, vaultAddr common.Address, wallet system.Wallet, topUpLimit *big.Int) *FeeVaultWithdrawal {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+
+	// All fee vaults share the FeeVault interface
+	vault, err := bindings.NewSequencerFeeVault(vaultAddr, client)
+	require.NoError(t, err)
+	opts := &bind.CallOpts{Context: ctx}
+	minWithdrawal, err := vault.MINWITHDRAWALAMOUNT(opts)
+	require.NoError(t, err)
+	recipient, err := vault.RECIPIENT(opts)
+	require.NoError(t, err)
+	network, err := vault.WITHDRAWALNETWORK(opts)
+	require.NoError(t, err)
+	logger.Info("Fee vault config",
+		"vault", vaultAddr,
+		"minWithdrawal", minWithdrawal,
+		"recipient", recipient,
+		"network", network)
+
+	// Top the vault up to its minimum withdrawal amount
+	balance, err := client.BalanceAt(ctx, vaultAddr, nil)
+	require.NoError(t, err)
+	if deficit := new(big.Int).Sub(minWithdrawal, balance); deficit.Sign() > 0 {
+		if deficit.Cmp(topUpLimit) > 0 {
+			t.Skipf("fee vault %s needs %s wei more to reach its minimum withdrawal amount, above the top-up limit of %s wei", vaultAddr, deficit, topUpLimit)
+		}
+		logger.Info("Topping up fee vault to its minimum withdrawal amount", "vault", vaultAddr, "amount", deficit)
+		_, _, err := txutils.SendTx(ctx, chainID, client, wallet, &vaultAddr, deficit, nil)
+		require.NoError(t, err, "failed to top up fee vault")
+	}
+
+	// Withdraw the vault
+	vaultABI, err := bindings.SequencerFeeVaultMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := vaultABI.Pack("withdraw")
+	require.NoError(t, err)
+	receipt, _, err := txutils.SendTx(ctx, chainID, client, wallet, &vaultAddr, big.NewInt(0), data)
+	require.NoError(t, err, "failed to withdraw fee vault")
+	logger.Info("Fee vault withdrawn", "vault", vaultAddr, "block", receipt.BlockNumber)
+
+	var withdrawal *bindings.SequencerFeeVaultWithdrawal0
+	for _, l := range receipt.Logs {
+		if l.Address != vaultAddr {
+			continue
+		}
+		if ev, err := vault.ParseWithdrawal0(*l); err == nil {
+			withdrawal = ev
+			break
+		}
+	}
+	require.NotNil(t, withdrawal, "withdrawal event not found")
+	require.Equal(t, recipient, withdrawal.To, "withdrawal should be to the recipient of the vault")
+	require.Equal(t, network, withdrawal.WithdrawalNetwork, "withdrawal should be to the network of the vault")
+	require.True(t, withdrawal.Value.Cmp(minWithdrawal) >= 0, "withdrawal of %s should be at least the minimum withdrawal amount %s", withdrawal.Value, minWithdrawal)
+
+	// The vault accounts for the withdrawn value
+	prevBlock := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	processedBefore, err := vault.TotalProcessed(&bind.CallOpts{Context: ctx, BlockNumber: prevBlock})
+	require.NoError(t, err)
+	processedAfter, err := vault.TotalProcessed(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber})
+	require.NoError(t, err)
+	require.Equal(t, withdrawal.Value, new(big.Int).Sub(processedAfter, processedBefore), "total processed should increase by the withdrawn value")
+
+	switch network {
+	case WithdrawalNetworkL2:
+		// The recipient is paid directly. Its balance is only exact if it did not pay for the withdrawal.
+		if recipient != wallet.Address() {
+			before, err := client.BalanceAt(ctx, recipient, prevBlock)
+			require.NoError(t, err)
+			after, err := client.BalanceAt(ctx, recipient, receipt.BlockNumber)
+			require.NoError(t, err)
+			require.Equal(t, withdrawal.Value, new(big.Int).Sub(after, before), "L2 recipient should receive the withdrawn value")
+		}
+	case WithdrawalNetworkL1:
+		// The recipient is paid through the bridge, once the withdrawal is finalized on L1
+		bridge, err := bindings.NewL2StandardBridge(predeploys.L2StandardBridgeAddr, client)
+		require.NoError(t, err)
+		var bridged *bindings.L2StandardBridgeETHBridgeInitiated
+		for _, l := range receipt.Logs {
+			if l.Address != predeploys.L2StandardBridgeAddr {
+				continue
+			}
+			if ev, err := bridge.ParseETHBridgeInitiated(*l); err == nil {
+				bridged = ev
+				break
+			}
+		}
+		require.NotNil(t, bridged, "bridge withdrawal event not found")
+		require.Equal(t, vaultAddr, bridged.From, "bridge withdrawal should be from the vault")
+		require.Equal(t, recipient, bridged.To, "bridge withdrawal should be to the L1 recipient")
+		require.Equal(t, withdrawal.Value, bridged.Amount, "bridge withdrawal should be of the withdrawn value")
+	default:
+		t.Fatalf("unknown withdrawal network %d", network)
+	}
+
+	return &FeeVaultWithdrawal{
+		Vault:     vaultAddr,
+		Value:     withdrawal.Value,
+		Recipient: recipient,
+		Network:   network,
+		Receipt:   receipt,
+	}
+}
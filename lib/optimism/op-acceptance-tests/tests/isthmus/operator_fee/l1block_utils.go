@@ -0,0 +1,109 @@
+package operatorfee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// L1BlockUpdateTimeout is how long the L2 chain may take to adopt fee parameters that are set in the SystemConfig.
+const L1BlockUpdateTimeout = 2 * time.Minute
+
+// l1BlockPollInterval is how often the head of the L2 chain is polled, if the node has no websocket endpoint.
+const l1BlockPollInterval = time.Second
+
+// L1BlockFeeParams are the fee parameters of the L1Block predeploy, which the L2 chain derives from the SystemConfig.
+type L1BlockFeeParams struct {
+	BaseFeeScalar       uint32
+	BlobBaseFeeScalar   uint32
+	OperatorFeeScalar   uint32
+	OperatorFeeConstant uint64
+}
+
+// ReadL1BlockFeeParams reads the fee parameters of the L1Block predeploy at the L2 block.
+func ReadL1BlockFeeParams(ctx context.Context, l1Block *bindings.L1Block, block *big.Int) (L1BlockFeeParams, error) {
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+	var params L1BlockFeeParams
+	var err error
+	if params.BaseFeeScalar, err = l1Block.BaseFeeScalar(opts); err != nil {
+		return params, fmt.Errorf("failed to read base fee scalar: %w", err)
+	}
+	if params.BlobBaseFeeScalar, err = l1Block.BlobBaseFeeScalar(opts); err != nil {
+		return params, fmt.Errorf("failed to read blob base fee scalar: %w", err)
+	}
+	if params.OperatorFeeScalar, err = l1Block.OperatorFeeScalar(opts); err != nil {
+		return params, fmt.Errorf("failed to read operator fee scalar: %w", err)
+	}
+	if params.OperatorFeeConstant, err = l1Block.OperatorFeeConstant(opts); err != nil {
+		return params, fmt.Errorf("failed to read operator fee constant: %w", err)
+	}
+	return params, nil
+}
+
+// WaitForL1BlockFeeParams waits until the L1Block predeploy has the expected fee parameters at the head of the L2 chain,
+// and returns the header of the first head that has them. New heads are received over the websocket endpoint
+// of the node, or polled if the node has none.
+func WaitForL1BlockFeeParams(ctx context.Context, node system.Node, l1Block *bindings.L1Block, expected L1BlockFeeParams, timeout time.Duration) (*gethTypes.Header, error) {
+	logger := systest.LoggerFrom(ctx)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := node.GethClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	// heads are received over the subscription, or polled if the node has no websocket endpoint
+	heads := make(chan *gethTypes.Header, 16)
+	var subErr <-chan error
+	var poll <-chan time.Time
+	sub, err := node.SubscribeNewHeads(ctx, heads)
+	switch {
+	case errors.Is(err, system.ErrNoWebsocket):
+		ticker := time.NewTicker(l1BlockPollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	case err != nil:
+		return nil, fmt.Errorf("failed to subscribe to new heads: %w", err)
+	default:
+		defer sub.Unsubscribe()
+		subErr = sub.Err()
+	}
+
+	// the parameters may be set at the current head already
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 head: %w", err)
+	}
+	for {
+		params, err := ReadL1BlockFeeParams(ctx, l1Block, head.Number)
+		if err != nil {
+			return nil, err
+		}
+		if params == expected {
+			logger.Info("L1Block fee parameters updated", "block", head.Number, "params", params)
+			return head, nil
+		}
+		logger.Debug("L1Block fee parameters not updated yet", "block", head.Number, "params", params)
+
+		select {
+		case head = <-heads:
+		case <-poll:
+			if head, err = client.HeaderByNumber(ctx, nil); err != nil {
+				return nil, fmt.Errorf("failed to get L2 head: %w", err)
+			}
+		case err := <-subErr:
+			return nil, fmt.Errorf("new heads subscription failed: %w", err)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("L1Block fee parameters are %+v, expected %+v: %w", params, expected, ctx.Err())
+		}
+	}
+}
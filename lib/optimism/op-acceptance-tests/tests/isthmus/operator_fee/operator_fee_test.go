@@ -1,13 +1,15 @@
 package operatorfee
 
 import (
+	"fmt"
 	"log/slog"
 	"math/big"
 	"testing"
-	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/fees"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/txutils"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/validators"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/types"
 	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
@@ -15,11 +17,18 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
+// Funds of the test wallets of each test case. L1 test wallets only pay for deposits and blob-carrying transactions.
+var (
+	l2TestWalletFunds = big.NewInt(params.Ether)
+	l1TestWalletFunds = big.NewInt(params.Ether / 10)
+)
+
 // TestFees verifies that L1/L2 fees are handled properly in different fork configurations
 func TestOperatorFee(t *testing.T) {
 	logger := testlog.Logger(t, slog.LevelDebug)
@@ -28,18 +37,42 @@ func TestOperatorFee(t *testing.T) {
 
 	logger.Info("Starting operator fee test", "chain", chainIdx)
 
+	// Define test cases with different operator fee parameters
+	numRandomValuesForEachDimm := 1
+	seed, err := Seed()
+	require.NoError(t, err)
+	strategy, err := SelectedStrategy()
+	require.NoError(t, err)
+	schedule, err := SelectedSchedule()
+	require.NoError(t, err)
+	t.Logf("generating test cases with seed %d and strategy %s, set %s and %s to reproduce", seed, strategy, SeedVar, StrategyVar)
+	testCases := FilterTestParamsCases(GenerateAllTestParamsCases(numRandomValuesForEachDimm, strategy), SelectedCaseIDs())
+	require.NotEmpty(t, testCases, "no test cases selected")
+	groups := GroupTestParamsCases(testCases)
+
+	// In parallel, every transaction shape of a test case has wallets of its own, which are funded at once
+	concurrentWallets := 1
+	if schedule == ScheduleParallel {
+		concurrentWallets = len(AllTxShapes())
+	}
+	l1Funds := new(big.Int).Mul(l1TestWalletFunds, big.NewInt(int64(concurrentWallets)))
+	l1Funds.Add(l1Funds, big.NewInt(params.Ether))
+	l2Funds := new(big.Int).Mul(l2TestWalletFunds, big.NewInt(int64(concurrentWallets)))
+	l2Funds.Add(l2Funds, big.NewInt(params.Ether))
+
 	// Get validators and getters for accessing the system and wallets
-	l1WalletGetter, l1WalletValidator := validators.AcquireL1WalletWithFunds(types.NewBalance(big.NewInt(params.Ether)))
-	l2WalletGetter, l2WalletValidator := validators.AcquireL2WalletWithFunds(chainIdx, types.NewBalance(big.NewInt(params.Ether)))
+	l1WalletGetter, l1WalletValidator := validators.AcquireL1WalletWithFunds(types.NewBalance(l1Funds))
+	l2WalletGetter, l2WalletValidator := validators.AcquireL2WalletWithFunds(chainIdx, types.NewBalance(l2Funds))
 
 	logger.Info("Acquired test wallets with funds")
 
 	// Run isthmus test
 	_, forkValidator := validators.AcquireL2WithFork(chainIdx, rollup.Isthmus)
 	nodesValidator := validators.HasSufficientL2Nodes(chainIdx, 2)
-	logger.Info("Running system test", "fork", "Isthmus", "nodes", 2)
+	logger.Info("Running system test", "fork", "Isthmus", "nodes", 2, "schedule", schedule)
 	systest.SystemTest(t,
 		func(t systest.T, sys system.System) {
+			logger := systest.LoggerFrom(t.Context())
 			logger.Info("Starting operator fee test scenario", "chain", chainIdx)
 			// Get the low-level system and wallet
 			l1Wallet := l1WalletGetter(t.Context())
@@ -55,14 +88,37 @@ func TestOperatorFee(t *testing.T) {
 			require.NoError(t, err)
 			logger.Info("L2 wallet balance", "balance", l2WalletBalance)
 
-			// Define test cases with different operator fee parameters
-			numRandomValuesForEachDimm := 1
-			testCases := GenerateAllTestParamsCases(numRandomValuesForEachDimm)
+			env := setupOperatorFeeEnv(t, sys, l1Wallet, l2Wallet, chainIdx)
+
+			switch schedule {
+			case ScheduleParallel:
+				// Each group applies its operator fee parameters once, and runs the shapes of its test cases in parallel
+				for i, group := range groups {
+					t.Run(fmt.Sprintf("group_%d", i), func(t systest.T) {
+						operatorFeeGroupProcedure(t, env, group)
+					})
+				}
+			default:
+				// For each test case, verify the operator fee parameters
+				for _, tc := range testCases {
+					t.Run(tc.ID, func(t systest.T) {
+						operatorFeeTestProcedure(t, env, tc)
+					})
+				}
+			}
 
-			// For each test case, verify the operator fee parameters
-			for _, tc := range testCases {
-				t.Run(tc.ID, func(t systest.T) {
-					operatorFeeTestProcedure(t, sys, l1Wallet, l2Wallet, chainIdx, tc, logger)
+			// Withdraw the fee vaults that the test cases credited, with up to half of the funds of the L2 wallet to top them up
+			topUpLimit := new(big.Int).Div(l2WalletBalance, big.NewInt(2))
+			vaults := []struct {
+				name string
+				addr common.Address
+			}{
+				{"operator_fee_vault", predeploys.OperatorFeeVaultAddr},
+				{"sequencer_fee_vault", predeploys.SequencerFeeVaultAddr},
+			}
+			for _, vault := range vaults {
+				t.Run(vault.name+"_withdrawal", func(t systest.T) {
+					VerifyFeeVaultWithdrawal(t, env.l2ChainID, l2GethSeqClient, vault.addr, l2Wallet, topUpLimit)
 				})
 			}
 		},
@@ -73,15 +129,26 @@ func TestOperatorFee(t *testing.T) {
 	)
 }
 
-func operatorFeeTestProcedure(t systest.T, sys system.System, l1FundingWallet system.Wallet, l2FundingWallet system.Wallet, chainIdx uint64, tc TestParams, logger log.Logger) {
+// operatorFeeEnv is what all test cases share. It is set up once, as the setup waits for transactions on L1.
+type operatorFeeEnv struct {
+	l1Chain          system.Chain
+	l2Chain          system.L2Chain
+	l1ChainID        *big.Int
+	l1GethClient     *ethclient.Client
+	l2ChainID        *big.Int
+	l2GethSeqClient  *ethclient.Client
+	contracts        *system.ChainContracts
+	systemConfigAddr common.Address
+	rollupOwner      system.Wallet
+	l1FundingWallet  system.Wallet
+	l2FundingWallet  system.Wallet
+	feeChecker       *fees.FeeChecker
+	balanceReader    *fees.BalanceReader
+}
+
+func setupOperatorFeeEnv(t systest.T, sys system.System, l1FundingWallet system.Wallet, l2FundingWallet system.Wallet, chainIdx uint64) *operatorFeeEnv {
 	ctx := t.Context()
-	logger.Info("Starting operator fee test",
-		"test_case", tc.ID,
-		"operator_fee_constant", tc.OperatorFeeConstant,
-		"operator_fee_scalar", tc.OperatorFeeScalar,
-		"l1_fee_constant", tc.L1BlobBaseFeeScalar,
-		"l1_fee_scalar", tc.L1BaseFeeScalar,
-	)
+	logger := systest.LoggerFrom(ctx)
 
 	// ==========
 	// Read-only Test Setup + Invariant Checks
@@ -95,13 +162,6 @@ func operatorFeeTestProcedure(t systest.T, sys system.System, l1FundingWallet sy
 	l2GethSeqClient, err := l2Chain.Nodes()[0].GethClient()
 	require.NoError(t, err)
 
-	// Setup chain fork detection
-	secondCheck, err := systest.CheckForChainFork(t.Context(), l2Chain, logger)
-	require.NoError(t, err, "error checking for chain fork")
-	defer func() {
-		require.NoError(t, secondCheck(), "error checking for chain fork")
-	}()
-
 	l2StartHeader, err := l2GethSeqClient.HeaderByNumber(ctx, nil)
 	require.NoError(t, err)
 
@@ -124,42 +184,34 @@ func operatorFeeTestProcedure(t systest.T, sys system.System, l1FundingWallet sy
 	l2ChainConfig, err := l2Chain.Config()
 	require.NoError(t, err)
 
-	l2ChainID := l2ChainConfig.ChainID
-
 	// Create fee checker
 	logger.Info("Creating fee checker utility")
-	feeChecker := NewFeeChecker(t, l2GethSeqClient, l2ChainConfig, logger)
-
-	// Setup GasPriceOracle contract binding
-	logger.Info("Connecting to GasPriceOracle contract")
-	gpoContract, err := bindings.NewGasPriceOracle(predeploys.GasPriceOracleAddr, l2GethSeqClient)
-	require.NoError(t, err)
+	feeChecker := fees.NewFeeChecker(t, l2GethSeqClient, l2ChainConfig)
 
-	// Setup L2 L1Block contract binding
-	l2L1BlockContract, err := bindings.NewL1Block(predeploys.L1BlockAddr, l2GethSeqClient)
-	require.NoError(t, err)
-
-	// Initialize systemconfig contract
-	logger.Info("Getting SystemConfig contract")
-	systemConfigProxyAddr, ok := l2Chain.L1Addresses()["systemConfigProxy"]
-	require.True(t, ok, "system config proxy address not found")
-	systemConfig, err := bindings.NewSystemConfig(systemConfigProxyAddr, l1GethClient)
+	// Setup contract bindings of the GasPriceOracle and L1Block predeploys, and of the SystemConfig on L1
+	logger.Info("Binding chain contracts")
+	contracts, err := system.Contracts(l2Chain)
 	require.NoError(t, err)
+	systemConfigProxyAddr := l2Chain.L1Addresses()[system.SystemConfigAddressName]
 
 	// Verify system config proxy owner is the rollup owner
-	owner, err := systemConfig.Owner(&bind.CallOpts{BlockNumber: nil})
+	owner, err := contracts.SystemConfig.Owner(&bind.CallOpts{BlockNumber: nil})
 	require.NoError(t, err)
 	require.Equal(t, owner, l1RollupOwnerWallet.Address(), "system config proxy owner should be the rollup owner")
 
 	// Verify GPO isthmus view matches chain isthmus view
-	gpoIsthmus, err := gpoContract.IsIsthmus(&bind.CallOpts{BlockNumber: l2StartHeader.Number})
+	forks, err := l2Chain.ForkSchedule()
+	require.NoError(t, err)
+	chainIsthmus := forks.IsActive(string(rollup.Isthmus), l2StartHeader.Time)
+	require.True(t, chainIsthmus, "chain must have isthmus active")
+	gpoIsthmus, err := contracts.GasPriceOracle.IsIsthmus(&bind.CallOpts{BlockNumber: l2StartHeader.Number})
 	require.NoError(t, err)
-	require.True(t, gpoIsthmus, "GPO and chain must have same isthmus view")
+	require.Equal(t, chainIsthmus, gpoIsthmus, "GPO and chain must have same isthmus view")
 	logger.Info("Verified GPO contract has correct Isthmus view")
 
 	// Create balance reader
 	logger.Info("Creating balance reader")
-	balanceReader := NewBalanceReader(t, l2GethSeqClient, logger)
+	balanceReader := fees.NewBalanceReader(t, l2Chain.Nodes()[0])
 
 	// Wait for first block after genesis. The genesis block has zero L1Block
 	// values and will throw off the GPO checks
@@ -167,127 +219,228 @@ func operatorFeeTestProcedure(t systest.T, sys system.System, l1FundingWallet sy
 	_, err = l2GethSeqClient.HeaderByNumber(ctx, big.NewInt(1))
 	require.NoError(t, err)
 
+	// Fund l1RollupOwnerWallet wallet from faucet
+	logger.Info("Funding rollup owner wallet with 10 ETH")
+	_, _, err = txutils.SendValueTx(ctx, l1ChainID, l1GethClient, l1FundingWallet, l1RollupOwnerWallet.Address(), new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(10)), true)
+	require.NoError(t, err, "Error funding owner wallet")
+	systest.ReturnFunds(t, sys.L1(), l1RollupOwnerWallet, l1FundingWallet.Address())
+
+	// Restore the original fee parameters once the test is done, before the owner wallet returns its funds
+	RestoreFeeParams(t, l1ChainID, l1GethClient, contracts.SystemConfig, systemConfigProxyAddr, l1RollupOwnerWallet)
+
+	return &operatorFeeEnv{
+		l1Chain:          sys.L1(),
+		l2Chain:          l2Chain,
+		l1ChainID:        l1ChainID,
+		l1GethClient:     l1GethClient,
+		l2ChainID:        l2ChainConfig.ChainID,
+		l2GethSeqClient:  l2GethSeqClient,
+		contracts:        contracts,
+		systemConfigAddr: systemConfigProxyAddr,
+		rollupOwner:      l1RollupOwnerWallet,
+		l1FundingWallet:  l1FundingWallet,
+		l2FundingWallet:  l2FundingWallet,
+		feeChecker:       feeChecker,
+		balanceReader:    balanceReader,
+	}
+}
+
+// applyFeeParams sets the fee parameters of the SystemConfig, and waits for the L2 chain to adopt them
+func (e *operatorFeeEnv) applyFeeParams(t systest.T, feeParams FeeParams) {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+	logger.Info("Applying fee parameters", "params", feeParams)
+	ApplyFeeParams(t, e.l1ChainID, e.l1GethClient, e.contracts.SystemConfig, e.systemConfigAddr, e.rollupOwner, feeParams)
+
+	// wait for the L2 chain to derive the fee parameters, and verify the L1Block contract has the test case values
+	logger.Info("Waiting for L2 chain to adopt the fee parameters")
+	_, err := WaitForL1BlockFeeParams(ctx, e.l2Chain.Nodes()[0], e.contracts.L1Block, L1BlockFeeParams{
+		BaseFeeScalar:       feeParams.L1BaseFeeScalar,
+		BlobBaseFeeScalar:   feeParams.L1BlobBaseFeeScalar,
+		OperatorFeeScalar:   feeParams.OperatorFeeScalar,
+		OperatorFeeConstant: feeParams.OperatorFeeConstant,
+	}, L1BlockUpdateTimeout)
+	require.NoError(t, err, "L1Block fee parameters do not match test case values")
+}
+
+// newTxShapeEnv creates and funds the test wallets on L2, which return their funds once the test is done.
+// If fundL1 is set, a test wallet on L1 is created and funded as well, or else the L1 funding wallet is used.
+func (e *operatorFeeEnv) newTxShapeEnv(t systest.T, fundL1 bool) *TxShapeEnv {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+
 	// Create test wallets
-	logger.Info("Creating test wallet 1")
-	l2TestWallet1, err := NewTestWallet(ctx, l2Chain)
-	require.NoError(t, err)
-	logger.Info("Test wallet 1", "address", l2TestWallet1.Address().Hex())
+	l2TestWallet1 := systest.NewWallet(t, e.l2Chain, e.l2FundingWallet.Address())
+	l2TestWallet2 := systest.NewWallet(t, e.l2Chain, e.l2FundingWallet.Address())
+	logger.Info("Test wallets", "from", l2TestWallet1.Address().Hex(), "to", l2TestWallet2.Address().Hex())
 
-	logger.Info("Creating test wallet 2")
-	l2TestWallet2, err := NewTestWallet(ctx, l2Chain)
+	// Fund test wallet from faucet
+	logger.Info("Funding test wallet with ETH", "amount", l2TestWalletFunds)
+	_, _, err := txutils.SendValueTx(ctx, e.l2ChainID, e.l2GethSeqClient, e.l2FundingWallet, l2TestWallet1.Address(), l2TestWalletFunds, true)
+	require.NoError(t, err, "Error funding test wallet")
+
+	// check that the balance of l2TestWallet1 is now the fund amount
+	balance, err := e.l2GethSeqClient.BalanceAt(ctx, l2TestWallet1.Address(), nil)
 	require.NoError(t, err)
-	logger.Info("Test wallet 2", "address", l2TestWallet2.Address().Hex())
+	require.Equal(t, l2TestWalletFunds, balance, "balance of l2TestWallet1 should be the fund amount")
+
+	l1Wallet := e.l1FundingWallet
+	if fundL1 {
+		l1Wallet = systest.NewWallet(t, e.l1Chain, e.l1FundingWallet.Address())
+		logger.Info("Funding L1 test wallet with ETH", "address", l1Wallet.Address().Hex(), "amount", l1TestWalletFunds)
+		_, _, err = txutils.SendValueTx(ctx, e.l1ChainID, e.l1GethClient, e.l1FundingWallet, l1Wallet.Address(), l1TestWalletFunds, true)
+		require.NoError(t, err, "Error funding L1 test wallet")
+	}
+
+	return &TxShapeEnv{
+		L1ChainID: e.l1ChainID,
+		L1Client:  e.l1GethClient,
+		L1Wallet:  l1Wallet,
+		Portal:    e.contracts.OptimismPortal,
+		L2ChainID: e.l2ChainID,
+		L2Client:  e.l2GethSeqClient,
+		From:      l2TestWallet1,
+		To:        l2TestWallet2.Address(),
+	}
+}
 
-	fundAmount := big.NewInt(1e18)
+// operatorFeeTestProcedure applies the fee parameters of the test case, and verifies the fees of each shape of
+// transaction in turn, with the changes of the fee vaults of each transaction.
+func operatorFeeTestProcedure(t systest.T, env *operatorFeeEnv, tc TestParams) {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+	logger.Info("Starting operator fee test",
+		"test_case", tc.ID,
+		"operator_fee_constant", tc.OperatorFeeConstant,
+		"operator_fee_scalar", tc.OperatorFeeScalar,
+		"l1_fee_constant", tc.L1BlobBaseFeeScalar,
+		"l1_fee_scalar", tc.L1BaseFeeScalar,
+	)
+
+	// Setup chain fork detection
+	secondCheck, err := systest.CheckForChainFork(ctx, env.l2Chain, logger)
+	require.NoError(t, err, "error checking for chain fork")
+	defer func() {
+		require.NoError(t, secondCheck(), "error checking for chain fork")
+	}()
 
 	// ==========
 	// Begin Test
 	// ==========
 
-	// Fund l1RollupOwnerWallet wallet from faucet
-	logger.Info("Funding rollup owner wallet with 10 ETH")
-	_, _, err = SendValueTx(ctx, l1ChainID, l1GethClient, l1FundingWallet, l1RollupOwnerWallet.Address(), new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(10)), true)
-	require.NoError(t, err, "Error funding owner wallet")
-	defer func() {
-		ReturnRemainingFunds(t, ctx, l1ChainID, l1GethClient, l1RollupOwnerWallet, l1FundingWallet, logger)
-	}()
+	shapeEnv := env.newTxShapeEnv(t, false)
+	env.applyFeeParams(t, tc.FeeParams())
 
-	// Fund test wallet from faucet
-	logger.Info("Funding test wallet with ETH", "amount", fundAmount)
-	_, _, err = SendValueTx(ctx, l2ChainID, l2GethSeqClient, l2FundingWallet, l2TestWallet1.Address(), fundAmount, true)
-	require.NoError(t, err, "Error funding test wallet")
+	// Verify the fees of each shape of transaction
+	for _, shape := range AllTxShapes() {
+		t.Run(string(shape), func(t systest.T) {
+			verifyTxShapeFees(t, shapeEnv, shape, env.l2GethSeqClient, env.feeChecker, env.balanceReader, env.contracts.GasPriceOracle, true)
+		})
+	}
+}
+
+// operatorFeeGroupProcedure applies the operator fee parameters of the group once, and runs its test cases in turn,
+// each of which only updates the L1 fee scalars, and verifies the fees of each shape of transaction in parallel,
+// each with wallets of its own. The transactions share blocks, so the charges of their senders are verified
+// per transaction, and the fee vaults over all blocks of the group, as the L1 fees are taken from the receipts.
+func operatorFeeGroupProcedure(t systest.T, env *operatorFeeEnv, group TestParamsGroup) {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+	logger.Info("Starting operator fee test group",
+		"operator_fee_constant", group.OperatorFeeConstant,
+		"operator_fee_scalar", group.OperatorFeeScalar,
+		"cases", len(group.Cases),
+	)
+
+	// Setup chain fork detection
+	secondCheck, err := systest.CheckForChainFork(ctx, env.l2Chain, logger)
+	require.NoError(t, err, "error checking for chain fork")
 	defer func() {
-		ReturnRemainingFunds(t, ctx, l2ChainID, l2GethSeqClient, l2TestWallet1, l2FundingWallet, logger)
+		require.NoError(t, secondCheck(), "error checking for chain fork")
 	}()
 
-	// check that the balance of l2TestWallet1 is now the fund amount
-	balance, err := l2GethSeqClient.BalanceAt(ctx, l2TestWallet1.Address(), nil)
-	require.NoError(t, err)
-	require.Equal(t, fundAmount, balance, "balance of l2TestWallet1 should be the fund amount")
-
-	// Update operator fee parameters
-	logger.Info("Updating operator fee parameters",
-		"constant", tc.OperatorFeeConstant,
-		"scalar", tc.OperatorFeeScalar)
-	_, receipt := UpdateOperatorFeeParams(t, l1ChainID, l1GethClient, systemConfig, systemConfigProxyAddr, l1RollupOwnerWallet, tc.OperatorFeeConstant, tc.OperatorFeeScalar, logger)
-	logger.Info("Operator fee parameters updated", "block", receipt.BlockNumber)
-
-	// Update L1 fee parameters
-	logger.Info("Updating L1 fee parameters",
-		"l1BaseFeeScalar", tc.L1BaseFeeScalar,
-		"l1BlobBaseFeeScalar", tc.L1BlobBaseFeeScalar)
-	_, _ = UpdateL1FeeParams(t, l1ChainID, l1GethClient, systemConfig, systemConfigProxyAddr, l1RollupOwnerWallet, tc.L1BaseFeeScalar, tc.L1BlobBaseFeeScalar, logger)
-	logger.Info("Operator fee parameters updated", "block", receipt.BlockNumber)
-
-	// sleep to allow for the L2 nodes to sync to L1 origin where operator fee was set
-	delay := 30 * time.Second
-	logger.Info("Waiting for L2 nodes to sync with L1 origin where operator fee was set", "delay", delay)
-	time.Sleep(delay)
-
-	// Verify L1Block contract values have been updated to match test case values
-	baseFeeScalar, err := l2L1BlockContract.BaseFeeScalar(&bind.CallOpts{BlockNumber: nil})
-	require.NoError(t, err)
-	logger.Info("L1Block base fee scalar", "scalar", baseFeeScalar)
-	require.Equal(t, tc.L1BaseFeeScalar, baseFeeScalar, "L1Block base fee scalar does not match test case value")
+	env.applyFeeParams(t, group.Cases[0].FeeParams())
 
-	blobBaseFeeScalar, err := l2L1BlockContract.BlobBaseFeeScalar(&bind.CallOpts{BlockNumber: nil})
+	startHeader, err := env.l2GethSeqClient.HeaderByNumber(ctx, nil)
 	require.NoError(t, err)
-	logger.Info("L1Block blob base fee scalar", "scalar", blobBaseFeeScalar)
-	require.Equal(t, tc.L1BlobBaseFeeScalar, blobBaseFeeScalar, "L1Block blob base fee scalar does not match test case value")
+	startBalances := env.balanceReader.SampleBalances(ctx, startHeader.Number)
+
+	for _, tc := range group.Cases {
+		// The parallel sub-tests are done, and have returned their funds, once the sub-test of the case returns
+		t.Run(tc.ID, func(t systest.T) {
+			// the operator fee parameters are unchanged, so only the L1 fee scalars are updated, if they differ
+			env.applyFeeParams(t, tc.FeeParams())
+			for _, shape := range AllTxShapes() {
+				t.Run(string(shape), func(t systest.T) {
+					// wallets are funded before the sub-test runs in parallel, as the funding wallets are shared
+					shapeEnv := env.newTxShapeEnv(t, true)
+					t.Parallel()
+					verifyTxShapeFees(t, shapeEnv, shape, env.l2GethSeqClient, env.feeChecker, env.balanceReader, env.contracts.GasPriceOracle, false)
+				})
+			}
+		})
+	}
 
-	operatorFeeConstant, err := l2L1BlockContract.OperatorFeeConstant(&bind.CallOpts{BlockNumber: nil})
+	// Verify the fee vaults were credited the fees of all transactions since the parameters were applied
+	endHeader, err := env.l2GethSeqClient.HeaderByNumber(ctx, nil)
 	require.NoError(t, err)
-	logger.Info("L1Block operator fee constant", "constant", operatorFeeConstant)
-	require.Equal(t, tc.OperatorFeeConstant, operatorFeeConstant, "L1Block operator fee constant does not match test case value")
-
-	operatorFeeScalar, err := l2L1BlockContract.OperatorFeeScalar(&bind.CallOpts{BlockNumber: nil})
+	logger.Info("Verifying fee vault balances of the group", "from", startHeader.Number, "to", endHeader.Number)
+	endBalances := env.balanceReader.SampleBalances(ctx, endHeader.Number)
+	expectedChanges, err := env.feeChecker.CalculateExpectedBlockChanges(ctx, startHeader.Number, endHeader.Number)
 	require.NoError(t, err)
-	logger.Info("L1Block operator fee scalar", "scalar", operatorFeeScalar)
-	require.Equal(t, tc.OperatorFeeScalar, operatorFeeScalar, "L1Block operator fee scalar does not match test case value")
+	fees.AssertBalanceChanges(t, startBalances, endBalances, expectedChanges)
+}
+
+// verifyTxShapeFees sends a transaction of the shape, and verifies the fees it was charged.
+// If checkVaults is set, it verifies that the fee vaults were credited as well, which requires that no other
+// transactions are sent at the same time.
+func verifyTxShapeFees(t systest.T, env *TxShapeEnv, shape TxShape, l2GethSeqClient *ethclient.Client, feeChecker *fees.FeeChecker, balanceReader *fees.BalanceReader, gpoContract *bindings.GasPriceOracle, checkVaults bool) {
+	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
+	sender := env.Sender(shape)
 
 	l2PreTestHeader, err := l2GethSeqClient.HeaderByNumber(ctx, nil)
 	require.NoError(t, err)
 
 	// Get initial balances
-	logger.Info("Sampling initial balances", "block", l2PreTestHeader.Number.Uint64())
-	startBalances := balanceReader.SampleBalances(ctx, l2PreTestHeader.Number, l2TestWallet1.Address())
+	logger.Info("Sampling initial balances", "shape", shape, "block", l2PreTestHeader.Number.Uint64())
+	startBalances := balanceReader.SampleBalances(ctx, l2PreTestHeader.Number, sender)
 	logger.Debug("Initial balances", "balances", startBalances)
 
 	// Send the test transaction
 	logger.Info("Current base fee", "fee", l2PreTestHeader.BaseFee)
-	receipt, tx, err := SendValueTx(ctx, l2ChainID, l2GethSeqClient, l2TestWallet1, l2TestWallet2.Address(), big.NewInt(1000), true)
-
-	defer func() {
-		ReturnRemainingFunds(t, ctx, l2ChainID, l2GethSeqClient, l2TestWallet1, l2FundingWallet, logger)
-		ReturnRemainingFunds(t, ctx, l2ChainID, l2GethSeqClient, l2TestWallet2, l2FundingWallet, logger)
-	}()
-
+	receipt, tx, err := SendShapedTx(ctx, env, shape)
 	require.NoError(t, err, "failed to send test transaction where it should succeed")
 	logger.Info("Transaction confirmed",
+		"shape", shape,
 		"block", receipt.BlockNumber.Uint64(),
 		"hash", tx.Hash().Hex())
 
 	// Get final balances after transaction
 	logger.Info("Sampling final balances", "block", receipt.BlockNumber.Uint64())
-	endBalances := balanceReader.SampleBalances(ctx, receipt.BlockNumber, l2TestWallet1.Address())
+	endBalances := balanceReader.SampleBalances(ctx, receipt.BlockNumber, sender)
 	logger.Debug("Final balances", "balances", endBalances)
 
-	// Calculate L1 fee for GPO verification
 	l2EndHeader, err := l2GethSeqClient.HeaderByNumber(ctx, receipt.BlockNumber)
 	require.NoError(t, err)
-	txBytes, err := tx.MarshalBinary()
-	require.NoError(t, err)
-	l1Fee := feeChecker.L1Cost(tx.RollupCostData(), l2EndHeader.Time)
-	logger.Debug("Calculated L1 fee", "fee", l1Fee)
 
-	// Verify gas price oracle L1 fee calculation
-	adjustedGPOFee, err := gpoContract.GetL1Fee(&bind.CallOpts{BlockNumber: receipt.BlockNumber}, txBytes)
-	require.NoError(t, err)
-	logger.Debug("GPO contract L1 fee", "fee", adjustedGPOFee)
-	// Verify that GPO contract L1 fee calculation matches local L1 fee calculation
-	require.Equal(t, l1Fee, adjustedGPOFee, "GPO reports L1 fee mismatch")
-	// Verify execution L1 fee calculation matches GPO and local L1 fee calculation
-	require.Equal(t, l1Fee, receipt.L1Fee, "l1 fee in receipt is correct")
+	// Deposits are charged no L1 fee, so there is none for the GPO to verify
+	if !tx.IsDepositTx() {
+		// Calculate L1 fee for GPO verification
+		txBytes, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		l1Fee := feeChecker.L1Cost(tx.RollupCostData(), l2EndHeader.Time)
+		logger.Debug("Calculated L1 fee", "fee", l1Fee)
+
+		// Verify gas price oracle L1 fee calculation
+		adjustedGPOFee, err := gpoContract.GetL1Fee(&bind.CallOpts{BlockNumber: receipt.BlockNumber}, txBytes)
+		require.NoError(t, err)
+		logger.Debug("GPO contract L1 fee", "fee", adjustedGPOFee)
+		// Verify that GPO contract L1 fee calculation matches local L1 fee calculation
+		require.Equal(t, l1Fee, adjustedGPOFee, "GPO reports L1 fee mismatch")
+		// Verify execution L1 fee calculation matches GPO and local L1 fee calculation
+		require.Equal(t, l1Fee, receipt.L1Fee, "l1 fee in receipt is correct")
+	}
 
 	// Calculate expected fee changes from raw inputs
 	logger.Info("Calculating expected balance changes based on transaction data")
@@ -295,15 +448,14 @@ func operatorFeeTestProcedure(t systest.T, sys system.System, l1FundingWallet sy
 		receipt.GasUsed,
 		l2EndHeader,
 		tx,
+		sender,
 	)
 	logger.Debug("Expected balance changes", "changes", expectedChanges)
 
-	// Calculate expected end balances using the new method
-	expectedEndBalances := startBalances.Add(expectedChanges)
-	expectedEndBalances.BlockNumber = l2EndHeader.Number
-	logger.Debug("Expected final balances", "balances", expectedEndBalances)
-
-	// Assert that actual end balances match what we calculated
-	logger.Info("Verifying actual balances match expected balances")
-	AssertSnapshotsEqual(t, expectedEndBalances, endBalances)
+	// Assert that actual balance changes match what we calculated
+	logger.Info("Verifying actual balance changes match expected balance changes")
+	if !checkVaults {
+		expectedChanges = map[common.Address]*big.Int{sender: expectedChanges[sender]}
+	}
+	fees.AssertBalanceChanges(t, startBalances, endBalances, expectedChanges)
 }
@@ -2,163 +2,136 @@ package operatorfee
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/systest"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/sysconfig"
 	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/log"
-	"github.com/lmittmann/w3"
 	"github.com/stretchr/testify/require"
 )
 
-func UpdateOperatorFeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfig *bindings.SystemConfig, systemConfigAddress common.Address, wallet system.Wallet, operatorFeeConstant uint64, operatorFeeScalar uint32, logger log.Logger) (*gethTypes.Transaction, *gethTypes.Receipt) {
+// UpdateOperatorFeeParams sets the operator fee parameters of the SystemConfig, and verifies that they were set.
+func UpdateOperatorFeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfigAddress common.Address, wallet system.Wallet, operatorFeeConstant uint64, operatorFeeScalar uint32) (*gethTypes.Transaction, *gethTypes.Receipt) {
 	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
 	logger.Info("Updating operator fee params",
 		"constant", operatorFeeConstant,
 		"scalar", operatorFeeScalar)
 
-	nonce, err := client.PendingNonceAt(ctx, wallet.Address())
+	admin, err := sysconfig.NewAdmin(l1ChainID, client, systemConfigAddress, wallet)
 	require.NoError(t, err)
-	logger.Debug("Using nonce",
-		"nonce", nonce,
-		"wallet", wallet.Address().Hex())
-
-	// Construct call input
-	logger.Debug("Constructing function call to setOperatorFeeScalars")
-	funcSetOperatorFeeScalars := w3.MustNewFunc(`setOperatorFeeScalars(uint32 _operatorFeeScalar, uint64 _operatorFeeConstant)`, "")
-	args, err := funcSetOperatorFeeScalars.EncodeArgs(
-		operatorFeeScalar,
-		operatorFeeConstant,
-	)
-	require.NoError(t, err)
-
-	// Calculate gas parameters
-	gasLimit, gasTipCap, gasFeeCap, err := CalculateGasParams(ctx, client, wallet.Address(), systemConfigAddress, big.NewInt(0), args)
-	if err != nil {
-		logger.Warn("Error calculating gas parameters", "error", err)
-	}
-
-	tx := gethTypes.NewTx(&gethTypes.DynamicFeeTx{
-		To:        &systemConfigAddress,
-		Gas:       gasLimit,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Nonce:     nonce,
-		Value:     big.NewInt(0),
-		Data:      args,
-	})
-	signer := gethTypes.NewLondonSigner(l1ChainID)
-	signedTx, err := gethTypes.SignTx(tx, signer, wallet.PrivateKey())
-	require.NoError(t, err)
-	logger.Debug("Transaction signed", "hash", signedTx.Hash().Hex())
-
-	logger.Info("Sending transaction to the network")
-	err = client.SendTransaction(context.Background(), signedTx)
-	require.NoError(t, err)
-
-	// Wait for transaction receipt with timeout
-	logger.Info("Waiting for transaction confirmation")
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
-	receipt, err := waitForTransaction(ctx, client, signedTx.Hash())
-	require.NoError(t, err, "Failed to wait for transaction receipt")
-	require.NotNil(t, receipt)
-	require.Equal(t, gethTypes.ReceiptStatusSuccessful, receipt.Status)
+	receipt, tx, err := admin.SetOperatorFeeScalars(ctx, operatorFeeScalar, operatorFeeConstant)
+	require.NoError(t, err, "Failed to update operator fee params")
 	logger.Info("Transaction confirmed",
+		"hash", tx.Hash().Hex(),
 		"block", receipt.BlockNumber,
 		"gasUsed", receipt.GasUsed)
 
-	// Verify the operator fee scalars were set correctly
-	RequireOperatorFeeParamValues(t, systemConfig, receipt.BlockNumber, operatorFeeConstant, operatorFeeScalar)
-
 	return tx, receipt
 }
 
-func RequireOperatorFeeParamValues(t systest.T, systemConfig *bindings.SystemConfig, blockNumber *big.Int, expectedOperatorFeeConstant uint64, expectedOperatorFeeScalar uint32) {
-	operatorFeeConstant, err := systemConfig.OperatorFeeConstant(&bind.CallOpts{BlockNumber: blockNumber})
-	require.NoError(t, err)
-	require.Equal(t, operatorFeeConstant, expectedOperatorFeeConstant, "operator fee constant should match expectations")
-
-	operatorFeeScalar, err := systemConfig.OperatorFeeScalar(&bind.CallOpts{BlockNumber: blockNumber})
-	require.NoError(t, err)
-	require.Equal(t, operatorFeeScalar, expectedOperatorFeeScalar, "operator fee scalar should match expectations")
-}
-
-func RequireL1FeeParamValues(t systest.T, systemConfig *bindings.SystemConfig, blockNumber *big.Int, expectedL1BaseFeeScalar uint32, expectedL1BlobBaseFeeScalar uint32) {
-	l1BaseFeeScalar, err := systemConfig.BasefeeScalar(&bind.CallOpts{BlockNumber: blockNumber})
-	require.NoError(t, err)
-	require.Equal(t, l1BaseFeeScalar, expectedL1BaseFeeScalar, "l1 base fee scalar should match expectations")
-
-	blobBaseFeeScalar, err := systemConfig.BlobbasefeeScalar(&bind.CallOpts{BlockNumber: blockNumber})
-	require.NoError(t, err)
-	require.Equal(t, blobBaseFeeScalar, expectedL1BlobBaseFeeScalar, "l1 blob base fee scalar should match expectations")
-}
-
-func UpdateL1FeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfig *bindings.SystemConfig, systemConfigAddress common.Address, wallet system.Wallet, l1BaseFeeScalar uint32, l1BlobBaseFeeScalar uint32, logger log.Logger) (*gethTypes.Transaction, *gethTypes.Receipt) {
+// UpdateL1FeeParams sets the L1 fee scalars of the SystemConfig, and verifies that they were set.
+func UpdateL1FeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfigAddress common.Address, wallet system.Wallet, l1BaseFeeScalar uint32, l1BlobBaseFeeScalar uint32) (*gethTypes.Transaction, *gethTypes.Receipt) {
 	ctx := t.Context()
+	logger := systest.LoggerFrom(ctx)
 	logger.Info("Updating L1 fee params",
 		"base fee scalar", l1BaseFeeScalar,
 		"blob base fee scalar", l1BlobBaseFeeScalar)
 
-	nonce, err := client.PendingNonceAt(ctx, wallet.Address())
-	require.NoError(t, err)
-	logger.Debug("Using nonce",
-		"nonce", nonce,
-		"wallet", wallet.Address().Hex())
-
-	// Construct call input
-	logger.Debug("Constructing function call to setGasConfigEcotone")
-	funcSetGasConfigEcotone := w3.MustNewFunc(`setGasConfigEcotone(uint32 _basefeeScalar, uint32 _blobbasefeeScalar)`, "")
-	args, err := funcSetGasConfigEcotone.EncodeArgs(
-		l1BaseFeeScalar,
-		l1BlobBaseFeeScalar,
-	)
+	admin, err := sysconfig.NewAdmin(l1ChainID, client, systemConfigAddress, wallet)
 	require.NoError(t, err)
+	receipt, tx, err := admin.SetGasConfigEcotone(ctx, l1BaseFeeScalar, l1BlobBaseFeeScalar)
+	require.NoError(t, err, "Failed to update L1 fee params")
+	logger.Info("Transaction confirmed",
+		"hash", tx.Hash().Hex(),
+		"block", receipt.BlockNumber,
+		"gasUsed", receipt.GasUsed)
 
-	// Calculate gas parameters
-	gasLimit, gasTipCap, gasFeeCap, err := CalculateGasParams(ctx, client, wallet.Address(), systemConfigAddress, big.NewInt(0), args)
-	if err != nil {
-		logger.Warn("Error calculating gas parameters", "error", err)
-	}
+	return tx, receipt
+}
 
-	tx := gethTypes.NewTx(&gethTypes.DynamicFeeTx{
-		To:        &systemConfigAddress,
-		Gas:       gasLimit,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Nonce:     nonce,
-		Value:     big.NewInt(0),
-		Data:      args,
-	})
-	signer := gethTypes.NewLondonSigner(l1ChainID)
-	signedTx, err := gethTypes.SignTx(tx, signer, wallet.PrivateKey())
-	require.NoError(t, err)
-	logger.Debug("Transaction signed", "hash", signedTx.Hash().Hex())
+// restoreFeeParamsTimeout is how long restoring the fee parameters of the SystemConfig may take, once the test is done.
+const restoreFeeParamsTimeout = 3 * time.Minute
 
-	logger.Info("Sending transaction to the network")
-	err = client.SendTransaction(context.Background(), signedTx)
-	require.NoError(t, err)
+// FeeParams are the fee parameters of a SystemConfig.
+type FeeParams struct {
+	OperatorFeeConstant uint64
+	OperatorFeeScalar   uint32
+	L1BaseFeeScalar     uint32
+	L1BlobBaseFeeScalar uint32
+}
 
-	// Wait for transaction receipt with timeout
-	logger.Info("Waiting for transaction confirmation")
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
-	receipt, err := waitForTransaction(ctx, client, signedTx.Hash())
-	require.NoError(t, err, "Failed to wait for transaction receipt")
-	require.NotNil(t, receipt)
-	require.Equal(t, gethTypes.ReceiptStatusSuccessful, receipt.Status)
-	logger.Info("Transaction confirmed",
-		"block", receipt.BlockNumber,
-		"gasUsed", receipt.GasUsed)
+// ReadFeeParams reads the fee parameters of the SystemConfig at the block, or at the latest block if it is nil.
+func ReadFeeParams(ctx context.Context, systemConfig *bindings.SystemConfig, blockNumber *big.Int) (FeeParams, error) {
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: blockNumber}
+	var params FeeParams
+	var err error
+	if params.OperatorFeeConstant, err = systemConfig.OperatorFeeConstant(opts); err != nil {
+		return FeeParams{}, fmt.Errorf("failed to read operator fee constant: %w", err)
+	}
+	if params.OperatorFeeScalar, err = systemConfig.OperatorFeeScalar(opts); err != nil {
+		return FeeParams{}, fmt.Errorf("failed to read operator fee scalar: %w", err)
+	}
+	if params.L1BaseFeeScalar, err = systemConfig.BasefeeScalar(opts); err != nil {
+		return FeeParams{}, fmt.Errorf("failed to read l1 base fee scalar: %w", err)
+	}
+	if params.L1BlobBaseFeeScalar, err = systemConfig.BlobbasefeeScalar(opts); err != nil {
+		return FeeParams{}, fmt.Errorf("failed to read l1 blob base fee scalar: %w", err)
+	}
+	return params, nil
+}
 
-	// Verify the operator fee scalars were set correctly
-	RequireL1FeeParamValues(t, systemConfig, receipt.BlockNumber, l1BaseFeeScalar, l1BlobBaseFeeScalar)
+// RestoreFeeParams captures the current fee parameters of the SystemConfig, and registers a cleanup that restores
+// them once the test is done, and verifies that they were restored, so that tests leave shared devnets as they found them.
+// It must be registered after the wallet is funded, for the cleanup to run before the funds of the wallet are returned.
+func RestoreFeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfig *bindings.SystemConfig, systemConfigAddress common.Address, wallet system.Wallet) FeeParams {
+	t.Helper()
+	original, err := ReadFeeParams(t.Context(), systemConfig, nil)
+	require.NoError(t, err, "failed to capture fee params")
+	systest.LoggerFrom(t.Context()).Info("Captured original fee params", "params", original)
+
+	// the context of the test may be done by the time cleanups run
+	ctx := context.WithoutCancel(t.Context())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(ctx, restoreFeeParamsTimeout)
+		defer cancel()
+		t := t.WithContext(ctx)
+		logger := systest.LoggerFrom(ctx)
+
+		logger.Info("Restoring original fee params", "params", original)
+		if !ApplyFeeParams(t, l1ChainID, client, systemConfig, systemConfigAddress, wallet, original) {
+			logger.Info("Fee params are unchanged, nothing to restore")
+			return
+		}
+
+		restored, err := ReadFeeParams(ctx, systemConfig, nil)
+		require.NoError(t, err, "failed to read restored fee params")
+		require.Equal(t, original, restored, "fee params should be restored")
+	})
+	return original
+}
 
-	return tx, receipt
+// ApplyFeeParams sets the fee parameters of the SystemConfig, with only the updates of the parameters that changed,
+// so that parameter sets which share values take fewer L1 transactions. It returns whether any parameter changed.
+func ApplyFeeParams(t systest.T, l1ChainID *big.Int, client *ethclient.Client, systemConfig *bindings.SystemConfig, systemConfigAddress common.Address, wallet system.Wallet, params FeeParams) bool {
+	t.Helper()
+	current, err := ReadFeeParams(t.Context(), systemConfig, nil)
+	require.NoError(t, err, "failed to read current fee params")
+	if current == params {
+		return false
+	}
+	if current.OperatorFeeConstant != params.OperatorFeeConstant || current.OperatorFeeScalar != params.OperatorFeeScalar {
+		UpdateOperatorFeeParams(t, l1ChainID, client, systemConfigAddress, wallet, params.OperatorFeeConstant, params.OperatorFeeScalar)
+	}
+	if current.L1BaseFeeScalar != params.L1BaseFeeScalar || current.L1BlobBaseFeeScalar != params.L1BlobBaseFeeScalar {
+		UpdateL1FeeParams(t, l1ChainID, client, systemConfigAddress, wallet, params.L1BaseFeeScalar, params.L1BlobBaseFeeScalar)
+	}
+	return true
 }
@@ -2,19 +2,140 @@ package operatorfee
 
 import (
 	cryptoRand "crypto/rand"
+	"flag"
 	"fmt"
 	"math"
 	"math/big"
 	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
-// init seeds the global PRNG using the current time.
-var seededRand *rand.Rand // Added package-level generator
+// Environment variables of the generation of test cases. The flags of the same purpose take precedence over them.
+const (
+	// SeedVar is the environment variable of the seed of the generated test cases, which defaults to the current time.
+	SeedVar = "OPERATOR_FEE_SEED"
+	// StrategyVar is the environment variable of the GenerationStrategy of the generated test cases.
+	StrategyVar = "OPERATOR_FEE_STRATEGY"
+	// CasesVar is the environment variable of the comma-separated IDs of the test cases to run, which defaults to all.
+	CasesVar = "OPERATOR_FEE_CASES"
+	// ScheduleVar is the environment variable of the Schedule of the test cases.
+	ScheduleVar = "OPERATOR_FEE_SCHEDULE"
+)
+
+var (
+	seedFlag     = flag.String("operator-fee.seed", "", "seed of the generated operator fee test cases, overrides "+SeedVar)
+	strategyFlag = flag.String("operator-fee.case-strategy", "", "strategy of the generated operator fee test cases, overrides "+StrategyVar)
+	runCaseFlag  = flag.String("operator-fee.run-case", "", "comma-separated IDs of the operator fee test cases to run, overrides "+CasesVar)
+	scheduleFlag = flag.String("operator-fee.schedule", "", "schedule of the operator fee test cases, overrides "+ScheduleVar)
+)
+
+var (
+	seedOnce   sync.Once
+	seed       int64
+	seedErr    error
+	seededRand *rand.Rand
+)
+
+// Seed returns the seed of the generated test cases, from the -operator-fee.seed flag or SeedVar, or else the current time.
+// Tests should log it, so that failures of generated test cases can be reproduced.
+// Flags are only parsed once tests run, so the seed is resolved on first use.
+func Seed() (int64, error) {
+	seedOnce.Do(func() {
+		seed = time.Now().UTC().UnixNano()
+		if value := flagOrEnv(*seedFlag, SeedVar); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				seedErr = fmt.Errorf("invalid seed %q: %w", value, err)
+			} else {
+				seed = parsed
+			}
+		}
+		seededRand = rand.New(rand.NewSource(seed))
+	})
+	return seed, seedErr
+}
+
+func rng() *rand.Rand {
+	_, _ = Seed()
+	return seededRand
+}
+
+func flagOrEnv(flagValue string, envVar string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(envVar)
+}
+
+// GenerationStrategy is how the values of generated test cases are drawn.
+type GenerationStrategy string
+
+const (
+	// StrategyUniform draws values uniformly, so most of them are close to the maximum.
+	StrategyUniform GenerationStrategy = "uniform"
+	// StrategyWeighted draws values log-uniformly, so values of all magnitudes are equally likely.
+	StrategyWeighted GenerationStrategy = "weighted"
+	// StrategyBoundary draws values at the boundaries of powers of two, where overflows and rounding errors are likely.
+	StrategyBoundary GenerationStrategy = "boundary"
+)
+
+// SelectedStrategy returns the strategy of the -operator-fee.case-strategy flag or StrategyVar, which defaults to StrategyUniform.
+func SelectedStrategy() (GenerationStrategy, error) {
+	switch strategy := GenerationStrategy(flagOrEnv(*strategyFlag, StrategyVar)); strategy {
+	case "":
+		return StrategyUniform, nil
+	case StrategyUniform, StrategyWeighted, StrategyBoundary:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown generation strategy %q", strategy)
+	}
+}
+
+// value draws a value of the bit width with the strategy.
+func (s GenerationStrategy) value(r *rand.Rand, bits int) uint64 {
+	switch s {
+	case StrategyWeighted:
+		n := r.Intn(bits + 1)
+		if n == 0 {
+			return 0
+		}
+		// a value with exactly n bits
+		return r.Uint64()>>(64-n) | 1<<(n-1)
+	case StrategyBoundary:
+		n := r.Intn(bits) + 1
+		mask := ^uint64(0) >> (64 - n)
+		pow := uint64(1) << (n - 1)
+		return []uint64{mask, pow, min(pow+1, mask)}[r.Intn(3)]
+	default:
+		return r.Uint64() >> (64 - bits)
+	}
+}
+
+// Schedule is how the test cases are run.
+type Schedule string
+
+const (
+	// ScheduleSequential runs the test cases one after another, each with its own fee parameters.
+	ScheduleSequential Schedule = "sequential"
+	// ScheduleParallel applies the operator fee parameters once for the test cases that share them,
+	// and runs the transaction shapes of each test case in parallel.
+	ScheduleParallel Schedule = "parallel"
+)
 
-func init() {
-	// rand.Seed(time.Now().UTC().UnixNano()) // Deprecated
-	seededRand = rand.New(rand.NewSource(time.Now().UTC().UnixNano())) // Use new generator
+// SelectedSchedule returns the schedule of the -operator-fee.schedule flag or ScheduleVar, which defaults to ScheduleSequential.
+func SelectedSchedule() (Schedule, error) {
+	switch schedule := Schedule(flagOrEnv(*scheduleFlag, ScheduleVar)); schedule {
+	case "":
+		return ScheduleSequential, nil
+	case ScheduleSequential, ScheduleParallel:
+		return schedule, nil
+	default:
+		return "", fmt.Errorf("unknown schedule %q", schedule)
+	}
 }
 
 type TestParams struct {
@@ -25,7 +146,48 @@ type TestParams struct {
 	L1BlobBaseFeeScalar uint32
 }
 
-func GenerateAllTestParamsCases(numGeneratedValues int) []TestParams {
+// FeeParams returns the fee parameters of the SystemConfig that the test case runs with.
+func (tc TestParams) FeeParams() FeeParams {
+	return FeeParams{
+		OperatorFeeConstant: tc.OperatorFeeConstant,
+		OperatorFeeScalar:   tc.OperatorFeeScalar,
+		L1BaseFeeScalar:     tc.L1BaseFeeScalar,
+		L1BlobBaseFeeScalar: tc.L1BlobBaseFeeScalar,
+	}
+}
+
+// TestParamsGroup are test cases that share operator fee parameters. The operator fee parameters are applied once,
+// and the test cases of the group only update the L1 fee scalars, which take effect with the next L1 block.
+type TestParamsGroup struct {
+	OperatorFeeScalar   uint32
+	OperatorFeeConstant uint64
+	Cases               []TestParams
+}
+
+// GroupTestParamsCases groups the test cases by their operator fee parameters, in the order the parameters first appear.
+func GroupTestParamsCases(cases []TestParams) []TestParamsGroup {
+	type operatorFeeParams struct {
+		scalar   uint32
+		constant uint64
+	}
+	var groups []TestParamsGroup
+	index := make(map[operatorFeeParams]int)
+	for _, tc := range cases {
+		key := operatorFeeParams{tc.OperatorFeeScalar, tc.OperatorFeeConstant}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, TestParamsGroup{OperatorFeeScalar: tc.OperatorFeeScalar, OperatorFeeConstant: tc.OperatorFeeConstant})
+		}
+		groups[i].Cases = append(groups[i].Cases, tc)
+	}
+	return groups
+}
+
+// GenerateAllTestParamsCases generates the test cases of all combinations of the specific edge case values,
+// and of values generated with the strategy.
+func GenerateAllTestParamsCases(numGeneratedValues int, strategy GenerationStrategy) []TestParams {
 	// Specific values for testing edge cases
 	operatorFeeScalarSpecificValues := []uint32{0, math.MaxUint32}
 	operatorFeeConstantSpecificValues := []uint64{0, math.MaxUint64}
@@ -33,10 +195,10 @@ func GenerateAllTestParamsCases(numGeneratedValues int) []TestParams {
 	l1BlobBaseFeeScalarSpecificValues := []uint32{0, math.MaxUint32}
 
 	// Generate random values for broader test coverage
-	operatorFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues)
-	operatorFeeConstantGeneratedValues := GenerateUint64s(numGeneratedValues)
-	l1BaseFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues)
-	l1BlobBaseFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues)
+	operatorFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues, strategy)
+	operatorFeeConstantGeneratedValues := GenerateUint64s(numGeneratedValues, strategy)
+	l1BaseFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues, strategy)
+	l1BlobBaseFeeScalarGeneratedValues := GenerateUint32s(numGeneratedValues, strategy)
 
 	specificValues := GenerateTestParamsCases(
 		"specific",
@@ -82,20 +244,51 @@ func GenerateTestParamsCases(
 	return results
 }
 
-func GenerateUint64s(n int) []uint64 {
+func GenerateUint64s(n int, strategy GenerationStrategy) []uint64 {
 	results := make([]uint64, n)
 	for i := 0; i < n; i++ {
-		// results[i] = rand.Uint64() // Use package generator
-		results[i] = seededRand.Uint64()
+		results[i] = strategy.value(rng(), 64)
 	}
 	return results
 }
 
-func GenerateUint32s(n int) []uint32 {
+func GenerateUint32s(n int, strategy GenerationStrategy) []uint32 {
 	results := make([]uint32, n)
 	for i := 0; i < n; i++ {
-		// results[i] = rand.Uint32() // Use package generator
-		results[i] = seededRand.Uint32()
+		results[i] = uint32(strategy.value(rng(), 32))
+	}
+	return results
+}
+
+// SelectedCaseIDs returns the IDs of the test cases to run, from the -operator-fee.run-case flag or CasesVar, or nil to run all.
+func SelectedCaseIDs() []string {
+	value := flagOrEnv(*runCaseFlag, CasesVar)
+	if value == "" {
+		return nil
+	}
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// FilterTestParamsCases returns the test cases with the IDs, or all test cases if there are no IDs.
+func FilterTestParamsCases(cases []TestParams, ids []string) []TestParams {
+	if len(ids) == 0 {
+		return cases
+	}
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+	var results []TestParams
+	for _, tc := range cases {
+		if selected[tc.ID] {
+			results = append(results, tc)
+		}
 	}
 	return results
 }
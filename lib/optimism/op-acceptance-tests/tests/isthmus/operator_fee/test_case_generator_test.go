@@ -0,0 +1,138 @@
+package operatorfee
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationStrategies(t *testing.T) {
+	t.Run("same seed generates same values", func(t *testing.T) {
+		for _, strategy := range []GenerationStrategy{StrategyUniform, StrategyWeighted, StrategyBoundary} {
+			r1, r2 := rand.New(rand.NewSource(42)), rand.New(rand.NewSource(42))
+			for i := 0; i < 100; i++ {
+				require.Equal(t, strategy.value(r1, 64), strategy.value(r2, 64), "strategy %s", strategy)
+			}
+		}
+	})
+
+	t.Run("values fit the bit width", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, strategy := range []GenerationStrategy{StrategyUniform, StrategyWeighted, StrategyBoundary} {
+			for i := 0; i < 1000; i++ {
+				assert.LessOrEqual(t, strategy.value(r, 32), uint64(math.MaxUint32), "strategy %s", strategy)
+			}
+		}
+	})
+
+	t.Run("weighted values cover all magnitudes", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		small := 0
+		for i := 0; i < 1000; i++ {
+			if bits.Len64(StrategyWeighted.value(r, 64)) <= 32 {
+				small++
+			}
+		}
+		// uniform values are practically never this small
+		assert.Greater(t, small, 300)
+	})
+
+	t.Run("boundary values are at powers of two", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			v := StrategyBoundary.value(r, 64)
+			isPow := v&(v-1) == 0
+			isMask := v&(v+1) == 0
+			isPowPlusOne := (v-1)&(v-2) == 0
+			assert.True(t, isPow || isMask || isPowPlusOne, "value %d is not at a boundary", v)
+		}
+	})
+}
+
+func TestFilterTestParamsCases(t *testing.T) {
+	cases := GenerateAllTestParamsCases(1, StrategyBoundary)
+	require.NotEmpty(t, cases)
+
+	assert.Equal(t, cases, FilterTestParamsCases(cases, nil), "all cases are selected without IDs")
+
+	selected := FilterTestParamsCases(cases, []string{"specific_case_3", "generated_case_0", "unknown"})
+	require.Len(t, selected, 2)
+	assert.Equal(t, "specific_case_3", selected[0].ID)
+	assert.Equal(t, "generated_case_0", selected[1].ID)
+}
+
+func TestSelectedCaseIDs(t *testing.T) {
+	t.Setenv(CasesVar, "specific_case_3, generated_case_0,")
+	assert.Equal(t, []string{"specific_case_3", "generated_case_0"}, SelectedCaseIDs())
+}
+
+func TestSelectedStrategy(t *testing.T) {
+	t.Setenv(StrategyVar, "")
+	strategy, err := SelectedStrategy()
+	require.NoError(t, err)
+	assert.Equal(t, StrategyUniform, strategy)
+
+	t.Setenv(StrategyVar, string(StrategyBoundary))
+	strategy, err = SelectedStrategy()
+	require.NoError(t, err)
+	assert.Equal(t, StrategyBoundary, strategy)
+
+	t.Setenv(StrategyVar, "unknown")
+	_, err = SelectedStrategy()
+	require.Error(t, err)
+}
+
+func TestSelectedSchedule(t *testing.T) {
+	t.Setenv(ScheduleVar, "")
+	schedule, err := SelectedSchedule()
+	require.NoError(t, err)
+	assert.Equal(t, ScheduleSequential, schedule)
+
+	t.Setenv(ScheduleVar, string(ScheduleParallel))
+	schedule, err = SelectedSchedule()
+	require.NoError(t, err)
+	assert.Equal(t, ScheduleParallel, schedule)
+
+	t.Setenv(ScheduleVar, "unknown")
+	_, err = SelectedSchedule()
+	require.Error(t, err)
+}
+
+func TestGroupTestParamsCases(t *testing.T) {
+	cases := []TestParams{
+		{ID: "a", OperatorFeeScalar: 1, OperatorFeeConstant: 2},
+		{ID: "b", OperatorFeeScalar: 3},
+		{ID: "c", OperatorFeeScalar: 1, OperatorFeeConstant: 2, L1BaseFeeScalar: 5},
+		{ID: "d", L1BaseFeeScalar: 4},
+	}
+	groups := GroupTestParamsCases(cases)
+	require.Len(t, groups, 3)
+	assert.Equal(t, uint32(1), groups[0].OperatorFeeScalar)
+	assert.Equal(t, uint64(2), groups[0].OperatorFeeConstant)
+	assert.Equal(t, []TestParams{cases[0], cases[2]}, groups[0].Cases, "cases that only differ in L1 fee scalars share a group")
+	assert.Equal(t, []TestParams{cases[1]}, groups[1].Cases)
+	assert.Equal(t, []TestParams{cases[3]}, groups[2].Cases)
+
+	// every combination of two values of each parameter has four combinations of operator fee parameters
+	specific := GenerateTestParamsCases("specific", []uint32{0, math.MaxUint32}, []uint64{0, math.MaxUint64}, []uint32{0, math.MaxUint32}, []uint32{0, math.MaxUint32})
+	require.Len(t, specific, 16)
+	require.Len(t, GroupTestParamsCases(specific), 4)
+
+	all := GenerateAllTestParamsCases(2, StrategyBoundary)
+	require.Len(t, all, 32)
+	groups = GroupTestParamsCases(all)
+	assert.LessOrEqual(t, len(groups), 8, "groups should share the operator fee parameters of several cases")
+	total := 0
+	for _, group := range groups {
+		for _, tc := range group.Cases {
+			assert.Equal(t, group.OperatorFeeScalar, tc.OperatorFeeScalar, "case %s", tc.ID)
+			assert.Equal(t, group.OperatorFeeConstant, tc.OperatorFeeConstant, "case %s", tc.ID)
+			total++
+		}
+	}
+	assert.Equal(t, len(all), total)
+}
@@ -0,0 +1,174 @@
+package operatorfee
+
+import (
+	"context"
+	cryptoRand "crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/system"
+	"github.com/ethereum-optimism/optimism/devnet-sdk/testing/testlib/txutils"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// DepositTimeout is how long to wait for a deposit to be included on L2, after it is included on L1.
+const DepositTimeout = 5 * time.Minute
+
+// depositGasLimit is the L2 gas limit of deposits, which is bought on L1.
+const depositGasLimit = 100_000
+
+// calldataSize is the size of the calldata of calldata-heavy transactions.
+const calldataSize = 4096
+
+// deploymentCode is the init code of a contract whose runtime code is a single STOP.
+var deploymentCode = common.FromHex("0x60016000f3")
+
+// TxShape is a shape of transaction that fees are verified for.
+type TxShape string
+
+const (
+	// TxShapeValueTransfer is a plain value transfer between two accounts.
+	TxShapeValueTransfer TxShape = "value_transfer"
+	// TxShapeContractDeployment is a transaction that deploys a contract.
+	TxShapeContractDeployment TxShape = "contract_deployment"
+	// TxShapeCalldataHeavy is a call with kilobytes of calldata, which dominates its L1 fee.
+	TxShapeCalldataHeavy TxShape = "calldata_heavy"
+	// TxShapeDeposit is a deposit through the OptimismPortal on L1, which is charged no fees on L2.
+	TxShapeDeposit TxShape = "deposit"
+	// TxShapeBlobL1 is a value transfer after a blob-carrying transaction on L1, which moves the blob base fee of the L1 fee.
+	TxShapeBlobL1 TxShape = "blob_l1"
+)
+
+// AllTxShapes returns all shapes of transactions.
+func AllTxShapes() []TxShape {
+	return []TxShape{
+		TxShapeValueTransfer,
+		TxShapeContractDeployment,
+		TxShapeCalldataHeavy,
+		TxShapeDeposit,
+		TxShapeBlobL1,
+	}
+}
+
+// TxShapeEnv is what transactions of all shapes are sent with.
+type TxShapeEnv struct {
+	L1ChainID *big.Int
+	L1Client  *ethclient.Client
+	// L1Wallet sends the deposits and the blob-carrying transactions on L1.
+	L1Wallet system.Wallet
+	Portal   *bindings.OptimismPortal
+
+	L2ChainID *big.Int
+	L2Client  *ethclient.Client
+	// From sends the transactions on L2, to To.
+	From system.Wallet
+	To   common.Address
+}
+
+// Sender returns the account on L2 that is charged for transactions of the shape.
+func (e *TxShapeEnv) Sender(shape TxShape) common.Address {
+	if shape == TxShapeDeposit {
+		// Deposits of accounts, unlike contracts, are not aliased
+		return e.L1Wallet.Address()
+	}
+	return e.From.Address()
+}
+
+// SendShapedTx sends a transaction of the shape, and waits for it to succeed on L2.
+// It returns the receipt and the transaction on L2.
+func SendShapedTx(ctx context.Context, env *TxShapeEnv, shape TxShape) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	switch shape {
+	case TxShapeValueTransfer:
+		return txutils.SendTx(ctx, env.L2ChainID, env.L2Client, env.From, &env.To, big.NewInt(1000), nil)
+	case TxShapeContractDeployment:
+		return txutils.SendTx(ctx, env.L2ChainID, env.L2Client, env.From, nil, big.NewInt(0), deploymentCode)
+	case TxShapeCalldataHeavy:
+		data, err := heavyCalldata(calldataSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		return txutils.SendTx(ctx, env.L2ChainID, env.L2Client, env.From, &env.To, big.NewInt(0), data)
+	case TxShapeDeposit:
+		return SendDepositTx(ctx, env, env.To, big.NewInt(1000))
+	case TxShapeBlobL1:
+		data := make(eth.Data, calldataSize)
+		if _, err := cryptoRand.Read(data); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate blob data: %w", err)
+		}
+		if _, _, err := txutils.SendBlobTx(ctx, env.L1ChainID, env.L1Client, env.L1Wallet, data); err != nil {
+			return nil, nil, fmt.Errorf("failed to send blob transaction: %w", err)
+		}
+		return txutils.SendTx(ctx, env.L2ChainID, env.L2Client, env.From, &env.To, big.NewInt(1000), nil)
+	default:
+		return nil, nil, fmt.Errorf("unknown transaction shape %q", shape)
+	}
+}
+
+// heavyCalldata returns calldata of the size, with random bytes in the first half and zero bytes in the second,
+// so that both the zero and non-zero bytes of the L1 fee are covered.
+func heavyCalldata(size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := cryptoRand.Read(data[:size/2]); err != nil {
+		return nil, fmt.Errorf("failed to generate calldata: %w", err)
+	}
+	return data, nil
+}
+
+// SendDepositTx deposits the value to the account on L2 through the OptimismPortal, minting it from L1,
+// and waits for the deposit to be included on L2. It returns the receipt and the deposit transaction on L2.
+func SendDepositTx(ctx context.Context, env *TxShapeEnv, to common.Address, value *big.Int) (*gethTypes.Receipt, *gethTypes.Transaction, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(env.L1Wallet.PrivateKey(), env.L1ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+	opts.Context = ctx
+	opts.Value = value
+	l1Tx, err := env.Portal.DepositTransaction(opts, to, value, depositGasLimit, false, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send deposit: %w", err)
+	}
+
+	l1Receipt, err := txutils.Confirm(ctx, env.L1Client, l1Tx.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("deposit failed on L1: %w", err)
+	}
+
+	deposit, err := depositFromReceipt(l1Receipt)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx := gethTypes.NewTx(deposit)
+
+	l2Ctx, cancel := context.WithTimeout(ctx, DepositTimeout)
+	defer cancel()
+	receipt, err := txutils.WaitForTransaction(l2Ctx, env.L2Client, tx.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wait for deposit on L2: %w", err)
+	}
+	if receipt.Status != gethTypes.ReceiptStatusSuccessful {
+		return nil, nil, fmt.Errorf("expected successful deposit on L2 (1), instead got status: %d", receipt.Status)
+	}
+	return receipt, tx, nil
+}
+
+// depositFromReceipt returns the deposit of the first deposit event in the receipt.
+func depositFromReceipt(receipt *gethTypes.Receipt) (*gethTypes.DepositTx, error) {
+	for _, l := range receipt.Logs {
+		if len(l.Topics) == 0 || l.Topics[0] != derive.DepositEventABIHash {
+			continue
+		}
+		deposit, err := derive.UnmarshalDepositLogEvent(l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode deposit event: %w", err)
+		}
+		return deposit, nil
+	}
+	return nil, fmt.Errorf("no deposit event in receipt of transaction %s", receipt.TxHash)
+}
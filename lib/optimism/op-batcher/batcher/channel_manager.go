@@ -360,6 +360,26 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	return nil
 }
 
+// Flush closes the current channel and outputs all of its frames, so the blocks
+// added to it are submitted without waiting for the channel to fill up or time out.
+// Blocks that have not been added to a channel yet are not affected.
+// It returns the latest L2 block of the queued channels, which is submitted once the flushed frames are,
+// or the zero block ID if no channel is queued.
+func (s *channelManager) Flush() (eth.BlockID, error) {
+	if s.currentChannel != nil && !s.currentChannel.IsFull() && s.currentChannel.InputBytes() > 0 {
+		s.currentChannel.Close()
+		if err := s.outputFrames(); err != nil {
+			return eth.BlockID{}, err
+		}
+	}
+	for i := len(s.channelQueue) - 1; i >= 0; i-- {
+		if latest := s.channelQueue[i].LatestL2(); latest != (eth.BlockID{}) {
+			return latest, nil
+		}
+	}
+	return eth.BlockID{}, nil
+}
+
 // registerL1Block registers the given block at the current channel.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
 	s.currentChannel.CheckTimeout(l1Head.Number)
@@ -648,6 +648,31 @@ func TestChannelManager_PruneChannels(t *testing.T) {
 	}
 }
 
+func TestChannelManager_Flush(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	// nothing to flush yet
+	latest, err := m.Flush()
+	require.NoError(t, err)
+	require.Equal(t, eth.BlockID{}, latest)
+
+	require.NoError(t, m.AddL2Block(newMiniL2Block(0)))
+	// the block is added to a channel, but the channel is far from full
+	_, err = m.TxData(eth.BlockID{}, false)
+	require.ErrorIs(t, err, io.EOF)
+
+	latest, err = m.Flush()
+	require.NoError(t, err)
+	require.Equal(t, m.currentChannel.LatestL2(), latest)
+	require.Equal(t, uint64(0), latest.Number)
+	require.ErrorIs(t, m.currentChannel.FullErr(), ErrTerminated)
+	_, err = m.TxData(eth.BlockID{}, false)
+	require.NoError(t, err)
+}
+
 func TestChannelManager_ChannelOutFactory(t *testing.T) {
 	type ChannelOutWrapper struct {
 		derive.ChannelOut
@@ -247,6 +247,23 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// Flush closes the channel that is currently being filled, so the blocks loaded into it
+// are submitted in the next publishing round, without waiting for the channel to fill up.
+// It returns the latest L2 block that is pending submission, or the zero block ID if there is none.
+func (l *BatchSubmitter) Flush() (eth.BlockID, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.running {
+		return eth.BlockID{}, ErrBatcherNotRunning
+	}
+
+	l.Log.Info("Flushing Batch Submitter")
+	l.channelMgrMutex.Lock()
+	defer l.channelMgrMutex.Unlock()
+	return l.channelMgr.Flush()
+}
+
 // loadBlocksIntoState loads the blocks between start and end (inclusive).
 // If there is a reorg, it will return an error.
 func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context, start, end uint64) error {
@@ -7,12 +7,14 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	Flush() (eth.BlockID, error)
 }
 
 type adminAPI struct {
@@ -43,3 +45,7 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+func (a *adminAPI) FlushBatcher(_ context.Context) (eth.BlockID, error) {
+	return a.b.Flush()
+}
@@ -111,6 +111,11 @@ func (s *L2Sequencer) ActL2StartBlock(t Testing) {
 	s.l2Building = true
 }
 
+// IsBuilding returns whether the sequencer is currently building an L2 block
+func (s *L2Sequencer) IsBuilding() bool {
+	return s.l2Building
+}
+
 // ActL2EndBlock completes a new L2 block and applies it to the L2 chain as new canonical unsafe head
 func (s *L2Sequencer) ActL2EndBlock(t Testing) {
 	if !s.l2Building {
@@ -208,44 +213,9 @@ func (s *L2Sequencer) ActBuildL2ToTime(t Testing, target uint64) {
 	}
 }
 
-func (s *L2Sequencer) ActBuildL2ToCanyon(t Testing) {
-	require.NotNil(t, s.RollupCfg.CanyonTime, "cannot activate CanyonTime when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.CanyonTime {
-		s.ActL2EmptyBlock(t)
-	}
-}
-
-func (s *L2Sequencer) ActBuildL2ToEcotone(t Testing) {
-	require.NotNil(t, s.RollupCfg.EcotoneTime, "cannot activate Ecotone when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.EcotoneTime {
-		s.ActL2EmptyBlock(t)
-	}
-}
-
-func (s *L2Sequencer) ActBuildL2ToFjord(t Testing) {
-	require.NotNil(t, s.RollupCfg.FjordTime, "cannot activate FjordTime when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.FjordTime {
-		s.ActL2EmptyBlock(t)
-	}
-}
-
-func (s *L2Sequencer) ActBuildL2ToGranite(t Testing) {
-	require.NotNil(t, s.RollupCfg.GraniteTime, "cannot activate GraniteTime when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.GraniteTime {
-		s.ActL2EmptyBlock(t)
-	}
-}
-
-func (s *L2Sequencer) ActBuildL2ToHolocene(t Testing) {
-	require.NotNil(t, s.RollupCfg.HoloceneTime, "cannot activate HoloceneTime when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.HoloceneTime {
-		s.ActL2EmptyBlock(t)
-	}
-}
-
-func (s *L2Sequencer) ActBuildL2ToIsthmus(t Testing) {
-	require.NotNil(t, s.RollupCfg.IsthmusTime, "cannot activate IsthmusTime when it is not scheduled")
-	for s.L2Unsafe().Time < *s.RollupCfg.IsthmusTime {
-		s.ActL2EmptyBlock(t)
-	}
+// ActBuildL2ToFork builds empty blocks until the fork is active
+func (s *L2Sequencer) ActBuildL2ToFork(t Testing, fork rollup.ForkName) {
+	activation := s.RollupCfg.ActivationTime(fork)
+	require.NotNil(t, activation, "cannot activate %s when it is not scheduled", fork)
+	s.ActBuildL2ToTime(t, *activation)
 }
@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// WithdrawalsRootMode is what the withdrawals root of an L2 block header is expected to be.
+type WithdrawalsRootMode int
+
+const (
+	// WithdrawalsRootPreCanyon expects no withdrawals root, as blocks before Canyon have no withdrawals.
+	WithdrawalsRootPreCanyon WithdrawalsRootMode = iota
+	// WithdrawalsRootPreIsthmus expects the root of the empty withdrawals list, from Canyon until Isthmus.
+	WithdrawalsRootPreIsthmus
+	// WithdrawalsRootIsthmusEmpty expects the root of an empty storage trie, from Isthmus on,
+	// while the L2ToL1MessagePasser holds no withdrawals.
+	WithdrawalsRootIsthmusEmpty
+	// WithdrawalsRootIsthmus expects the storage root of the L2ToL1MessagePasser at the block, from Isthmus on.
+	WithdrawalsRootIsthmus
+)
+
+func (m WithdrawalsRootMode) String() string {
+	switch m {
+	case WithdrawalsRootPreCanyon:
+		return "pre-canyon"
+	case WithdrawalsRootPreIsthmus:
+		return "pre-isthmus"
+	case WithdrawalsRootIsthmusEmpty:
+		return "isthmus-empty"
+	case WithdrawalsRootIsthmus:
+		return "isthmus"
+	default:
+		return "unknown"
+	}
+}
+
+// VerifyWithdrawalsRoot verifies the withdrawals root of the L2 block header against the mode. From Isthmus on,
+// the root commits to the storage of the L2ToL1MessagePasser, which is read with eth_getProof from the rpc at the block.
+func VerifyWithdrawalsRoot(t Testing, rpc client.RPC, header *types.Header, mode WithdrawalsRootMode) {
+	switch mode {
+	case WithdrawalsRootPreCanyon:
+		require.Nil(t, header.WithdrawalsHash, "withdrawals root must be nil before Canyon")
+	case WithdrawalsRootPreIsthmus, WithdrawalsRootIsthmusEmpty:
+		require.NotNil(t, header.WithdrawalsHash, "withdrawals root must be set from Canyon")
+		require.Equal(t, types.EmptyWithdrawalsHash, *header.WithdrawalsHash, "withdrawals root must be empty (%s)", mode)
+	case WithdrawalsRootIsthmus:
+		require.NotNil(t, header.WithdrawalsHash, "withdrawals root must be set from Canyon")
+		var proof *eth.AccountResult
+		err := rpc.CallContext(t.Ctx(), &proof, "eth_getProof", predeploys.L2ToL1MessagePasserAddr, []string{}, hexutil.EncodeBig(header.Number))
+		require.NoError(t, err, "failed to get proof of the L2ToL1MessagePasser")
+		require.NotNil(t, proof, "no proof of the L2ToL1MessagePasser")
+		require.Equal(t, proof.StorageHash, *header.WithdrawalsHash, "withdrawals root must be the storage root of the L2ToL1MessagePasser")
+	default:
+		t.Fatalf("unknown withdrawals root mode %d", mode)
+	}
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// proofRPC serves eth_getProof of the L2ToL1MessagePasser with the storage root, at the block.
+type proofRPC struct {
+	block       string
+	storageHash common.Hash
+}
+
+func (p *proofRPC) Close() {}
+
+func (p *proofRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if method != "eth_getProof" || args[0] != predeploys.L2ToL1MessagePasserAddr || args[2] != p.block {
+		return errors.New("unexpected call")
+	}
+	*(result.(**eth.AccountResult)) = &eth.AccountResult{StorageHash: p.storageHash}
+	return nil
+}
+
+func (p *proofRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (p *proofRPC) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestVerifyWithdrawalsRoot(t *testing.T) {
+	storageHash := common.HexToHash("0x1234")
+	cl := &proofRPC{block: hexutil.EncodeBig(big.NewInt(7)), storageHash: storageHash}
+	header := func(withdrawalsHash *common.Hash) *types.Header {
+		return &types.Header{Number: big.NewInt(7), WithdrawalsHash: withdrawalsHash}
+	}
+	empty := types.EmptyWithdrawalsHash
+
+	VerifyWithdrawalsRoot(NewDefaultTesting(t), cl, header(nil), WithdrawalsRootPreCanyon)
+	VerifyWithdrawalsRoot(NewDefaultTesting(t), cl, header(&empty), WithdrawalsRootPreIsthmus)
+	VerifyWithdrawalsRoot(NewDefaultTesting(t), cl, header(&empty), WithdrawalsRootIsthmusEmpty)
+	VerifyWithdrawalsRoot(NewDefaultTesting(t), cl, header(&storageHash), WithdrawalsRootIsthmus)
+
+	require.Equal(t, "isthmus-empty", WithdrawalsRootIsthmusEmpty.String())
+}
@@ -0,0 +1,165 @@
+package dsl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrForcedEmpty is returned when a tx with forced inclusion is not included, as the engine builds empty blocks.
+var ErrForcedEmpty = errors.New("tx not included: engine is forced to build empty blocks")
+
+type SubmitterOption func(s *BlockControlledSubmitter)
+
+// WithAutoSeal configures whether fetching the receipt of a tx seals the block that is being built with it.
+// Enabled by default. When disabled, the test is responsible for sealing the block with ActL2EndBlock.
+func WithAutoSeal(v bool) SubmitterOption {
+	return func(s *BlockControlledSubmitter) {
+		s.autoSeal = v
+	}
+}
+
+// WithAutoStart starts building a new block when a tx is submitted while no block is being built.
+func WithAutoStart() SubmitterOption {
+	return func(s *BlockControlledSubmitter) {
+		s.autoStart = true
+	}
+}
+
+// WithTxsPerBlock seals the block that is being built once n txs of this submitter have been included in it.
+// Zero, the default, does not limit the number of txs per block.
+func WithTxsPerBlock(n int) SubmitterOption {
+	return func(s *BlockControlledSubmitter) {
+		s.txsPerBlock = n
+	}
+}
+
+// WithForcedInclusion includes txs even if the engine is forced to build empty blocks.
+func WithForcedInclusion() SubmitterOption {
+	return func(s *BlockControlledSubmitter) {
+		s.forceInclusion = true
+	}
+}
+
+// BlockControlledSubmitter plans txs against the in-memory engine of a chain.
+// Txs are included directly by the block builder instead of being submitted via RPC,
+// and blocks are sealed when the receipt of a tx in the block being built is fetched.
+// It implements both the txplan.TransactionSubmitter and txplan.ReceiptGetter interfaces.
+type BlockControlledSubmitter struct {
+	t     helpers.Testing
+	chain *Chain
+	from  common.Address
+	sc    *sources.EthClient
+
+	autoSeal       bool
+	autoStart      bool
+	txsPerBlock    int
+	forceInclusion bool
+
+	// pending maps txs in the block that is being built to the number of that block
+	pending map[common.Hash]uint64
+
+	// receipts are the intermediate receipts, returned by the block builder before sealing.
+	receipts []*types.Receipt
+}
+
+func NewBlockControlledSubmitter(t helpers.Testing, chain *Chain, from common.Address, opts ...SubmitterOption) *BlockControlledSubmitter {
+	s := &BlockControlledSubmitter{
+		t:        t,
+		chain:    chain,
+		from:     from,
+		sc:       chain.SequencerEngine.SourceClient(t, 10),
+		autoSeal: true,
+		pending:  make(map[common.Hash]uint64),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *BlockControlledSubmitter) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if s.autoStart && !s.chain.Sequencer.IsBuilding() {
+		s.chain.Sequencer.ActL2StartBlock(s.t)
+	}
+	receipt, err := s.includeTx(tx)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		// the engine is forced to build empty blocks, which only fails txs that must be included
+		if s.forceInclusion {
+			return ErrForcedEmpty
+		}
+		s.receipts = append(s.receipts, nil)
+		return nil
+	}
+	// be aware that this receipt is not finalized...
+	// which means its info may be incorrect, such as block hash
+	s.receipts = append(s.receipts, receipt)
+	num := s.buildingNumber()
+	s.pending[tx.Hash()] = num
+	if s.txsPerBlock > 0 && s.pendingIn(num) >= s.txsPerBlock {
+		s.seal()
+	}
+	return nil
+}
+
+func (s *BlockControlledSubmitter) includeTx(tx *types.Transaction) (*types.Receipt, error) {
+	engineAPI := s.chain.SequencerEngine.EngineApi
+	if !s.forceInclusion {
+		return engineAPI.IncludeTx(tx, s.from)
+	}
+	prev := engineAPI.ForcedEmpty()
+	engineAPI.SetForceEmpty(false)
+	defer engineAPI.SetForceEmpty(prev)
+	return engineAPI.IncludeTx(tx, s.from)
+}
+
+func (s *BlockControlledSubmitter) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	// close the l2 block before fetching the actual receipt, if the tx is still part of it
+	if num, ok := s.pending[txHash]; ok && s.autoSeal &&
+		s.chain.Sequencer.IsBuilding() && num == s.buildingNumber() {
+		s.seal()
+	}
+	return s.sc.TransactionReceipt(ctx, txHash)
+}
+
+func (s *BlockControlledSubmitter) buildingNumber() uint64 {
+	return s.chain.Sequencer.L2Unsafe().Number + 1
+}
+
+func (s *BlockControlledSubmitter) seal() {
+	s.chain.Sequencer.ActL2EndBlock(s.t)
+	s.pending = make(map[common.Hash]uint64)
+}
+
+// pendingIn counts the txs of this submitter that are pending in the given block.
+func (s *BlockControlledSubmitter) pendingIn(num uint64) int {
+	count := 0
+	for _, n := range s.pending {
+		if n == num {
+			count++
+		}
+	}
+	return count
+}
+
+// Receipts returns the intermediate receipts of all submitted txs, in submission order.
+// The receipt of a tx that was not included, as the engine is forced to build empty blocks, is nil.
+// These are returned by the block builder before sealing, so block info such as the block hash is not final.
+func (s *BlockControlledSubmitter) Receipts() []*types.Receipt {
+	return s.receipts
+}
+
+// LastReceipt returns the intermediate receipt of the last submitted tx, or nil if none was submitted or included.
+func (s *BlockControlledSubmitter) LastReceipt() *types.Receipt {
+	if len(s.receipts) == 0 {
+		return nil
+	}
+	return s.receipts[len(s.receipts)-1]
+}
@@ -1,7 +1,7 @@
 package interop
 
 import (
-	"context"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -10,46 +10,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-acceptance-tests/tests/interop"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/interop/dsl"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/txintent"
 	"github.com/ethereum-optimism/optimism/op-service/txplan"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
-type txSubmitter struct {
-	t       helpers.Testing
-	chain   *dsl.Chain
-	from    common.Address
-	receipt *types.Receipt
-}
-
-func (ts *txSubmitter) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	// we need low level interaction here
-	// do not submit transactions via RPC, instead directly interact with block builder
-	receipt, err := ts.chain.SequencerEngine.EngineApi.IncludeTx(tx, ts.from)
-	if err == nil {
-		// be aware that this receipt is not finalized...
-		// which means its info may be incorrect, such as block hash
-		// you must call ActL2EndBlock to seal the L2 block
-		ts.receipt = receipt
-	}
-	return err
-}
-
-type receiptGetter struct {
-	t     helpers.Testing
-	chain *dsl.Chain
-	sc    *sources.EthClient
-}
-
-func (rg *receiptGetter) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	// close l2 block before fetching actual receipt
-	rg.chain.Sequencer.ActL2EndBlock(rg.t)
-	return rg.sc.TransactionReceipt(ctx, txHash)
-}
-
 func TestTxPlanDeployEventLogger(gt *testing.T) {
 	t := helpers.NewDefaultTesting(gt)
 
@@ -61,7 +29,7 @@ func TestTxPlanDeployEventLogger(gt *testing.T) {
 
 	l2sc := actors.ChainA.SequencerEngine.SourceClient(t, 10)
 
-	submitter1 := &txSubmitter{t: t, chain: actors.ChainA, from: aliceA.address}
+	submitter1 := dsl.NewBlockControlledSubmitter(t, actors.ChainA, aliceA.address)
 	// txplan options for only tx submission, not ensuring block inclusion
 	opts1 := txplan.Combine(
 		txplan.WithPrivateKey(aliceA.secret),
@@ -82,8 +50,7 @@ func TestTxPlanDeployEventLogger(gt *testing.T) {
 	latestBlock, err := deployTxWithoutSeal.AgainstBlock.Eval(t.Ctx())
 	require.NoError(t, err)
 
-	getter := &receiptGetter{t: t, chain: actors.ChainA, sc: l2sc}
-	submitter2 := &txSubmitter{t: t, chain: actors.ChainA, from: aliceA.address}
+	submitter2 := dsl.NewBlockControlledSubmitter(t, actors.ChainA, aliceA.address)
 	// txplan options for tx submission and ensuring block inclusion
 	opts2 := txplan.Combine(
 		txplan.WithPrivateKey(aliceA.secret),
@@ -92,7 +59,7 @@ func TestTxPlanDeployEventLogger(gt *testing.T) {
 		// no pending nonce
 		txplan.WithEstimator(l2sc, false),
 		txplan.WithTransactionSubmitter(submitter2),
-		txplan.WithAssumedInclusion(getter),
+		txplan.WithAssumedInclusion(submitter2),
 		txplan.WithBlockInclusionInfo(l2sc),
 	)
 	deployTx := txplan.NewPlannedTx(opts2, txplan.WithData(deployCalldata))
@@ -109,13 +76,13 @@ func TestTxPlanDeployEventLogger(gt *testing.T) {
 	// all intermediate receipts / finalized receipt must contain the contractAddress field
 	// because they all deployed contract
 	require.NotNil(t, receipt.ContractAddress)
-	require.NotNil(t, submitter1.receipt.ContractAddress)
-	require.NotNil(t, submitter2.receipt.ContractAddress)
+	require.NotNil(t, submitter1.LastReceipt().ContractAddress)
+	require.NotNil(t, submitter2.LastReceipt().ContractAddress)
 
 	// different nonce so different contract address
-	require.NotEqual(t, submitter1.receipt.ContractAddress, submitter2.receipt.ContractAddress)
+	require.NotEqual(t, submitter1.LastReceipt().ContractAddress, submitter2.LastReceipt().ContractAddress)
 	// second and the finalized contract address must be equal
-	require.Equal(t, submitter2.receipt.ContractAddress, receipt.ContractAddress)
+	require.Equal(t, submitter2.LastReceipt().ContractAddress, receipt.ContractAddress)
 
 	includedBlock, err := deployTx.IncludedBlock.Eval(t.Ctx())
 	require.NoError(t, err)
@@ -126,8 +93,7 @@ func TestTxPlanDeployEventLogger(gt *testing.T) {
 
 func DefaultTxOpts(t helpers.Testing, user *userWithKeys, chain *dsl.Chain) txplan.Option {
 	sc := chain.SequencerEngine.SourceClient(t, 10)
-	getter := &receiptGetter{t: t, chain: chain, sc: sc}
-	submitter := &txSubmitter{t: t, chain: chain, from: user.address}
+	submitter := dsl.NewBlockControlledSubmitter(t, chain, user.address)
 	// txplan options for tx submission and ensuring block inclusion
 	return txplan.Combine(
 		txplan.WithPrivateKey(user.secret),
@@ -136,7 +102,7 @@ func DefaultTxOpts(t helpers.Testing, user *userWithKeys, chain *dsl.Chain) txpl
 		txplan.WithPendingNonce(sc),
 		txplan.WithEstimator(sc, false),
 		txplan.WithTransactionSubmitter(submitter),
-		txplan.WithAssumedInclusion(getter),
+		txplan.WithAssumedInclusion(submitter),
 		txplan.WithBlockInclusionInfo(sc),
 	)
 }
@@ -322,3 +288,98 @@ func TestBreakTimestampInvariant(gt *testing.T) {
 	// but reached block number as 1
 	assertHeads(t, actors.ChainB, 1, 1, 1, 1)
 }
+
+func TestBlockControlledSubmitterOptions(gt *testing.T) {
+	var (
+		actors *dsl.InteropActors
+		alice  *userWithKeys
+	)
+	resetTest := func(t helpers.Testing) {
+		is := dsl.SetupInterop(t)
+		actors = is.CreateActors()
+		actors.PrepareChainState(t)
+		alice = setupUser(t, is, actors.ChainA, 0)
+	}
+	// newTxs signs n value transfers of alice, with consecutive nonces
+	newTxs := func(t helpers.Testing, n int) []*types.Transaction {
+		client := actors.ChainA.SequencerEngine.EthClient()
+		nonce, err := client.NonceAt(t.Ctx(), alice.address, nil)
+		require.NoError(t, err)
+		signer := types.LatestSignerForChainID(actors.ChainA.RollupCfg.L2ChainID)
+		txs := make([]*types.Transaction, n)
+		for i := range txs {
+			txs[i], err = types.SignNewTx(alice.secret, signer, &types.DynamicFeeTx{
+				ChainID:   actors.ChainA.RollupCfg.L2ChainID,
+				Nonce:     nonce + uint64(i),
+				GasTipCap: big.NewInt(params.GWei),
+				GasFeeCap: big.NewInt(10 * params.GWei),
+				Gas:       params.TxGas,
+				To:        &common.Address{0xaa},
+				Value:     big.NewInt(1),
+			})
+			require.NoError(t, err)
+		}
+		return txs
+	}
+
+	gt.Run("auto start", func(gt *testing.T) {
+		t := helpers.NewDefaultTesting(gt)
+		resetTest(t)
+		head := actors.ChainA.Sequencer.L2Unsafe().Number
+		submitter := dsl.NewBlockControlledSubmitter(t, actors.ChainA, alice.address, dsl.WithAutoStart())
+		tx := newTxs(t, 1)[0]
+		require.NoError(t, submitter.SendTransaction(t.Ctx(), tx))
+		require.True(t, actors.ChainA.Sequencer.IsBuilding(), "sending a tx starts a block")
+
+		receipt, err := submitter.TransactionReceipt(t.Ctx(), tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		require.Equal(t, head+1, receipt.BlockNumber.Uint64())
+		require.False(t, actors.ChainA.Sequencer.IsBuilding(), "fetching the receipt seals the block")
+	})
+
+	gt.Run("txs per block", func(gt *testing.T) {
+		t := helpers.NewDefaultTesting(gt)
+		resetTest(t)
+		head := actors.ChainA.Sequencer.L2Unsafe().Number
+		submitter := dsl.NewBlockControlledSubmitter(t, actors.ChainA, alice.address, dsl.WithAutoStart(), dsl.WithTxsPerBlock(2))
+		txs := newTxs(t, 3)
+		for _, tx := range txs[:2] {
+			require.NoError(t, submitter.SendTransaction(t.Ctx(), tx))
+		}
+		require.False(t, actors.ChainA.Sequencer.IsBuilding(), "the block is sealed once it has 2 txs")
+		require.Equal(t, head+1, actors.ChainA.Sequencer.L2Unsafe().Number)
+		require.NoError(t, submitter.SendTransaction(t.Ctx(), txs[2]))
+		require.True(t, actors.ChainA.Sequencer.IsBuilding(), "the next tx starts a new block")
+
+		for i, tx := range txs {
+			receipt, err := submitter.TransactionReceipt(t.Ctx(), tx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, head+1+uint64(i/2), receipt.BlockNumber.Uint64(), "tx %d", i)
+		}
+		require.Len(t, submitter.Receipts(), 3)
+	})
+
+	gt.Run("forced empty", func(gt *testing.T) {
+		t := helpers.NewDefaultTesting(gt)
+		resetTest(t)
+		actors.ChainA.Sequencer.ActL2StartBlock(t)
+		// starting a block resets the empty block mode
+		engineAPI := actors.ChainA.SequencerEngine.EngineApi
+		engineAPI.SetForceEmpty(true)
+
+		txs := newTxs(t, 2)
+		submitter := dsl.NewBlockControlledSubmitter(t, actors.ChainA, alice.address)
+		require.NoError(t, submitter.SendTransaction(t.Ctx(), txs[0]), "txs that are not included are not an error")
+		require.Nil(t, submitter.LastReceipt())
+
+		forced := dsl.NewBlockControlledSubmitter(t, actors.ChainA, alice.address, dsl.WithForcedInclusion())
+		require.NoError(t, forced.SendTransaction(t.Ctx(), txs[0]))
+		require.NotNil(t, forced.LastReceipt(), "forced inclusion includes the tx in the empty block")
+		require.True(t, engineAPI.ForcedEmpty(), "forced inclusion restores the empty block mode")
+
+		receipt, err := forced.TransactionReceipt(t.Ctx(), txs[0].Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	})
+}
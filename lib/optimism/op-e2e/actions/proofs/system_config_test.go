@@ -98,7 +98,7 @@ func testSystemConfigEarlyIsthmusUpgrade(gt *testing.T, testCfg *helpers.TestCfg
 	requireL1InfoParams(safeL2, 0, 0)
 	env.RunFaultProofProgram(t, safeL2.Number, testCfg.CheckResult, testCfg.InputParams...)
 
-	sequencer.ActBuildL2ToIsthmus(t)
+	sequencer.ActBuildL2ToFork(t, rollup.Isthmus)
 	sequencer.ActL2EmptyBlock(t) // one more to have Isthmus L1 info deposit
 	safeL2 = env.BatchMineAndSync(t)
 	require.True(t, env.Sd.RollupCfg.IsIsthmus(sequencer.SyncStatus().SafeL2.Time))
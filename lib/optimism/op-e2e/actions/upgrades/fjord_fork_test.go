@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
@@ -65,7 +66,7 @@ func TestFjordNetworkUpgradeTransactions(gt *testing.T) {
 	initialGasPriceOracleAddress, err := ethCl.StorageAt(context.Background(), predeploys.GasPriceOracleAddr, genesis.ImplementationSlot, nil)
 	require.NoError(t, err)
 
-	sequencer.ActBuildL2ToFjord(t)
+	sequencer.ActBuildL2ToFork(t, rollup.Fjord)
 
 	// get latest block
 	latestBlock, err := ethCl.BlockByNumber(context.Background(), nil)
@@ -92,7 +92,7 @@ func TestHoloceneLateActivationAndReset(gt *testing.T) {
 
 	// Build a few L2 blocks. We only need the L1 inclusion to advance past Holocene and Holocene
 	// shouldn't activate with L2 time.
-	env.Seq.ActBuildL2ToHolocene(t)
+	env.Seq.ActBuildL2ToFork(t, rollup.Holocene)
 
 	// verify in logs that stage transformations hasn't happened yet, activates by L1 inclusion block
 	requireHoloceneTransformationLogs(e2esys.RoleSeq, 0)
@@ -6,15 +6,12 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
-	"github.com/ethereum-optimism/optimism/op-service/client"
-	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -23,9 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,6 +30,41 @@ var (
 	isthmusOperatorFeeVaultCodeHash = common.HexToHash("0x57dc55c9c09ca456fa728f253fe7b895d3e6aae0706104935fe87c7721001971")
 )
 
+// isthmusUpgradeExpectations are the contracts of the Isthmus network upgrade transactions.
+var isthmusUpgradeExpectations = []UpgradeExpectation{
+	{
+		Name:     "L1Block",
+		Txs:      2, // deploy + upgrade proxy
+		Deployer: derive.L1BlockIsthmusDeployerAddress,
+		Proxy:    predeploys.L1BlockAddr,
+		CodeHash: isthmusL1BlockCodeHash,
+		Version:  "1.6.0",
+	},
+	{
+		Name:     "GasPriceOracle",
+		Txs:      3, // deploy + upgrade proxy + setIsthmus
+		Deployer: derive.GasPriceOracleIsthmusDeployerAddress,
+		Proxy:    predeploys.GasPriceOracleAddr,
+		CodeHash: isthmusGasPriceOracleCodeHash,
+		Version:  "1.4.0",
+	},
+	{
+		Name:     "OperatorFeeVault",
+		Txs:      2, // deploy + upgrade proxy
+		Deployer: derive.OperatorFeeVaultDeployerAddress,
+		Proxy:    predeploys.OperatorFeeVaultAddr,
+		CodeHash: isthmusOperatorFeeVaultCodeHash,
+		Version:  "1.0.0",
+	},
+	{
+		Name:     "EIP-2935",
+		Txs:      1, // deploy
+		Deployer: predeploys.EIP2935ContractDeployer,
+		CodeHash: predeploys.EIP2935ContractCodeHash,
+		Code:     predeploys.EIP2935ContractCode,
+	},
+}
+
 var zeroHex64 = hexutil.Uint64(0)
 
 func TestIsthmusActivationAtGenesis(gt *testing.T) {
@@ -60,7 +90,7 @@ func TestIsthmusActivationAtGenesis(gt *testing.T) {
 	// Make verifier sync, then check the block
 	env.Verifier.ActL2PipelineFull(t)
 	block := env.VerifEngine.L2Chain().CurrentBlock()
-	verifyIsthmusHeaderWithdrawalsRoot(gt, env.SeqEngine.RPCClient(), block, true)
+	helpers.VerifyWithdrawalsRoot(t, env.SeqEngine.RPCClient(), block, helpers.WithdrawalsRootIsthmus)
 	require.Equal(t, types.EmptyRequestsHash, *block.RequestsHash, "isthmus block must have requests hash")
 
 	// Check genesis config type can convert to a valid block
@@ -110,10 +140,10 @@ func TestWithdrawlsRootPreCanyonAndIsthmus(gt *testing.T) {
 	sequencer.ActL2PipelineFull(t)
 	verifier.ActL2PipelineFull(t)
 
-	verifyPreCanyonHeaderWithdrawalsRoot(gt, engine.L2Chain().CurrentBlock())
+	helpers.VerifyWithdrawalsRoot(t, engine.RPCClient(), engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootPreCanyon)
 
 	// build blocks until canyon activates
-	sequencer.ActBuildL2ToCanyon(t)
+	sequencer.ActBuildL2ToFork(t, rollup.Canyon)
 
 	// Send withdrawal transaction
 	// Bind L2 Withdrawer Contract
@@ -133,7 +163,7 @@ func TestWithdrawlsRootPreCanyonAndIsthmus(gt *testing.T) {
 	sequencer.ActL2EmptyBlock(t)
 	sequencer.ActL2EmptyBlock(t)
 
-	verifyPreIsthmusHeaderWithdrawalsRoot(gt, engine.L2Chain().CurrentBlock())
+	helpers.VerifyWithdrawalsRoot(t, engine.RPCClient(), engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootPreIsthmus)
 }
 
 // In this section, we will test the following combinations
@@ -178,7 +208,7 @@ func testWithdrawlsRootIsthmus(gt *testing.T, withdrawalTx bool, withdrawalTxBlo
 	sequencer.ActL2PipelineFull(t)
 	verifier.ActL2PipelineFull(t)
 
-	verifyPreIsthmusHeaderWithdrawalsRoot(gt, engine.L2Chain().CurrentBlock())
+	helpers.VerifyWithdrawalsRoot(t, engine.RPCClient(), engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootPreIsthmus)
 
 	ethCl := engine.EthClient()
 	for i := 1; i <= totalBlocks; i++ {
@@ -214,7 +244,7 @@ func testWithdrawlsRootIsthmus(gt *testing.T, withdrawalTx bool, withdrawalTxBlo
 
 	// we set withdrawals root only at or after isthmus
 	if totalBlocks >= isthmusOffset {
-		verifyIsthmusHeaderWithdrawalsRoot(gt, rpcCl, engine.L2Chain().CurrentBlock(), true)
+		helpers.VerifyWithdrawalsRoot(t, rpcCl, engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootIsthmus)
 	}
 }
 
@@ -236,10 +266,10 @@ func TestWithdrawlsRootPostIsthmus(gt *testing.T) {
 	sequencer.ActL2PipelineFull(t)
 	verifier.ActL2PipelineFull(t)
 
-	verifyPreIsthmusHeaderWithdrawalsRoot(gt, engine.L2Chain().CurrentBlock())
+	helpers.VerifyWithdrawalsRoot(t, engine.RPCClient(), engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootPreIsthmus)
 
 	rpcCl := engine.RPCClient()
-	verifyIsthmusHeaderWithdrawalsRoot(gt, rpcCl, engine.L2Chain().CurrentBlock(), false)
+	helpers.VerifyWithdrawalsRoot(t, rpcCl, engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootIsthmusEmpty)
 
 	// Send withdrawal transaction
 	// Bind L2 Withdrawer Contract
@@ -269,44 +299,7 @@ func TestWithdrawlsRootPostIsthmus(gt *testing.T) {
 	require.NoError(t, err, "withdrawal initiated on L2 sequencer")
 	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "transaction had incorrect status")
 
-	verifyIsthmusHeaderWithdrawalsRoot(gt, rpcCl, engine.L2Chain().CurrentBlock(), true)
-}
-
-// Pre-Canyon, the withdrawals root field in the header should be nil
-func verifyPreCanyonHeaderWithdrawalsRoot(gt *testing.T, header *types.Header) {
-	require.Nil(gt, header.WithdrawalsHash)
-}
-
-// Post-Canyon, the withdrawals root field in the header should be EmptyWithdrawalsHash
-func verifyPreIsthmusHeaderWithdrawalsRoot(gt *testing.T, header *types.Header) {
-	require.Equal(gt, types.EmptyWithdrawalsHash, *header.WithdrawalsHash)
-}
-
-func verifyIsthmusHeaderWithdrawalsRoot(gt *testing.T, rpcCl client.RPC, header *types.Header, l2toL1MPPresent bool) {
-	getStorageRoot := func(rpcCl client.RPC, ctx context.Context, address common.Address, blockTag string) common.Hash {
-		var getProofResponse *eth.AccountResult
-		err := rpcCl.CallContext(ctx, &getProofResponse, "eth_getProof", address, []common.Hash{}, blockTag)
-		assert.NoError(gt, err)
-		assert.NotNil(gt, getProofResponse)
-		return getProofResponse.StorageHash
-	}
-
-	if !l2toL1MPPresent {
-		require.Equal(gt, types.EmptyWithdrawalsHash, *header.WithdrawalsHash)
-	} else {
-		storageHash := getStorageRoot(rpcCl, context.Background(), predeploys.L2ToL1MessagePasserAddr, "latest")
-		require.Equal(gt, *header.WithdrawalsHash, storageHash)
-	}
-}
-
-func checkContractVersion(gt *testing.T, client *ethclient.Client, addr common.Address, expectedVersion string) {
-	isemver, err := bindings.NewISemver(addr, client)
-	require.NoError(gt, err)
-
-	version, err := isemver.Version(nil)
-	require.NoError(gt, err)
-
-	require.Equal(gt, expectedVersion, version)
+	helpers.VerifyWithdrawalsRoot(t, rpcCl, engine.L2Chain().CurrentBlock(), helpers.WithdrawalsRootIsthmus)
 }
 
 func TestIsthmusNetworkUpgradeTransactions(gt *testing.T) {
@@ -336,71 +329,20 @@ func TestIsthmusNetworkUpgradeTransactions(gt *testing.T) {
 	gasPriceOracle, err := bindings.NewGasPriceOracleCaller(predeploys.GasPriceOracleAddr, ethCl)
 	require.NoError(t, err)
 
-	// Get current implementations addresses (by slot) for L1Block + GasPriceOracle
-	initialL1BlockAddress, err := ethCl.StorageAt(context.Background(), predeploys.L1BlockAddr, genesis.ImplementationSlot, nil)
-	require.NoError(t, err)
-	initialGasPriceOracleAddress, err := ethCl.StorageAt(context.Background(), predeploys.GasPriceOracleAddr, genesis.ImplementationSlot, nil)
-	require.NoError(t, err)
-
 	// Build to the isthmus block
-	sequencer.ActBuildL2ToIsthmus(t)
-
-	// get latest block
-	latestBlock, err := ethCl.BlockByNumber(context.Background(), nil)
-	require.NoError(t, err)
-	require.Equal(t, sequencer.L2Unsafe().Number, latestBlock.Number().Uint64())
+	sequencer.ActBuildL2ToFork(t, rollup.Isthmus)
 
-	transactions := latestBlock.Transactions()
-
-	// L1Block: 1 set-L1-info + 1 deploy
 	// See [derive.IsthmusNetworkUpgradeTransactions]
-	require.Equal(t, 9, len(transactions))
-
-	// All transactions are successful
-	for i := 1; i < 9; i++ {
-		txn := transactions[i]
-		receipt, err := ethCl.TransactionReceipt(context.Background(), txn.Hash())
-		require.NoError(t, err)
-		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
-		require.NotEmpty(t, txn.Data(), "upgrade tx must provide input data")
-	}
-
-	expectedL1BlockAddress := crypto.CreateAddress(derive.L1BlockIsthmusDeployerAddress, 0)
-
-	// L1 Block Proxy is updated
-	updatedL1BlockAddress, err := ethCl.StorageAt(context.Background(), predeploys.L1BlockAddr, genesis.ImplementationSlot, latestBlock.Number())
-	require.NoError(t, err)
-	require.Equal(t, expectedL1BlockAddress, common.BytesToAddress(updatedL1BlockAddress))
-	require.NotEqualf(t, initialL1BlockAddress, updatedL1BlockAddress, "Gas L1 Block address should have changed")
-	verifyCodeHashMatches(t, ethCl, expectedL1BlockAddress, isthmusL1BlockCodeHash)
-
-	expectedGasPriceOracleAddress := crypto.CreateAddress(derive.GasPriceOracleIsthmusDeployerAddress, 0)
-
-	// Gas Price Oracle Proxy is updated
-	updatedGasPriceOracleAddress, err := ethCl.StorageAt(context.Background(), predeploys.GasPriceOracleAddr, genesis.ImplementationSlot, latestBlock.Number())
-	require.NoError(t, err)
-	require.Equal(t, expectedGasPriceOracleAddress, common.BytesToAddress(updatedGasPriceOracleAddress))
-	require.NotEqualf(t, initialGasPriceOracleAddress, updatedGasPriceOracleAddress, "Gas Price Oracle Proxy address should have changed")
-	verifyCodeHashMatches(t, ethCl, expectedGasPriceOracleAddress, isthmusGasPriceOracleCodeHash)
+	latestBlock := VerifyUpgradeTxs(t, ethCl, isthmusUpgradeExpectations)
+	require.Equal(t, sequencer.L2Unsafe().Number, latestBlock.NumberU64())
 
 	// Check that Isthmus was activated
 	isIsthmus, err := gasPriceOracle.IsIsthmus(nil)
 	require.NoError(t, err)
 	require.True(t, isIsthmus)
 
-	expectedOperatorFeeVaultAddress := crypto.CreateAddress(derive.OperatorFeeVaultDeployerAddress, 0)
-
-	// Operator Fee vault is updated
-	updatedOperatorFeeVaultAddress, err := ethCl.StorageAt(context.Background(), predeploys.OperatorFeeVaultAddr, genesis.ImplementationSlot, latestBlock.Number())
-	require.NoError(t, err)
-	require.Equal(t, expectedOperatorFeeVaultAddress, common.BytesToAddress(updatedOperatorFeeVaultAddress))
-	verifyCodeHashMatches(t, ethCl, expectedOperatorFeeVaultAddress, isthmusOperatorFeeVaultCodeHash)
-
 	// EIP-2935 contract is deployed
-	expectedBlockHashAddress := crypto.CreateAddress(predeploys.EIP2935ContractDeployer, 0)
-	require.Equal(t, predeploys.EIP2935ContractAddr, expectedBlockHashAddress)
-	code := verifyCodeHashMatches(t, ethCl, predeploys.EIP2935ContractAddr, predeploys.EIP2935ContractCodeHash)
-	require.Equal(t, predeploys.EIP2935ContractCode, code)
+	require.Equal(t, predeploys.EIP2935ContractAddr, crypto.CreateAddress(predeploys.EIP2935ContractDeployer, 0))
 
 	// Test that the beacon-block-root has been set
 	checkRecentBlockHash := func(blockNumber uint64, expectedHash common.Hash, msg string) {
@@ -412,11 +354,6 @@ func TestIsthmusNetworkUpgradeTransactions(gt *testing.T) {
 		require.Equal(t, expectedHash, common.BytesToHash(rootValue), msg)
 	}
 
-	// Check contract versions
-	checkContractVersion(gt, ethCl, common.BytesToAddress(updatedL1BlockAddress), "1.6.0")
-	checkContractVersion(gt, ethCl, common.BytesToAddress(updatedGasPriceOracleAddress), "1.4.0")
-	checkContractVersion(gt, ethCl, common.BytesToAddress(updatedOperatorFeeVaultAddress), "1.0.0")
-
 	// Legacy check:
 	// > The first block is an exception in upgrade-networks,
 	// > since the recent-block-hash contract isn't there at Isthmus activation,
@@ -0,0 +1,94 @@
+package upgrades
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+// UpgradeExpectation is what the network upgrade transactions of a fork do to a contract.
+type UpgradeExpectation struct {
+	// Name of the contract, for failure messages.
+	Name string
+	// Txs is the number of upgrade transactions of the contract, e.g. its deployment and the upgrade of its proxy.
+	Txs int
+	// Deployer deploys the contract with its first nonce.
+	Deployer common.Address
+	// Proxy is the proxy that is upgraded to the deployed contract, if any.
+	Proxy common.Address
+	// CodeHash is the hash of the code of the deployed contract.
+	CodeHash common.Hash
+	// Code is the code of the deployed contract, if it is known.
+	Code []byte
+	// Version is the semver of the deployed contract, if it has one.
+	Version string
+}
+
+// Address returns the address the contract is deployed at.
+func (e UpgradeExpectation) Address() common.Address {
+	return crypto.CreateAddress(e.Deployer, 0)
+}
+
+// VerifyUpgradeTxs verifies that the latest block is the activation block of a fork, with the L1 info transaction
+// followed by the network upgrade transactions of the expected contracts, which all succeeded. Each contract must be
+// deployed with the expected code, and its proxy, if any, must point at it from that block on. It returns the block.
+func VerifyUpgradeTxs(t helpers.Testing, client *ethclient.Client, expected []UpgradeExpectation) *types.Block {
+	ctx := context.Background()
+	block, err := client.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	// the L1 info transaction, followed by the upgrade transactions
+	upgradeTxs := 0
+	for _, e := range expected {
+		upgradeTxs += e.Txs
+	}
+	transactions := block.Transactions()
+	require.Len(t, transactions, 1+upgradeTxs, "activation block must hold the L1 info and the upgrade transactions")
+	for _, tx := range transactions[1:] {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "upgrade tx %s must succeed", tx.Hash())
+		require.NotEmpty(t, tx.Data(), "upgrade tx must provide input data")
+	}
+
+	parent := new(big.Int).Sub(block.Number(), big.NewInt(1))
+	for _, e := range expected {
+		address := e.Address()
+
+		if e.Proxy != (common.Address{}) {
+			before, err := client.StorageAt(ctx, e.Proxy, genesis.ImplementationSlot, parent)
+			require.NoError(t, err)
+			after, err := client.StorageAt(ctx, e.Proxy, genesis.ImplementationSlot, block.Number())
+			require.NoError(t, err)
+			require.NotEqual(t, address, common.BytesToAddress(before), "%s proxy must not point at the upgrade before activation", e.Name)
+			require.Equal(t, address, common.BytesToAddress(after), "%s proxy must point at the upgrade", e.Name)
+		}
+
+		code := verifyCodeHashMatches(t, client, address, e.CodeHash)
+		if e.Code != nil {
+			require.Equal(t, e.Code, code, "%s code must match", e.Name)
+		}
+		if e.Version != "" {
+			checkContractVersion(t, client, address, e.Version)
+		}
+	}
+	return block
+}
+
+func checkContractVersion(t helpers.Testing, client *ethclient.Client, addr common.Address, expectedVersion string) {
+	isemver, err := bindings.NewISemver(addr, client)
+	require.NoError(t, err)
+
+	version, err := isemver.Version(nil)
+	require.NoError(t, err)
+
+	require.Equal(t, expectedVersion, version)
+}
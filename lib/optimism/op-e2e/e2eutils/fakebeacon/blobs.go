@@ -107,9 +107,6 @@ func (f *FakeBeacon) Start(addr string) error {
 			}
 		}
 
-		var mockBeaconBlockRoot [32]byte
-		mockBeaconBlockRoot[0] = 42
-		binary.LittleEndian.PutUint64(mockBeaconBlockRoot[32-8:], slot)
 		sidecars := make([]*eth.APIBlobSidecar, len(indices))
 		for i, ix := range indices {
 			if ix >= uint64(len(bundle.Blobs)) {
@@ -118,16 +115,11 @@ func (f *FakeBeacon) Start(addr string) error {
 				return
 			}
 			sidecars[i] = &eth.APIBlobSidecar{
-				Index:         eth.Uint64String(ix),
-				KZGCommitment: eth.Bytes48(bundle.Commitments[ix]),
-				KZGProof:      eth.Bytes48(bundle.Proofs[ix]),
-				SignedBlockHeader: eth.SignedBeaconBlockHeader{
-					Message: eth.BeaconBlockHeader{
-						StateRoot: mockBeaconBlockRoot,
-						Slot:      eth.Uint64String(slot),
-					},
-				},
-				InclusionProof: make([]eth.Bytes32, 0),
+				Index:             eth.Uint64String(ix),
+				KZGCommitment:     eth.Bytes48(bundle.Commitments[ix]),
+				KZGProof:          eth.Bytes48(bundle.Proofs[ix]),
+				SignedBlockHeader: mockBeaconBlockHeader(slot),
+				InclusionProof:    make([]eth.Bytes32, 0),
 			}
 			copy(sidecars[i].Blob[:], bundle.Blobs[ix])
 		}
@@ -135,6 +127,28 @@ func (f *FakeBeacon) Start(addr string) error {
 			f.log.Error("blobs handler err", "err", err)
 		}
 	})
+	mux.HandleFunc("/eth/v1/beacon/headers/", func(w http.ResponseWriter, r *http.Request) {
+		blockID := strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/headers/")
+		slot, err := strconv.ParseUint(blockID, 10, 64)
+		if err != nil {
+			f.log.Error("could not parse block id from request", "url", r.URL.Path, "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var resp struct {
+			Data struct {
+				Root      eth.Bytes32                 `json:"root"`
+				Canonical bool                        `json:"canonical"`
+				Header    eth.SignedBeaconBlockHeader `json:"header"`
+			} `json:"data"`
+		}
+		resp.Data.Canonical = true
+		resp.Data.Header = mockBeaconBlockHeader(slot)
+		resp.Data.Root = resp.Data.Header.Message.StateRoot
+		if err := json.NewEncoder(w).Encode(&resp); err != nil {
+			f.log.Error("headers handler err", "err", err)
+		}
+	})
 	mux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, r *http.Request) {
 		err := json.NewEncoder(w).Encode(&eth.APIVersionResponse{Data: eth.VersionInformation{Version: "fakebeacon 1.2.3"}})
 		if err != nil {
@@ -159,6 +173,20 @@ func (f *FakeBeacon) Start(addr string) error {
 	return nil
 }
 
+// mockBeaconBlockHeader is the header of the beacon block of the given slot.
+// The fake beacon does not build beacon blocks, so the header only identifies the slot.
+func mockBeaconBlockHeader(slot uint64) eth.SignedBeaconBlockHeader {
+	var mockBeaconBlockRoot [32]byte
+	mockBeaconBlockRoot[0] = 42
+	binary.LittleEndian.PutUint64(mockBeaconBlockRoot[32-8:], slot)
+	return eth.SignedBeaconBlockHeader{
+		Message: eth.BeaconBlockHeader{
+			StateRoot: mockBeaconBlockRoot,
+			Slot:      eth.Uint64String(slot),
+		},
+	}
+}
+
 func (f *FakeBeacon) StoreBlobsBundle(slot uint64, bundle *engine.BlobsBundleV1) error {
 	f.blobsLock.Lock()
 	defer f.blobsLock.Unlock()
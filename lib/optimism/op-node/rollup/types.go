@@ -558,6 +558,37 @@ func (c *Config) IsActivationBlock(oldTime, newTime uint64) ForkName {
 	return ""
 }
 
+// ActivationTime returns the activation time of the hardfork, or nil if it is not scheduled.
+// Bedrock is active from genesis, so its activation time is zero.
+func (c *Config) ActivationTime(hardfork ForkName) *uint64 {
+	switch hardfork {
+	case Bedrock:
+		return new(uint64)
+	case Regolith:
+		return c.RegolithTime
+	case Canyon:
+		return c.CanyonTime
+	case Delta:
+		return c.DeltaTime
+	case Ecotone:
+		return c.EcotoneTime
+	case Fjord:
+		return c.FjordTime
+	case Granite:
+		return c.GraniteTime
+	case Holocene:
+		return c.HoloceneTime
+	case Isthmus:
+		return c.IsthmusTime
+	case Jovian:
+		return c.JovianTime
+	case Interop:
+		return c.InteropTime
+	default:
+		return nil
+	}
+}
+
 func (c *Config) ActivateAtGenesis(hardfork ForkName) {
 	// IMPORTANT! ordered from newest to oldest
 	switch hardfork {
@@ -334,6 +334,24 @@ func TestActivations(t *testing.T) {
 	}
 }
 
+func TestActivationTime(t *testing.T) {
+	for i, fork := range AllForks {
+		t.Run(string(fork), func(t *testing.T) {
+			config := &Config{}
+			config.ActivateAtGenesis(fork)
+			for _, active := range AllForks[:i+1] {
+				activation := config.ActivationTime(active)
+				require.NotNil(t, activation, "%s activates at genesis", active)
+				require.Zero(t, *activation)
+			}
+			for _, inactive := range AllForks[i+1:] {
+				require.Nil(t, config.ActivationTime(inactive), "%s is not scheduled", inactive)
+			}
+		})
+	}
+	require.Nil(t, (&Config{}).ActivationTime(None))
+}
+
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
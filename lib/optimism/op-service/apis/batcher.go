@@ -1,10 +1,17 @@
 package apis
 
-import "context"
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
 
 type BatcherActivity interface {
 	StartBatcher(ctx context.Context) error
 	StopBatcher(ctx context.Context) error
+	// FlushBatcher submits the loaded L2 blocks without waiting for the current channel to fill up,
+	// and returns the latest L2 block pending submission, or the zero block ID if there is none.
+	FlushBatcher(ctx context.Context) (eth.BlockID, error)
 }
 
 type BatcherAdminServer interface {
@@ -0,0 +1,39 @@
+package batching
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type NonceCall struct {
+	addr common.Address
+}
+
+var _ Call = (*NonceCall)(nil)
+
+func NewNonceCall(addr common.Address) *NonceCall {
+	return &NonceCall{addr}
+}
+
+func (n *NonceCall) ToBatchElemCreator() (BatchElementCreator, error) {
+	return func(block rpcblock.Block) (any, rpc.BatchElem) {
+		out := new(hexutil.Uint64)
+		return out, rpc.BatchElem{
+			Method: "eth_getTransactionCount",
+			Args:   []interface{}{n.addr, block.ArgValue()},
+			Result: &out,
+		}
+	}, nil
+}
+
+func (n *NonceCall) HandleResult(result interface{}) (*CallResult, error) {
+	val, ok := result.(*hexutil.Uint64)
+	if !ok {
+		return nil, fmt.Errorf("response %v was not a *hexutil.Uint64", result)
+	}
+	return &CallResult{out: []interface{}{uint64(*val)}}, nil
+}
@@ -0,0 +1,24 @@
+package batching
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNonce(t *testing.T) {
+	addr := common.Address{0xab, 0xcd}
+	expectedNonce := uint64(42)
+
+	stub := test.NewRpcStub(t)
+	stub.AddExpectedCall(test.NewGetTransactionCountCall(addr, rpcblock.Latest, expectedNonce))
+
+	caller := NewMultiCaller(stub, DefaultBatchSize)
+	result, err := caller.SingleCall(context.Background(), rpcblock.Latest, NewNonceCall(addr))
+	require.NoError(t, err)
+	require.Equal(t, expectedNonce, result.GetUint64(0))
+}
@@ -80,6 +80,14 @@ func NewGetBalanceCall(addr common.Address, block rpcblock.Block, balance *big.I
 	}
 }
 
+func NewGetTransactionCountCall(addr common.Address, block rpcblock.Block, nonce uint64) ExpectedRpcCall {
+	return &GenericExpectedCall{
+		method: "eth_getTransactionCount",
+		args:   []interface{}{addr, block.ArgValue()},
+		result: hexutil.Uint64(nonce),
+	}
+}
+
 func (c *GenericExpectedCall) Matches(rpcMethod string, args ...interface{}) error {
 	if rpcMethod != c.method {
 		return fmt.Errorf("expected method %v but was %v", c.method, rpcMethod)